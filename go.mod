@@ -7,6 +7,7 @@ toolchain go1.24.9
 require (
 	github.com/Combine-Capital/cqc v0.3.1
 	github.com/golang-jwt/jwt/v5 v5.3.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/stretchr/testify v1.9.0
 	google.golang.org/protobuf v1.36.10
 )
@@ -4,12 +4,24 @@ package auth
 import (
 	"context"
 	"fmt"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
 )
 
 // BearerConfig contains configuration for Bearer token authentication.
 type BearerConfig struct {
 	// Token is the static bearer token for API authentication
 	Token string
+
+	// Credentials, if set, supplies the token (Secret) in place of Token,
+	// which must then be empty. KeyID is an optional label for the token,
+	// reported by ActiveKeyID. Rotated tokens are used from the next request
+	// on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// no token. Requests continue to carry the previous token.
+	OnCredentialError func(err error)
 }
 
 // BearerSigner implements Bearer token authentication for FalconX and similar venues.
@@ -25,36 +37,58 @@ type BearerConfig struct {
 // Thread-safe: This implementation is safe for concurrent use.
 type BearerSigner struct {
 	config BearerConfig
+	keys   *keyring[string] // tokens
 }
 
 // NewBearerSigner creates a new Bearer token signer.
 // The token should be a valid bearer token provided by the venue.
 func NewBearerSigner(config BearerConfig) (*BearerSigner, error) {
-	if config.Token == "" {
-		return nil, fmt.Errorf("token is required")
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{Secret: config.Token})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := newKeyring(provider, parseBearerCredentials, config.OnCredentialError)
+	if err != nil {
+		return nil, err
 	}
 
 	return &BearerSigner{
 		config: config,
+		keys:   keys,
 	}, nil
 }
 
+// parseBearerCredentials returns the token of bearer credentials.
+func parseBearerCredentials(creds credentials.Credentials) (string, error) {
+	if creds.Secret == "" {
+		return "", fmt.Errorf("token is required")
+	}
+	return creds.Secret, nil
+}
+
+// ActiveKeyID returns the label of the token currently in use, or an empty
+// string for unlabeled tokens.
+func (s *BearerSigner) ActiveKeyID() string {
+	return s.keys.keyID()
+}
+
 // Sign generates Bearer token authentication header for an API request.
 // It returns the Authorization: Bearer <token> header.
 //
 // Unlike HMAC or JWT signing, this method doesn't compute any signature.
 // It simply returns the pre-configured token as a bearer token header.
 //
-// The context parameter is accepted for interface compliance and future
-// extensibility (e.g., token rotation), but is not currently used.
+// The context is passed to BearerConfig.Credentials, if set, when checking
+// for a rotated token.
 //
 // Returns an error only if the signer is misconfigured (which should be
 // caught during initialization).
 func (s *BearerSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
-	// Return Authorization: Bearer header with the configured token
+	// Return Authorization: Bearer header with the current token
+	active := s.keys.current(ctx)
 	return &SignResult{
 		Headers: map[string]string{
-			"Authorization": "Bearer " + s.config.Token,
+			"Authorization": "Bearer " + active.key,
 		},
 	}, nil
 }
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+)
+
+// KeyReporter is implemented by signers that report which key they sign
+// with. Every signer in this package implements it.
+type KeyReporter interface {
+	// ActiveKeyID returns the KeyID of the credentials currently used for
+	// signing.
+	ActiveKeyID() string
+}
+
+// credentialProvider returns the provider a signer config selects: provider
+// if set, or static credentials built from the config's fields. Setting both
+// is an error, so that a config never silently ignores one of them.
+func credentialProvider(provider credentials.Provider, static credentials.Credentials) (credentials.Provider, error) {
+	if provider == nil {
+		return credentials.Static(static), nil
+	}
+	if static != (credentials.Credentials{}) {
+		return nil, fmt.Errorf("static credentials and credential provider are mutually exclusive")
+	}
+	return provider, nil
+}
+
+// activeKey is a version of a signer's credentials and the key material
+// parsed from them.
+type activeKey[K any] struct {
+	creds credentials.Credentials
+	key   K
+}
+
+// keyring holds a signer's current credentials in parsed form and swaps them
+// atomically when the provider returns new ones. Each request signs with the
+// activeKey it loaded, so a rotation never affects a request in flight.
+type keyring[K any] struct {
+	provider credentials.Provider
+	parse    func(credentials.Credentials) (K, error)
+	onError  func(error)
+	active   atomic.Pointer[activeKey[K]]
+
+	mu       sync.Mutex // serializes parsing
+	rejected *credentials.Credentials
+}
+
+// newKeyring loads and parses the provider's initial credentials. Unlike
+// later rotations, a failure here is returned: the signer has no previous
+// credentials to fall back to.
+func newKeyring[K any](provider credentials.Provider, parse func(credentials.Credentials) (K, error), onError func(error)) (*keyring[K], error) {
+	creds, err := provider.Credentials(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credentials: %w", err)
+	}
+	key, err := parse(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &keyring[K]{provider: provider, parse: parse, onError: onError}
+	r.active.Store(&activeKey[K]{creds: creds, key: key})
+	return r, nil
+}
+
+// current returns the key to sign a request with, switching to the
+// provider's credentials first if they changed. If the provider fails or its
+// new credentials cannot be parsed, the error is passed to onError and the
+// previous key is returned; credentials that were rejected once are not
+// parsed again.
+func (r *keyring[K]) current(ctx context.Context) *activeKey[K] {
+	active := r.active.Load()
+	creds, err := r.provider.Credentials(ctx)
+	if err != nil {
+		r.report(fmt.Errorf("failed to load credentials: %w", err))
+		return active
+	}
+	if creds == active.creds {
+		return active
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Another request may have rotated while we waited
+	active = r.active.Load()
+	if creds == active.creds || (r.rejected != nil && creds == *r.rejected) {
+		return active
+	}
+	key, err := r.parse(creds)
+	if err != nil {
+		r.rejected = &creds
+		r.report(fmt.Errorf("rotated credentials for key %q rejected: %w", creds.KeyID, err))
+		return active
+	}
+	next := &activeKey[K]{creds: creds, key: key}
+	r.active.Store(next)
+	r.rejected = nil
+	return next
+}
+
+// keyID returns the KeyID of the active credentials.
+func (r *keyring[K]) keyID() string {
+	return r.active.Load().creds.KeyID
+}
+
+func (r *keyring[K]) report(err error) {
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
@@ -0,0 +1,174 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingCredentials is a CredentialProvider whose credentials and error can
+// be swapped by the test.
+type rotatingCredentials struct {
+	mu    sync.Mutex
+	creds credentials.Credentials
+	err   error
+	calls int
+}
+
+func (p *rotatingCredentials) Credentials(context.Context) (credentials.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	return p.creds, p.err
+}
+
+func (p *rotatingCredentials) set(creds credentials.Credentials, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.creds, p.err = creds, err
+}
+
+func TestSigners_ImplementKeyReporter(t *testing.T) {
+	var _ auth.KeyReporter = (*auth.HMACSigner)(nil)
+	var _ auth.KeyReporter = (*auth.JWTSigner)(nil)
+	var _ auth.KeyReporter = (*auth.BearerSigner)(nil)
+	var _ auth.KeyReporter = (*auth.MPCSigner)(nil)
+	var _ auth.KeyReporter = (*auth.HMACSHA512Signer)(nil)
+	var _ auth.KeyReporter = (*auth.Ed25519Signer)(nil)
+	var _ auth.KeyReporter = (*auth.RSASigner)(nil)
+}
+
+func TestHMACSigner_CredentialRotation(t *testing.T) {
+	ctx := context.Background()
+	req := auth.SignRequest{Method: "GET", Path: "/api/v3/brokerage/accounts", Timestamp: testTimestamp}
+	provider := &rotatingCredentials{creds: credentials.Credentials{KeyID: "key-1", Secret: testSecret, Passphrase: "pass-1"}}
+
+	var reported []error
+	signer, err := auth.NewHMACSigner(auth.HMACConfig{
+		Credentials:       provider,
+		OnCredentialError: func(err error) { reported = append(reported, err) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", signer.ActiveKeyID())
+
+	first, err := signer.Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", first.Headers["CB-ACCESS-KEY"])
+	assert.Equal(t, "pass-1", first.Headers["CB-ACCESS-PASSPHRASE"])
+
+	t.Run("rotation", func(t *testing.T) {
+		provider.set(credentials.Credentials{KeyID: "key-2", Secret: "b3RoZXItc2VjcmV0", Passphrase: "pass-2"}, nil)
+
+		result, err := signer.Sign(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "key-2", signer.ActiveKeyID())
+		assert.Equal(t, "key-2", result.Headers["CB-ACCESS-KEY"])
+		assert.Equal(t, "pass-2", result.Headers["CB-ACCESS-PASSPHRASE"])
+		assert.NotEqual(t, first.Headers["CB-ACCESS-SIGN"], result.Headers["CB-ACCESS-SIGN"])
+		assert.Empty(t, reported)
+	})
+
+	t.Run("invalid rotation keeps previous key", func(t *testing.T) {
+		provider.set(credentials.Credentials{KeyID: "key-3", Secret: testInvalidB64, Passphrase: "pass-3"}, nil)
+
+		for range 3 {
+			result, err := signer.Sign(ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, "key-2", result.Headers["CB-ACCESS-KEY"])
+		}
+		assert.Equal(t, "key-2", signer.ActiveKeyID())
+		require.Len(t, reported, 1, "rejected credentials are reported once")
+		assert.ErrorContains(t, reported[0], `"key-3"`)
+	})
+
+	t.Run("provider failure keeps previous key", func(t *testing.T) {
+		reported = nil
+		provider.set(credentials.Credentials{}, errors.New("secret manager unavailable"))
+
+		result, err := signer.Sign(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "key-2", result.Headers["CB-ACCESS-KEY"])
+		require.Len(t, reported, 1)
+		assert.ErrorContains(t, reported[0], "secret manager unavailable")
+	})
+}
+
+func TestJWTSigner_CredentialRotation(t *testing.T) {
+	ctx := context.Background()
+	req := auth.SignRequest{Method: "GET", Path: "/v1/portfolios"}
+	provider := &rotatingCredentials{creds: credentials.Credentials{KeyID: "key-1", Secret: generateTestECKey(t)}}
+
+	signer, err := auth.NewJWTSigner(auth.JWTConfig{
+		Credentials: provider,
+		ExpiresIn:   testExpiresIn,
+		CacheTokens: true,
+	})
+	require.NoError(t, err)
+
+	kid := func(result *auth.SignResult) string {
+		token, _, err := jwt.NewParser().ParseUnverified(result.Headers["Authorization"][len("Bearer "):], jwt.MapClaims{})
+		require.NoError(t, err)
+		return token.Header["kid"].(string)
+	}
+
+	first, err := signer.Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", kid(first))
+
+	provider.set(credentials.Credentials{KeyID: "key-2", Secret: generateTestECKey(t)}, nil)
+	rotated, err := signer.Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", signer.ActiveKeyID())
+	assert.Equal(t, "key-2", kid(rotated), "tokens cached for the previous key are not reused")
+
+	provider.set(credentials.Credentials{KeyID: "key-3", Secret: "not a pem key"}, nil)
+	kept, err := signer.Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", signer.ActiveKeyID())
+	assert.Equal(t, rotated.Headers["Authorization"], kept.Headers["Authorization"])
+}
+
+func TestBearerSigner_CredentialRotation(t *testing.T) {
+	provider := &rotatingCredentials{creds: credentials.Credentials{KeyID: "token-1", Secret: "secret-token-1"}}
+	signer, err := auth.NewBearerSigner(auth.BearerConfig{Credentials: provider})
+	require.NoError(t, err)
+
+	provider.set(credentials.Credentials{KeyID: "token-2", Secret: "secret-token-2"}, nil)
+	result, err := signer.Sign(context.Background(), auth.SignRequest{Method: "GET", Path: "/v1/balances"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret-token-2", result.Headers["Authorization"])
+	assert.Equal(t, "token-2", signer.ActiveKeyID())
+}
+
+func TestNewSigners_CredentialProvider(t *testing.T) {
+	provider := credentials.Static{KeyID: "key-1", Secret: testSecret}
+
+	t.Run("mutually exclusive with static credentials", func(t *testing.T) {
+		_, err := auth.NewHMACSigner(auth.HMACConfig{APIKey: testAPIKey, Credentials: provider})
+		assert.ErrorContains(t, err, "mutually exclusive")
+		_, err = auth.NewBearerSigner(auth.BearerConfig{Token: "token", Credentials: provider})
+		assert.ErrorContains(t, err, "mutually exclusive")
+	})
+
+	t.Run("initial credentials are required", func(t *testing.T) {
+		failing := &rotatingCredentials{err: errors.New("secret manager unavailable")}
+		_, err := auth.NewHMACSigner(auth.HMACConfig{Credentials: failing})
+		assert.ErrorContains(t, err, "secret manager unavailable")
+
+		_, err = auth.NewJWTSigner(auth.JWTConfig{Credentials: credentials.Static{KeyID: testKeyName}})
+		assert.Error(t, err, "invalid initial credentials fail construction")
+	})
+
+	t.Run("static credentials report their key", func(t *testing.T) {
+		signer, err := auth.NewHMACSigner(auth.HMACConfig{APIKey: testAPIKey, Secret: testSecret, Passphrase: testPassphrase})
+		require.NoError(t, err)
+		assert.Equal(t, testAPIKey, signer.ActiveKeyID())
+	})
+}
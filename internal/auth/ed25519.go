@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+)
+
+// Ed25519Config contains configuration for Ed25519 authentication.
+type Ed25519Config struct {
+	// APIKey is the API key identifier, sent in Layout.KeyHeader
+	APIKey string
+
+	// PrivateKey is the PEM-encoded PKCS#8 Ed25519 private key
+	// ("PRIVATE KEY", as generated by openssl genpkey -algorithm ed25519)
+	PrivateKey string
+
+	// Credentials, if set, supplies the API key (KeyID) and PEM-encoded
+	// private key (Secret) in place of the fields above, which must then be
+	// empty. Rotated credentials are used from the next request on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// credentials that cannot be used. Signing continues with the previous
+	// credentials.
+	OnCredentialError func(err error)
+
+	// Layout describes the signed payload and where the nonce, key and
+	// signature are sent.
+	Layout PayloadLayout
+
+	// Now returns the current time used for nonces (default: time.Now). Set
+	// it to a clock synchronized with the venue, such as
+	// client.ServerClock.Now, to compensate for local clock skew.
+	Now func() time.Time
+}
+
+// Ed25519Signer implements Ed25519 request signing, as used by Binance
+// Ed25519 API keys:
+//
+//	signature = Ed25519(privateKey, payload)
+//
+// where payload is built from the request as described by
+// Ed25519Config.Layout. Ed25519 signatures are deterministic, so a request
+// and nonce always produce the same signature.
+//
+// Thread-safe: This implementation is safe for concurrent use.
+type Ed25519Signer struct {
+	config Ed25519Config
+	signer *layoutSigner[ed25519.PrivateKey]
+}
+
+// NewEd25519Signer creates a new Ed25519 signer.
+func NewEd25519Signer(config Ed25519Config) (*Ed25519Signer, error) {
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{
+		KeyID:  config.APIKey,
+		Secret: config.PrivateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := newKeyring(provider, parseEd25519Credentials, config.OnCredentialError)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newLayoutSigner(config.Layout, config.Now, keys, signEd25519)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ed25519Signer{
+		config: config,
+		signer: signer,
+	}, nil
+}
+
+// parseEd25519Credentials validates Ed25519 credentials and parses the
+// private key.
+func parseEd25519Credentials(creds credentials.Credentials) (ed25519.PrivateKey, error) {
+	if creds.KeyID == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if creds.Secret == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
+
+	privateKey, err := parseEd25519PrivateKey(creds.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return privateKey, nil
+}
+
+// parseEd25519PrivateKey parses a PEM-encoded PKCS#8 Ed25519 private key.
+func parseEd25519PrivateKey(pemKey string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#8 private key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not an Ed25519 private key")
+	}
+	return edKey, nil
+}
+
+// signEd25519 returns the Ed25519 signature of payload.
+func signEd25519(privateKey ed25519.PrivateKey, payload []byte) ([]byte, error) {
+	return ed25519.Sign(privateKey, payload), nil
+}
+
+// ActiveKeyID returns the API key currently used for signing.
+func (s *Ed25519Signer) ActiveKeyID() string {
+	return s.signer.keys.keyID()
+}
+
+// Sign generates Ed25519 authentication for a request. The nonce is
+// req.Timestamp if set, or the current Unix time in the layout's nonce unit.
+// Depending on the layout, the result carries the nonce, API key and
+// signature as headers or query parameters, and a body with the nonce added.
+//
+// Returns an error if signature generation fails.
+func (s *Ed25519Signer) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
+	return s.signer.Sign(ctx, req)
+}
+
+// Verify that Ed25519Signer implements the Signer interface
+var _ Signer = (*Ed25519Signer)(nil)
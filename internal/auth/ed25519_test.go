@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// binanceLayout is Binance's signed endpoint scheme.
+var binanceLayout = auth.PayloadLayout{
+	Parts:          []auth.PayloadPart{auth.PartQuery, auth.PartBody},
+	NonceName:      "timestamp",
+	NoncePlacement: auth.NonceInQuery,
+	KeyHeader:      "X-MBX-APIKEY",
+	SignatureParam: "signature",
+}
+
+// binanceQuery is the order query signed by the Ed25519 and RSA vectors in
+// testdata/README.md, without its timestamp.
+const binanceQuery = "symbol=BTCUSDT&side=SELL&type=LIMIT&timeInForce=GTC&quantity=1&price=0.2"
+
+// readTestKey reads a PEM key from testdata.
+func readTestKey(t *testing.T, elem ...string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(append([]string{"testdata"}, elem...)...))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestNewEd25519Signer_Validation(t *testing.T) {
+	privateKey := readTestKey(t, "ed25519", "private_key.pem")
+
+	tests := []struct {
+		name    string
+		config  auth.Ed25519Config
+		wantErr string
+	}{
+		{
+			name:   "valid config",
+			config: auth.Ed25519Config{APIKey: "api-key", PrivateKey: privateKey, Layout: binanceLayout},
+		},
+		{
+			name:    "missing API key",
+			config:  auth.Ed25519Config{PrivateKey: privateKey, Layout: binanceLayout},
+			wantErr: "API key is required",
+		},
+		{
+			name:    "missing private key",
+			config:  auth.Ed25519Config{APIKey: "api-key", Layout: binanceLayout},
+			wantErr: "private key is required",
+		},
+		{
+			name:    "invalid PEM",
+			config:  auth.Ed25519Config{APIKey: "api-key", PrivateKey: "not a pem key", Layout: binanceLayout},
+			wantErr: "failed to parse private key",
+		},
+		{
+			name:    "EC key",
+			config:  auth.Ed25519Config{APIKey: "api-key", PrivateKey: readTestKey(t, "mpc", "private_key.pem"), Layout: binanceLayout},
+			wantErr: "failed to parse private key",
+		},
+		{
+			name:    "both static credentials and provider",
+			config:  auth.Ed25519Config{APIKey: "api-key", Credentials: credentials.Static{KeyID: "api-key", Secret: privateKey}, Layout: binanceLayout},
+			wantErr: "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := auth.NewEd25519Signer(tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, signer)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "api-key", signer.ActiveKeyID())
+		})
+	}
+}
+
+func TestEd25519Signer_Sign_TestVectors(t *testing.T) {
+	// testdata/ed25519/private_key.pem holds the RFC 8032 TEST 1 key
+	privateKey := readTestKey(t, "ed25519", "private_key.pem")
+
+	t.Run("RFC 8032 TEST 1", func(t *testing.T) {
+		signer, err := auth.NewEd25519Signer(auth.Ed25519Config{
+			APIKey:     "api-key",
+			PrivateKey: privateKey,
+			Layout: auth.PayloadLayout{
+				Parts:             []auth.PayloadPart{auth.PartBody},
+				SignatureHeader:   "X-Signature",
+				SignatureEncoding: auth.SignatureHex,
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := signer.Sign(context.Background(), auth.SignRequest{Method: "GET", Path: "/"})
+		require.NoError(t, err)
+		assert.Equal(t, "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+			result.Headers["X-Signature"])
+	})
+
+	t.Run("binance layout", func(t *testing.T) {
+		signer, err := auth.NewEd25519Signer(auth.Ed25519Config{
+			APIKey:     "api-key",
+			PrivateKey: privateKey,
+			Layout:     binanceLayout,
+		})
+		require.NoError(t, err)
+
+		result, err := signer.Sign(context.Background(), auth.SignRequest{
+			Method:    "POST",
+			Path:      "/api/v3/order",
+			RawQuery:  binanceQuery,
+			Timestamp: "1668481559918",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"timestamp": "1668481559918",
+			"signature": "y9aW/+h7Ht5RYUgJlwCrziJWVCQEAVswbfIWMUW+f+Qh0+7YURsnrZrwHST7Y8ZGRlCC4fmkbiGNSmN27XYgBw==",
+		}, result.QueryParams)
+		assert.Equal(t, "signature", result.TrailingQueryParam)
+		assert.Equal(t, map[string]string{"X-MBX-APIKEY": "api-key"}, result.Headers)
+	})
+}
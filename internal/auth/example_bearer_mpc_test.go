@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/Combine-Capital/cqvx/internal/auth"
 )
@@ -138,3 +139,48 @@ func ExampleMPCSigner_withCustomSigner() {
 	// All requests will be signed via MPC
 	_ = client // Use client for requests
 }
+
+// ExampleMPCSigner_privateKey demonstrates Fordefi API request signing with a
+// P-256 private key.
+func ExampleMPCSigner_privateKey() {
+	keyPEM, err := os.ReadFile("testdata/mpc/private_key.pem")
+	if err != nil {
+		log.Fatalf("failed to read private key: %v", err)
+	}
+
+	signer, err := auth.NewMPCSigner(auth.MPCConfig{
+		APIKey:     "your-api-user-token",
+		PrivateKey: string(keyPEM),
+	})
+	if err != nil {
+		log.Fatalf("failed to create MPC signer: %v", err)
+	}
+
+	req := auth.SignRequest{
+		Method:    "GET",
+		Path:      "/api/v1/vaults",
+		Timestamp: "1700000000000",
+	}
+	result, err := signer.Sign(context.Background(), req)
+	if err != nil {
+		log.Fatalf("failed to sign request: %v", err)
+	}
+
+	// Check the signature with the public key
+	pubPEM, err := os.ReadFile("testdata/mpc/public_key.pem")
+	if err != nil {
+		log.Fatalf("failed to read public key: %v", err)
+	}
+	payload := auth.MPCSignaturePayload(req.Path, req.Timestamp, req.Body)
+	verifyErr := auth.VerifyMPCSignature(string(pubPEM), payload, result.Headers["X-Signature"])
+
+	fmt.Printf("Authorization: %s\n", result.Headers["Authorization"])
+	fmt.Printf("X-Timestamp: %s\n", result.Headers["X-Timestamp"])
+	fmt.Printf("Payload: %s\n", payload)
+	fmt.Printf("Signature valid: %v\n", verifyErr == nil)
+	// Output:
+	// Authorization: Bearer your-api-user-token
+	// X-Timestamp: 1700000000000
+	// Payload: /api/v1/vaults|1700000000000|
+	// Signature valid: true
+}
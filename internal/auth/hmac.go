@@ -6,9 +6,13 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
 )
 
 // HMACConfig contains configuration for HMAC-SHA256 authentication.
@@ -21,12 +25,31 @@ type HMACConfig struct {
 
 	// Passphrase is the API passphrase (CB-ACCESS-PASSPHRASE header)
 	Passphrase string
+
+	// Credentials, if set, supplies the API key (KeyID), secret and
+	// passphrase in place of the fields above, which must then be empty.
+	// Rotated credentials are used from the next request on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// credentials that cannot be used. Signing continues with the previous
+	// credentials.
+	OnCredentialError func(err error)
+
+	// Now returns the current time used for request timestamps
+	// (default: time.Now). Set it to a clock synchronized with the venue, such
+	// as client.ServerClock.Now, to compensate for local clock skew.
+	Now func() time.Time
 }
 
 // HMACSigner implements Coinbase Exchange HMAC-SHA256 authentication.
 // It generates signatures according to Coinbase's authentication specification:
 //
-//	CB-ACCESS-SIGN = base64(hmac_sha256(base64_decode(secret), timestamp + method + path + body))
+//	CB-ACCESS-SIGN = base64(hmac_sha256(base64_decode(secret), timestamp + method + requestPath + body))
+//
+// where requestPath is the path followed by the query string exactly as sent
+// (e.g., "/orders?limit=10&product_id=BTC-USD"). Coinbase verifies the request
+// target byte for byte, so the query is neither reordered nor re-encoded.
 //
 // Required headers:
 //   - CB-ACCESS-KEY: The API key
@@ -34,33 +57,59 @@ type HMACConfig struct {
 //   - CB-ACCESS-TIMESTAMP: Unix timestamp in seconds
 //   - CB-ACCESS-PASSPHRASE: The API passphrase
 //
+// The key, secret and passphrase rotate together when HMACConfig.Credentials
+// supplies new ones; each request is signed with a single consistent set.
+//
 // Thread-safe: This implementation is safe for concurrent use.
 type HMACSigner struct {
 	config HMACConfig
+	keys   *keyring[[]byte] // decoded secrets
 }
 
 // NewHMACSigner creates a new HMAC-SHA256 signer for Coinbase Exchange.
 // The secret must be base64-encoded as provided by Coinbase.
 func NewHMACSigner(config HMACConfig) (*HMACSigner, error) {
-	if config.APIKey == "" {
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{
+		KeyID:      config.APIKey,
+		Secret:     config.Secret,
+		Passphrase: config.Passphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := newKeyring(provider, parseHMACCredentials, config.OnCredentialError)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HMACSigner{
+		config: config,
+		keys:   keys,
+	}, nil
+}
+
+// parseHMACCredentials validates HMAC credentials and decodes the secret.
+func parseHMACCredentials(creds credentials.Credentials) ([]byte, error) {
+	if creds.KeyID == "" {
 		return nil, fmt.Errorf("API key is required")
 	}
-	if config.Secret == "" {
+	if creds.Secret == "" {
 		return nil, fmt.Errorf("secret is required")
 	}
-	if config.Passphrase == "" {
+	if creds.Passphrase == "" {
 		return nil, fmt.Errorf("passphrase is required")
 	}
 
-	// Validate that secret is valid base64
-	_, err := base64.StdEncoding.DecodeString(config.Secret)
+	secret, err := base64.StdEncoding.DecodeString(creds.Secret)
 	if err != nil {
 		return nil, fmt.Errorf("secret must be valid base64: %w", err)
 	}
+	return secret, nil
+}
 
-	return &HMACSigner{
-		config: config,
-	}, nil
+// ActiveKeyID returns the API key currently used for signing.
+func (s *HMACSigner) ActiveKeyID() string {
+	return s.keys.keyID()
 }
 
 // Sign generates HMAC-SHA256 authentication headers for a Coinbase API request.
@@ -68,30 +117,28 @@ func NewHMACSigner(config HMACConfig) (*HMACSigner, error) {
 //
 // The signature is computed as:
 //
-//	prehash = timestamp + method + path + body
+//	prehash = timestamp + method + requestPath + body
 //	signature = base64(HMAC-SHA256(base64_decode(secret), prehash))
 //
+// where requestPath is req.RequestPath().
+//
 // Returns an error if signature generation fails.
 func (s *HMACSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
+	active := s.keys.current(ctx)
+
 	// Generate timestamp if not provided (Unix seconds as string)
 	timestamp := req.Timestamp
 	if timestamp == "" {
-		timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+		timestamp = strconv.FormatInt(currentTime(s.config.Now).Unix(), 10)
 	}
 
-	// Construct prehash string: timestamp + method + path + body
+	// Construct prehash string: timestamp + method + requestPath + body
 	// For GET requests with no body, body should be empty string
 	body := string(req.Body)
-	prehash := timestamp + req.Method + req.Path + body
+	prehash := timestamp + req.Method + req.RequestPath() + body
 
-	// Decode the base64-encoded secret
-	decodedSecret, err := base64.StdEncoding.DecodeString(s.config.Secret)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode secret: %w", err)
-	}
-
-	// Compute HMAC-SHA256
-	h := hmac.New(sha256.New, decodedSecret)
+	// Compute HMAC-SHA256 with the decoded secret
+	h := hmac.New(sha256.New, active.key)
 	h.Write([]byte(prehash))
 	signature := h.Sum(nil)
 
@@ -101,13 +148,46 @@ func (s *HMACSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, er
 	// Return authentication headers
 	return &SignResult{
 		Headers: map[string]string{
-			"CB-ACCESS-KEY":        s.config.APIKey,
+			"CB-ACCESS-KEY":        active.creds.KeyID,
 			"CB-ACCESS-SIGN":       signatureB64,
 			"CB-ACCESS-TIMESTAMP":  timestamp,
-			"CB-ACCESS-PASSPHRASE": s.config.Passphrase,
+			"CB-ACCESS-PASSPHRASE": active.creds.Passphrase,
 		},
 	}, nil
 }
 
+// SubscriptionSignature authenticates a Coinbase Advanced Trade WebSocket
+// subscribe message; its fields are sent as the message's api_key, timestamp
+// and signature.
+type SubscriptionSignature struct {
+	APIKey    string
+	Timestamp string
+	Signature string
+}
+
+// SignSubscription signs a Coinbase Advanced Trade WebSocket subscribe message
+// for channel and productIDs. The signature is computed as:
+//
+//	prehash = timestamp + channel + strings.Join(productIDs, ",")
+//	signature = hex(HMAC-SHA256(secret, prehash))
+//
+// The feed authenticates the subscribe message itself rather than a request
+// path, so the passphrase is not sent and the secret is used as provided.
+func (s *HMACSigner) SignSubscription(ctx context.Context, channel string, productIDs []string) (*SubscriptionSignature, error) {
+	active := s.keys.current(ctx)
+
+	timestamp := strconv.FormatInt(currentTime(s.config.Now).Unix(), 10)
+	prehash := timestamp + channel + strings.Join(productIDs, ",")
+
+	h := hmac.New(sha256.New, []byte(active.creds.Secret))
+	h.Write([]byte(prehash))
+
+	return &SubscriptionSignature{
+		APIKey:    active.creds.KeyID,
+		Timestamp: timestamp,
+		Signature: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
 // Verify that HMACSigner implements the Signer interface
 var _ Signer = (*HMACSigner)(nil)
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+)
+
+// HMACSHA512Config contains configuration for HMAC-SHA512 authentication.
+type HMACSHA512Config struct {
+	// APIKey is the API key identifier, sent in Layout.KeyHeader
+	APIKey string
+
+	// Secret is the base64-encoded API secret (will be decoded for signing)
+	Secret string
+
+	// Credentials, if set, supplies the API key (KeyID) and secret in place
+	// of the fields above, which must then be empty. Rotated credentials are
+	// used from the next request on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// credentials that cannot be used. Signing continues with the previous
+	// credentials.
+	OnCredentialError func(err error)
+
+	// Layout describes the signed payload and where the nonce, key and
+	// signature are sent.
+	Layout PayloadLayout
+
+	// Now returns the current time used for nonces (default: time.Now). Set
+	// it to a clock synchronized with the venue, such as
+	// client.ServerClock.Now, to compensate for local clock skew.
+	Now func() time.Time
+}
+
+// HMACSHA512Signer implements HMAC-SHA512 authentication with base64-encoded
+// secrets, as used by Kraken-style venues:
+//
+//	signature = HMAC-SHA512(base64_decode(secret), payload)
+//
+// where payload is built from the request as described by
+// HMACSHA512Config.Layout. Nonces are Unix times that strictly increase
+// across the signer's requests.
+//
+// Thread-safe: This implementation is safe for concurrent use.
+type HMACSHA512Signer struct {
+	config HMACSHA512Config
+	signer *layoutSigner[[]byte] // decoded secrets
+}
+
+// NewHMACSHA512Signer creates a new HMAC-SHA512 signer.
+// The secret must be base64-encoded as provided by the venue.
+func NewHMACSHA512Signer(config HMACSHA512Config) (*HMACSHA512Signer, error) {
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{
+		KeyID:  config.APIKey,
+		Secret: config.Secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := newKeyring(provider, parseHMACSHA512Credentials, config.OnCredentialError)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newLayoutSigner(config.Layout, config.Now, keys, signHMACSHA512)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HMACSHA512Signer{
+		config: config,
+		signer: signer,
+	}, nil
+}
+
+// parseHMACSHA512Credentials validates HMAC-SHA512 credentials and decodes
+// the secret.
+func parseHMACSHA512Credentials(creds credentials.Credentials) ([]byte, error) {
+	if creds.KeyID == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if creds.Secret == "" {
+		return nil, fmt.Errorf("secret is required")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(creds.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("secret must be valid base64: %w", err)
+	}
+	return secret, nil
+}
+
+// signHMACSHA512 returns the HMAC-SHA512 of payload keyed with secret.
+func signHMACSHA512(secret, payload []byte) ([]byte, error) {
+	h := hmac.New(sha512.New, secret)
+	h.Write(payload)
+	return h.Sum(nil), nil
+}
+
+// ActiveKeyID returns the API key currently used for signing.
+func (s *HMACSHA512Signer) ActiveKeyID() string {
+	return s.signer.keys.keyID()
+}
+
+// Sign generates HMAC-SHA512 authentication for a request. The nonce is
+// req.Timestamp if set, or the current Unix time in the layout's nonce unit.
+// Depending on the layout, the result carries the nonce, API key and
+// signature as headers or query parameters, and a body with the nonce added.
+//
+// Returns an error if signature generation fails.
+func (s *HMACSHA512Signer) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
+	return s.signer.Sign(ctx, req)
+}
+
+// Verify that HMACSHA512Signer implements the Signer interface
+var _ Signer = (*HMACSHA512Signer)(nil)
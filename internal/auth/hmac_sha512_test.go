@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// krakenLayout is Kraken's REST signing scheme.
+var krakenLayout = auth.PayloadLayout{
+	Parts:           []auth.PayloadPart{auth.PartPath, auth.PartNonceBodySHA256},
+	NonceName:       "nonce",
+	NoncePlacement:  auth.NonceInBody,
+	KeyHeader:       "API-Key",
+	SignatureHeader: "API-Sign",
+}
+
+const krakenSecret = "kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg=="
+
+func TestNewHMACSHA512Signer_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  auth.HMACSHA512Config
+		wantErr string
+	}{
+		{
+			name:   "valid config",
+			config: auth.HMACSHA512Config{APIKey: "api-key", Secret: krakenSecret, Layout: krakenLayout},
+		},
+		{
+			name:    "missing API key",
+			config:  auth.HMACSHA512Config{Secret: krakenSecret, Layout: krakenLayout},
+			wantErr: "API key is required",
+		},
+		{
+			name:    "missing secret",
+			config:  auth.HMACSHA512Config{APIKey: "api-key", Layout: krakenLayout},
+			wantErr: "secret is required",
+		},
+		{
+			name:    "invalid base64 secret",
+			config:  auth.HMACSHA512Config{APIKey: "api-key", Secret: testInvalidB64, Layout: krakenLayout},
+			wantErr: "secret must be valid base64",
+		},
+		{
+			name:    "missing layout",
+			config:  auth.HMACSHA512Config{APIKey: "api-key", Secret: krakenSecret},
+			wantErr: "invalid payload layout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := auth.NewHMACSHA512Signer(tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, signer)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "api-key", signer.ActiveKeyID())
+		})
+	}
+}
+
+func TestHMACSHA512Signer_Sign_KrakenTestVector(t *testing.T) {
+	// Vector from Kraken's REST authentication documentation; see
+	// testdata/README.md
+	signer, err := auth.NewHMACSHA512Signer(auth.HMACSHA512Config{
+		APIKey: "api-key",
+		Secret: krakenSecret,
+		Layout: krakenLayout,
+	})
+	require.NoError(t, err)
+
+	result, err := signer.Sign(context.Background(), auth.SignRequest{
+		Method:    "POST",
+		Path:      "/0/private/AddOrder",
+		Body:      []byte("ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25"),
+		Timestamp: "1616492376594",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "nonce=1616492376594&ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25", string(result.Body))
+	assert.Equal(t, "api-key", result.Headers["API-Key"])
+	assert.Equal(t, "4/dpxb3iT4tp/ZCVEwSnEsLxx0bqyhLpdfOpc6fn7OR8+UClSV5n9E6aSS8MPtnRfp32bAb0nmbRn6H8ndwLUQ==", result.Headers["API-Sign"])
+	assert.Empty(t, result.QueryParams)
+}
+
+func TestHMACSHA512Signer_Sign_RFC4231TestVector(t *testing.T) {
+	// RFC 4231 test case 2
+	signer, err := auth.NewHMACSHA512Signer(auth.HMACSHA512Config{
+		APIKey: "api-key",
+		Secret: base64.StdEncoding.EncodeToString([]byte("Jefe")),
+		Layout: auth.PayloadLayout{
+			Parts:             []auth.PayloadPart{auth.PartBody},
+			SignatureHeader:   "X-Signature",
+			SignatureEncoding: auth.SignatureHex,
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := signer.Sign(context.Background(), auth.SignRequest{
+		Method: "POST",
+		Path:   "/",
+		Body:   []byte("what do ya want for nothing?"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "164b7a7bfcf819e2e395fbe73b56e0a387bd64222e831fd610270cd7ea2505549758bf75c05a994a6d034f65f8f0e6fdcaeab1a34d4a6b4b636e070a38bce737",
+		result.Headers["X-Signature"])
+	assert.Nil(t, result.Body, "body is unchanged without a nonce")
+}
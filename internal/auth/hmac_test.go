@@ -2,8 +2,12 @@ package auth_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/Combine-Capital/cqvx/internal/auth"
 	"github.com/stretchr/testify/assert"
@@ -144,6 +148,20 @@ func TestHMACSigner_Sign_GeneratesTimestamp(t *testing.T) {
 	assert.Regexp(t, "^[0-9]+$", result.Headers["CB-ACCESS-TIMESTAMP"])
 }
 
+func TestHMACSigner_Sign_UsesConfiguredClock(t *testing.T) {
+	signer, err := auth.NewHMACSigner(auth.HMACConfig{
+		APIKey:     testAPIKey,
+		Secret:     testSecret,
+		Passphrase: testPassphrase,
+		Now:        func() time.Time { return time.Unix(1640995200, 0) },
+	})
+	require.NoError(t, err)
+
+	result, err := signer.Sign(context.Background(), auth.SignRequest{Method: "GET", Path: "/api/v3/brokerage/accounts"})
+	require.NoError(t, err)
+	assert.Equal(t, testTimestamp, result.Headers["CB-ACCESS-TIMESTAMP"])
+}
+
 func TestHMACSigner_Sign_PostWithBody(t *testing.T) {
 	config := auth.HMACConfig{
 		APIKey:     testAPIKey,
@@ -435,6 +453,83 @@ func TestHMACSigner_Sign_KnownTestVector(t *testing.T) {
 	assert.Equal(t, expectedSignature, result.Headers["CB-ACCESS-SIGN"])
 }
 
+func TestHMACSigner_Sign_QueryTestVectors(t *testing.T) {
+	signer, err := auth.NewHMACSigner(auth.HMACConfig{
+		APIKey:     "api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("secret")),
+		Passphrase: "passphrase",
+	})
+	require.NoError(t, err)
+
+	// Vectors from testdata/README.md. The query is signed exactly as sent:
+	// reordering or re-encoding it changes the signature.
+	tests := []struct {
+		name      string
+		path      string
+		rawQuery  string
+		signature string
+	}{
+		{
+			name:      "query",
+			path:      "/orders",
+			rawQuery:  "product_id=BTC-USD&limit=10",
+			signature: "pXbliwh9rlLsufNtlzEehrkuWtgrGpNAlrROEXBapho=",
+		},
+		{
+			name:      "reordered query",
+			path:      "/orders",
+			rawQuery:  "limit=10&product_id=BTC-USD",
+			signature: "6e6/EkYPS749COcvXu69n67i8uW7D6d1rJj+xj4wDU8=",
+		},
+		{
+			name:      "encoded and repeated parameters",
+			path:      "/orders/historical/batch",
+			rawQuery:  "start_date=2024-01-15T10%3A30%3A00Z&order_status=OPEN&order_status=FILLED",
+			signature: "b0FpEtV0FIeMj+/YqRoEuDv0C+lqLREcXOrmbOuNmIQ=",
+		},
+		{
+			name:      "no query",
+			path:      "/orders",
+			signature: "c0bz9rdYCiGfAsKzIyfvmtx6eU1fbWn3SVcwKIVqZM4=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := signer.Sign(context.Background(), auth.SignRequest{
+				Method:    "GET",
+				Path:      tt.path,
+				RawQuery:  tt.rawQuery,
+				Timestamp: "1234567890",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.signature, result.Headers["CB-ACCESS-SIGN"])
+		})
+	}
+}
+
+func TestHMACSigner_SignSubscription(t *testing.T) {
+	signer, err := auth.NewHMACSigner(auth.HMACConfig{
+		APIKey:     "api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("secret")),
+		Passphrase: "passphrase",
+		Now:        func() time.Time { return time.Unix(1234567890, 0) },
+	})
+	require.NoError(t, err)
+
+	result, err := signer.SignSubscription(context.Background(), "level2", []string{"BTC-USD", "ETH-USD"})
+	require.NoError(t, err)
+
+	// prehash = "1234567890level2BTC-USD,ETH-USD", keyed by the secret as provided
+	mac := hmac.New(sha256.New, []byte("c2VjcmV0"))
+	mac.Write([]byte("1234567890level2BTC-USD,ETH-USD"))
+	assert.Equal(t, &auth.SubscriptionSignature{
+		APIKey:    "api-key",
+		Timestamp: "1234567890",
+		Signature: hex.EncodeToString(mac.Sum(nil)),
+	}, result)
+}
+
 func TestHMACSigner_ImplementsSigner(t *testing.T) {
 	config := auth.HMACConfig{
 		APIKey:     testAPIKey,
@@ -10,8 +10,10 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -23,8 +25,33 @@ type JWTConfig struct {
 	// PrivateKey is the PEM-encoded EC private key
 	PrivateKey string
 
+	// Credentials, if set, supplies the key name (KeyID) and PEM-encoded
+	// private key (Secret) in place of the fields above, which must then be
+	// empty. Rotated keys are used from the next request on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// a key that cannot be parsed. Signing continues with the previous key.
+	OnCredentialError func(err error)
+
 	// ExpiresIn is the JWT expiration time in seconds (default: 120)
 	ExpiresIn int64
+
+	// CacheTokens reuses the token signed for a uri for later requests to
+	// the same uri until RefreshMargin before it expires, instead of signing
+	// a token per request. Coinbase accepts a token for any number of
+	// requests within its validity window.
+	CacheTokens bool
+
+	// RefreshMargin is how many seconds before expiry a cached token is
+	// replaced, leaving it time to reach the venue (default: 30, or half of
+	// ExpiresIn if that is shorter). Only used with CacheTokens.
+	RefreshMargin int64
+
+	// Now returns the current time used for the nbf and exp claims
+	// (default: time.Now). Set it to a clock synchronized with the venue, such
+	// as client.ServerClock.Now, to compensate for local clock skew.
+	Now func() time.Time
 }
 
 // JWTSigner implements Coinbase Prime JWT authentication using ES256.
@@ -43,44 +70,91 @@ type JWTConfig struct {
 //   - sub: API key name
 //   - uri: "{METHOD} {HOST}{PATH}" (e.g., "GET api.coinbase.com/api/v3/brokerage/accounts")
 //
+// The uri claim never includes the query string: Coinbase matches it against
+// the request path alone, so "GET /orders?limit=10" is signed as "GET
+// api.coinbase.com/orders".
+//
+// With JWTConfig.CacheTokens, each token is reused for its uri while it is
+// valid by the signer's clock and more than RefreshMargin from expiry. The
+// nonce is then per token rather than per request. Tokens signed with a key
+// that has since been rotated out are not reused.
+//
 // Thread-safe: This implementation is safe for concurrent use.
 type JWTSigner struct {
-	config     JWTConfig
-	privateKey *ecdsa.PrivateKey
+	config JWTConfig
+	keys   *keyring[*ecdsa.PrivateKey]
+	cache  *tokenCache // nil unless CacheTokens is set
 }
 
 // NewJWTSigner creates a new JWT signer for Coinbase Prime.
 // The private key must be a PEM-encoded EC private key.
 func NewJWTSigner(config JWTConfig) (*JWTSigner, error) {
-	if config.KeyName == "" {
-		return nil, fmt.Errorf("key name is required")
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{
+		KeyID:  config.KeyName,
+		Secret: config.PrivateKey,
+	})
+	if err != nil {
+		return nil, err
 	}
-	if config.PrivateKey == "" {
-		return nil, fmt.Errorf("private key is required")
+	keys, err := newKeyring(provider, parseJWTCredentials, config.OnCredentialError)
+	if err != nil {
+		return nil, err
 	}
 
 	// Set default expiration if not provided
 	if config.ExpiresIn <= 0 {
 		config.ExpiresIn = 120 // 2 minutes default
 	}
+	if config.RefreshMargin < 0 {
+		return nil, fmt.Errorf("refresh margin must be non-negative")
+	}
+	if config.RefreshMargin == 0 {
+		config.RefreshMargin = min(defaultRefreshMargin, config.ExpiresIn/2)
+	}
+	if config.CacheTokens && config.RefreshMargin >= config.ExpiresIn {
+		return nil, fmt.Errorf("refresh margin must be less than the token expiration time")
+	}
+
+	signer := &JWTSigner{
+		config: config,
+		keys:   keys,
+	}
+	if config.CacheTokens {
+		signer.cache = &tokenCache{tokens: make(map[string]cachedToken)}
+	}
+	return signer, nil
+}
+
+// parseJWTCredentials validates JWT credentials and parses the PEM-encoded
+// private key.
+func parseJWTCredentials(creds credentials.Credentials) (*ecdsa.PrivateKey, error) {
+	if creds.KeyID == "" {
+		return nil, fmt.Errorf("key name is required")
+	}
+	if creds.Secret == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
 
-	// Parse PEM-encoded private key
-	privateKey, err := parseECPrivateKey(config.PrivateKey)
+	privateKey, err := parseECPrivateKey(creds.Secret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
+	return privateKey, nil
+}
 
-	return &JWTSigner{
-		config:     config,
-		privateKey: privateKey,
-	}, nil
+// ActiveKeyID returns the API key name currently used for signing.
+func (s *JWTSigner) ActiveKeyID() string {
+	return s.keys.keyID()
 }
 
 // Sign generates a JWT for authenticating Coinbase Prime API requests.
 // It returns an Authorization: Bearer <JWT> header.
 //
 // The URI is constructed from the request method, a host (derived from headers or default),
-// and the request path. Format: "{METHOD} {HOST}{PATH}"
+// and the request path without its query string. Format: "{METHOD} {HOST}{PATH}"
+//
+// With CacheTokens, a token cached for the URI is returned while it is still
+// fresh; otherwise a new one is signed and cached.
 //
 // Returns an error if JWT generation fails.
 func (s *JWTSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
@@ -93,42 +167,119 @@ func (s *JWTSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, err
 	// Construct URI: "METHOD HOST/PATH"
 	uri := fmt.Sprintf("%s %s%s", req.Method, host, req.Path)
 
+	active := s.keys.current(ctx)
+	now := currentTime(s.config.Now).Unix()
+	if s.cache != nil {
+		if token, ok := s.cache.get(uri, now, s.config.RefreshMargin, active.key); ok {
+			return bearerResult(token), nil
+		}
+	}
+
+	tokenString, err := s.newToken(active, uri, now)
+	if err != nil {
+		return nil, err
+	}
+	if s.cache != nil {
+		s.cache.put(uri, cachedToken{
+			token:     tokenString,
+			key:       active.key,
+			notBefore: now,
+			expires:   now + s.config.ExpiresIn,
+		})
+	}
+	return bearerResult(tokenString), nil
+}
+
+// newToken creates and signs a JWT for uri with the active key that is valid
+// from now, a Unix timestamp, for ExpiresIn seconds.
+func (s *JWTSigner) newToken(active *activeKey[*ecdsa.PrivateKey], uri string, now int64) (string, error) {
 	// Generate random nonce for replay protection
 	nonce, err := generateNonce()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	// Current time for nbf and exp claims
-	now := time.Now().Unix()
-
 	// Create JWT claims
 	claims := jwt.MapClaims{
 		"iss": "cdp",
 		"nbf": now,
 		"exp": now + s.config.ExpiresIn,
-		"sub": s.config.KeyName,
+		"sub": active.creds.KeyID,
 		"uri": uri,
 	}
 
 	// Create JWT with custom headers
 	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
-	token.Header["kid"] = s.config.KeyName
+	token.Header["kid"] = active.creds.KeyID
 	token.Header["nonce"] = nonce
 	token.Header["typ"] = "JWT"
 
 	// Sign the token
-	tokenString, err := token.SignedString(s.privateKey)
+	tokenString, err := token.SignedString(active.key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign JWT: %w", err)
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
 	}
+	return tokenString, nil
+}
 
-	// Return Authorization: Bearer header
+// bearerResult returns the Authorization header carrying token.
+func bearerResult(token string) *SignResult {
 	return &SignResult{
 		Headers: map[string]string{
-			"Authorization": "Bearer " + tokenString,
+			"Authorization": "Bearer " + token,
 		},
-	}, nil
+	}
+}
+
+// defaultRefreshMargin is the default JWTConfig.RefreshMargin in seconds.
+const defaultRefreshMargin = 30
+
+// cachedToken is a signed JWT, the key that signed it and its validity
+// window in Unix seconds.
+type cachedToken struct {
+	token     string
+	key       *ecdsa.PrivateKey
+	notBefore int64
+	expires   int64
+}
+
+// tokenCache holds the latest token signed for each uri. Expired tokens are
+// swept out at most once per sweep interval, so the cache stays bounded by the
+// number of distinct uris signed within a token lifetime.
+type tokenCache struct {
+	mu        sync.RWMutex
+	tokens    map[string]cachedToken
+	nextSweep int64
+}
+
+// get returns the token cached for uri if it was signed with key and is valid
+// at now and more than margin seconds from expiry. Checking nbf as well means
+// a clock that was corrected backwards does not reuse tokens from the venue's
+// future.
+func (c *tokenCache) get(uri string, now, margin int64, key *ecdsa.PrivateKey) (string, bool) {
+	c.mu.RLock()
+	cached, ok := c.tokens[uri]
+	c.mu.RUnlock()
+	if !ok || cached.key != key || now < cached.notBefore || now >= cached.expires-margin {
+		return "", false
+	}
+	return cached.token, true
+}
+
+// put caches token for uri, sweeping out expired tokens if a sweep is due.
+func (c *tokenCache) put(uri string, token cachedToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if token.notBefore >= c.nextSweep {
+		for key, cached := range c.tokens {
+			if cached.expires <= token.notBefore {
+				delete(c.tokens, key)
+			}
+		}
+		c.nextSweep = token.expires
+	}
+	c.tokens[uri] = token
 }
 
 // parseECPrivateKey parses a PEM-encoded EC private key.
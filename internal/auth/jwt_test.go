@@ -7,7 +7,10 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -94,6 +97,26 @@ func TestNewJWTSigner_Validation(t *testing.T) {
 			},
 			expectError: "private key is required",
 		},
+		{
+			name: "refresh margin not less than expiry",
+			config: auth.JWTConfig{
+				KeyName:       testKeyName,
+				PrivateKey:    privateKey,
+				ExpiresIn:     testExpiresIn,
+				CacheTokens:   true,
+				RefreshMargin: testExpiresIn,
+			},
+			expectError: "refresh margin must be less than the token expiration time",
+		},
+		{
+			name: "negative refresh margin",
+			config: auth.JWTConfig{
+				KeyName:       testKeyName,
+				PrivateKey:    privateKey,
+				RefreshMargin: -1,
+			},
+			expectError: "refresh margin must be non-negative",
+		},
 		{
 			name: "invalid PEM format",
 			config: auth.JWTConfig{
@@ -190,6 +213,7 @@ func TestJWTSigner_Sign_URIConstruction(t *testing.T) {
 		name        string
 		method      string
 		path        string
+		rawQuery    string
 		expectedURI string
 	}{
 		{
@@ -210,13 +234,21 @@ func TestJWTSigner_Sign_URIConstruction(t *testing.T) {
 			path:        "/api/v3/brokerage/orders/123",
 			expectedURI: "DELETE api.coinbase.com/api/v3/brokerage/orders/123",
 		},
+		{
+			name:        "query is excluded",
+			method:      "GET",
+			path:        "/api/v3/brokerage/orders/historical/batch",
+			rawQuery:    "product_ids=BTC-USD&start_date=2024-01-15T10%3A30%3A00Z",
+			expectedURI: "GET api.coinbase.com/api/v3/brokerage/orders/historical/batch",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := auth.SignRequest{
-				Method: tt.method,
-				Path:   tt.path,
+				Method:   tt.method,
+				Path:     tt.path,
+				RawQuery: tt.rawQuery,
 			}
 
 			result, err := signer.Sign(context.Background(), req)
@@ -277,6 +309,121 @@ func TestJWTSigner_Sign_ExpirationTime(t *testing.T) {
 	assert.Equal(t, int64(nbf)+60, int64(exp))
 }
 
+func TestJWTSigner_Sign_UsesConfiguredClock(t *testing.T) {
+	serverTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	signer, err := auth.NewJWTSigner(auth.JWTConfig{
+		KeyName:    testKeyName,
+		PrivateKey: generateTestECKey(t),
+		Now:        func() time.Time { return serverTime },
+	})
+	require.NoError(t, err)
+
+	result, err := signer.Sign(context.Background(), auth.SignRequest{Method: "GET", Path: "/v1/portfolios"})
+	require.NoError(t, err)
+
+	tokenString := strings.TrimPrefix(result.Headers["Authorization"], "Bearer ")
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	require.NoError(t, err)
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, float64(serverTime.Unix()), claims["nbf"])
+	assert.Equal(t, float64(serverTime.Unix()+testExpiresIn), claims["exp"])
+}
+
+func TestJWTSigner_Sign_CacheTokens(t *testing.T) {
+	privateKey := generateTestECKey(t)
+	var mu sync.Mutex
+	now := time.Unix(1700000000, 0)
+	setNow := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+	newSigner := func(t *testing.T) *auth.JWTSigner {
+		signer, err := auth.NewJWTSigner(auth.JWTConfig{
+			KeyName:     testKeyName,
+			PrivateKey:  privateKey,
+			ExpiresIn:   testExpiresIn,
+			CacheTokens: true,
+			Now: func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+				return now
+			},
+		})
+		require.NoError(t, err)
+		return signer
+	}
+	sign := func(t *testing.T, signer *auth.JWTSigner, path string) string {
+		result, err := signer.Sign(context.Background(), auth.SignRequest{Method: "GET", Path: path})
+		require.NoError(t, err)
+		return result.Headers["Authorization"]
+	}
+	start := time.Unix(1700000000, 0)
+
+	t.Run("reused per uri", func(t *testing.T) {
+		setNow(start)
+		signer := newSigner(t)
+		token := sign(t, signer, "/v1/portfolios/p1/orders")
+
+		setNow(start.Add(60 * time.Second))
+		assert.Equal(t, token, sign(t, signer, "/v1/portfolios/p1/orders"))
+		assert.NotEqual(t, token, sign(t, signer, "/v1/portfolios/p1/balances"))
+	})
+
+	t.Run("refreshed within margin of expiry", func(t *testing.T) {
+		setNow(start)
+		signer := newSigner(t)
+		token := sign(t, signer, "/v1/portfolios")
+
+		setNow(start.Add(89 * time.Second))
+		assert.Equal(t, token, sign(t, signer, "/v1/portfolios"))
+
+		setNow(start.Add(90 * time.Second))
+		refreshed := sign(t, signer, "/v1/portfolios")
+		assert.NotEqual(t, token, refreshed, "default margin is 30 seconds")
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(strings.TrimPrefix(refreshed, "Bearer "), jwt.MapClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, float64(start.Unix()+90), parsed.Claims.(jwt.MapClaims)["nbf"])
+	})
+
+	t.Run("clock corrected backwards", func(t *testing.T) {
+		setNow(start)
+		signer := newSigner(t)
+		token := sign(t, signer, "/v1/portfolios")
+
+		setNow(start.Add(-10 * time.Second))
+		assert.NotEqual(t, token, sign(t, signer, "/v1/portfolios"), "token is not yet valid by the corrected clock")
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		setNow(start)
+		signer := newSigner(t)
+		var wg sync.WaitGroup
+		for i := range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				path := fmt.Sprintf("/v1/portfolios/p%d", i%5)
+				first := sign(t, signer, path)
+				assert.NotEmpty(t, first)
+			}()
+		}
+		wg.Wait()
+
+		for i := range 5 {
+			path := fmt.Sprintf("/v1/portfolios/p%d", i)
+			assert.Equal(t, sign(t, signer, path), sign(t, signer, path))
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		signer, err := auth.NewJWTSigner(auth.JWTConfig{KeyName: testKeyName, PrivateKey: privateKey})
+		require.NoError(t, err)
+		assert.NotEqual(t, sign(t, signer, "/v1/portfolios"), sign(t, signer, "/v1/portfolios"))
+	})
+}
+
 func TestJWTSigner_Sign_NonceUniqueness(t *testing.T) {
 	privateKey := generateTestECKey(t)
 
@@ -473,3 +620,42 @@ func BenchmarkJWTSigner_Sign(b *testing.B) {
 		}
 	}
 }
+
+// Benchmark signing a request to an endpoint polled at high frequency, with
+// and without token caching. With caching, tokens are signed once per
+// validity window instead of once per request.
+func BenchmarkJWTSigner_Sign_Polling(b *testing.B) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(b, err)
+	x509Encoded, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(b, err)
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: x509Encoded}))
+
+	req := auth.SignRequest{
+		Method:  "GET",
+		Path:    "/v1/portfolios/test-portfolio/orders",
+		Headers: http.Header{"Host": []string{"api.prime.coinbase.com"}},
+	}
+
+	for _, cache := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cache=%t", cache), func(b *testing.B) {
+			signer, err := auth.NewJWTSigner(auth.JWTConfig{
+				KeyName:     testKeyName,
+				PrivateKey:  privateKey,
+				ExpiresIn:   testExpiresIn,
+				CacheTokens: cache,
+			})
+			require.NoError(b, err)
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := signer.Sign(ctx, req); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// PayloadPart is a request component included in a signed payload.
+type PayloadPart int
+
+const (
+	// PartNonce is the request nonce or timestamp.
+	PartNonce PayloadPart = iota
+
+	// PartMethod is the HTTP method (e.g., "GET").
+	PartMethod
+
+	// PartPath is the request path without the query string.
+	PartPath
+
+	// PartQuery is the query string without the leading "?", including a
+	// nonce placed in the query. It is signed exactly as sent unless
+	// PayloadLayout.CanonicalQuery is set.
+	PartQuery
+
+	// PartRequestPath is the path followed by "?" and PartQuery, or the path
+	// alone if there is no query.
+	PartRequestPath
+
+	// PartBody is the request body, including a nonce placed in the body.
+	PartBody
+
+	// PartNonceBodySHA256 is the raw SHA-256 digest of the nonce followed by
+	// PartBody, as signed by Kraken.
+	PartNonceBodySHA256
+)
+
+// NoncePlacement selects where the request nonce is sent.
+type NoncePlacement int
+
+const (
+	// NonceInHeader sends the nonce in the header named
+	// PayloadLayout.NonceName.
+	NonceInHeader NoncePlacement = iota
+
+	// NonceInQuery appends the nonce to the query string as the parameter
+	// named PayloadLayout.NonceName.
+	NonceInQuery
+
+	// NonceInBody prepends the nonce to a form-encoded request body as the
+	// field named PayloadLayout.NonceName.
+	NonceInBody
+)
+
+// SignatureEncoding selects how signature bytes are encoded.
+type SignatureEncoding int
+
+const (
+	// SignatureBase64 encodes signatures with standard, padded base64.
+	SignatureBase64 SignatureEncoding = iota
+
+	// SignatureHex encodes signatures as lower case hex.
+	SignatureHex
+)
+
+// DefaultNonceUnit is the unit of generated nonces when no positive
+// PayloadLayout.NonceUnit is set.
+const DefaultNonceUnit = time.Millisecond
+
+// PayloadLayout describes, independently of the signing algorithm, how a
+// venue builds the payload it verifies and where it expects the nonce, key
+// and signature. It lets HMACSHA512Signer, Ed25519Signer and RSASigner
+// authenticate venues without venue-specific signer code.
+//
+// The payload is the Parts joined by Separator. For example, Binance signs
+// the query string and body, with the timestamp as a query parameter and the
+// signature appended after it:
+//
+//	PayloadLayout{
+//	    Parts:          []PayloadPart{PartQuery, PartBody},
+//	    NonceName:      "timestamp",
+//	    NoncePlacement: NonceInQuery,
+//	    KeyHeader:      "X-MBX-APIKEY",
+//	    SignatureParam: "signature",
+//	}
+//
+// and Kraken signs the path and a digest of the nonce and form body:
+//
+//	PayloadLayout{
+//	    Parts:           []PayloadPart{PartPath, PartNonceBodySHA256},
+//	    NonceName:       "nonce",
+//	    NoncePlacement:  NonceInBody,
+//	    KeyHeader:       "API-Key",
+//	    SignatureHeader: "API-Sign",
+//	}
+type PayloadLayout struct {
+	// Parts lists the request components signed, in order.
+	Parts []PayloadPart
+
+	// Separator is placed between parts (default: none).
+	Separator string
+
+	// CanonicalQuery signs the query in canonical form (see CanonicalQuery)
+	// instead of exactly as sent.
+	CanonicalQuery bool
+
+	// NonceName is the header, query parameter or body field carrying the
+	// nonce, depending on NoncePlacement. If empty, no nonce is sent, and
+	// Parts must not include the nonce.
+	NonceName string
+
+	// NoncePlacement selects where the nonce is sent (default: NonceInHeader).
+	NoncePlacement NoncePlacement
+
+	// NonceUnit is the unit of the Unix time used as nonce: time.Second,
+	// time.Millisecond or time.Microsecond (default: DefaultNonceUnit).
+	// Generated nonces strictly increase, so a signer issuing several
+	// requests per unit runs ahead of the clock; pick a unit finer than the
+	// request rate.
+	NonceUnit time.Duration
+
+	// KeyHeader, if set, is the header carrying the API key.
+	KeyHeader string
+
+	// SignatureHeader is the header carrying the signature. Exactly one of
+	// SignatureHeader and SignatureParam must be set.
+	SignatureHeader string
+
+	// SignatureParam is the query parameter carrying the signature. It is
+	// appended after every other parameter, including a nonce placed in the
+	// query.
+	SignatureParam string
+
+	// SignatureEncoding selects how the signature is encoded
+	// (default: SignatureBase64).
+	SignatureEncoding SignatureEncoding
+}
+
+// validate checks that the layout describes a complete signing scheme.
+func (l PayloadLayout) validate() error {
+	if len(l.Parts) == 0 {
+		return fmt.Errorf("payload layout must include at least one part")
+	}
+	for _, part := range l.Parts {
+		if part < PartNonce || part > PartNonceBodySHA256 {
+			return fmt.Errorf("unknown payload part %d", part)
+		}
+		if (part == PartNonce || part == PartNonceBodySHA256) && l.NonceName == "" {
+			return fmt.Errorf("nonce name is required when the payload includes the nonce")
+		}
+	}
+	if l.NoncePlacement < NonceInHeader || l.NoncePlacement > NonceInBody {
+		return fmt.Errorf("unknown nonce placement %d", l.NoncePlacement)
+	}
+	switch l.NonceUnit {
+	case 0, time.Second, time.Millisecond, time.Microsecond:
+	default:
+		return fmt.Errorf("nonce unit must be a second, millisecond or microsecond")
+	}
+	if (l.SignatureHeader == "") == (l.SignatureParam == "") {
+		return fmt.Errorf("exactly one of signature header and signature parameter is required")
+	}
+	if l.SignatureEncoding < SignatureBase64 || l.SignatureEncoding > SignatureHex {
+		return fmt.Errorf("unknown signature encoding %d", l.SignatureEncoding)
+	}
+	return nil
+}
+
+// layoutSigner signs requests as described by a PayloadLayout with the keys
+// of a keyring. It implements the Sign methods of the layout-based signers,
+// which differ only in how keys are parsed and payloads signed.
+type layoutSigner[K any] struct {
+	layout    PayloadLayout
+	now       func() time.Time
+	keys      *keyring[K]
+	sign      func(key K, payload []byte) ([]byte, error)
+	lastNonce atomic.Int64
+}
+
+// newLayoutSigner validates layout and returns a signer for it.
+func newLayoutSigner[K any](layout PayloadLayout, now func() time.Time, keys *keyring[K], sign func(K, []byte) ([]byte, error)) (*layoutSigner[K], error) {
+	if err := layout.validate(); err != nil {
+		return nil, fmt.Errorf("invalid payload layout: %w", err)
+	}
+	if layout.NonceUnit == 0 {
+		layout.NonceUnit = DefaultNonceUnit
+	}
+	return &layoutSigner[K]{layout: layout, now: now, keys: keys, sign: sign}, nil
+}
+
+// Sign builds the payload of req, signs it with the active key and returns
+// the nonce, key and signature placed as the layout specifies. req.Timestamp,
+// if set, is used as the nonce.
+func (s *layoutSigner[K]) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
+	active := s.keys.current(ctx)
+
+	nonce := req.Timestamp
+	if nonce == "" {
+		nonce = strconv.FormatInt(s.nextNonce(), 10)
+	}
+
+	result := &SignResult{Headers: map[string]string{}}
+	query, body := req.RawQuery, req.Body
+	if s.layout.NonceName != "" {
+		field := url.QueryEscape(s.layout.NonceName) + "=" + url.QueryEscape(nonce)
+		switch s.layout.NoncePlacement {
+		case NonceInHeader:
+			result.Headers[s.layout.NonceName] = nonce
+		case NonceInQuery:
+			// Signed as the middleware appends it
+			if query != "" {
+				query += "&"
+			}
+			query += field
+			result.QueryParams = map[string]string{s.layout.NonceName: nonce}
+		case NonceInBody:
+			if len(body) > 0 {
+				field += "&"
+			}
+			body = append([]byte(field), body...)
+			result.Body = body
+		}
+	}
+	if s.layout.CanonicalQuery {
+		query = CanonicalQuery(query)
+	}
+
+	parts := make([]string, len(s.layout.Parts))
+	for i, part := range s.layout.Parts {
+		switch part {
+		case PartNonce:
+			parts[i] = nonce
+		case PartMethod:
+			parts[i] = req.Method
+		case PartPath:
+			parts[i] = req.Path
+		case PartQuery:
+			parts[i] = query
+		case PartRequestPath:
+			parts[i] = req.Path
+			if query != "" {
+				parts[i] += "?" + query
+			}
+		case PartBody:
+			parts[i] = string(body)
+		case PartNonceBodySHA256:
+			digest := sha256.Sum256(append([]byte(nonce), body...))
+			parts[i] = string(digest[:])
+		}
+	}
+
+	signature, err := s.sign(active.key, []byte(strings.Join(parts, s.layout.Separator)))
+	if err != nil {
+		return nil, fmt.Errorf("signing failed: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(signature)
+	if s.layout.SignatureEncoding == SignatureHex {
+		encoded = hex.EncodeToString(signature)
+	}
+
+	if s.layout.SignatureHeader != "" {
+		result.Headers[s.layout.SignatureHeader] = encoded
+	} else {
+		if result.QueryParams == nil {
+			result.QueryParams = map[string]string{}
+		}
+		result.QueryParams[s.layout.SignatureParam] = encoded
+		result.TrailingQueryParam = s.layout.SignatureParam
+	}
+	if s.layout.KeyHeader != "" {
+		result.Headers[s.layout.KeyHeader] = active.creds.KeyID
+	}
+	return result, nil
+}
+
+// nextNonce returns the current time in the layout's nonce unit, or one more
+// than the previous nonce if the clock has not advanced past it.
+func (s *layoutSigner[K]) nextNonce() int64 {
+	now := currentTime(s.now).UnixNano() / int64(s.layout.NonceUnit)
+	for {
+		last := s.lastNonce.Load()
+		next := max(now, last+1)
+		if s.lastNonce.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
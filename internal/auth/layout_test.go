@@ -0,0 +1,221 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLayoutSigner returns an HMAC-SHA512 signer whose signature is easy to
+// recompute, for tests of the layout itself.
+func newLayoutSigner(t *testing.T, layout auth.PayloadLayout, now func() time.Time) *auth.HMACSHA512Signer {
+	t.Helper()
+	signer, err := auth.NewHMACSHA512Signer(auth.HMACSHA512Config{
+		APIKey: "api-key",
+		Secret: base64.StdEncoding.EncodeToString([]byte("secret")),
+		Layout: layout,
+		Now:    now,
+	})
+	require.NoError(t, err)
+	return signer
+}
+
+func TestPayloadLayout_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  auth.PayloadLayout
+		wantErr string
+	}{
+		{
+			name:    "no parts",
+			layout:  auth.PayloadLayout{SignatureHeader: "X-Signature"},
+			wantErr: "at least one part",
+		},
+		{
+			name:    "unknown part",
+			layout:  auth.PayloadLayout{Parts: []auth.PayloadPart{99}, SignatureHeader: "X-Signature"},
+			wantErr: "unknown payload part",
+		},
+		{
+			name:    "signed nonce without name",
+			layout:  auth.PayloadLayout{Parts: []auth.PayloadPart{auth.PartNonce, auth.PartBody}, SignatureHeader: "X-Signature"},
+			wantErr: "nonce name is required",
+		},
+		{
+			name:    "unsupported nonce unit",
+			layout:  auth.PayloadLayout{Parts: []auth.PayloadPart{auth.PartBody}, NonceUnit: time.Minute, SignatureHeader: "X-Signature"},
+			wantErr: "nonce unit",
+		},
+		{
+			name:    "signature header and parameter",
+			layout:  auth.PayloadLayout{Parts: []auth.PayloadPart{auth.PartBody}, SignatureHeader: "X-Signature", SignatureParam: "signature"},
+			wantErr: "exactly one of signature header and signature parameter",
+		},
+		{
+			name:    "unknown signature encoding",
+			layout:  auth.PayloadLayout{Parts: []auth.PayloadPart{auth.PartBody}, SignatureHeader: "X-Signature", SignatureEncoding: 7},
+			wantErr: "unknown signature encoding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.NewHMACSHA512Signer(auth.HMACSHA512Config{
+				APIKey: "api-key",
+				Secret: base64.StdEncoding.EncodeToString([]byte("secret")),
+				Layout: tt.layout,
+			})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPayloadLayout_Payload(t *testing.T) {
+	req := auth.SignRequest{
+		Method:    "POST",
+		Path:      "/v1/orders",
+		RawQuery:  "symbol=BTC-USD&limit=10",
+		Body:      []byte(`{"size":"1"}`),
+		Timestamp: "1700000000",
+	}
+	layout := auth.PayloadLayout{
+		Parts:           []auth.PayloadPart{auth.PartNonce, auth.PartMethod, auth.PartRequestPath, auth.PartBody},
+		Separator:       "\n",
+		NonceName:       "X-Timestamp",
+		KeyHeader:       "X-Api-Key",
+		SignatureHeader: "X-Signature",
+	}
+
+	// reference signs payload the way the layout describes it
+	reference := newLayoutSigner(t, auth.PayloadLayout{
+		Parts:           []auth.PayloadPart{auth.PartBody},
+		SignatureHeader: "X-Signature",
+	}, nil)
+	expected := func(payload string) string {
+		result, err := reference.Sign(context.Background(), auth.SignRequest{Body: []byte(payload)})
+		require.NoError(t, err)
+		return result.Headers["X-Signature"]
+	}
+
+	t.Run("query as sent", func(t *testing.T) {
+		result, err := newLayoutSigner(t, layout, nil).Sign(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "1700000000", result.Headers["X-Timestamp"])
+		assert.Equal(t, "api-key", result.Headers["X-Api-Key"])
+		assert.Equal(t, expected("1700000000\nPOST\n/v1/orders?symbol=BTC-USD&limit=10\n"+`{"size":"1"}`), result.Headers["X-Signature"])
+	})
+
+	t.Run("canonical query", func(t *testing.T) {
+		canonical := layout
+		canonical.CanonicalQuery = true
+		result, err := newLayoutSigner(t, canonical, nil).Sign(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, expected("1700000000\nPOST\n/v1/orders?limit=10&symbol=BTC-USD\n"+`{"size":"1"}`), result.Headers["X-Signature"])
+	})
+}
+
+func TestPayloadLayout_Nonce(t *testing.T) {
+	fixed := time.Unix(1700000000, 123456789)
+	layout := auth.PayloadLayout{
+		Parts:           []auth.PayloadPart{auth.PartNonce},
+		NonceName:       "X-Nonce",
+		SignatureHeader: "X-Signature",
+	}
+
+	nonces := func(layout auth.PayloadLayout) []string {
+		signer := newLayoutSigner(t, layout, func() time.Time { return fixed })
+		var nonces []string
+		for range 3 {
+			result, err := signer.Sign(context.Background(), auth.SignRequest{Method: "GET", Path: "/"})
+			require.NoError(t, err)
+			nonces = append(nonces, result.Headers["X-Nonce"])
+		}
+		return nonces
+	}
+
+	assert.Equal(t, []string{"1700000000123", "1700000000124", "1700000000125"}, nonces(layout),
+		"nonces strictly increase when the clock does not advance")
+
+	layout.NonceUnit = time.Second
+	assert.Equal(t, "1700000000", nonces(layout)[0])
+	layout.NonceUnit = time.Microsecond
+	assert.Equal(t, "1700000000123456", nonces(layout)[0])
+}
+
+func TestPayloadLayout_Middleware(t *testing.T) {
+	t.Run("nonce and signature in query", func(t *testing.T) {
+		publicKey := readEd25519PublicKey(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Binance verifies the query and body preceding the signature
+			query, signature, ok := strings.Cut(r.URL.RawQuery, "&signature=")
+			require.True(t, ok, "signature is the last parameter: %s", r.URL.RawQuery)
+			assert.True(t, strings.HasPrefix(query, binanceQuery+"&timestamp="))
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			decoded, err := base64.StdEncoding.DecodeString(r.URL.Query().Get("signature"))
+			require.NoError(t, err)
+			assert.NotEmpty(t, signature)
+			assert.True(t, ed25519.Verify(publicKey, append([]byte(query), body...), decoded))
+			assert.Equal(t, "api-key", r.Header.Get("X-MBX-APIKEY"))
+		}))
+		defer server.Close()
+
+		signer, err := auth.NewEd25519Signer(auth.Ed25519Config{
+			APIKey:     "api-key",
+			PrivateKey: readTestKey(t, "ed25519", "private_key.pem"),
+			Layout:     binanceLayout,
+		})
+		require.NoError(t, err)
+		httpClient := &http.Client{Transport: auth.Middleware(signer, nil)}
+
+		resp, err := httpClient.Post(server.URL+"/api/v3/order?"+binanceQuery, "application/x-www-form-urlencoded", strings.NewReader("newClientOrderId=abc"))
+		require.NoError(t, err)
+		resp.Body.Close()
+	})
+
+	t.Run("nonce in body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Regexp(t, `^nonce=[0-9]+&pair=XBTUSD$`, string(body))
+			assert.Equal(t, int64(len(body)), r.ContentLength)
+			assert.NotEmpty(t, r.Header.Get("API-Sign"))
+		}))
+		defer server.Close()
+
+		signer, err := auth.NewHMACSHA512Signer(auth.HMACSHA512Config{
+			APIKey: "api-key",
+			Secret: krakenSecret,
+			Layout: krakenLayout,
+		})
+		require.NoError(t, err)
+		httpClient := &http.Client{Transport: auth.Middleware(signer, nil)}
+
+		resp, err := httpClient.Post(server.URL+"/0/private/Balance", "application/x-www-form-urlencoded", strings.NewReader("pair=XBTUSD"))
+		require.NoError(t, err)
+		resp.Body.Close()
+	})
+}
+
+// readEd25519PublicKey reads the public key of the Ed25519 test key pair.
+func readEd25519PublicKey(t *testing.T) ed25519.PublicKey {
+	t.Helper()
+	block, _ := pem.Decode([]byte(readTestKey(t, "ed25519", "public_key.pem")))
+	require.NotNil(t, block)
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+	return key.(ed25519.PublicKey)
+}
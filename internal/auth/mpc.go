@@ -3,26 +3,60 @@ package auth
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"strconv"
 	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
 )
 
 // MPCConfig contains configuration for MPC (Multi-Party Computation) authentication.
+//
+// Exactly one of PrivateKey or SignerFunc must be set. PrivateKey selects the
+// built-in Fordefi request signing scheme; SignerFunc delegates signing to a
+// caller-provided function (e.g., an external MPC or HSM service).
 type MPCConfig struct {
-	// APIKey is the API key identifier for Fordefi
+	// APIKey is the API key identifier for Fordefi.
+	// With PrivateKey, this is the API user access token sent as a bearer token.
 	APIKey string
 
+	// PrivateKey is the PEM-encoded P-256 private key of the Fordefi API Signer
+	// (PKCS#8 or SEC 1 "EC PRIVATE KEY"). When set, requests are signed with
+	// ECDSA over the Fordefi payload (see MPCSignaturePayload).
+	PrivateKey string
+
 	// SignerFunc is a function that performs MPC signing.
 	// It takes the message to sign and returns the signature.
-	// For MVP, this can be a stub that returns a pre-configured signature.
-	// Post-MVP, this will integrate with an actual MPC signing service.
+	// Use this to integrate an external signing service; for Fordefi API
+	// request signing, set PrivateKey instead.
 	//
 	// The function signature is:
 	//   func(ctx context.Context, message []byte) (signature string, error)
 	SignerFunc func(ctx context.Context, message []byte) (string, error)
+
+	// Credentials, if set, supplies the API key (KeyID) and, without
+	// SignerFunc, the PEM-encoded private key (Secret) in place of APIKey and
+	// PrivateKey, which must then be empty. Rotated credentials are used from
+	// the next request on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// credentials that cannot be used. Signing continues with the previous
+	// credentials.
+	OnCredentialError func(err error)
+
+	// Now returns the current time used for request timestamps
+	// (default: time.Now). Set it to a clock synchronized with the venue, such
+	// as client.ServerClock.Now, to compensate for local clock skew.
+	Now func() time.Time
 }
 
 // MPCSigner implements MPC (Multi-Party Computation) authentication for Fordefi.
@@ -30,27 +64,43 @@ type MPCConfig struct {
 // collaboratively sign requests without any single party having access to
 // the complete private key.
 //
-// For MVP, this is a stub implementation that uses a configurable signing
-// function. Post-MVP enhancements will integrate with actual MPC providers
-// like Fireblocks, Fordefi's native MPC, or other custody solutions.
+// With MPCConfig.PrivateKey, the signer implements Fordefi's API request signing:
+//
+//	payload   = path + "|" + timestamp + "|" + body
+//	signature = base64(DER(ECDSA-P256(SHA-256(payload))))
 //
-// Required headers (Fordefi-specific):
+// where path is the request path without the query string, as in Fordefi's
+// request signing documentation.
+//
+// Required headers (PrivateKey):
+//   - Authorization: Bearer {APIKey}
+//   - X-Timestamp: Unix timestamp in milliseconds
+//   - X-Signature: The base64-encoded DER signature
+//
+// With MPCConfig.SignerFunc, the message is passed to the function and its
+// result is sent verbatim.
+//
+// Required headers (SignerFunc):
 //   - X-API-KEY: The API key identifier
 //   - X-TIMESTAMP: Unix timestamp in milliseconds
 //   - X-SIGNATURE: The MPC-generated signature
 //
-// The signature is computed over:
+// The SignerFunc message is computed over:
 //
 //	message = timestamp + method + path + body
 //
+// The query string is not part of either message.
+//
 // Thread-safe: This implementation is safe for concurrent use if the
 // provided SignerFunc is thread-safe.
 type MPCSigner struct {
 	config MPCConfig
+	keys   *keyring[*ecdsa.PrivateKey] // nil keys with SignerFunc
 }
 
 // NewMPCSigner creates a new MPC signer for Fordefi.
-// The SignerFunc must be provided to perform actual signing operations.
+// Either PrivateKey or SignerFunc must be provided to perform signing.
+// A PrivateKey must be a PEM-encoded key on the P-256 curve.
 //
 // Example stub SignerFunc for testing:
 //
@@ -60,34 +110,93 @@ type MPCSigner struct {
 //	    return hex.EncodeToString(hash[:]), nil
 //	}
 func NewMPCSigner(config MPCConfig) (*MPCSigner, error) {
-	if config.APIKey == "" {
-		return nil, fmt.Errorf("API key is required")
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{
+		KeyID:  config.APIKey,
+		Secret: config.PrivateKey,
+	})
+	if err != nil {
+		return nil, err
 	}
-	if config.SignerFunc == nil {
-		return nil, fmt.Errorf("signer function is required")
+	parse := func(creds credentials.Credentials) (*ecdsa.PrivateKey, error) {
+		return parseMPCCredentials(creds, config.SignerFunc != nil)
+	}
+	keys, err := newKeyring(provider, parse, config.OnCredentialError)
+	if err != nil {
+		return nil, err
 	}
 
 	return &MPCSigner{
 		config: config,
+		keys:   keys,
 	}, nil
 }
 
+// parseMPCCredentials validates MPC credentials and parses the private key,
+// which must be absent if an external signer function is used.
+func parseMPCCredentials(creds credentials.Credentials, hasSignerFunc bool) (*ecdsa.PrivateKey, error) {
+	if creds.KeyID == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if creds.Secret != "" && hasSignerFunc {
+		return nil, fmt.Errorf("private key and signer function are mutually exclusive")
+	}
+
+	switch {
+	case creds.Secret != "":
+		privateKey, err := parseECPrivateKey(creds.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		if privateKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("private key must use the P-256 curve")
+		}
+		return privateKey, nil
+	case !hasSignerFunc:
+		return nil, fmt.Errorf("signer function is required")
+	default:
+		return nil, nil
+	}
+}
+
+// ActiveKeyID returns the API key currently used for signing.
+func (s *MPCSigner) ActiveKeyID() string {
+	return s.keys.keyID()
+}
+
 // Sign generates MPC authentication headers for a Fordefi API request.
-// It constructs the message to sign, calls the configured SignerFunc to
-// obtain the signature, and returns the required authentication headers.
+// It constructs the message to sign, signs it with the configured private key
+// or SignerFunc, and returns the required authentication headers.
 //
 // The message format is:
 //
-//	message = timestamp + method + path + body
+//	PrivateKey: path + "|" + timestamp + "|" + body
+//	SignerFunc: timestamp + method + path + body
 //
-// Where timestamp is Unix time in milliseconds.
+// Where timestamp is Unix time in milliseconds. The path is signed exactly as
+// given, without the query string.
 //
 // Returns an error if signature generation fails.
 func (s *MPCSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
 	// Generate timestamp if not provided (Unix milliseconds as string)
 	timestamp := req.Timestamp
 	if timestamp == "" {
-		timestamp = strconv.FormatInt(time.Now().UnixMilli(), 10)
+		timestamp = strconv.FormatInt(currentTime(s.config.Now).UnixMilli(), 10)
+	}
+
+	active := s.keys.current(ctx)
+	if active.key != nil {
+		signature, err := signECDSA(active.key, MPCSignaturePayload(req.Path, timestamp, req.Body))
+		if err != nil {
+			return nil, fmt.Errorf("MPC signing failed: %w", err)
+		}
+
+		return &SignResult{
+			Headers: map[string]string{
+				"Authorization": "Bearer " + active.creds.KeyID,
+				"X-Timestamp":   timestamp,
+				"X-Signature":   signature,
+			},
+		}, nil
 	}
 
 	// Construct message to sign: timestamp + method + path + body
@@ -103,16 +212,91 @@ func (s *MPCSigner) Sign(ctx context.Context, req SignRequest) (*SignResult, err
 	// Return authentication headers
 	return &SignResult{
 		Headers: map[string]string{
-			"X-API-KEY":   s.config.APIKey,
+			"X-API-KEY":   active.creds.KeyID,
 			"X-TIMESTAMP": timestamp,
 			"X-SIGNATURE": signature,
 		},
 	}, nil
 }
 
+// signECDSA signs the SHA-256 digest of payload with privateKey and returns
+// the base64-encoded ASN.1 DER signature.
+func signECDSA(privateKey *ecdsa.PrivateKey, payload []byte) (string, error) {
+	digest := sha256.Sum256(payload)
+	der, err := ecdsa.SignASN1(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// MPCSignaturePayload returns the exact bytes Fordefi expects to be signed for
+// a request:
+//
+//	path + "|" + timestamp + "|" + body
+//
+// The path includes the API prefix (e.g., "/api/v1/transactions") and excludes
+// the query string; timestamp is Unix milliseconds; body is the raw request
+// body (empty for GET requests).
+func MPCSignaturePayload(path, timestamp string, body []byte) []byte {
+	payload := make([]byte, 0, len(path)+len(timestamp)+len(body)+2)
+	payload = append(payload, path...)
+	payload = append(payload, '|')
+	payload = append(payload, timestamp...)
+	payload = append(payload, '|')
+	payload = append(payload, body...)
+	return payload
+}
+
+// VerifyMPCSignature checks a base64-encoded DER ECDSA signature over payload
+// against a PEM-encoded P-256 public key (PKIX "PUBLIC KEY"). It is the
+// counterpart of the PrivateKey signing mode and is intended for tests and
+// for validating signer configuration.
+//
+// Returns nil if the signature is valid.
+func VerifyMPCSignature(publicKeyPEM string, payload []byte, signature string) error {
+	publicKey, err := parseECPublicKey(publicKeyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if publicKey.Curve != elliptic.P256() {
+		return fmt.Errorf("public key must use the P-256 curve")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("signature must be valid base64: %w", err)
+	}
+
+	digest := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(publicKey, digest[:], der) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// parseECPublicKey parses a PEM-encoded PKIX EC public key.
+func parseECPublicKey(pemKey string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not an EC public key")
+	}
+	return ecKey, nil
+}
+
 // DefaultMPCSignerFunc is a default stub implementation for testing.
 // It returns the SHA256 hash of the message as a hex string.
 // This should NOT be used in production - it's only for testing and MVP demonstration.
+// Use MPCConfig.PrivateKey for real Fordefi request signing.
 func DefaultMPCSignerFunc(ctx context.Context, message []byte) (string, error) {
 	hash := sha256.Sum256(message)
 	return hex.EncodeToString(hash[:]), nil
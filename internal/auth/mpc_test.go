@@ -2,10 +2,18 @@ package auth
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"testing"
@@ -52,6 +60,42 @@ func TestNewMPCSigner(t *testing.T) {
 			wantErr: true,
 			errMsg:  "signer function is required",
 		},
+		{
+			name: "private key",
+			config: MPCConfig{
+				APIKey:     "valid-api-key",
+				PrivateKey: readMPCKey(t, "private_key.pem"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "private key and signer function",
+			config: MPCConfig{
+				APIKey:     "valid-api-key",
+				PrivateKey: readMPCKey(t, "private_key.pem"),
+				SignerFunc: mockSignerFunc,
+			},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+		{
+			name: "malformed private key",
+			config: MPCConfig{
+				APIKey:     "valid-api-key",
+				PrivateKey: "not a pem key",
+			},
+			wantErr: true,
+			errMsg:  "failed to parse private key",
+		},
+		{
+			name: "non P-256 private key",
+			config: MPCConfig{
+				APIKey:     "valid-api-key",
+				PrivateKey: generateECKeyPEM(t, elliptic.P384()),
+			},
+			wantErr: true,
+			errMsg:  "P-256",
+		},
 		{
 			name: "both empty",
 			config: MPCConfig{
@@ -267,6 +311,19 @@ func TestMPCSigner_Sign_TimestampGeneration(t *testing.T) {
 	assert.LessOrEqual(t, timestamp, afterTime)
 }
 
+func TestMPCSigner_Sign_UsesConfiguredClock(t *testing.T) {
+	signer, err := NewMPCSigner(MPCConfig{
+		APIKey:     "test-api-key",
+		PrivateKey: readMPCKey(t, "private_key.pem"),
+		Now:        func() time.Time { return time.UnixMilli(1700000000000) },
+	})
+	require.NoError(t, err)
+
+	result, err := signer.Sign(context.Background(), SignRequest{Method: "GET", Path: "/api/v1/vaults"})
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000000", result.Headers["X-Timestamp"])
+}
+
 func TestMPCSigner_Sign_Concurrent(t *testing.T) {
 	// Test that the signer is safe for concurrent use
 	var callCount int
@@ -533,6 +590,27 @@ func TestMPCSigner_MessageFormat(t *testing.T) {
 	assert.Equal(t, expectedMessage, string(capturedMessage))
 }
 
+func TestMPCSigner_MessageFormat_QueryExcluded(t *testing.T) {
+	var capturedMessage []byte
+	signer, err := NewMPCSigner(MPCConfig{
+		APIKey: "test-api-key",
+		SignerFunc: func(ctx context.Context, message []byte) (string, error) {
+			capturedMessage = message
+			return "test-signature", nil
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = signer.Sign(context.Background(), SignRequest{
+		Method:    "GET",
+		Path:      "/api/v1/orders",
+		RawQuery:  "symbol=BTC-USD&limit=10",
+		Timestamp: "1234567890",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890GET/api/v1/orders", string(capturedMessage))
+}
+
 func TestMPCSigner_EmptyPathAndBody(t *testing.T) {
 	// Test edge case with empty path and body
 	var capturedMessage []byte
@@ -562,6 +640,162 @@ func TestMPCSigner_EmptyPathAndBody(t *testing.T) {
 	assert.Equal(t, expectedMessage, string(capturedMessage))
 }
 
+func TestMPCSigner_PrivateKey(t *testing.T) {
+	publicKey := readMPCKey(t, "public_key.pem")
+	signer, err := NewMPCSigner(MPCConfig{
+		APIKey:     "fordefi-api-user-token",
+		PrivateKey: readMPCKey(t, "private_key.pem"),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		request SignRequest
+		payload string
+	}{
+		{
+			name:    "GET request",
+			request: SignRequest{Method: "GET", Path: "/api/v1/vaults", Timestamp: "1700000000000"},
+			payload: "/api/v1/vaults|1700000000000|",
+		},
+		{
+			name: "POST request with body",
+			request: SignRequest{
+				Method:    "POST",
+				Path:      "/api/v1/transactions",
+				Body:      []byte(`{"vault_id":"vault-eth-1"}`),
+				Timestamp: "1700000000000",
+			},
+			payload: `/api/v1/transactions|1700000000000|{"vault_id":"vault-eth-1"}`,
+		},
+		{
+			name: "query excluded",
+			request: SignRequest{
+				Method:    "GET",
+				Path:      "/api/v1/transactions",
+				RawQuery:  "page=2&size=50&states=pending_approval",
+				Timestamp: "1700000000000",
+			},
+			payload: "/api/v1/transactions|1700000000000|",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := signer.Sign(context.Background(), tt.request)
+			require.NoError(t, err)
+
+			assert.Equal(t, "Bearer fordefi-api-user-token", result.Headers["Authorization"])
+			assert.Equal(t, "1700000000000", result.Headers["X-Timestamp"])
+			assert.NotContains(t, result.Headers, "X-API-KEY")
+			assert.Empty(t, result.QueryParams)
+
+			assert.Equal(t, tt.payload, string(MPCSignaturePayload(tt.request.Path, tt.request.Timestamp, tt.request.Body)))
+			assert.NoError(t, VerifyMPCSignature(publicKey, []byte(tt.payload), result.Headers["X-Signature"]))
+			assert.Error(t, VerifyMPCSignature(publicKey, []byte(tt.payload+"tampered"), result.Headers["X-Signature"]))
+		})
+	}
+
+	t.Run("generated timestamp", func(t *testing.T) {
+		before := time.Now().UnixMilli()
+		result, err := signer.Sign(context.Background(), SignRequest{Method: "GET", Path: "/api/v1/vaults"})
+		require.NoError(t, err)
+
+		timestamp, err := strconv.ParseInt(result.Headers["X-Timestamp"], 10, 64)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, timestamp, before)
+
+		payload := MPCSignaturePayload("/api/v1/vaults", result.Headers["X-Timestamp"], nil)
+		assert.NoError(t, VerifyMPCSignature(publicKey, payload, result.Headers["X-Signature"]))
+	})
+
+	t.Run("PKCS8 key", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		pkcs8 := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+		signer, err := NewMPCSigner(MPCConfig{APIKey: "token", PrivateKey: pkcs8})
+		require.NoError(t, err)
+		result, err := signer.Sign(context.Background(), SignRequest{Path: "/api/v1/vaults", Timestamp: "1"})
+		require.NoError(t, err)
+
+		sig, err := base64.StdEncoding.DecodeString(result.Headers["X-Signature"])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("/api/v1/vaults|1|"))
+		assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+	})
+}
+
+func TestVerifyMPCSignature(t *testing.T) {
+	publicKey := readMPCKey(t, "public_key.pem")
+
+	// Vectors from testdata/README.md, signed with OpenSSL.
+	vectors := []struct {
+		name      string
+		payload   string
+		signature string
+	}{
+		{
+			name:      "GET request",
+			payload:   "/api/v1/vaults|1700000000000|",
+			signature: "MEUCIGoljpmHEfkqX/98QDzdnIRu75Eq9IltQ0z4HKSyr1wwAiEAoMe8RN18PcSkz62kJdr/H866sHhmEZEER+DLXL2Jr+U=",
+		},
+		{
+			name:      "POST request",
+			payload:   `/api/v1/transactions|1700000000000|{"vault_id":"vault-eth-1","signer_type":"api_signer","type":"evm_transaction"}`,
+			signature: "MEUCIQDnOk2SSsIC/ILhuTCJxGouuc2PMxD25umj5OfBQHGfrwIgULxcPQab28Z3uzrAQ7uNI6/fiTU1qwsiYAJs0jDdNJE=",
+		},
+		{
+			name:      "GET request with query",
+			payload:   "/api/v1/transactions|1700000000000|",
+			signature: "MEUCIBIBZYpLIZp12YVwi580dNxXx3LNZ4szVySohhJWmZpxAiEAtmIBH7IaIaJk/i04uRkyg+kemjI6N2IZBWKcTENPiEc=",
+		},
+	}
+
+	for _, tt := range vectors {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, VerifyMPCSignature(publicKey, []byte(tt.payload), tt.signature))
+		})
+	}
+
+	t.Run("wrong payload", func(t *testing.T) {
+		err := VerifyMPCSignature(publicKey, []byte("/api/v1/vaults|1700000000001|"), vectors[0].signature)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		other := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		assert.Error(t, VerifyMPCSignature(other, []byte(vectors[0].payload), vectors[0].signature))
+	})
+
+	t.Run("malformed inputs", func(t *testing.T) {
+		assert.ErrorContains(t, VerifyMPCSignature("not a pem key", []byte("x"), vectors[0].signature), "public key")
+		assert.ErrorContains(t, VerifyMPCSignature(publicKey, []byte("x"), "%%%"), "base64")
+	})
+}
+
+func readMPCKey(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "mpc", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func generateECKeyPEM(t *testing.T, curve elliptic.Curve) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
 // mockMPCRoundTripper is a mock http.RoundTripper for testing
 type mockMPCRoundTripper struct {
 	roundTripFunc func(*http.Request) (*http.Response, error)
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing.
+const minRSAKeyBits = 2048
+
+// RSAConfig contains configuration for RSA-SHA256 authentication.
+type RSAConfig struct {
+	// APIKey is the API key identifier, sent in Layout.KeyHeader
+	APIKey string
+
+	// PrivateKey is the PEM-encoded RSA private key, PKCS#8 ("PRIVATE KEY")
+	// or PKCS#1 ("RSA PRIVATE KEY"), of at least 2048 bits
+	PrivateKey string
+
+	// Credentials, if set, supplies the API key (KeyID) and PEM-encoded
+	// private key (Secret) in place of the fields above, which must then be
+	// empty. Rotated credentials are used from the next request on.
+	Credentials credentials.Provider
+
+	// OnCredentialError, if set, is called when Credentials fails or returns
+	// credentials that cannot be used. Signing continues with the previous
+	// credentials.
+	OnCredentialError func(err error)
+
+	// Layout describes the signed payload and where the nonce, key and
+	// signature are sent.
+	Layout PayloadLayout
+
+	// Now returns the current time used for nonces (default: time.Now). Set
+	// it to a clock synchronized with the venue, such as
+	// client.ServerClock.Now, to compensate for local clock skew.
+	Now func() time.Time
+}
+
+// RSASigner implements RSA-SHA256 request signing, as used by Binance RSA
+// API keys:
+//
+//	signature = RSASSA-PKCS1-v1_5(privateKey, SHA-256(payload))
+//
+// where payload is built from the request as described by RSAConfig.Layout.
+// PKCS#1 v1.5 signatures are deterministic, so a request and nonce always
+// produce the same signature.
+//
+// Thread-safe: This implementation is safe for concurrent use.
+type RSASigner struct {
+	config RSAConfig
+	signer *layoutSigner[*rsa.PrivateKey]
+}
+
+// NewRSASigner creates a new RSA-SHA256 signer.
+func NewRSASigner(config RSAConfig) (*RSASigner, error) {
+	provider, err := credentialProvider(config.Credentials, credentials.Credentials{
+		KeyID:  config.APIKey,
+		Secret: config.PrivateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := newKeyring(provider, parseRSACredentials, config.OnCredentialError)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newLayoutSigner(config.Layout, config.Now, keys, signRSA)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RSASigner{
+		config: config,
+		signer: signer,
+	}, nil
+}
+
+// parseRSACredentials validates RSA credentials and parses the private key.
+func parseRSACredentials(creds credentials.Credentials) (*rsa.PrivateKey, error) {
+	if creds.KeyID == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if creds.Secret == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
+
+	privateKey, err := parseRSAPrivateKey(creds.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	if privateKey.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("private key must be at least %d bits", minRSAKeyBits)
+	}
+	return privateKey, nil
+}
+
+// parseRSAPrivateKey parses a PEM-encoded PKCS#8 or PKCS#1 RSA private key.
+func parseRSAPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block")
+	}
+
+	// Try parsing as PKCS8
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err == nil {
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("key is not an RSA private key")
+		}
+		return rsaKey, nil
+	}
+
+	// Try parsing as PKCS1
+	rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSA private key: %w", err)
+	}
+	return rsaKey, nil
+}
+
+// signRSA returns the RSASSA-PKCS1-v1_5 signature of the SHA-256 digest of
+// payload.
+func signRSA(privateKey *rsa.PrivateKey, payload []byte) ([]byte, error) {
+	digest := sha256.Sum256(payload)
+	return rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+}
+
+// ActiveKeyID returns the API key currently used for signing.
+func (s *RSASigner) ActiveKeyID() string {
+	return s.signer.keys.keyID()
+}
+
+// Sign generates RSA-SHA256 authentication for a request. The nonce is
+// req.Timestamp if set, or the current Unix time in the layout's nonce unit.
+// Depending on the layout, the result carries the nonce, API key and
+// signature as headers or query parameters, and a body with the nonce added.
+//
+// Returns an error if signature generation fails.
+func (s *RSASigner) Sign(ctx context.Context, req SignRequest) (*SignResult, error) {
+	return s.signer.Sign(ctx, req)
+}
+
+// Verify that RSASigner implements the Signer interface
+var _ Signer = (*RSASigner)(nil)
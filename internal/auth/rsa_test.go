@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRSASigner_Validation(t *testing.T) {
+	privateKey := readTestKey(t, "rsa", "private_key.pem")
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weakPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(weakKey)}))
+
+	tests := []struct {
+		name    string
+		config  auth.RSAConfig
+		wantErr string
+	}{
+		{
+			name:   "valid config",
+			config: auth.RSAConfig{APIKey: "api-key", PrivateKey: privateKey, Layout: binanceLayout},
+		},
+		{
+			name:    "missing API key",
+			config:  auth.RSAConfig{PrivateKey: privateKey, Layout: binanceLayout},
+			wantErr: "API key is required",
+		},
+		{
+			name:    "missing private key",
+			config:  auth.RSAConfig{APIKey: "api-key", Layout: binanceLayout},
+			wantErr: "private key is required",
+		},
+		{
+			name:    "Ed25519 key",
+			config:  auth.RSAConfig{APIKey: "api-key", PrivateKey: readTestKey(t, "ed25519", "private_key.pem"), Layout: binanceLayout},
+			wantErr: "key is not an RSA private key",
+		},
+		{
+			name:    "key too short",
+			config:  auth.RSAConfig{APIKey: "api-key", PrivateKey: weakPEM, Layout: binanceLayout},
+			wantErr: "at least 2048 bits",
+		},
+		{
+			name: "no signature placement",
+			config: auth.RSAConfig{APIKey: "api-key", PrivateKey: privateKey, Layout: auth.PayloadLayout{
+				Parts: []auth.PayloadPart{auth.PartQuery},
+			}},
+			wantErr: "exactly one of signature header and signature parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := auth.NewRSASigner(tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, signer)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "api-key", signer.ActiveKeyID())
+		})
+	}
+}
+
+func TestRSASigner_Sign_TestVector(t *testing.T) {
+	signer, err := auth.NewRSASigner(auth.RSAConfig{
+		APIKey:     "api-key",
+		PrivateKey: readTestKey(t, "rsa", "private_key.pem"),
+		Layout:     binanceLayout,
+	})
+	require.NoError(t, err)
+
+	// Produced with openssl dgst -sha256 -sign; see testdata/README.md
+	result, err := signer.Sign(context.Background(), auth.SignRequest{
+		Method:    "POST",
+		Path:      "/api/v3/order",
+		RawQuery:  binanceQuery,
+		Timestamp: "1668481559918",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "G3nnCGL9aFyauHmxzPdV0j4Oh3/ZAXmHy2EB892zwvM5ed3/+LtCgv2zq1uavb+C3m7CDiG4ty4au9OJw4OVs47iFc8uxfmwHHrvW4jB8xbLMya04Yog0tyJdnBU61wVPMo7iFlXAq9TQXi9MayZuvYHoJpQCpSYxyEe+QptLghGXFPnexFH1Ivmf72cFmO6X7EoBIJvogxxfMu+kscD2OXR5s9XZjKsA2Y6IqxMwu3FLsSqS042GKgk2VafgVf9FKDvSHUJmGvbmZpUwtZrCZ72xLL4AbH/0SyJRDE0mJKGBEtbMYnKSX+eyo0erg+J8vwYxDQz1dCsxwdx+G7WMA==",
+		result.QueryParams["signature"])
+}
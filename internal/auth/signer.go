@@ -7,6 +7,10 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 )
 
 // SignRequest represents an HTTP request to be signed.
@@ -15,9 +19,15 @@ type SignRequest struct {
 	// Method is the HTTP method (GET, POST, etc.)
 	Method string
 
-	// Path is the request path (e.g., "/orders")
+	// Path is the request path without the query string (e.g., "/orders")
 	Path string
 
+	// RawQuery is the encoded query string without the leading "?", exactly
+	// as sent (e.g., "limit=10&product_ids=BTC-USD"). Each signer decides
+	// whether and in what form the query is signed; see RequestPath and
+	// CanonicalQuery.
+	RawQuery string
+
 	// Body is the request body (may be empty for GET requests)
 	Body []byte
 
@@ -29,13 +39,104 @@ type SignRequest struct {
 	Headers http.Header
 }
 
+// RequestPath returns the path followed by "?" and the raw query, if any
+// (e.g., "/orders?limit=10"): the request target as it appears on the wire.
+func (r SignRequest) RequestPath() string {
+	if r.RawQuery == "" {
+		return r.Path
+	}
+	return r.Path + "?" + r.RawQuery
+}
+
+// CanonicalQuery returns a query string in canonical form, so that requests
+// differing only in parameter order or percent-encoding sign alike:
+//   - parameters are sorted by name, then by value
+//   - names and values are percent-encoded per RFC 3986: everything except
+//     letters, digits and "-._~" is escaped with upper case hex, and spaces
+//     are "%20" ("+" in the input is read as a space)
+//   - empty parameters ("a&&b") are dropped; a name without "=" keeps none
+//
+// Components with invalid escapes are read as literal text.
+func CanonicalQuery(rawQuery string) string {
+	type param struct {
+		pair        string
+		name, value string
+	}
+	var params []param
+	for _, part := range strings.Split(rawQuery, "&") {
+		if part == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(part, "=")
+		name, value = rfc3986Escape(queryUnescape(name)), rfc3986Escape(queryUnescape(value))
+		pair := name
+		if hasValue {
+			pair += "=" + value
+		}
+		params = append(params, param{pair: pair, name: name, value: value})
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		if params[i].name != params[j].name {
+			return params[i].name < params[j].name
+		}
+		return params[i].value < params[j].value
+	})
+
+	pairs := make([]string, len(params))
+	for i, p := range params {
+		pairs[i] = p.pair
+	}
+	return strings.Join(pairs, "&")
+}
+
+// queryUnescape decodes a query component, returning it unchanged if it holds
+// an invalid escape.
+func queryUnescape(s string) string {
+	unescaped, err := url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+	return unescaped
+}
+
+// rfc3986Escape percent-encodes every byte of s except RFC 3986 unreserved
+// characters.
+func rfc3986Escape(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0x0f])
+		}
+	}
+	return b.String()
+}
+
 // SignResult contains the authentication information to be added to the request.
 type SignResult struct {
 	// Headers contains authentication headers to add to the request
 	Headers map[string]string
 
-	// QueryParams contains authentication query parameters to add to the request
+	// QueryParams contains authentication query parameters to append to the
+	// request's query string, after any parameters already present, in
+	// order of name
 	QueryParams map[string]string
+
+	// TrailingQueryParam, if set, names the entry of QueryParams appended
+	// after all others, for venues that expect the signature to follow the
+	// parameters it signs
+	TrailingQueryParam string
+
+	// Body, if non-nil, replaces the request body, for signers that add
+	// authentication fields (such as a nonce) to it
+	Body []byte
 }
 
 // Signer defines the interface for request authentication.
@@ -48,6 +149,7 @@ type SignResult struct {
 //   - JWT for Coinbase Prime
 //   - Bearer token for FalconX
 //   - MPC signing for Fordefi
+//   - HMAC-SHA512, Ed25519 and RSA-SHA256 with a configurable PayloadLayout
 type Signer interface {
 	// Sign generates authentication information for an HTTP request.
 	// It returns SignResult containing headers and/or query parameters to add.
@@ -58,6 +160,15 @@ type Signer interface {
 	Sign(ctx context.Context, req SignRequest) (*SignResult, error)
 }
 
+// currentTime returns now(), or time.Now() if now is nil, for signers whose
+// config accepts a venue-synchronized clock.
+func currentTime(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now()
+	}
+	return now()
+}
+
 // Middleware creates an HTTP middleware function that applies authentication
 // to outgoing requests using the provided Signer.
 //
@@ -105,6 +216,7 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	signReq := SignRequest{
 		Method:    req.Method,
 		Path:      req.URL.Path,
+		RawQuery:  req.URL.RawQuery,
 		Body:      body,
 		Headers:   req.Header,
 		Timestamp: "", // Let signer generate timestamp
@@ -121,13 +233,36 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Header.Set(key, value)
 	}
 
-	// Apply authentication query parameters
+	// Append authentication query parameters, leaving the signed query
+	// string as it was
 	if len(result.QueryParams) > 0 {
-		q := req.URL.Query()
+		q, trailing := url.Values{}, url.Values{}
 		for key, value := range result.QueryParams {
-			q.Set(key, value)
+			if key == result.TrailingQueryParam {
+				trailing.Set(key, value)
+			} else {
+				q.Set(key, value)
+			}
+		}
+		for _, params := range []url.Values{q, trailing} {
+			if len(params) == 0 {
+				continue
+			}
+			if req.URL.RawQuery != "" {
+				req.URL.RawQuery += "&"
+			}
+			req.URL.RawQuery += params.Encode()
+		}
+	}
+
+	// Replace the body if the signer extended it
+	if result.Body != nil {
+		signedBody := result.Body
+		req.Body = io.NopCloser(bytes.NewReader(signedBody))
+		req.ContentLength = int64(len(signedBody))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(signedBody)), nil
 		}
-		req.URL.RawQuery = q.Encode()
 	}
 
 	// Forward the signed request
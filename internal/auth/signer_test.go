@@ -135,7 +135,7 @@ func TestMiddleware_AppliesQueryParameters(t *testing.T) {
 		Transport: auth.Middleware(signer, nil),
 	}
 
-	req, err := http.NewRequest("GET", server.URL+"/test?existing=param", nil)
+	req, err := http.NewRequest("GET", server.URL+"/test?existing=param&b=2&a=1", nil)
 	require.NoError(t, err)
 
 	resp, err := client.Do(req)
@@ -149,6 +149,7 @@ func TestMiddleware_AppliesQueryParameters(t *testing.T) {
 	assert.Equal(t, "test-key", query.Get("api_key"))
 	assert.Equal(t, "1234567890", query.Get("timestamp"))
 	assert.Equal(t, "test-sig", query.Get("signature"))
+	assert.True(t, strings.HasPrefix(capturedRequest.URL.RawQuery, "existing=param&b=2&a=1&"), "signed query is kept as sent")
 }
 
 // TestMiddleware_WithRequestBody tests signing requests with body
@@ -431,10 +432,11 @@ func TestSignRequest_PathExtraction(t *testing.T) {
 	}))
 	defer server.Close()
 
-	var capturedPath string
+	var capturedPath, capturedQuery string
 	signer := &mockSigner{
 		signFunc: func(ctx context.Context, req auth.SignRequest) (*auth.SignResult, error) {
 			capturedPath = req.Path
+			capturedQuery = req.RawQuery
 			return &auth.SignResult{}, nil
 		},
 	}
@@ -443,7 +445,7 @@ func TestSignRequest_PathExtraction(t *testing.T) {
 		Transport: auth.Middleware(signer, nil),
 	}
 
-	req, err := http.NewRequest("GET", server.URL+"/api/v1/orders/123?limit=10", nil)
+	req, err := http.NewRequest("GET", server.URL+"/api/v1/orders/123?limit=10&cursor=a%2Bb", nil)
 	require.NoError(t, err)
 
 	resp, err := client.Do(req)
@@ -451,4 +453,36 @@ func TestSignRequest_PathExtraction(t *testing.T) {
 	defer resp.Body.Close()
 
 	assert.Equal(t, "/api/v1/orders/123", capturedPath)
+	assert.Equal(t, "limit=10&cursor=a%2Bb", capturedQuery, "query is passed as sent")
+}
+
+// TestSignRequest_RequestPath tests joining the path and raw query
+func TestSignRequest_RequestPath(t *testing.T) {
+	assert.Equal(t, "/orders", auth.SignRequest{Path: "/orders"}.RequestPath())
+	assert.Equal(t, "/orders?b=2&a=1", auth.SignRequest{Path: "/orders", RawQuery: "b=2&a=1"}.RequestPath())
+}
+
+// TestCanonicalQuery tests query canonicalization vectors
+func TestCanonicalQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		want     string
+	}{
+		{name: "empty", rawQuery: "", want: ""},
+		{name: "sorted by name", rawQuery: "product_id=BTC-USD&limit=10", want: "limit=10&product_id=BTC-USD"},
+		{name: "repeated names sorted by value", rawQuery: "status=OPEN&status=FILLED", want: "status=FILLED&status=OPEN"},
+		{name: "plus is a space", rawQuery: "note=a+b", want: "note=a%20b"},
+		{name: "escapes normalized", rawQuery: "date=2024-01-15T10%3a30%3A00Z&id=BTC%2DUSD", want: "date=2024-01-15T10%3A30%3A00Z&id=BTC-USD"},
+		{name: "reserved characters escaped", rawQuery: "q=a/b:c,d", want: "q=a%2Fb%3Ac%2Cd"},
+		{name: "unreserved characters kept", rawQuery: "q=a-b.c_d~e", want: "q=a-b.c_d~e"},
+		{name: "utf-8", rawQuery: "asset=%C3%A9", want: "asset=%C3%A9"},
+		{name: "empty parameters dropped", rawQuery: "b=&&a", want: "a&b="},
+		{name: "invalid escape kept", rawQuery: "q=100%", want: "q=100%25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, auth.CanonicalQuery(tt.rawQuery))
+		})
+	}
 }
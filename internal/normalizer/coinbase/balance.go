@@ -151,9 +151,9 @@ func NormalizeAccountBalance(ctx context.Context, raw []byte) (map[string]float6
 	var accountsResp CoinbaseAccountsResponse
 	if err := json.Unmarshal(raw, &accountsResp); err == nil && len(accountsResp.Accounts) > 0 {
 		for _, account := range accountsResp.Accounts {
-			available := normalizer.ParseDecimalOrZero(account.AvailableBalance.Value)
-			held := normalizer.ParseDecimalOrZero(account.Hold.Value)
-			balances[account.Currency] = available + held
+			available := normalizer.ParseExactDecimalOrZero(account.AvailableBalance.Value)
+			held := normalizer.ParseExactDecimalOrZero(account.Hold.Value)
+			balances[account.Currency] = available.Add(held).Float64()
 		}
 		return balances, nil
 	}
@@ -164,9 +164,74 @@ func NormalizeAccountBalance(ctx context.Context, raw []byte) (map[string]float6
 		return nil, fmt.Errorf("failed to parse coinbase account: %w", err)
 	}
 
-	available := normalizer.ParseDecimalOrZero(account.AvailableBalance.Value)
-	held := normalizer.ParseDecimalOrZero(account.Hold.Value)
-	balances[account.Currency] = available + held
+	available := normalizer.ParseExactDecimalOrZero(account.AvailableBalance.Value)
+	held := normalizer.ParseExactDecimalOrZero(account.Hold.Value)
+	balances[account.Currency] = available.Add(held).Float64()
 
 	return balances, nil
 }
+
+// NormalizeAccountBalances converts a Coinbase accounts JSON response to CQC Balance protobufs.
+//
+// Each Coinbase account holds a single currency, so one Balance is returned per account:
+//   - Available is the account's available_balance
+//   - Locked is the account's hold
+//   - Total is available + hold
+//
+// The response may be an accounts list or a single account object.
+func NormalizeAccountBalances(ctx context.Context, raw []byte) ([]*venuesv1.Balance, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty balance response")
+	}
+
+	var accounts []CoinbaseAccount
+
+	var accountsResp CoinbaseAccountsResponse
+	if err := json.Unmarshal(raw, &accountsResp); err == nil && accountsResp.Accounts != nil {
+		accounts = accountsResp.Accounts
+	} else {
+		var account CoinbaseAccount
+		if err := json.Unmarshal(raw, &account); err != nil {
+			return nil, fmt.Errorf("failed to parse coinbase account: %w", err)
+		}
+		accounts = []CoinbaseAccount{account}
+	}
+
+	balances := make([]*venuesv1.Balance, 0, len(accounts))
+	for _, account := range accounts {
+		balances = append(balances, normalizeAccountBalance(account))
+	}
+
+	return balances, nil
+}
+
+// normalizeAccountBalance converts a single Coinbase account to a CQC Balance.
+func normalizeAccountBalance(account CoinbaseAccount) *venuesv1.Balance {
+	available := normalizer.ParseExactDecimalOrZero(account.AvailableBalance.Value)
+	held := normalizer.ParseExactDecimalOrZero(account.Hold.Value)
+	total := available.Add(held).Float64()
+
+	venueId := "coinbase"
+	balanceType := venuesv1.BalanceType_BALANCE_TYPE_SPOT
+	tradeable := account.Active && account.Ready
+
+	balance := &venuesv1.Balance{
+		BalanceId:   &account.UUID,
+		AccountId:   &account.UUID,
+		VenueId:     &venueId,
+		AssetId:     &account.Currency,
+		BalanceType: &balanceType,
+		Total:       &total,
+		Available:   normalizer.Float64Ptr(available.Float64()),
+		Locked:      normalizer.Float64Ptr(held.Float64()),
+		Tradeable:   &tradeable,
+	}
+
+	if account.UpdatedAt != "" {
+		if ts, err := normalizer.ParseTimestamp(account.UpdatedAt); err == nil {
+			balance.UpdatedAt = ts
+		}
+	}
+
+	return balance
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
 )
 
 // CoinbaseError represents an error response from the Coinbase API.
@@ -17,52 +20,82 @@ type CoinbaseError struct {
 	EditFailure     string `json:"edit_failure_reason"`
 }
 
-// NormalizeError converts a Coinbase API error response to a structured error.
-// It parses the error response and classifies it based on HTTP status code and error message.
+// venueID identifies Coinbase in classified errors.
+const venueID = "coinbase"
+
+// NormalizeError converts a Coinbase API error response to a *venueerr.Error.
+// It parses the error body and classifies it based on HTTP status code and error message.
+// Retry-After and rate limit headers are read by venueerr.FromResponse; rate
+// limits are bucketed as "public" for /market/ endpoints and "private" otherwise.
 //
 // Error Classification:
-//   - 401/403: Authentication/Authorization errors (Permanent)
-//   - 429: Rate limit errors (RateLimit)
-//   - 400: Invalid request errors (Permanent if client error, otherwise Temporary)
-//   - 500/503: Server errors (Temporary)
-//   - Other: Temporary by default
+//   - 401/403: venueerr.ErrAuth
+//   - 429: venueerr.ErrRateLimited
+//   - 400: venueerr.ErrInsufficientFunds or ErrNotFound if the error says so,
+//     ErrInvalidOrder if it carries an order or preview failure reason, and
+//     ErrInvalidRequest otherwise
+//   - 404: venueerr.ErrNotFound
+//   - 5xx and other statuses: venueerr.ErrUnavailable
+//   - 400/401/403 whose code or message is one venueerr.ClassifyClockSkew
+//     knows for a rejected timestamp or token validity window: venueerr.ErrClockSkew, overriding the above
 //
 // Returns an error with appropriate classification and original error details.
-func NormalizeError(statusCode int, body []byte) error {
+func NormalizeError(resp *http.Response, body []byte) error {
+	venueErr := venueerr.FromResponse(venueID, resp)
+	if venueErr.RateLimit != nil {
+		venueErr.RateLimit.Bucket = rateLimitBucket(resp.Request)
+	}
 	if len(body) == 0 {
-		return fmt.Errorf("coinbase api error: status %d (no body)", statusCode)
+		venueErr.Message = "no body"
+		return venueErr
 	}
 
 	var cbErr CoinbaseError
 	if err := json.Unmarshal(body, &cbErr); err != nil {
-		// If we can't parse the error, return raw body
-		return fmt.Errorf("coinbase api error: status %d: %s", statusCode, string(body))
+		// If we can't parse the error, keep the raw body
+		venueErr.Message = string(body)
+		venueerr.ClassifyClockSkew(venueErr, venueErr.Message)
+		return venueErr
 	}
 
-	// Construct error message with all available details
-	msg := formatErrorMessage(cbErr)
-
-	// Classify error based on status code
-	return classifyError(statusCode, msg, &cbErr)
+	venueErr.Code = cbErr.Error
+	venueErr.Message = formatErrorMessage(cbErr)
+	if resp.StatusCode == http.StatusBadRequest {
+		venueErr.Category = classifyFailure(cbErr, venueErr.Category)
+	}
+	venueerr.ClassifyClockSkew(venueErr, cbErr.Message)
+	return venueErr
 }
 
-// formatErrorMessage constructs a comprehensive error message from Coinbase error fields.
-func formatErrorMessage(cbErr CoinbaseError) string {
-	msg := "coinbase api error"
-
-	if cbErr.Error != "" {
-		msg = fmt.Sprintf("%s: %s", msg, cbErr.Error)
+// rateLimitBucket returns the Advanced Trade rate limit a request counts
+// against: public market data endpoints are limited separately from
+// authenticated ones.
+func rateLimitBucket(req *http.Request) string {
+	if req != nil && req.URL != nil && strings.Contains(req.URL.Path, "/market/") {
+		return "public"
 	}
-
-	if cbErr.Message != "" {
-		if cbErr.Error == "" {
-			msg = fmt.Sprintf("%s: %s", msg, cbErr.Message)
-		} else {
-			msg = fmt.Sprintf("%s (%s)", msg, cbErr.Message)
-		}
+	return "private"
+}
+
+// NewRejectionError returns the error for a request that Coinbase rejected in
+// a successful response, such as an order, cancel or edit failure. The
+// category is taken from the failure reason (e.g., "INSUFFICIENT_FUND" is
+// venueerr.ErrInsufficientFunds), falling back to fallback for reasons that
+// name no more specific category.
+func NewRejectionError(cbErr CoinbaseError, fallback error) *venueerr.Error {
+	return &venueerr.Error{
+		Venue:    venueID,
+		Category: classifyFailure(cbErr, fallback),
+		Code:     cbErr.Error,
+		Message:  formatErrorMessage(cbErr),
 	}
+}
+
+// formatErrorMessage constructs the error message from the Coinbase message
+// and failure details.
+func formatErrorMessage(cbErr CoinbaseError) string {
+	msg := cbErr.Message
 
-	// Add additional details if available
 	details := []string{}
 	if cbErr.ErrorDetails != "" {
 		details = append(details, cbErr.ErrorDetails)
@@ -78,9 +111,9 @@ func formatErrorMessage(cbErr CoinbaseError) string {
 	}
 
 	if len(details) > 0 {
-		detailStr := details[0]
-		for i := 1; i < len(details); i++ {
-			detailStr = detailStr + "; " + details[i]
+		detailStr := strings.Join(details, "; ")
+		if msg == "" {
+			return detailStr
 		}
 		msg = fmt.Sprintf("%s [%s]", msg, detailStr)
 	}
@@ -88,140 +121,23 @@ func formatErrorMessage(cbErr CoinbaseError) string {
 	return msg
 }
 
-// classifyError determines the error type based on HTTP status code and error content.
-func classifyError(statusCode int, msg string, cbErr *CoinbaseError) error {
-	baseErr := fmt.Errorf("%s (status: %d)", msg, statusCode)
-
-	switch statusCode {
-	case http.StatusUnauthorized, http.StatusForbidden:
-		// Authentication/authorization failures are permanent
-		return &PermanentError{Err: baseErr, Code: "AUTH_FAILURE"}
-
-	case http.StatusTooManyRequests:
-		// Rate limit errors
-		return &RateLimitError{Err: baseErr, Code: "RATE_LIMIT"}
-
-	case http.StatusBadRequest:
-		// Bad request - check if it's a client error (permanent) or server issue (temporary)
-		if isClientError(cbErr) {
-			return &PermanentError{Err: baseErr, Code: "INVALID_REQUEST"}
-		}
-		return &TemporaryError{Err: baseErr, Code: "BAD_REQUEST"}
-
-	case http.StatusNotFound:
-		// Resource not found - permanent
-		return &PermanentError{Err: baseErr, Code: "NOT_FOUND"}
-
-	case http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
-		// Server errors are temporary
-		return &TemporaryError{Err: baseErr, Code: "SERVER_ERROR"}
-
+// classifyFailure determines the category of a rejected request from its
+// error code, failure reasons and message, returning fallback if none applies.
+func classifyFailure(cbErr CoinbaseError, fallback error) error {
+	text := strings.ToLower(strings.Join([]string{
+		cbErr.Error, cbErr.Message, cbErr.ErrorDetails,
+		cbErr.PreviewFailure, cbErr.NewOrderFailure, cbErr.EditFailure,
+	}, " "))
+
+	switch {
+	case strings.Contains(text, "insufficient"):
+		return venueerr.ErrInsufficientFunds
+	case strings.Contains(text, "not_found"), strings.Contains(text, "not found"),
+		strings.Contains(text, "unknown_cancel_order"):
+		return venueerr.ErrNotFound
+	case cbErr.PreviewFailure != "", cbErr.NewOrderFailure != "":
+		return venueerr.ErrInvalidOrder
 	default:
-		// Unknown errors default to temporary
-		return &TemporaryError{Err: baseErr, Code: "UNKNOWN"}
-	}
-}
-
-// isClientError determines if an error is caused by client input (permanent) vs server issues (temporary).
-func isClientError(cbErr *CoinbaseError) bool {
-	// Check for error messages that indicate client-side issues
-	clientErrors := []string{
-		"invalid",
-		"missing",
-		"insufficient",
-		"exceed",
-		"too small",
-		"too large",
-		"not allowed",
-		"unsupported",
-		"duplicate",
-		"malformed",
+		return fallback
 	}
-
-	msg := cbErr.Error + " " + cbErr.Message + " " + cbErr.ErrorDetails
-	for _, pattern := range clientErrors {
-		if contains(msg, pattern) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// contains checks if s contains substr (case-insensitive).
-func contains(s, substr string) bool {
-	// Simple case-insensitive check
-	s = toLower(s)
-	substr = toLower(substr)
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
-// toLower converts a string to lowercase.
-func toLower(s string) string {
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
-		}
-		result[i] = c
-	}
-	return string(result)
-}
-
-// Error types for classification
-
-// PermanentError represents an error that won't succeed on retry (e.g., invalid request, auth failure).
-type PermanentError struct {
-	Err  error
-	Code string
-}
-
-func (e *PermanentError) Error() string {
-	return fmt.Sprintf("permanent error [%s]: %v", e.Code, e.Err)
-}
-
-func (e *PermanentError) Unwrap() error {
-	return e.Err
-}
-
-// TemporaryError represents an error that might succeed on retry (e.g., server error, timeout).
-type TemporaryError struct {
-	Err  error
-	Code string
-}
-
-func (e *TemporaryError) Error() string {
-	return fmt.Sprintf("temporary error [%s]: %v", e.Code, e.Err)
-}
-
-func (e *TemporaryError) Unwrap() error {
-	return e.Err
-}
-
-func (e *TemporaryError) Temporary() bool {
-	return true
-}
-
-// RateLimitError represents a rate limit error.
-type RateLimitError struct {
-	Err  error
-	Code string
-}
-
-func (e *RateLimitError) Error() string {
-	return fmt.Sprintf("rate limit error [%s]: %v", e.Code, e.Err)
-}
-
-func (e *RateLimitError) Unwrap() error {
-	return e.Err
-}
-
-func (e *RateLimitError) RateLimit() bool {
-	return true
 }
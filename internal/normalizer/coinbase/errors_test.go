@@ -1,219 +1,239 @@
 package coinbase
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
 )
 
 func TestNormalizeError(t *testing.T) {
 	tests := []struct {
-		name       string
-		statusCode int
-		body       []byte
-		wantType   string // "permanent", "temporary", "ratelimit"
-		wantCode   string
+		name         string
+		statusCode   int
+		body         []byte
+		wantCategory error
+		wantCode     string
 	}{
 		{
-			name:       "authentication failure",
-			statusCode: http.StatusUnauthorized,
-			body:       []byte(`{"error": "unauthorized", "message": "Invalid API key"}`),
-			wantType:   "permanent",
-			wantCode:   "AUTH_FAILURE",
+			name:         "authentication failure",
+			statusCode:   http.StatusUnauthorized,
+			body:         []byte(`{"error": "unauthorized", "message": "Invalid API key"}`),
+			wantCategory: venueerr.ErrAuth,
+			wantCode:     "unauthorized",
+		},
+		{
+			name:         "authorization failure",
+			statusCode:   http.StatusForbidden,
+			body:         []byte(`{"error": "forbidden", "message": "Insufficient permissions"}`),
+			wantCategory: venueerr.ErrAuth,
+			wantCode:     "forbidden",
+		},
+		{
+			name:         "rate limit error",
+			statusCode:   http.StatusTooManyRequests,
+			body:         []byte(`{"error": "rate_limit", "message": "Too many requests"}`),
+			wantCategory: venueerr.ErrRateLimited,
+			wantCode:     "rate_limit",
 		},
 		{
-			name:       "authorization failure",
-			statusCode: http.StatusForbidden,
-			body:       []byte(`{"error": "forbidden", "message": "Insufficient permissions"}`),
-			wantType:   "permanent",
-			wantCode:   "AUTH_FAILURE",
+			name:         "invalid request",
+			statusCode:   http.StatusBadRequest,
+			body:         []byte(`{"error": "invalid_request", "message": "Invalid order size"}`),
+			wantCategory: venueerr.ErrInvalidRequest,
+			wantCode:     "invalid_request",
 		},
 		{
-			name:       "rate limit error",
-			statusCode: http.StatusTooManyRequests,
-			body:       []byte(`{"error": "rate_limit", "message": "Too many requests"}`),
-			wantType:   "ratelimit",
-			wantCode:   "RATE_LIMIT",
+			name:         "insufficient funds",
+			statusCode:   http.StatusBadRequest,
+			body:         []byte(`{"error": "insufficient_funds", "message": "Not enough balance"}`),
+			wantCategory: venueerr.ErrInsufficientFunds,
+			wantCode:     "insufficient_funds",
 		},
 		{
-			name:       "invalid request - client error",
-			statusCode: http.StatusBadRequest,
-			body:       []byte(`{"error": "invalid_request", "message": "Invalid order size"}`),
-			wantType:   "permanent",
-			wantCode:   "INVALID_REQUEST",
+			name:         "order failure reason",
+			statusCode:   http.StatusBadRequest,
+			body:         []byte(`{"error": "order_failed", "message": "Order failed", "new_order_failure_reason": "INVALID_LIMIT_PRICE"}`),
+			wantCategory: venueerr.ErrInvalidOrder,
+			wantCode:     "order_failed",
 		},
 		{
-			name:       "insufficient funds - client error",
-			statusCode: http.StatusBadRequest,
-			body:       []byte(`{"error": "insufficient_funds", "message": "Not enough balance"}`),
-			wantType:   "permanent",
-			wantCode:   "INVALID_REQUEST",
+			name:         "unknown order in bad request",
+			statusCode:   http.StatusBadRequest,
+			body:         []byte(`{"error": "UNKNOWN_CANCEL_ORDER", "message": "order cannot be cancelled"}`),
+			wantCategory: venueerr.ErrNotFound,
+			wantCode:     "UNKNOWN_CANCEL_ORDER",
 		},
 		{
-			name:       "bad request - server issue",
-			statusCode: http.StatusBadRequest,
-			body:       []byte(`{"error": "processing_error", "message": "Request failed"}`),
-			wantType:   "temporary",
-			wantCode:   "BAD_REQUEST",
+			name:         "not found error",
+			statusCode:   http.StatusNotFound,
+			body:         []byte(`{"error": "not_found", "message": "Order not found"}`),
+			wantCategory: venueerr.ErrNotFound,
+			wantCode:     "not_found",
 		},
 		{
-			name:       "not found error",
-			statusCode: http.StatusNotFound,
-			body:       []byte(`{"error": "not_found", "message": "Order not found"}`),
-			wantType:   "permanent",
-			wantCode:   "NOT_FOUND",
+			name:         "server error",
+			statusCode:   http.StatusInternalServerError,
+			body:         []byte(`{"error": "internal_error", "message": "Server error"}`),
+			wantCategory: venueerr.ErrUnavailable,
+			wantCode:     "internal_error",
 		},
 		{
-			name:       "server error",
-			statusCode: http.StatusInternalServerError,
-			body:       []byte(`{"error": "internal_error", "message": "Server error"}`),
-			wantType:   "temporary",
-			wantCode:   "SERVER_ERROR",
+			name:         "service unavailable",
+			statusCode:   http.StatusServiceUnavailable,
+			body:         []byte(`{"error": "service_unavailable", "message": "Service down"}`),
+			wantCategory: venueerr.ErrUnavailable,
+			wantCode:     "service_unavailable",
 		},
 		{
-			name:       "service unavailable",
-			statusCode: http.StatusServiceUnavailable,
-			body:       []byte(`{"error": "service_unavailable", "message": "Service down"}`),
-			wantType:   "temporary",
-			wantCode:   "SERVER_ERROR",
+			name:         "clock skew",
+			statusCode:   http.StatusUnauthorized,
+			body:         []byte(`{"error": "unauthorized", "message": "request timestamp expired"}`),
+			wantCategory: venueerr.ErrClockSkew,
+			wantCode:     "unauthorized",
 		},
 		{
-			name:       "empty body",
-			statusCode: http.StatusInternalServerError,
-			body:       []byte{},
-			wantType:   "error", // Just check it returns an error
-			wantCode:   "",
+			name:         "empty body",
+			statusCode:   http.StatusInternalServerError,
+			body:         []byte{},
+			wantCategory: venueerr.ErrUnavailable,
 		},
 		{
-			name:       "malformed JSON",
-			statusCode: http.StatusBadRequest,
-			body:       []byte(`{invalid json`),
-			wantType:   "error",
-			wantCode:   "",
+			name:         "malformed JSON",
+			statusCode:   http.StatusBadRequest,
+			body:         []byte(`{invalid json`),
+			wantCategory: venueerr.ErrInvalidRequest,
 		},
 		{
-			name:       "error with details",
-			statusCode: http.StatusBadRequest,
-			body:       []byte(`{"error": "invalid_order", "message": "Order failed", "error_details": "Size too small", "preview_failure_reason": "Insufficient funds"}`),
-			wantType:   "permanent",
-			wantCode:   "INVALID_REQUEST",
+			name:         "error with details",
+			statusCode:   http.StatusBadRequest,
+			body:         []byte(`{"error": "invalid_order", "message": "Order failed", "error_details": "Size too small", "preview_failure_reason": "PREVIEW_INVALID_BASE_SIZE_TOO_SMALL"}`),
+			wantCategory: venueerr.ErrInvalidOrder,
+			wantCode:     "invalid_order",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := NormalizeError(tt.statusCode, tt.body)
+			err := NormalizeError(&http.Response{StatusCode: tt.statusCode}, tt.body)
 			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
 
-			// Check error type
-			switch tt.wantType {
-			case "permanent":
-				if _, ok := err.(*PermanentError); !ok {
-					t.Errorf("expected PermanentError, got %T: %v", err, err)
-				}
-				if perr, ok := err.(*PermanentError); ok && perr.Code != tt.wantCode {
-					t.Errorf("expected code %q, got %q", tt.wantCode, perr.Code)
-				}
-			case "temporary":
-				if _, ok := err.(*TemporaryError); !ok {
-					t.Errorf("expected TemporaryError, got %T: %v", err, err)
-				}
-				if terr, ok := err.(*TemporaryError); ok && terr.Code != tt.wantCode {
-					t.Errorf("expected code %q, got %q", tt.wantCode, terr.Code)
-				}
-			case "ratelimit":
-				if _, ok := err.(*RateLimitError); !ok {
-					t.Errorf("expected RateLimitError, got %T: %v", err, err)
-				}
-				if rerr, ok := err.(*RateLimitError); ok && rerr.Code != tt.wantCode {
-					t.Errorf("expected code %q, got %q", tt.wantCode, rerr.Code)
-				}
-			case "error":
-				// Just verify it's an error
+			if !errors.Is(err, tt.wantCategory) {
+				t.Errorf("expected category %v, got %v", tt.wantCategory, err)
 			}
 
-			// Error message should contain status code
-			if tt.statusCode > 0 && !contains(err.Error(), "status") {
-				t.Errorf("error message should contain status code: %v", err)
+			var venueErr *venueerr.Error
+			if !errors.As(err, &venueErr) {
+				t.Fatalf("expected *venueerr.Error, got %T: %v", err, err)
+			}
+			if venueErr.Venue != "coinbase" {
+				t.Errorf("expected venue coinbase, got %q", venueErr.Venue)
+			}
+			if venueErr.Code != tt.wantCode {
+				t.Errorf("expected code %q, got %q", tt.wantCode, venueErr.Code)
+			}
+			if venueErr.StatusCode != tt.statusCode {
+				t.Errorf("expected status %d, got %d", tt.statusCode, venueErr.StatusCode)
 			}
 		})
 	}
 }
 
-func TestIsClientError(t *testing.T) {
+func TestNormalizeErrorRateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second).Truncate(time.Second)
+	req := httptest.NewRequest(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":           []string{"2"},
+			"X-Ratelimit-Limit":     []string{"30"},
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+		},
+		Request: req,
+	}
+
+	err := NormalizeError(resp, []byte(`{"error": "rate_limit_exceeded", "message": "Too many requests"}`))
+	if !errors.Is(err, venueerr.ErrRateLimited) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+	if got := venueerr.RetryAfter(err); got != 2*time.Second {
+		t.Errorf("expected retry after 2s, got %v", got)
+	}
+
+	limit := venueerr.RateLimitOf(err)
+	if limit == nil {
+		t.Fatal("expected rate limit")
+	}
+	want := venueerr.RateLimit{Bucket: "private", Limit: 30, Remaining: 0, Reset: reset}
+	if limit.Bucket != want.Bucket || limit.Limit != want.Limit || limit.Remaining != want.Remaining || !limit.Reset.Equal(want.Reset) {
+		t.Errorf("expected %+v, got %+v", want, *limit)
+	}
+
+	resp.Request = httptest.NewRequest(http.MethodGet, "/api/v3/brokerage/market/products", nil)
+	resp.Header = nil
+	limit = venueerr.RateLimitOf(NormalizeError(resp, nil))
+	if limit == nil || limit.Bucket != "public" || limit.Limit != -1 || limit.Remaining != -1 {
+		t.Errorf("expected unknown public budget, got %+v", limit)
+	}
+}
+
+func TestNewRejectionError(t *testing.T) {
 	tests := []struct {
-		name   string
-		cbErr  *CoinbaseError
-		expect bool
+		name         string
+		cbErr        CoinbaseError
+		fallback     error
+		wantCategory error
 	}{
 		{
-			name:   "invalid request",
-			cbErr:  &CoinbaseError{Error: "invalid_size"},
-			expect: true,
-		},
-		{
-			name:   "missing field",
-			cbErr:  &CoinbaseError{Message: "missing required field"},
-			expect: true,
-		},
-		{
-			name:   "insufficient funds",
-			cbErr:  &CoinbaseError{ErrorDetails: "insufficient balance"},
-			expect: true,
+			name:         "insufficient funds",
+			cbErr:        CoinbaseError{Error: "INSUFFICIENT_FUND", PreviewFailure: "PREVIEW_INSUFFICIENT_FUND"},
+			fallback:     venueerr.ErrInvalidOrder,
+			wantCategory: venueerr.ErrInsufficientFunds,
 		},
 		{
-			name:   "size too small",
-			cbErr:  &CoinbaseError{Message: "Order size too small"},
-			expect: true,
+			name:         "invalid order",
+			cbErr:        CoinbaseError{Error: "INVALID_LIMIT_PRICE_POST_ONLY"},
+			fallback:     venueerr.ErrInvalidOrder,
+			wantCategory: venueerr.ErrInvalidOrder,
 		},
 		{
-			name:   "exceeds limit",
-			cbErr:  &CoinbaseError{Error: "exceeds maximum"},
-			expect: true,
+			name:         "unknown cancel order",
+			cbErr:        CoinbaseError{Error: "UNKNOWN_CANCEL_ORDER"},
+			fallback:     venueerr.ErrInvalidRequest,
+			wantCategory: venueerr.ErrNotFound,
 		},
 		{
-			name:   "not allowed",
-			cbErr:  &CoinbaseError{Message: "operation not allowed"},
-			expect: true,
+			name:         "order already done",
+			cbErr:        CoinbaseError{Error: "ORDER_NOT_FOUND_OR_ALREADY_DONE"},
+			fallback:     venueerr.ErrInvalidRequest,
+			wantCategory: venueerr.ErrNotFound,
 		},
 		{
-			name:   "unsupported",
-			cbErr:  &CoinbaseError{Error: "unsupported order type"},
-			expect: true,
-		},
-		{
-			name:   "duplicate",
-			cbErr:  &CoinbaseError{Message: "duplicate client_order_id"},
-			expect: true,
-		},
-		{
-			name:   "malformed",
-			cbErr:  &CoinbaseError{ErrorDetails: "malformed request"},
-			expect: true,
-		},
-		{
-			name:   "server error",
-			cbErr:  &CoinbaseError{Error: "internal_server_error"},
-			expect: false,
-		},
-		{
-			name:   "processing error",
-			cbErr:  &CoinbaseError{Message: "processing failed"},
-			expect: false,
-		},
-		{
-			name:   "timeout",
-			cbErr:  &CoinbaseError{Error: "request timeout"},
-			expect: false,
+			name:         "other edit failure",
+			cbErr:        CoinbaseError{Error: "ORDER_IS_NOT_EDITABLE"},
+			fallback:     venueerr.ErrInvalidRequest,
+			wantCategory: venueerr.ErrInvalidRequest,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isClientError(tt.cbErr)
-			if got != tt.expect {
-				t.Errorf("isClientError() = %v, want %v for error: %+v", got, tt.expect, tt.cbErr)
+			err := NewRejectionError(tt.cbErr, tt.fallback)
+			if !errors.Is(err, tt.wantCategory) {
+				t.Errorf("expected category %v, got %v", tt.wantCategory, err)
+			}
+			if err.Code != tt.cbErr.Error {
+				t.Errorf("expected code %q, got %q", tt.cbErr.Error, err.Code)
+			}
+			if err.StatusCode != 0 {
+				t.Errorf("expected no status, got %d", err.StatusCode)
 			}
 		})
 	}
@@ -230,7 +250,7 @@ func TestFormatErrorMessage(t *testing.T) {
 			cbErr: CoinbaseError{
 				Error: "invalid_request",
 			},
-			expect: "coinbase api error: invalid_request",
+			expect: "",
 		},
 		{
 			name: "error and message",
@@ -238,14 +258,7 @@ func TestFormatErrorMessage(t *testing.T) {
 				Error:   "invalid_request",
 				Message: "Order size too small",
 			},
-			expect: "coinbase api error: invalid_request (Order size too small)",
-		},
-		{
-			name: "message only",
-			cbErr: CoinbaseError{
-				Message: "Order failed",
-			},
-			expect: "coinbase api error: Order failed",
+			expect: "Order size too small",
 		},
 		{
 			name: "with error details",
@@ -254,7 +267,7 @@ func TestFormatErrorMessage(t *testing.T) {
 				Message:      "Validation failed",
 				ErrorDetails: "Size must be greater than 0.001",
 			},
-			expect: "coinbase api error: invalid_request (Validation failed) [Size must be greater than 0.001]",
+			expect: "Validation failed [Size must be greater than 0.001]",
 		},
 		{
 			name: "with multiple details",
@@ -264,12 +277,14 @@ func TestFormatErrorMessage(t *testing.T) {
 				PreviewFailure:  "Insufficient funds",
 				NewOrderFailure: "Market closed",
 			},
-			expect: "coinbase api error: order_failed (Order could not be placed) [preview: Insufficient funds; order: Market closed]",
+			expect: "Order could not be placed [preview: Insufficient funds; order: Market closed]",
 		},
 		{
-			name:   "empty error",
-			cbErr:  CoinbaseError{},
-			expect: "coinbase api error",
+			name: "details only",
+			cbErr: CoinbaseError{
+				EditFailure: "ORDER_IS_NOT_EDITABLE",
+			},
+			expect: "edit: ORDER_IS_NOT_EDITABLE",
 		},
 	}
 
@@ -282,109 +297,3 @@ func TestFormatErrorMessage(t *testing.T) {
 		})
 	}
 }
-
-func TestErrorTypes(t *testing.T) {
-	t.Run("PermanentError", func(t *testing.T) {
-		baseErr := &PermanentError{
-			Err:  http.ErrBodyNotAllowed,
-			Code: "INVALID_REQUEST",
-		}
-
-		if !contains(baseErr.Error(), "permanent") {
-			t.Errorf("PermanentError.Error() should contain 'permanent': %v", baseErr.Error())
-		}
-
-		if !contains(baseErr.Error(), "INVALID_REQUEST") {
-			t.Errorf("PermanentError.Error() should contain code: %v", baseErr.Error())
-		}
-
-		if baseErr.Unwrap() != http.ErrBodyNotAllowed {
-			t.Errorf("PermanentError.Unwrap() failed")
-		}
-	})
-
-	t.Run("TemporaryError", func(t *testing.T) {
-		baseErr := &TemporaryError{
-			Err:  http.ErrServerClosed,
-			Code: "SERVER_ERROR",
-		}
-
-		if !contains(baseErr.Error(), "temporary") {
-			t.Errorf("TemporaryError.Error() should contain 'temporary': %v", baseErr.Error())
-		}
-
-		if !baseErr.Temporary() {
-			t.Error("TemporaryError.Temporary() should return true")
-		}
-
-		if baseErr.Unwrap() != http.ErrServerClosed {
-			t.Errorf("TemporaryError.Unwrap() failed")
-		}
-	})
-
-	t.Run("RateLimitError", func(t *testing.T) {
-		baseErr := &RateLimitError{
-			Err:  http.ErrHandlerTimeout,
-			Code: "RATE_LIMIT",
-		}
-
-		if !contains(baseErr.Error(), "rate limit") {
-			t.Errorf("RateLimitError.Error() should contain 'rate limit': %v", baseErr.Error())
-		}
-
-		if !baseErr.RateLimit() {
-			t.Error("RateLimitError.RateLimit() should return true")
-		}
-
-		if baseErr.Unwrap() != http.ErrHandlerTimeout {
-			t.Errorf("RateLimitError.Unwrap() failed")
-		}
-	})
-}
-
-func TestContains(t *testing.T) {
-	tests := []struct {
-		s      string
-		substr string
-		want   bool
-	}{
-		{"hello world", "world", true},
-		{"hello world", "WORLD", true}, // case-insensitive
-		{"hello world", "foo", false},
-		{"HELLO WORLD", "hello", true}, // case-insensitive
-		{"", "anything", false},
-		{"something", "", true}, // empty substring always matches
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.s+"_"+tt.substr, func(t *testing.T) {
-			got := contains(tt.s, tt.substr)
-			if got != tt.want {
-				t.Errorf("contains(%q, %q) = %v, want %v", tt.s, tt.substr, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestToLower(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"Hello", "hello"},
-		{"WORLD", "world"},
-		{"MixedCase", "mixedcase"},
-		{"123ABC", "123abc"},
-		{"already-lower", "already-lower"},
-		{"", ""},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			got := toLower(tt.input)
-			if got != tt.want {
-				t.Errorf("toLower(%q) = %q, want %q", tt.input, got, tt.want)
-			}
-		})
-	}
-}
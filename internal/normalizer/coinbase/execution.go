@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
 	"github.com/Combine-Capital/cqvx/internal/normalizer"
@@ -25,7 +26,7 @@ type CoinbaseFill struct {
 	ProductID          string `json:"product_id"`
 	SequenceTimestamp  string `json:"sequence_timestamp"`
 	LiquidityIndicator string `json:"liquidity_indicator"` // "MAKER", "TAKER", "UNKNOWN"
-	SizeInQuote        string `json:"size_in_quote"`
+	SizeInQuote        bool   `json:"size_in_quote"`
 	UserID             string `json:"user_id"`
 	Side               string `json:"side"`
 	RetailPortfolioID  string `json:"retail_portfolio_id"`
@@ -39,6 +40,10 @@ type CoinbaseFill struct {
 //   - Parsing decimal values for prices, quantities, and fees
 //   - Mapping liquidity indicators to maker/taker flags
 //   - Determining execution type from trade type
+//   - Setting the fee asset to the product's quote currency, in which
+//     Coinbase charges commission
+//
+// Liquidity and IsMaker are only set when Coinbase reports MAKER or TAKER.
 //
 // Returns an error if JSON parsing fails or required fields are missing.
 func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.ExecutionReport, error) {
@@ -65,9 +70,6 @@ func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.Execut
 	// Calculate value
 	value := price * quantity
 
-	// Determine if maker or taker
-	isMaker := cbFill.LiquidityIndicator == "MAKER"
-
 	// Parse execution type
 	executionType := parseExecutionType(cbFill.TradeType)
 
@@ -88,12 +90,23 @@ func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.Execut
 		Quantity:         &quantity,
 		Fee:              &fee,
 		TradeId:          &cbFill.TradeID,
-		IsMaker:          &isMaker,
-		Liquidity:        &cbFill.LiquidityIndicator,
 		VenueExecutionId: &cbFill.EntryID,
 		Value:            &value,
 	}
 
+	// Commission is charged in the quote currency (e.g., USD for BTC-USD)
+	if _, quote, ok := strings.Cut(cbFill.ProductID, "-"); ok && quote != "" {
+		report.FeeAssetId = &quote
+	}
+
+	// Determine if maker or taker
+	switch liquidity := strings.ToUpper(cbFill.LiquidityIndicator); liquidity {
+	case "MAKER", "TAKER":
+		isMaker := liquidity == "MAKER"
+		report.Liquidity = &liquidity
+		report.IsMaker = &isMaker
+	}
+
 	return report, nil
 }
 
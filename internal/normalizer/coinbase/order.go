@@ -9,6 +9,8 @@ import (
 
 	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
 	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // CoinbaseOrder represents a Coinbase order response.
@@ -302,3 +304,79 @@ func determineOrderType(config CoinbaseOrderConfiguration, orderTypeStr string)
 		return venuesv1.OrderType_ORDER_TYPE_UNSPECIFIED
 	}
 }
+
+// CoinbaseCreateOrderResponse represents the response from the create order endpoint.
+// Coinbase returns HTTP 200 for rejected orders and reports the failure in the body.
+type CoinbaseCreateOrderResponse struct {
+	Success            bool                       `json:"success"`
+	FailureReason      string                     `json:"failure_reason"`
+	OrderID            string                     `json:"order_id"`
+	SuccessResponse    CoinbaseCreateOrderSuccess `json:"success_response"`
+	ErrorResponse      CoinbaseError              `json:"error_response"`
+	OrderConfiguration CoinbaseOrderConfiguration `json:"order_configuration"`
+}
+
+// CoinbaseCreateOrderSuccess contains the identifiers of an accepted order.
+type CoinbaseCreateOrderSuccess struct {
+	OrderID       string `json:"order_id"`
+	ProductID     string `json:"product_id"`
+	Side          string `json:"side"`
+	ClientOrderID string `json:"client_order_id"`
+}
+
+// NormalizeCreateOrderResponse converts a Coinbase create order response to a CQC ExecutionReport.
+//
+// An accepted order yields an EXECUTION_TYPE_NEW report with status "PENDING"; the order's
+// price and quantity are taken from the echoed order configuration.
+//
+// A rejected order (success=false) yields a *venueerr.Error describing the
+// venue's failure reason, with category venueerr.ErrInvalidOrder unless the
+// reason names a more specific one such as venueerr.ErrInsufficientFunds.
+func NormalizeCreateOrderResponse(ctx context.Context, raw []byte) (*venuesv1.ExecutionReport, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty create order response")
+	}
+
+	var resp CoinbaseCreateOrderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase create order response: %w", err)
+	}
+
+	if !resp.Success {
+		cbErr := resp.ErrorResponse
+		if cbErr.Error == "" {
+			cbErr.Error = resp.FailureReason
+		}
+		return nil, NewRejectionError(cbErr, venueerr.ErrInvalidOrder)
+	}
+
+	success := resp.SuccessResponse
+	if success.OrderID == "" {
+		success.OrderID = resp.OrderID
+	}
+	if success.OrderID == "" {
+		return nil, fmt.Errorf("create order response missing order_id")
+	}
+
+	price, quantity := extractOrderConfiguration(resp.OrderConfiguration)
+	executionType := venuesv1.ExecutionType_EXECUTION_TYPE_NEW
+	orderStatus := "PENDING"
+	venueId := "coinbase"
+
+	report := &venuesv1.ExecutionReport{
+		ExecutionId:   &success.OrderID,
+		OrderId:       &success.OrderID,
+		VenueOrderId:  &success.OrderID,
+		ClientOrderId: &success.ClientOrderID,
+		VenueId:       &venueId,
+		VenueSymbol:   &success.ProductID,
+		ExecutionType: &executionType,
+		OrderStatus:   &orderStatus,
+		Side:          &success.Side,
+		Timestamp:     timestamppb.Now(),
+		Price:         &price,
+		Quantity:      &quantity,
+	}
+
+	return report, nil
+}
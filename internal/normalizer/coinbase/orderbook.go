@@ -21,10 +21,46 @@ type CoinbaseOrderBook struct {
 
 // PriceBook contains the actual order book data.
 type PriceBook struct {
-	ProductID string          `json:"product_id"`
-	Bids      [][]interface{} `json:"bids"` // [[price, size], ...]
-	Asks      [][]interface{} `json:"asks"` // [[price, size], ...]
-	Time      string          `json:"time"`
+	ProductID string      `json:"product_id"`
+	Bids      PriceLevels `json:"bids"` // [[price, size], ...] or [{"price", "size"}, ...]
+	Asks      PriceLevels `json:"asks"` // [[price, size], ...] or [{"price", "size"}, ...]
+	Time      string      `json:"time"`
+}
+
+// PriceLevels holds order book levels as [price, size] tuples.
+//
+// The v3 product_book endpoint returns levels as objects ({"price": "...", "size": "..."})
+// while WebSocket and legacy payloads use arrays. Both forms are accepted and
+// normalized to the array form.
+type PriceLevels [][]interface{}
+
+// UnmarshalJSON accepts both array and object encoded price levels.
+func (p *PriceLevels) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	levels := make(PriceLevels, 0, len(raw))
+	for i, item := range raw {
+		var tuple []interface{}
+		if err := json.Unmarshal(item, &tuple); err == nil {
+			levels = append(levels, tuple)
+			continue
+		}
+
+		var obj struct {
+			Price string `json:"price"`
+			Size  string `json:"size"`
+		}
+		if err := json.Unmarshal(item, &obj); err != nil {
+			return fmt.Errorf("invalid price level at index %d: %w", i, err)
+		}
+		levels = append(levels, []interface{}{obj.Price, obj.Size})
+	}
+
+	*p = levels
+	return nil
 }
 
 // NormalizeOrderBook converts a Coinbase order book JSON response to a CQC OrderBook protobuf.
@@ -75,21 +111,6 @@ func NormalizeOrderBook(ctx context.Context, raw []byte) (*marketsv1.OrderBook,
 		return nil, fmt.Errorf("failed to parse asks: %w", err)
 	}
 
-	// Calculate best bid, best ask, spread, mid price
-	var bestBid, bestAsk, spread, midPrice *float64
-	if len(bids) > 0 && bids[0].Price != nil {
-		bestBid = bids[0].Price
-	}
-	if len(asks) > 0 && asks[0].Price != nil {
-		bestAsk = asks[0].Price
-	}
-	if bestBid != nil && bestAsk != nil {
-		spreadVal := *bestAsk - *bestBid
-		spread = &spreadVal
-		midPriceVal := (*bestBid + *bestAsk) / 2.0
-		midPrice = &midPriceVal
-	}
-
 	// Build CQC OrderBook
 	venueId := "coinbase"
 	orderBook := &marketsv1.OrderBook{
@@ -98,11 +119,8 @@ func NormalizeOrderBook(ctx context.Context, raw []byte) (*marketsv1.OrderBook,
 		Timestamp:   timestamp,
 		Bids:        bids,
 		Asks:        asks,
-		BestBid:     bestBid,
-		BestAsk:     bestAsk,
-		Spread:      spread,
-		MidPrice:    midPrice,
 	}
+	normalizer.SetTopOfBook(orderBook)
 
 	return orderBook, nil
 }
@@ -0,0 +1,52 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CoinbaseProduct represents a product (trading pair) from the Coinbase
+// Advanced Trade products endpoint. Increments and sizes are decimal strings.
+//
+// Reference: https://docs.cdp.coinbase.com/advanced-trade/reference/retailbrokerageapi_getproducts
+type CoinbaseProduct struct {
+	ProductID       string `json:"product_id"`
+	BaseCurrencyID  string `json:"base_currency_id"`
+	QuoteCurrencyID string `json:"quote_currency_id"`
+	ProductType     string `json:"product_type"`
+	Status          string `json:"status"`
+	PriceIncrement  string `json:"price_increment"`
+	BaseIncrement   string `json:"base_increment"`
+	QuoteIncrement  string `json:"quote_increment"`
+	BaseMinSize     string `json:"base_min_size"`
+	BaseMaxSize     string `json:"base_max_size"`
+	QuoteMinSize    string `json:"quote_min_size"`
+	QuoteMaxSize    string `json:"quote_max_size"`
+	TradingDisabled bool   `json:"trading_disabled"`
+	IsDisabled      bool   `json:"is_disabled"`
+	ViewOnly        bool   `json:"view_only"`
+	CancelOnly      bool   `json:"cancel_only"`
+	LimitOnly       bool   `json:"limit_only"`
+	PostOnly        bool   `json:"post_only"`
+}
+
+// CoinbaseProductsResponse represents the response from the list products endpoint.
+type CoinbaseProductsResponse struct {
+	Products    []CoinbaseProduct `json:"products"`
+	NumProducts int               `json:"num_products"`
+}
+
+// ParseProducts parses a list products response.
+//
+// Returns an error if the response is empty or cannot be parsed.
+func ParseProducts(raw []byte) ([]CoinbaseProduct, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty products response")
+	}
+
+	var resp CoinbaseProductsResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase products: %w", err)
+	}
+	return resp.Products, nil
+}
@@ -0,0 +1,235 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WebSocket channel names used by the Advanced Trade market data feed.
+// Note that the level2 subscription publishes messages on the "l2_data" channel.
+const (
+	WSChannelLevel2        = "level2"
+	WSChannelL2Data        = "l2_data"
+	WSChannelMarketTrades  = "market_trades"
+	WSChannelUser          = "user"
+	WSChannelHeartbeats    = "heartbeats"
+	WSChannelSubscriptions = "subscriptions"
+)
+
+// WebSocket event types.
+const (
+	WSEventSnapshot = "snapshot"
+	WSEventUpdate   = "update"
+)
+
+// CoinbaseWSMessage is the envelope of every Advanced Trade WebSocket message.
+//
+// Error messages carry Type "error" and a Message instead of a channel and events.
+//
+// Reference: https://docs.cdp.coinbase.com/advanced-trade/docs/ws-channels
+type CoinbaseWSMessage struct {
+	Channel     string          `json:"channel"`
+	ClientID    string          `json:"client_id"`
+	Timestamp   string          `json:"timestamp"`
+	SequenceNum int64           `json:"sequence_num"`
+	Events      json.RawMessage `json:"events"`
+	Type        string          `json:"type"`
+	Message     string          `json:"message"`
+}
+
+// CoinbaseL2Event is a level2 snapshot or incremental update for one product.
+type CoinbaseL2Event struct {
+	Type      string             `json:"type"`
+	ProductID string             `json:"product_id"`
+	Updates   []CoinbaseL2Update `json:"updates"`
+}
+
+// CoinbaseL2Update is a single price level change. A NewQuantity of zero
+// removes the level from the book.
+type CoinbaseL2Update struct {
+	Side        string `json:"side"` // "bid" or "offer"
+	EventTime   string `json:"event_time"`
+	PriceLevel  string `json:"price_level"`
+	NewQuantity string `json:"new_quantity"`
+}
+
+// CoinbaseMarketTradesEvent is a batch of trades from the market_trades channel.
+type CoinbaseMarketTradesEvent struct {
+	Type   string          `json:"type"`
+	Trades []CoinbaseTrade `json:"trades"`
+}
+
+// CoinbaseUserEvent is a snapshot or update of the caller's orders from the
+// user channel. The snapshot lists open orders at subscription time.
+type CoinbaseUserEvent struct {
+	Type   string              `json:"type"`
+	Orders []CoinbaseUserOrder `json:"orders"`
+}
+
+// CoinbaseUserOrder is the cumulative state of one order on the user channel.
+type CoinbaseUserOrder struct {
+	OrderID            string `json:"order_id"`
+	ClientOrderID      string `json:"client_order_id"`
+	CumulativeQuantity string `json:"cumulative_quantity"`
+	LeavesQuantity     string `json:"leaves_quantity"`
+	AvgPrice           string `json:"avg_price"`
+	TotalFees          string `json:"total_fees"`
+	Status             string `json:"status"` // "PENDING", "OPEN", "FILLED", "CANCELLED", "EXPIRED", "FAILED"
+	ProductID          string `json:"product_id"`
+	CreationTime       string `json:"creation_time"`
+	OrderSide          string `json:"order_side"`
+	OrderType          string `json:"order_type"`
+	CancelReason       string `json:"cancel_reason"`
+	RejectReason       string `json:"reject_reason"`
+}
+
+// L2Level is a parsed level2 update ready to apply to a local book.
+type L2Level struct {
+	Bid      bool
+	Price    float64
+	Quantity float64
+}
+
+// ParseWSMessage parses a WebSocket message envelope.
+//
+// Returns an error if the message cannot be parsed or is an error message
+// sent by the venue (e.g., an invalid subscription).
+func ParseWSMessage(raw []byte) (*CoinbaseWSMessage, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty websocket message")
+	}
+
+	var msg CoinbaseWSMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase websocket message: %w", err)
+	}
+
+	if msg.Type == "error" {
+		return nil, fmt.Errorf("coinbase websocket error: %s", msg.Message)
+	}
+
+	return &msg, nil
+}
+
+// ParseL2Events decodes the events of an l2_data message.
+func ParseL2Events(msg *CoinbaseWSMessage) ([]CoinbaseL2Event, error) {
+	var events []CoinbaseL2Event
+	if err := json.Unmarshal(msg.Events, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase level2 events: %w", err)
+	}
+	return events, nil
+}
+
+// NormalizeL2Updates converts level2 updates to parsed price levels.
+//
+// Returns an error if a side is unknown or a price or quantity cannot be parsed.
+func NormalizeL2Updates(updates []CoinbaseL2Update) ([]L2Level, error) {
+	levels := make([]L2Level, 0, len(updates))
+	for _, update := range updates {
+		var bid bool
+		switch update.Side {
+		case "bid":
+			bid = true
+		case "offer", "ask":
+			bid = false
+		default:
+			return nil, fmt.Errorf("unknown level2 side: %q", update.Side)
+		}
+
+		price, err := normalizer.ParseDecimal(update.PriceLevel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price_level: %w", err)
+		}
+		quantity, err := normalizer.ParseDecimal(update.NewQuantity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid new_quantity: %w", err)
+		}
+
+		levels = append(levels, L2Level{Bid: bid, Price: price, Quantity: quantity})
+	}
+	return levels, nil
+}
+
+// NormalizeMarketTrades converts a market_trades message to CQC Trade protobufs.
+//
+// Snapshot events replay recent trades that were already published before the
+// subscription started. They are only included when includeSnapshot is true.
+func NormalizeMarketTrades(ctx context.Context, msg *CoinbaseWSMessage, includeSnapshot bool) ([]*marketsv1.Trade, error) {
+	var events []CoinbaseMarketTradesEvent
+	if err := json.Unmarshal(msg.Events, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase market_trades events: %w", err)
+	}
+
+	var trades []*marketsv1.Trade
+	for _, event := range events {
+		if event.Type == WSEventSnapshot && !includeSnapshot {
+			continue
+		}
+		for _, cbTrade := range event.Trades {
+			trade, err := normalizeSingleTrade(cbTrade)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, trade)
+		}
+	}
+
+	return trades, nil
+}
+
+// ParseUserEvents decodes the events of a user channel message.
+func ParseUserEvents(msg *CoinbaseWSMessage) ([]CoinbaseUserEvent, error) {
+	var events []CoinbaseUserEvent
+	if err := json.Unmarshal(msg.Events, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase user events: %w", err)
+	}
+	return events, nil
+}
+
+// NormalizeUserOrder converts a user channel order to a normalizer.OrderUpdate
+// for an OrderTracker, stamped with the message timestamp.
+//
+// The function handles:
+//   - Parsing cumulative quantity, leaves quantity, average price and fees
+//   - Mapping status, side and order type to CQC enums (PENDING is reported
+//     as ORDER_STATUS_PENDING and EXPIRED as ORDER_STATUS_EXPIRED so that
+//     acknowledgements and expiries are distinguishable)
+//
+// Missing or malformed decimals are treated as zero, as in NormalizeOrder.
+// Returns an error if the order ID is missing.
+func NormalizeUserOrder(order CoinbaseUserOrder, timestamp *timestamppb.Timestamp) (normalizer.OrderUpdate, error) {
+	if order.OrderID == "" {
+		return normalizer.OrderUpdate{}, fmt.Errorf("coinbase user order missing order_id")
+	}
+
+	var status venuesv1.OrderStatus
+	switch strings.ToUpper(order.Status) {
+	case "PENDING":
+		status = venuesv1.OrderStatus_ORDER_STATUS_PENDING
+	case "EXPIRED":
+		status = venuesv1.OrderStatus_ORDER_STATUS_EXPIRED
+	default:
+		status = normalizer.ParseOrderStatus(order.Status)
+	}
+
+	return normalizer.OrderUpdate{
+		OrderID:            order.OrderID,
+		ClientOrderID:      order.ClientOrderID,
+		VenueSymbol:        order.ProductID,
+		Side:               normalizer.ParseOrderSide(order.OrderSide),
+		OrderType:          normalizer.ParseOrderType(order.OrderType),
+		Status:             status,
+		CumulativeQuantity: normalizer.ParseExactDecimalOrZero(order.CumulativeQuantity),
+		RemainingQuantity:  normalizer.ParseExactDecimalOrZero(order.LeavesQuantity),
+		AverageFillPrice:   normalizer.ParseExactDecimalOrZero(order.AvgPrice),
+		TotalFees:          normalizer.ParseExactDecimalOrZero(order.TotalFees),
+		Timestamp:          timestamp,
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
 	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -123,6 +124,10 @@ func isNumeric(s string) bool {
 //   - "null" -> 0.0
 //
 // Returns an error for malformed decimal strings.
+//
+// The result is rounded to the nearest float64. Use ParseExactDecimal when the
+// value takes part in arithmetic (sums, differences, unit conversions) and
+// convert to float64 only when populating the CQC field.
 func ParseDecimal(s string) (float64, error) {
 	// Handle empty/null cases
 	s = strings.TrimSpace(s)
@@ -291,6 +296,95 @@ func ParseTimeInForce(s string) venuesv1.TimeInForce {
 	}
 }
 
+// Execution Report Utilities
+
+// ReplacedExecutionReport builds the EXECUTION_TYPE_REPLACED report returned
+// after an order is amended in place, from the order as re-read from the venue.
+//
+// The report carries the amended price and quantity along with fill progress.
+// Status, side and type use the CQC enum names without their prefix (e.g.,
+// "OPEN", "BUY", "LIMIT"). Venues do not consistently report when an edit was
+// accepted, so the timestamp is the current time; the execution ID is derived
+// from the order ID and that timestamp so that successive amendments are
+// distinguishable.
+func ReplacedExecutionReport(order *venuesv1.Order) *venuesv1.ExecutionReport {
+	timestamp := timestamppb.Now()
+	executionId := fmt.Sprintf("%s:replaced:%d", order.GetOrderId(), timestamp.AsTime().UnixNano())
+	return orderExecutionReport(order, venuesv1.ExecutionType_EXECUTION_TYPE_REPLACED, executionId, timestamp)
+}
+
+// RecoveredExecutionReport builds the EXECUTION_TYPE_NEW report returned when
+// an order placement whose response was lost is found on the venue by its
+// client order ID.
+//
+// The report reflects the order as read back, so its status may already be
+// past OPEN (e.g., "FILLED" for a market order). The execution ID is
+// "{order_id}:new" and the timestamp is the order's creation time, so that
+// the report matches the one the lost response would have produced.
+func RecoveredExecutionReport(order *venuesv1.Order) *venuesv1.ExecutionReport {
+	timestamp := order.GetCreatedAt()
+	if timestamp == nil {
+		timestamp = timestamppb.Now()
+	}
+	executionId := order.GetOrderId() + ":new"
+	return orderExecutionReport(order, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, executionId, timestamp)
+}
+
+// orderExecutionReport builds an execution report from an order's current state.
+func orderExecutionReport(order *venuesv1.Order, executionType venuesv1.ExecutionType, executionId string, timestamp *timestamppb.Timestamp) *venuesv1.ExecutionReport {
+	orderStatus := strings.TrimPrefix(order.GetStatus().String(), "ORDER_STATUS_")
+	side := strings.TrimPrefix(order.GetSide().String(), "ORDER_SIDE_")
+	orderType := strings.TrimPrefix(order.GetOrderType().String(), "ORDER_TYPE_")
+
+	return &venuesv1.ExecutionReport{
+		ExecutionId:        &executionId,
+		OrderId:            order.OrderId,
+		VenueOrderId:       order.VenueOrderId,
+		ClientOrderId:      order.ClientOrderId,
+		AccountId:          order.AccountId,
+		VenueId:            order.VenueId,
+		VenueSymbol:        order.VenueSymbol,
+		ExecutionType:      &executionType,
+		OrderStatus:        &orderStatus,
+		Side:               &side,
+		OrderType:          &orderType,
+		Timestamp:          timestamp,
+		Price:              order.Price,
+		Quantity:           order.Quantity,
+		CumulativeQuantity: order.FilledQuantity,
+		RemainingQuantity:  order.RemainingQuantity,
+		AverageFillPrice:   order.AverageFillPrice,
+	}
+}
+
+// Order Book Utilities
+
+// SetTopOfBook sets an order book's BestBid and BestAsk from its first bid and
+// ask level, and its Spread and MidPrice when both sides are present. Levels
+// must already be sorted best price first. Spread and mid price are computed
+// exactly from the decimal prices, so a spread of one tick is reported as the
+// tick size rather than as float64 noise around it.
+func SetTopOfBook(book *marketsv1.OrderBook) {
+	book.BestBid, book.BestAsk, book.Spread, book.MidPrice = nil, nil, nil, nil
+	if len(book.Bids) > 0 && book.Bids[0].Price != nil {
+		book.BestBid = Float64Ptr(book.Bids[0].GetPrice())
+	}
+	if len(book.Asks) > 0 && book.Asks[0].Price != nil {
+		book.BestAsk = Float64Ptr(book.Asks[0].GetPrice())
+	}
+	if book.BestBid == nil || book.BestAsk == nil {
+		return
+	}
+
+	bid, bidErr := DecimalFromFloat(*book.BestBid)
+	ask, askErr := DecimalFromFloat(*book.BestAsk)
+	if bidErr != nil || askErr != nil {
+		return
+	}
+	book.Spread = Float64Ptr(ask.Sub(bid).Float64())
+	book.MidPrice = Float64Ptr(bid.Add(ask).Quo(NewDecimal(2, 0), 18).Float64())
+}
+
 // String Utilities
 
 // StringPtr returns a pointer to the string value.
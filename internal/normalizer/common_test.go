@@ -1,12 +1,15 @@
 package normalizer
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
 	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TestParseTimestamp tests timestamp parsing with various formats
@@ -472,6 +475,93 @@ func TestInt64Ptr(t *testing.T) {
 	assert.Equal(t, i, *ptr)
 }
 
+// TestReplacedExecutionReport tests building a REPLACED report from an amended order
+func TestReplacedExecutionReport(t *testing.T) {
+	order := &venuesv1.Order{
+		OrderId:        StringPtr("order-1"),
+		VenueOrderId:   StringPtr("order-1"),
+		ClientOrderId:  StringPtr("client-1"),
+		VenueId:        StringPtr("coinbase"),
+		VenueSymbol:    StringPtr("BTC-USD"),
+		OrderType:      venuesv1.OrderType_ORDER_TYPE_LIMIT.Enum(),
+		Side:           venuesv1.OrderSide_ORDER_SIDE_BUY.Enum(),
+		Status:         venuesv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED.Enum(),
+		Price:          Float64Ptr(51000),
+		Quantity:       Float64Ptr(2),
+		FilledQuantity: Float64Ptr(0.5),
+	}
+
+	report := ReplacedExecutionReport(order)
+
+	require.NotNil(t, report.GetTimestamp())
+	assert.WithinDuration(t, time.Now(), report.GetTimestamp().AsTime(), time.Minute)
+	assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_REPLACED, report.GetExecutionType())
+	assert.Equal(t, fmt.Sprintf("order-1:replaced:%d", report.GetTimestamp().AsTime().UnixNano()), report.GetExecutionId())
+	assert.Equal(t, "order-1", report.GetOrderId())
+	assert.Equal(t, "client-1", report.GetClientOrderId())
+	assert.Equal(t, "coinbase", report.GetVenueId())
+	assert.Equal(t, "PARTIALLY_FILLED", report.GetOrderStatus())
+	assert.Equal(t, "BUY", report.GetSide())
+	assert.Equal(t, "LIMIT", report.GetOrderType())
+	assert.Equal(t, 51000.0, report.GetPrice())
+	assert.Equal(t, 2.0, report.GetQuantity())
+	assert.Equal(t, 0.5, report.GetCumulativeQuantity())
+}
+
+// TestRecoveredExecutionReport tests building a NEW report from an order found by client order ID
+func TestRecoveredExecutionReport(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	order := &venuesv1.Order{
+		OrderId:        StringPtr("order-1"),
+		ClientOrderId:  StringPtr("client-1"),
+		VenueSymbol:    StringPtr("BTC-USD"),
+		OrderType:      venuesv1.OrderType_ORDER_TYPE_MARKET.Enum(),
+		Side:           venuesv1.OrderSide_ORDER_SIDE_SELL.Enum(),
+		Status:         venuesv1.OrderStatus_ORDER_STATUS_FILLED.Enum(),
+		Quantity:       Float64Ptr(1),
+		FilledQuantity: Float64Ptr(1),
+		CreatedAt:      timestamppb.New(createdAt),
+	}
+
+	report := RecoveredExecutionReport(order)
+	assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+	assert.Equal(t, "order-1:new", report.GetExecutionId())
+	assert.Equal(t, createdAt, report.GetTimestamp().AsTime())
+	assert.Equal(t, "client-1", report.GetClientOrderId())
+	assert.Equal(t, "FILLED", report.GetOrderStatus())
+	assert.Equal(t, "SELL", report.GetSide())
+	assert.Equal(t, "MARKET", report.GetOrderType())
+	assert.Equal(t, 1.0, report.GetCumulativeQuantity())
+
+	order.CreatedAt = nil
+	assert.WithinDuration(t, time.Now(), RecoveredExecutionReport(order).GetTimestamp().AsTime(), time.Minute)
+}
+
+// TestSetTopOfBook tests best bid/ask, spread and mid price calculation
+func TestSetTopOfBook(t *testing.T) {
+	level := func(price float64) *marketsv1.OrderBookLevel {
+		return &marketsv1.OrderBookLevel{Price: Float64Ptr(price), Quantity: Float64Ptr(1)}
+	}
+
+	book := &marketsv1.OrderBook{
+		Bids: []*marketsv1.OrderBookLevel{level(50000.9), level(50000.1)},
+		Asks: []*marketsv1.OrderBookLevel{level(50001.1), level(50002)},
+	}
+	SetTopOfBook(book)
+	assert.Equal(t, 50000.9, book.GetBestBid())
+	assert.Equal(t, 50001.1, book.GetBestAsk())
+	assert.Equal(t, 0.2, book.GetSpread(), "spread is exact, not 0.20000000000436557")
+	assert.Equal(t, 50001.0, book.GetMidPrice())
+
+	// One-sided books have no spread or mid price
+	book.Asks = nil
+	SetTopOfBook(book)
+	assert.Equal(t, 50000.9, book.GetBestBid())
+	assert.Nil(t, book.BestAsk)
+	assert.Nil(t, book.Spread)
+	assert.Nil(t, book.MidPrice)
+}
+
 // TestSafeString tests safe string utility
 func TestSafeString(t *testing.T) {
 	s := "test"
@@ -0,0 +1,395 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExponent bounds the exponent accepted by ParseExactDecimal so that
+// hostile input such as "1e999999999" cannot force huge allocations.
+const maxDecimalExponent = 1000
+
+// Decimal is an exact, arbitrary-precision decimal number.
+//
+// Venues send prices, quantities and balances as decimal strings. Parsing them
+// into float64 (ParseDecimal) rounds values that have no exact binary
+// representation, which loses satoshi- and wei-level precision and makes sums
+// drift by dust amounts. Decimal keeps the value exactly, along with the
+// string it was parsed from, so that:
+//   - String returns the venue's original string unchanged
+//   - Add, Sub and Mul are exact; Quo and Round round half away from zero to
+//     an explicit number of decimal places
+//   - Cmp compares values, so "1.50" and "1.5" are equal
+//
+// Conversion into CQC fields: CQC protobuf messages carry amounts as double,
+// so the value must eventually become a float64. Do all arithmetic on Decimal
+// and call Float64 once, when populating the CQC field. Float64 returns the
+// float64 nearest to the exact value, which is the same result as parsing the
+// original string with ParseDecimal; no further error accumulates.
+//
+// The zero value is 0. Decimals are immutable; every operation returns a new
+// value, and values computed by arithmetic carry no original string.
+type Decimal struct {
+	coef *big.Int // value is coef * 10^exp; nil means zero
+	exp  int
+	raw  string // original string, if parsed
+}
+
+// ParseExactDecimal parses a decimal string without loss of precision.
+//
+// Accepted formats are optionally signed decimal numbers with an optional
+// fractional part and exponent: "123.45", "-0.00000001", "1.23e5", ".5".
+// Surrounding whitespace is ignored. Empty strings and "null" parse as zero,
+// matching ParseDecimal.
+//
+// Returns an error for malformed strings, NaN, infinities, and exponents
+// outside ±1000.
+func ParseExactDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "null" {
+		return Decimal{}, nil
+	}
+
+	mantissa, exponent, hasExponent := s, "", false
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent, hasExponent = s[:i], s[i+1:], true
+	}
+
+	sign := ""
+	switch {
+	case strings.HasPrefix(mantissa, "-"):
+		sign, mantissa = "-", mantissa[1:]
+	case strings.HasPrefix(mantissa, "+"):
+		mantissa = mantissa[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	digits := integer + fraction
+	if digits == "" || !isDigits(digits) {
+		return Decimal{}, fmt.Errorf("invalid decimal: %q", s)
+	}
+
+	exp := -len(fraction)
+	if hasExponent {
+		e, err := strconv.Atoi(exponent)
+		if err != nil || e < -maxDecimalExponent || e > maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal exponent: %q", s)
+		}
+		exp += e
+	}
+
+	coef, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %q", s)
+	}
+
+	return Decimal{coef: coef, exp: exp, raw: s}, nil
+}
+
+// ParseExactDecimalOrZero parses a decimal string, returning zero if parsing fails.
+// This is useful for optional numeric fields where a default is acceptable.
+func ParseExactDecimalOrZero(s string) Decimal {
+	d, err := ParseExactDecimal(s)
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// MustParseExactDecimal parses a decimal string and panics if parsing fails.
+// This should only be used in tests or when the input is guaranteed to be valid.
+func MustParseExactDecimal(s string) Decimal {
+	d, err := ParseExactDecimal(s)
+	if err != nil {
+		panic(fmt.Sprintf("MustParseExactDecimal: %v", err))
+	}
+	return d
+}
+
+// NewDecimal returns the decimal coef * 10^exp.
+// For example, NewDecimal(15, -1) is 1.5 and NewDecimal(15, 2) is 1500.
+func NewDecimal(coef int64, exp int) Decimal {
+	return Decimal{coef: big.NewInt(coef), exp: exp}
+}
+
+// DecimalFromFloat converts a float64 (e.g., a CQC field) to a Decimal using
+// the shortest decimal representation that round-trips to the same float64,
+// as FormatDecimal does. For example, 0.1 becomes exactly 0.1.
+//
+// Returns an error for NaN and infinities.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	d, err := ParseExactDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+	if err != nil {
+		return Decimal{}, err
+	}
+	d.raw = ""
+	return d, nil
+}
+
+// String returns the original string if the decimal was parsed, and otherwise
+// the same as PlainString.
+func (d Decimal) String() string {
+	if d.raw != "" {
+		return d.raw
+	}
+	return d.PlainString()
+}
+
+// PlainString returns the value as a plain decimal without exponent or
+// trailing zeros, regardless of how it was written (e.g., "1.50e2" -> "150").
+// Equal values always have the same PlainString.
+func (d Decimal) PlainString() string {
+	if d.Sign() == 0 {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.exp >= 0 {
+		return sign + digits + strings.Repeat("0", d.exp)
+	}
+
+	places := -d.exp
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	point := len(digits) - places
+	integer, fraction := digits[:point], strings.TrimRight(digits[point:], "0")
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// Float64 returns the float64 nearest to d, for populating CQC fields.
+// This is the only lossy operation on a Decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.PlainString(), 64)
+	return f
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	if d.coef == nil {
+		return 0
+	}
+	return d.coef.Sign()
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IsInteger reports whether d has no fractional part.
+func (d Decimal) IsInteger() bool {
+	if d.exp >= 0 || d.Sign() == 0 {
+		return true
+	}
+	return new(big.Int).Rem(d.coef, pow10(-d.exp)).Sign() == 0
+}
+
+// Cmp compares d and y and returns -1 if d < y, 0 if d == y, and +1 if d > y.
+func (d Decimal) Cmp(y Decimal) int {
+	a, b, _ := align(d, y)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and y have the same value.
+func (d Decimal) Equal(y Decimal) bool {
+	return d.Cmp(y) == 0
+}
+
+// Add returns d + y.
+func (d Decimal) Add(y Decimal) Decimal {
+	a, b, exp := align(d, y)
+	return Decimal{coef: a.Add(a, b), exp: exp}
+}
+
+// Sub returns d - y.
+func (d Decimal) Sub(y Decimal) Decimal {
+	a, b, exp := align(d, y)
+	return Decimal{coef: a.Sub(a, b), exp: exp}
+}
+
+// Mul returns d * y.
+func (d Decimal) Mul(y Decimal) Decimal {
+	if d.Sign() == 0 || y.Sign() == 0 {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Mul(d.coef, y.coef), exp: d.exp + y.exp}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	if d.Sign() == 0 {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Neg(d.coef), exp: d.exp}
+}
+
+// Shift returns d * 10^n. It is exact; use it to convert between an asset's
+// smallest unit and whole units (e.g., Shift(-18) converts wei to ether).
+func (d Decimal) Shift(n int) Decimal {
+	if d.Sign() == 0 {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Set(d.coef), exp: d.exp + n}
+}
+
+// Quo returns d / y rounded half away from zero to places decimal places.
+// Panics if y is zero.
+func (d Decimal) Quo(y Decimal, places int) Decimal {
+	if y.Sign() == 0 {
+		panic("normalizer: decimal division by zero")
+	}
+	if d.Sign() == 0 {
+		return Decimal{}
+	}
+
+	// d/y = (dc/yc) * 10^(de-ye); scale the quotient by 10^places so that
+	// integer division yields the result's coefficient.
+	num := new(big.Int).Set(d.coef)
+	den := new(big.Int).Set(y.coef)
+	if shift := d.exp - y.exp + places; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: quoRound(num, den), exp: -places}
+}
+
+// Round returns d rounded half away from zero to places decimal places.
+// Values with no more than places decimal places are returned unchanged.
+func (d Decimal) Round(places int) Decimal {
+	if d.Sign() == 0 || -d.exp <= places {
+		return d
+	}
+	return Decimal{coef: quoRound(d.coef, pow10(-d.exp-places)), exp: -places}
+}
+
+// RoundingMode selects the direction in which RoundToStep rounds values that
+// are not a multiple of the step.
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds to the nearest multiple, and halfway values
+	// away from zero, like Round.
+	RoundHalfAwayFromZero RoundingMode = iota
+
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+)
+
+// RoundToStep returns d rounded to a multiple of step, such as a venue's tick
+// size or lot size (e.g., 50000.005 with step 0.01 and RoundFloor is 50000).
+// Multiples of step are returned unchanged, as is d if step is not positive.
+func (d Decimal) RoundToStep(step Decimal, mode RoundingMode) Decimal {
+	if step.Sign() <= 0 || d.Sign() == 0 {
+		return d
+	}
+
+	// With a positive divisor, DivMod is floor division and 0 <= m < b.
+	a, b, exp := align(d, step)
+	q, m := new(big.Int).DivMod(a, b, new(big.Int))
+	if m.Sign() == 0 {
+		return d
+	}
+
+	switch mode {
+	case RoundFloor:
+	case RoundCeiling:
+		q.Add(q, big.NewInt(1))
+	default:
+		twice := m.Lsh(m, 1)
+		if c := twice.Cmp(b); c > 0 || (c == 0 && a.Sign() > 0) {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{coef: q.Mul(q, b), exp: exp}
+}
+
+// MarshalJSON encodes d as a JSON string, preserving the original string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string or number without loss of precision.
+// JSON null decodes as zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := ParseExactDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// align returns the coefficients of x and y scaled to their common (smaller)
+// exponent, as new big.Ints, along with that exponent.
+func align(x, y Decimal) (*big.Int, *big.Int, int) {
+	a, b := new(big.Int), new(big.Int)
+	if x.coef != nil {
+		a.Set(x.coef)
+	}
+	if y.coef != nil {
+		b.Set(y.coef)
+	}
+	switch {
+	case x.exp > y.exp:
+		a.Mul(a, pow10(x.exp-y.exp))
+		return a, b, y.exp
+	case y.exp > x.exp:
+		b.Mul(b, pow10(y.exp-x.exp))
+		return a, b, x.exp
+	default:
+		return a, b, x.exp
+	}
+}
+
+// quoRound returns num/den rounded half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign() == den.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// pow10 returns 10^n for n >= 0.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,275 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decimalString is a random, well-formed decimal string for property tests.
+// Generated strings cover signs, leading and trailing zeros, long integer and
+// fractional parts (beyond float64 precision) and exponents.
+type decimalString string
+
+// Generate implements quick.Generator.
+func (decimalString) Generate(r *rand.Rand, size int) reflect.Value {
+	digits := func(max int) string {
+		n := r.Intn(max + 1)
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			b.WriteByte(byte('0' + r.Intn(10)))
+		}
+		return b.String()
+	}
+
+	var b strings.Builder
+	switch r.Intn(4) {
+	case 0:
+		b.WriteByte('-')
+	case 1:
+		if r.Intn(4) == 0 {
+			b.WriteByte('+')
+		}
+	}
+	integer := digits(30)
+	fraction := digits(30)
+	if integer == "" && fraction == "" {
+		integer = "0"
+	}
+	b.WriteString(integer)
+	if fraction != "" || r.Intn(8) == 0 {
+		b.WriteByte('.')
+		b.WriteString(fraction)
+	}
+	if r.Intn(4) == 0 {
+		b.WriteString([]string{"e", "E", "e-", "e+"}[r.Intn(4)])
+		b.WriteString(strconv.Itoa(r.Intn(40)))
+	}
+	return reflect.ValueOf(decimalString(b.String()))
+}
+
+var quickConfig = &quick.Config{MaxCount: 5000}
+
+// TestParseExactDecimal tests parsing and formatting of exact decimals.
+func TestParseExactDecimal(t *testing.T) {
+	tests := []struct {
+		input   string
+		plain   string
+		wantErr bool
+	}{
+		{input: "123.45", plain: "123.45"},
+		{input: "1.50", plain: "1.5"},
+		{input: "100", plain: "100"},
+		{input: "0.00000001", plain: "0.00000001"},
+		{input: "-0.000000000000000001", plain: "-0.000000000000000001"},
+		{input: "123456789012345678901234567890.123456789012345678", plain: "123456789012345678901234567890.123456789012345678"},
+		{input: "1.23e5", plain: "123000"},
+		{input: "1.5E-3", plain: "0.0015"},
+		{input: ".5", plain: "0.5"},
+		{input: "5.", plain: "5"},
+		{input: "+7", plain: "7"},
+		{input: "-0", plain: "0"},
+		{input: "  42.10  ", plain: "42.1"},
+		{input: "", plain: "0"},
+		{input: "null", plain: "0"},
+		{input: "abc", wantErr: true},
+		{input: "NaN", wantErr: true},
+		{input: "Inf", wantErr: true},
+		{input: "1.2.3", wantErr: true},
+		{input: "1e", wantErr: true},
+		{input: "e5", wantErr: true},
+		{input: "--1", wantErr: true},
+		{input: "0x10", wantErr: true},
+		{input: "1_000", wantErr: true},
+		{input: "1e1001", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseExactDecimal(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.plain, d.PlainString())
+			if trimmed := strings.TrimSpace(tt.input); trimmed != "" && trimmed != "null" {
+				assert.Equal(t, trimmed, d.String(), "original string is preserved")
+			}
+		})
+	}
+
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.True(t, ParseExactDecimalOrZero("invalid").IsZero())
+	assert.Panics(t, func() { MustParseExactDecimal("invalid") })
+}
+
+// TestDecimalArithmetic tests exact arithmetic and rounding.
+func TestDecimalArithmetic(t *testing.T) {
+	d := MustParseExactDecimal
+
+	// 0.1 + 0.2 is not 0.3 in float64
+	assert.Equal(t, "0.3", d("0.1").Add(d("0.2")).String())
+	assert.Equal(t, 0.3, d("0.1").Add(d("0.2")).Float64())
+
+	assert.Equal(t, "-0.05", d("0.1").Sub(d("0.15")).String())
+	assert.Equal(t, "0.00000006", d("0.0002").Mul(d("0.0003")).String())
+	assert.Equal(t, "-2.5", d("2.5").Neg().String())
+	assert.Equal(t, "1.5", d("1500000000000000000").Shift(-18).String())
+	assert.Equal(t, "1500", d("1.5").Shift(3).String())
+
+	assert.Equal(t, "0.3333", d("1").Quo(d("3"), 4).String())
+	assert.Equal(t, "0.6667", d("2").Quo(d("3"), 4).String())
+	assert.Equal(t, "-0.6667", d("-2").Quo(d("3"), 4).String())
+	assert.Equal(t, "50030", d("50030").Quo(d("1"), 18).String())
+	assert.Equal(t, "2500", d("12.5").Quo(d("0.005"), 2).String())
+	assert.Panics(t, func() { d("1").Quo(Decimal{}, 2) })
+
+	assert.Equal(t, "1.24", d("1.235").Round(2).String())
+	assert.Equal(t, "-1.24", d("-1.235").Round(2).String())
+	assert.Equal(t, "1.2", d("1.234").Round(1).String())
+	assert.Equal(t, "1.5", d("1.5").Round(4).String(), "no rounding keeps the original")
+	assert.Equal(t, "100", d("149.99").Round(-2).String())
+
+	tick := d("0.01")
+	assert.Equal(t, "50000", d("50000.005").RoundToStep(tick, RoundFloor).String())
+	assert.Equal(t, "50000.01", d("50000.005").RoundToStep(tick, RoundCeiling).String())
+	assert.Equal(t, "50000.01", d("50000.005").RoundToStep(tick, RoundHalfAwayFromZero).String())
+	assert.Equal(t, "50000", d("50000.004").RoundToStep(tick, RoundHalfAwayFromZero).String())
+	assert.Equal(t, "-50000.01", d("-50000.005").RoundToStep(tick, RoundHalfAwayFromZero).String())
+	assert.Equal(t, "-50000.01", d("-50000.001").RoundToStep(tick, RoundFloor).String())
+	assert.Equal(t, "-50000", d("-50000.009").RoundToStep(tick, RoundCeiling).String())
+	assert.Equal(t, "1.25", d("1.3").RoundToStep(d("0.25"), RoundFloor).String())
+	assert.Equal(t, "1.5", d("1.3").RoundToStep(d("0.25"), RoundCeiling).String())
+	assert.Equal(t, "1500", d("1250").RoundToStep(d("500"), RoundHalfAwayFromZero).String())
+	assert.Equal(t, "1.50", d("1.50").RoundToStep(tick, RoundFloor).String(), "multiples keep the original")
+	assert.Equal(t, "1.234", d("1.234").RoundToStep(Decimal{}, RoundFloor).String(), "zero step is a no-op")
+
+	assert.Equal(t, 0, d("1.50").Cmp(d("1.5")))
+	assert.True(t, d("1.50").Equal(d("1.5")))
+	assert.Equal(t, -1, d("-1").Cmp(Decimal{}))
+	assert.Equal(t, 1, d("0.000000000000000001").Cmp(Decimal{}))
+	assert.True(t, d("2.000").IsInteger())
+	assert.False(t, d("2.001").IsInteger())
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, "15", NewDecimal(15, 0).String())
+	assert.Equal(t, "0.015", NewDecimal(15, -3).String())
+
+	f, err := DecimalFromFloat(0.1)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", f.String())
+	f, err = DecimalFromFloat(1e21)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000", f.String())
+}
+
+// TestDecimalJSON tests that JSON strings and numbers decode without loss.
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Quoted  Decimal `json:"quoted"`
+		Number  Decimal `json:"number"`
+		Null    Decimal `json:"null"`
+		Missing Decimal `json:"missing"`
+	}
+	raw := `{"quoted":"0.123456789012345678","number":12345678901234567890.5,"null":null}`
+	require.NoError(t, json.Unmarshal([]byte(raw), &v))
+	assert.Equal(t, "0.123456789012345678", v.Quoted.String())
+	assert.Equal(t, "12345678901234567890.5", v.Number.String())
+	assert.True(t, v.Null.IsZero())
+	assert.True(t, v.Missing.IsZero())
+
+	out, err := json.Marshal(v.Quoted)
+	require.NoError(t, err)
+	assert.Equal(t, `"0.123456789012345678"`, string(out))
+
+	var invalid Decimal
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &invalid))
+}
+
+// TestDecimalRoundTripProperties proves that parsing and formatting decimal
+// strings is lossless, and that arithmetic is exact.
+func TestDecimalRoundTripProperties(t *testing.T) {
+	t.Run("parse then String returns the input", func(t *testing.T) {
+		err := quick.Check(func(s decimalString) bool {
+			d, err := ParseExactDecimal(string(s))
+			return err == nil && d.String() == string(s)
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("plain form parses back to the same value", func(t *testing.T) {
+		err := quick.Check(func(s decimalString) bool {
+			d := MustParseExactDecimal(string(s))
+			plain := d.PlainString()
+			again, err := ParseExactDecimal(plain)
+			return err == nil && again.Equal(d) && again.PlainString() == plain
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("JSON round trip preserves the string", func(t *testing.T) {
+		err := quick.Check(func(s decimalString) bool {
+			data, err := json.Marshal(MustParseExactDecimal(string(s)))
+			if err != nil {
+				return false
+			}
+			var d Decimal
+			return json.Unmarshal(data, &d) == nil && d.String() == string(s)
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Float64 matches ParseDecimal", func(t *testing.T) {
+		err := quick.Check(func(s decimalString) bool {
+			want, err := ParseDecimal(string(s))
+			return err == nil && MustParseExactDecimal(string(s)).Float64() == want
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("addition and shifting are exact", func(t *testing.T) {
+		err := quick.Check(func(a, b decimalString, n int8) bool {
+			x, y := MustParseExactDecimal(string(a)), MustParseExactDecimal(string(b))
+			return x.Add(y).Sub(y).Equal(x) &&
+				x.Add(y).Equal(y.Add(x)) &&
+				x.Sub(y).Equal(y.Sub(x).Neg()) &&
+				x.Shift(int(n)).Shift(-int(n)).Equal(x)
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("exact division round trips through multiplication", func(t *testing.T) {
+		err := quick.Check(func(a, b decimalString) bool {
+			x, y := MustParseExactDecimal(string(a)), MustParseExactDecimal(string(b))
+			if y.IsZero() {
+				return true
+			}
+			product := x.Mul(y)
+			// product / y has at most as many decimal places as x (≤ 30 + 40)
+			return product.Quo(y, 80).Equal(x)
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("float64 values round trip through DecimalFromFloat", func(t *testing.T) {
+		err := quick.Check(func(f float64) bool {
+			d, err := DecimalFromFloat(f)
+			return err == nil && d.Float64() == f
+		}, quickConfig)
+		assert.NoError(t, err)
+	})
+}
+
+// BenchmarkParseExactDecimal benchmarks exact decimal parsing.
+func BenchmarkParseExactDecimal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseExactDecimal("50000.123456789012345678")
+	}
+}
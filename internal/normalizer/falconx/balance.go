@@ -0,0 +1,84 @@
+package falconx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+)
+
+// FalconXBalance represents one entry of the FalconX balances response.
+// FalconX reports a net position per token; it is negative when the client
+// owes FalconX (e.g., trading on credit before settlement).
+//
+// Reference: https://docs.falconx.io/ (GET /v1/balances)
+type FalconXBalance struct {
+	Token    string  `json:"token"`    // Token symbol (e.g., "BTC", "USD")
+	Balance  Decimal `json:"balance"`  // Net balance
+	Platform string  `json:"platform"` // "api", "browser", etc.
+}
+
+// NormalizeBalance converts a single FalconX balance entry to a CQC Balance protobuf.
+//
+// The function handles:
+//   - Parsing JSON response
+//   - Converting the net balance (number or string) to Total and Available
+//   - Reporting zero Locked, since RFQ trading places no holds
+//
+// Returns an error if JSON parsing fails or the token is missing.
+func NormalizeBalance(ctx context.Context, raw []byte) (*venuesv1.Balance, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty balance response")
+	}
+
+	var fxBalance FalconXBalance
+	if err := json.Unmarshal(raw, &fxBalance); err != nil {
+		return nil, fmt.Errorf("failed to parse falconx balance: %w", err)
+	}
+
+	return toBalance(fxBalance)
+}
+
+// NormalizeBalances converts the FalconX balances response (a JSON array) to CQC Balances.
+func NormalizeBalances(ctx context.Context, raw []byte) ([]*venuesv1.Balance, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty balances response")
+	}
+
+	var fxBalances []FalconXBalance
+	if err := json.Unmarshal(raw, &fxBalances); err != nil {
+		return nil, fmt.Errorf("failed to parse falconx balances: %w", err)
+	}
+
+	balances := make([]*venuesv1.Balance, 0, len(fxBalances))
+	for _, fxBalance := range fxBalances {
+		balance, err := toBalance(fxBalance)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+// toBalance builds a CQC Balance from a parsed FalconX balance entry.
+func toBalance(fxBalance FalconXBalance) (*venuesv1.Balance, error) {
+	if fxBalance.Token == "" {
+		return nil, fmt.Errorf("falconx balance missing token")
+	}
+
+	asset := strings.ToUpper(fxBalance.Token)
+	total := fxBalance.Balance.Value
+	available := total
+	var locked float64
+
+	return &venuesv1.Balance{
+		AssetId:   &asset,
+		Total:     &total,
+		Available: &available,
+		Locked:    &locked,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package falconx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// FalconXError represents an error response from the FalconX API.
+// FalconX wraps errors in a status envelope:
+//
+//	{"status": "failure", "error": {"code": "...", "reason": "..."}}
+type FalconXError struct {
+	Status string            `json:"status"`
+	Error  *FalconXErrorBody `json:"error"`
+}
+
+// FalconXErrorBody is the "error" object of a FalconX failure response.
+type FalconXErrorBody struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// venueID identifies FalconX in classified errors.
+const venueID = "falconx"
+
+// rateLimitBucket is the FalconX rate limit every request counts against.
+const rateLimitBucket = "rest"
+
+// NormalizeError converts a FalconX API error response to a *venueerr.Error.
+// It parses the error envelope and classifies it based on HTTP status code and error code.
+// Retry-After and rate limit headers are read by venueerr.FromResponse.
+//
+// Error Classification:
+//   - 401/403: venueerr.ErrAuth
+//   - 429: venueerr.ErrRateLimited
+//   - 404: venueerr.ErrNotFound
+//   - 400/422: venueerr.ErrInvalidRequest, or venueerr.ErrUnavailable if the
+//     code is a known transient condition
+//   - 5xx and other statuses: venueerr.ErrUnavailable
+//
+// Returns an error with appropriate classification and original error details.
+func NormalizeError(resp *http.Response, body []byte) error {
+	venueErr := venueerr.FromResponse(venueID, resp)
+	if venueErr.RateLimit != nil {
+		venueErr.RateLimit.Bucket = rateLimitBucket
+	}
+	parseErrorBody(venueErr, body)
+	return venueErr
+}
+
+// NormalizeFailure converts a failure FalconX reported with HTTP 200 and
+// "status": "failure" to a *venueerr.Error with a zero StatusCode. It is
+// classified as venueerr.ErrInvalidRequest, or venueerr.ErrUnavailable if the
+// code is a known transient condition.
+func NormalizeFailure(body []byte) error {
+	venueErr := &venueerr.Error{Venue: venueID, Category: venueerr.ErrInvalidRequest}
+	parseErrorBody(venueErr, body)
+	return venueErr
+}
+
+// parseErrorBody sets the code and message of venueErr from a FalconX error
+// envelope and refines rejections with transient codes.
+func parseErrorBody(venueErr *venueerr.Error, body []byte) {
+	if len(body) == 0 {
+		venueErr.Message = "no body"
+		return
+	}
+
+	var fxErr FalconXError
+	if err := json.Unmarshal(body, &fxErr); err != nil {
+		venueErr.Message = string(body)
+		return
+	}
+
+	if fxErr.Error != nil {
+		venueErr.Code = fxErr.Error.Code
+		venueErr.Message = fxErr.Error.Reason
+	}
+	if venueErr.Category == venueerr.ErrInvalidRequest && isTransientCode(venueErr.Code) {
+		// Only a few venue conditions are worth retrying
+		venueErr.Category = venueerr.ErrUnavailable
+	}
+}
+
+// isTransientCode reports whether a FalconX error code describes a condition
+// that may clear on retry (e.g., liquidity temporarily unavailable).
+func isTransientCode(code string) bool {
+	transientCodes := map[string]bool{
+		"LIQUIDITY_UNAVAILABLE": true,
+		"MARKET_CLOSED":         true,
+		"SERVICE_UNAVAILABLE":   true,
+		"TIMEOUT":               true,
+	}
+
+	return transientCodes[code]
+}
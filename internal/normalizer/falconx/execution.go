@@ -0,0 +1,221 @@
+package falconx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OrderTypeRFQ is the ExecutionReport order type reported for FalconX executions.
+const OrderTypeRFQ = "RFQ"
+
+// NormalizeExecutionReport converts a FalconX quote JSON response to a CQC ExecutionReport.
+//
+// An RFQ executes in full or not at all, so an executed quote is a single fill:
+//   - is_filled true: EXECUTION_TYPE_FILL, status "FILLED", price_executed and
+//     the full quantity, with cumulative quantity equal to quantity
+//   - is_filled false: EXECUTION_TYPE_NEW, status "PENDING" (accepted, not yet settled)
+//
+// Quantities requested in the quote token are converted to base quantity using
+// the execution price. The quote ID is used as execution, order and venue order ID.
+//
+// FalconX spreads are embedded in the quoted price, so a filled quote reports a
+// zero fee in the quote asset and TAKER liquidity (the client lifts the quote).
+//
+// Returns an error if JSON parsing fails, the response reports a failure,
+// or required fields are missing.
+func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.ExecutionReport, error) {
+	fxQuote, err := ParseQuote(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	side := strings.ToUpper(fxQuote.SideExecuted)
+	if side == "" {
+		side = strings.ToUpper(fxQuote.SideRequested)
+	}
+
+	var price float64
+	switch {
+	case fxQuote.PriceExecuted.Valid:
+		price = fxQuote.PriceExecuted.Value
+	case side == "BUY":
+		price = fxQuote.BuyPrice.Value
+	case side == "SELL":
+		price = fxQuote.SellPrice.Value
+	}
+
+	quantity := baseQuantity(fxQuote, price)
+
+	executionType := venuesv1.ExecutionType_EXECUTION_TYPE_NEW
+	orderStatus := "PENDING"
+	cumulative := 0.0
+	remaining := quantity
+	timestamp, err := normalizer.ParseTimestamp(fxQuote.TQuote)
+	if err != nil {
+		return nil, fmt.Errorf("invalid t_quote: %w", err)
+	}
+
+	if fxQuote.IsFilled {
+		if !fxQuote.PriceExecuted.Valid {
+			return nil, fmt.Errorf("falconx filled quote %s missing price_executed", fxQuote.FxQuoteID)
+		}
+		executionType = venuesv1.ExecutionType_EXECUTION_TYPE_FILL
+		orderStatus = "FILLED"
+		cumulative = quantity
+		remaining = 0
+
+		executedAt, err := normalizer.ParseTimestamp(fxQuote.TExecute)
+		if err != nil {
+			return nil, fmt.Errorf("invalid t_execute: %w", err)
+		}
+		if executedAt != nil {
+			timestamp = executedAt
+		}
+	}
+	if timestamp == nil {
+		timestamp = timestamppb.Now()
+	}
+
+	value := price * quantity
+	orderType := OrderTypeRFQ
+	venueSymbol := FormatSymbol(fxQuote.TokenPair.BaseToken, fxQuote.TokenPair.QuoteToken)
+	baseAsset := strings.ToUpper(fxQuote.TokenPair.BaseToken)
+	quoteAsset := strings.ToUpper(fxQuote.TokenPair.QuoteToken)
+
+	report := &venuesv1.ExecutionReport{
+		ExecutionId:        &fxQuote.FxQuoteID,
+		OrderId:            &fxQuote.FxQuoteID,
+		VenueOrderId:       &fxQuote.FxQuoteID,
+		VenueSymbol:        &venueSymbol,
+		AssetId:            &baseAsset,
+		QuoteAssetId:       &quoteAsset,
+		ExecutionType:      &executionType,
+		OrderStatus:        &orderStatus,
+		Side:               &side,
+		OrderType:          &orderType,
+		Timestamp:          timestamp,
+		Price:              &price,
+		Quantity:           &quantity,
+		CumulativeQuantity: &cumulative,
+		RemainingQuantity:  &remaining,
+		TradeId:            &fxQuote.FxQuoteID,
+		Value:              &value,
+	}
+
+	if fxQuote.ClientOrderID != "" {
+		report.ClientOrderId = &fxQuote.ClientOrderID
+	}
+
+	if fxQuote.IsFilled {
+		fee := 0.0
+		liquidity := "TAKER"
+		isMaker := false
+		report.Fee = &fee
+		report.FeeAssetId = &quoteAsset
+		report.Liquidity = &liquidity
+		report.IsMaker = &isMaker
+	}
+
+	return report, nil
+}
+
+// NormalizeOrder converts a FalconX quote JSON response to a CQC Order.
+//
+// FalconX has no resting orders; each quote is reported as a market order:
+//   - is_filled true -> ORDER_STATUS_FILLED
+//   - unfilled and past t_expiry -> ORDER_STATUS_EXPIRED
+//   - unfilled and still live -> ORDER_STATUS_OPEN
+//
+// Returns an error if JSON parsing fails, the response reports a failure,
+// or required fields are missing.
+func NormalizeOrder(ctx context.Context, raw []byte) (*venuesv1.Order, error) {
+	fxQuote, err := ParseQuote(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := normalizer.ParseTimestamp(fxQuote.TQuote)
+	if err != nil {
+		return nil, fmt.Errorf("invalid t_quote: %w", err)
+	}
+	expiresAt, err := normalizer.ParseTimestamp(fxQuote.TExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid t_expiry: %w", err)
+	}
+
+	sideStr := fxQuote.SideExecuted
+	if sideStr == "" {
+		sideStr = fxQuote.SideRequested
+	}
+	side := normalizer.ParseOrderSide(sideStr)
+	orderType := venuesv1.OrderType_ORDER_TYPE_MARKET
+
+	price := fxQuote.PriceExecuted.Value
+	if !fxQuote.PriceExecuted.Valid {
+		switch side {
+		case venuesv1.OrderSide_ORDER_SIDE_BUY:
+			price = fxQuote.BuyPrice.Value
+		case venuesv1.OrderSide_ORDER_SIDE_SELL:
+			price = fxQuote.SellPrice.Value
+		}
+	}
+	quantity := baseQuantity(fxQuote, price)
+
+	var status venuesv1.OrderStatus
+	var filled float64
+	switch {
+	case fxQuote.IsFilled:
+		status = venuesv1.OrderStatus_ORDER_STATUS_FILLED
+		filled = quantity
+	case expiresAt != nil && !time.Now().Before(expiresAt.AsTime()):
+		status = venuesv1.OrderStatus_ORDER_STATUS_EXPIRED
+	default:
+		status = venuesv1.OrderStatus_ORDER_STATUS_OPEN
+	}
+
+	venueSymbol := FormatSymbol(fxQuote.TokenPair.BaseToken, fxQuote.TokenPair.QuoteToken)
+
+	order := &venuesv1.Order{
+		OrderId:        &fxQuote.FxQuoteID,
+		VenueOrderId:   &fxQuote.FxQuoteID,
+		VenueSymbol:    &venueSymbol,
+		Side:           &side,
+		OrderType:      &orderType,
+		Quantity:       &quantity,
+		Price:          &price,
+		Status:         &status,
+		FilledQuantity: &filled,
+		CreatedAt:      createdAt,
+		ExpiresAt:      expiresAt,
+	}
+
+	if fxQuote.IsFilled {
+		order.AverageFillPrice = &price
+	}
+	if fxQuote.ClientOrderID != "" {
+		order.ClientOrderId = &fxQuote.ClientOrderID
+	}
+
+	return order, nil
+}
+
+// baseQuantity returns the quote's requested quantity in the base token,
+// converting a quote-token quantity at price. Returns zero if the conversion
+// is impossible (no price).
+func baseQuantity(fxQuote *FalconXQuote, price float64) float64 {
+	quantity := fxQuote.QuantityRequested.Value.Value
+	if strings.EqualFold(fxQuote.QuantityRequested.Token, fxQuote.TokenPair.QuoteToken) &&
+		!strings.EqualFold(fxQuote.TokenPair.BaseToken, fxQuote.TokenPair.QuoteToken) {
+		if price <= 0 {
+			return 0
+		}
+		return quantity / price
+	}
+	return quantity
+}
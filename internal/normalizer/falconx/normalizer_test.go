@@ -0,0 +1,322 @@
+package falconx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+// TestNormalizeQuote tests quote normalization.
+func TestNormalizeQuote(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("two-way quote", func(t *testing.T) {
+		quote, err := NormalizeQuote(ctx, readFixture(t, "quote.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "00c884b056f949338788dfb59e495377", quote.ID)
+		assert.Equal(t, "client-abc", quote.ClientOrderID)
+		assert.Equal(t, "BTC/USD", quote.VenueSymbol)
+		assert.Equal(t, "BTC", quote.BaseAsset)
+		assert.Equal(t, "USD", quote.QuoteAsset)
+		assert.Equal(t, "TWO_WAY", quote.Side)
+		assert.Equal(t, 50125.5, quote.Price("BUY"))
+		assert.Equal(t, 49875.25, quote.Price("sell"))
+		assert.Equal(t, 2.0, quote.Quantity)
+		assert.Equal(t, "BTC", quote.QuantityAsset)
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), quote.QuotedAt.UTC())
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 10, 0, time.UTC), quote.ExpiresAt.UTC())
+		assert.False(t, quote.Filled)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		quote, err := NormalizeQuote(ctx, readFixture(t, "quote.json"))
+		require.NoError(t, err)
+
+		assert.False(t, quote.Expired(quote.ExpiresAt.Add(-time.Millisecond)))
+		assert.True(t, quote.Expired(quote.ExpiresAt))
+		assert.True(t, quote.Expired(quote.ExpiresAt.Add(time.Second)))
+	})
+
+	t.Run("one-sided quote with string prices", func(t *testing.T) {
+		quote, err := NormalizeQuote(ctx, readFixture(t, "quote_notional.json"))
+		require.NoError(t, err)
+
+		assert.Zero(t, quote.Price("BUY"))
+		assert.Equal(t, 2500.0, quote.Price("SELL"))
+		assert.Equal(t, "USD", quote.QuantityAsset)
+	})
+
+	t.Run("in-body failure", func(t *testing.T) {
+		_, err := NormalizeQuote(ctx, readFixture(t, "error_quote_expired.json"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+		assert.Contains(t, err.Error(), "QUOTE_EXPIRED")
+	})
+
+	t.Run("missing expiry", func(t *testing.T) {
+		_, err := NormalizeQuote(ctx, []byte(`{"status":"success","fx_quote_id":"q1"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		_, err := NormalizeQuote(ctx, []byte{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := NormalizeQuote(ctx, []byte(`{invalid json}`))
+		assert.Error(t, err)
+	})
+}
+
+// TestNormalizeExecutionReport tests executed quote normalization.
+func TestNormalizeExecutionReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("executed buy", func(t *testing.T) {
+		report, err := NormalizeExecutionReport(ctx, readFixture(t, "quote_executed.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "00c884b056f949338788dfb59e495377", report.GetExecutionId())
+		assert.Equal(t, "00c884b056f949338788dfb59e495377", report.GetVenueOrderId())
+		assert.Equal(t, "client-abc", report.GetClientOrderId())
+		assert.Equal(t, "BTC/USD", report.GetVenueSymbol())
+		assert.Equal(t, "BTC", report.GetAssetId())
+		assert.Equal(t, "USD", report.GetQuoteAssetId())
+		assert.Equal(t, "EXECUTION_TYPE_FILL", report.GetExecutionType().String())
+		assert.Equal(t, "FILLED", report.GetOrderStatus())
+		assert.Equal(t, "BUY", report.GetSide())
+		assert.Equal(t, "RFQ", report.GetOrderType())
+		assert.Equal(t, 50125.5, report.GetPrice())
+		assert.Equal(t, 2.0, report.GetQuantity())
+		assert.Equal(t, 2.0, report.GetCumulativeQuantity())
+		assert.Zero(t, report.GetRemainingQuantity())
+		assert.Equal(t, 100251.0, report.GetValue())
+		require.NotNil(t, report.Fee)
+		assert.Zero(t, report.GetFee())
+		assert.Equal(t, "USD", report.GetFeeAssetId())
+		assert.Equal(t, "TAKER", report.GetLiquidity())
+		require.NotNil(t, report.IsMaker)
+		assert.False(t, report.GetIsMaker())
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 4, 250000000, time.UTC), report.GetTimestamp().AsTime())
+	})
+
+	t.Run("notional quantity", func(t *testing.T) {
+		report, err := NormalizeExecutionReport(ctx, readFixture(t, "quote_notional.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "SELL", report.GetSide())
+		assert.Equal(t, 2500.0, report.GetPrice())
+		assert.Equal(t, 4.0, report.GetQuantity())
+		assert.Equal(t, 10000.0, report.GetValue())
+		assert.Nil(t, report.ClientOrderId)
+	})
+
+	t.Run("unexecuted quote", func(t *testing.T) {
+		report, err := NormalizeExecutionReport(ctx, readFixture(t, "quote.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "EXECUTION_TYPE_NEW", report.GetExecutionType().String())
+		assert.Equal(t, "PENDING", report.GetOrderStatus())
+		assert.Zero(t, report.GetCumulativeQuantity())
+		assert.Equal(t, 2.0, report.GetRemainingQuantity())
+		assert.Nil(t, report.Fee)
+		assert.Nil(t, report.Liquidity)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		_, err := NormalizeExecutionReport(ctx, []byte{})
+		assert.Error(t, err)
+	})
+}
+
+// TestNormalizeOrder tests quote to order normalization.
+func TestNormalizeOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filled quote", func(t *testing.T) {
+		order, err := NormalizeOrder(ctx, readFixture(t, "quote_executed.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "00c884b056f949338788dfb59e495377", order.GetVenueOrderId())
+		assert.Equal(t, "ORDER_SIDE_BUY", order.GetSide().String())
+		assert.Equal(t, "ORDER_TYPE_MARKET", order.GetOrderType().String())
+		assert.Equal(t, "ORDER_STATUS_FILLED", order.GetStatus().String())
+		assert.Equal(t, 2.0, order.GetQuantity())
+		assert.Equal(t, 2.0, order.GetFilledQuantity())
+		assert.Equal(t, 50125.5, order.GetAverageFillPrice())
+	})
+
+	t.Run("expired quote", func(t *testing.T) {
+		order, err := NormalizeOrder(ctx, readFixture(t, "quote.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "ORDER_STATUS_EXPIRED", order.GetStatus().String())
+		assert.Zero(t, order.GetFilledQuantity())
+	})
+
+	t.Run("live quote", func(t *testing.T) {
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(readFixture(t, "quote.json"), &raw))
+		raw["t_expiry"] = time.Now().Add(time.Minute).UTC().Format(time.RFC3339Nano)
+		data, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		order, err := NormalizeOrder(ctx, data)
+		require.NoError(t, err)
+		assert.Equal(t, "ORDER_STATUS_OPEN", order.GetStatus().String())
+	})
+}
+
+// TestNormalizeBalance tests balance normalization.
+func TestNormalizeBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("balance list", func(t *testing.T) {
+		balances, err := NormalizeBalances(ctx, readFixture(t, "balances.json"))
+		require.NoError(t, err)
+		require.Len(t, balances, 3)
+
+		assert.Equal(t, "BTC", balances[0].GetAssetId())
+		assert.Equal(t, 10.5, balances[0].GetTotal())
+		assert.Equal(t, 10.5, balances[0].GetAvailable())
+		assert.Zero(t, balances[0].GetLocked())
+
+		// Negative balances (credit) are preserved
+		assert.Equal(t, "USD", balances[1].GetAssetId())
+		assert.Equal(t, -25000.75, balances[1].GetTotal())
+
+		assert.Equal(t, "ETH", balances[2].GetAssetId())
+		assert.Zero(t, balances[2].GetTotal())
+	})
+
+	t.Run("single balance", func(t *testing.T) {
+		balance, err := NormalizeBalance(ctx, []byte(`{"token":"SOL","balance":"12.5","platform":"api"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "SOL", balance.GetAssetId())
+		assert.Equal(t, 12.5, balance.GetTotal())
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := NormalizeBalance(ctx, []byte(`{"balance":1}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		_, err := NormalizeBalances(ctx, []byte{})
+		assert.Error(t, err)
+	})
+}
+
+// TestNormalizeError tests error classification.
+func TestNormalizeError(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         []byte
+		wantCategory error
+		wantCode     string
+	}{
+		{
+			name:         "unauthorized",
+			statusCode:   401,
+			body:         []byte(`{"status":"failure","error":{"code":"UNAUTHORIZED","reason":"invalid token"}}`),
+			wantCategory: venueerr.ErrAuth,
+			wantCode:     "UNAUTHORIZED",
+		},
+		{
+			name:         "rate limit",
+			statusCode:   429,
+			body:         []byte(`{"status":"failure","error":{"code":"RATE_LIMIT_EXCEEDED","reason":"slow down"}}`),
+			wantCategory: venueerr.ErrRateLimited,
+			wantCode:     "RATE_LIMIT_EXCEEDED",
+		},
+		{
+			name:         "in-body quote expired",
+			statusCode:   200,
+			body:         readFixture(t, "error_quote_expired.json"),
+			wantCategory: venueerr.ErrInvalidRequest,
+			wantCode:     "QUOTE_EXPIRED",
+		},
+		{
+			name:         "in-body liquidity unavailable",
+			statusCode:   200,
+			body:         readFixture(t, "error_liquidity.json"),
+			wantCategory: venueerr.ErrUnavailable,
+			wantCode:     "LIQUIDITY_UNAVAILABLE",
+		},
+		{
+			name:         "bad request",
+			statusCode:   400,
+			body:         []byte(`{"status":"failure","error":{"code":"INVALID_REQUEST","reason":"quantity too small"}}`),
+			wantCategory: venueerr.ErrInvalidRequest,
+			wantCode:     "INVALID_REQUEST",
+		},
+		{
+			name:         "not found",
+			statusCode:   404,
+			body:         []byte(`{"status":"failure","error":{"code":"QUOTE_NOT_FOUND","reason":"unknown quote"}}`),
+			wantCategory: venueerr.ErrNotFound,
+			wantCode:     "QUOTE_NOT_FOUND",
+		},
+		{
+			name:         "server error",
+			statusCode:   503,
+			body:         []byte(`{"status":"failure","error":{"code":"SERVICE_UNAVAILABLE","reason":"maintenance"}}`),
+			wantCategory: venueerr.ErrUnavailable,
+			wantCode:     "SERVICE_UNAVAILABLE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.statusCode == http.StatusOK {
+				err = NormalizeFailure(tt.body)
+			} else {
+				err = NormalizeError(&http.Response{StatusCode: tt.statusCode}, tt.body)
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.wantCategory)
+			assert.Contains(t, err.Error(), tt.wantCode)
+
+			var venueErr *venueerr.Error
+			require.ErrorAs(t, err, &venueErr)
+			assert.Equal(t, "falconx", venueErr.Venue)
+			assert.Equal(t, tt.wantCode, venueErr.Code)
+			if tt.statusCode == 200 {
+				assert.Zero(t, venueErr.StatusCode)
+			} else {
+				assert.Equal(t, tt.statusCode, venueErr.StatusCode)
+			}
+		})
+	}
+
+	t.Run("empty body", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 500}, []byte{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no body")
+	})
+
+	t.Run("non-JSON body", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 502}, []byte("Bad Gateway"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Bad Gateway")
+	})
+}
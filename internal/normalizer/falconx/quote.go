@@ -0,0 +1,206 @@
+// Package falconx converts FalconX RFQ API responses to CQC protobuf types.
+package falconx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+)
+
+// Quote status values returned in the "status" field of FalconX responses.
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// FalconXQuote represents a FalconX quote response.
+// The same structure is returned when requesting, executing and fetching a quote;
+// the execution fields are null until the quote is executed.
+//
+// Reference: https://docs.falconx.io/ (RFQ endpoints: /v1/quotes, /v1/quotes/execute)
+type FalconXQuote struct {
+	Status            string            `json:"status"`             // "success" or "failure"
+	FxQuoteID         string            `json:"fx_quote_id"`        // Quote identifier
+	BuyPrice          Decimal           `json:"buy_price"`          // Price to buy base (null for sell-only quotes)
+	SellPrice         Decimal           `json:"sell_price"`         // Price to sell base (null for buy-only quotes)
+	Platform          string            `json:"platform"`           // "api", "browser", etc.
+	TokenPair         FalconXTokenPair  `json:"token_pair"`         // Base and quote tokens
+	QuantityRequested FalconXQuantity   `json:"quantity_requested"` // Requested size and its token
+	SideRequested     string            `json:"side_requested"`     // "buy", "sell" or "two_way"
+	TQuote            string            `json:"t_quote"`            // Quote creation time
+	TExpiry           string            `json:"t_expiry"`           // Quote expiry time
+	IsFilled          bool              `json:"is_filled"`          // True once the quote has been executed
+	SideExecuted      string            `json:"side_executed"`      // "buy" or "sell" once executed
+	PriceExecuted     Decimal           `json:"price_executed"`     // Execution price once executed
+	TExecute          string            `json:"t_execute"`          // Execution time once executed
+	TraderEmail       string            `json:"trader_email"`
+	ClientOrderID     string            `json:"client_order_id"`
+	Error             *FalconXErrorBody `json:"error"`
+}
+
+// FalconXTokenPair is the base/quote pair of a quote.
+type FalconXTokenPair struct {
+	BaseToken  string `json:"base_token"`
+	QuoteToken string `json:"quote_token"`
+}
+
+// FalconXQuantity is a size denominated in a token, which may be either side of the pair.
+type FalconXQuantity struct {
+	Token string  `json:"token"`
+	Value Decimal `json:"value"`
+}
+
+// Decimal is a FalconX numeric field. FalconX encodes decimals as JSON numbers,
+// strings or null depending on the endpoint; Valid is false for null or empty values.
+type Decimal struct {
+	Value float64
+	Valid bool
+}
+
+// UnmarshalJSON accepts a JSON number, a numeric string or null.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("invalid decimal: %w", err)
+		}
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+
+	value, err := normalizer.ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = Decimal{Value: value, Valid: true}
+	return nil
+}
+
+// Quote is a normalized FalconX quote.
+//
+// CQC has no quote message, so quotes are normalized to this type and
+// executed quotes to a CQC ExecutionReport (see NormalizeExecutionReport).
+type Quote struct {
+	ID            string
+	ClientOrderID string
+	VenueSymbol   string // "BASE/QUOTE", e.g. "BTC/USD"
+	BaseAsset     string
+	QuoteAsset    string
+	Side          string // Requested side: "BUY", "SELL" or "TWO_WAY"
+	BuyPrice      float64
+	SellPrice     float64
+	Quantity      float64
+	QuantityAsset string
+	QuotedAt      time.Time
+	ExpiresAt     time.Time
+	Filled        bool
+}
+
+// Price returns the quoted price for the given side ("BUY" or "SELL"),
+// or zero if the quote has no price for that side.
+func (q *Quote) Price(side string) float64 {
+	switch strings.ToUpper(side) {
+	case "BUY":
+		return q.BuyPrice
+	case "SELL":
+		return q.SellPrice
+	default:
+		return 0
+	}
+}
+
+// Expired reports whether the quote can no longer be executed at now.
+func (q *Quote) Expired(now time.Time) bool {
+	return !q.ExpiresAt.IsZero() && !now.Before(q.ExpiresAt)
+}
+
+// FormatSymbol returns the venue symbol for a FalconX token pair ("BASE/QUOTE").
+func FormatSymbol(base, quote string) string {
+	return strings.ToUpper(base) + "/" + strings.ToUpper(quote)
+}
+
+// ParseQuote parses a FalconX quote response.
+//
+// FalconX reports some failures with HTTP 200 and "status": "failure"; these
+// are returned as errors classified by NormalizeFailure.
+func ParseQuote(raw []byte) (*FalconXQuote, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty quote response")
+	}
+
+	var quote FalconXQuote
+	if err := json.Unmarshal(raw, &quote); err != nil {
+		return nil, fmt.Errorf("failed to parse falconx quote: %w", err)
+	}
+
+	if quote.Status == StatusFailure {
+		return nil, NormalizeFailure(raw)
+	}
+	if quote.FxQuoteID == "" {
+		return nil, fmt.Errorf("falconx quote missing fx_quote_id")
+	}
+
+	return &quote, nil
+}
+
+// NormalizeQuote converts a FalconX quote JSON response to a normalized Quote.
+//
+// The function handles:
+//   - Parsing JSON response and in-body failures
+//   - Converting quote and expiry timestamps
+//   - Parsing buy/sell prices (either may be null for one-sided quotes)
+//   - Formatting the token pair as a "BASE/QUOTE" venue symbol
+//
+// Returns an error if JSON parsing fails, the response reports a failure,
+// or required fields are missing.
+func NormalizeQuote(ctx context.Context, raw []byte) (*Quote, error) {
+	fxQuote, err := ParseQuote(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	quotedAt, err := normalizer.ParseTimestamp(fxQuote.TQuote)
+	if err != nil {
+		return nil, fmt.Errorf("invalid t_quote: %w", err)
+	}
+	expiresAt, err := normalizer.ParseTimestamp(fxQuote.TExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid t_expiry: %w", err)
+	}
+	if expiresAt == nil {
+		return nil, fmt.Errorf("falconx quote missing t_expiry")
+	}
+
+	quote := &Quote{
+		ID:            fxQuote.FxQuoteID,
+		ClientOrderID: fxQuote.ClientOrderID,
+		VenueSymbol:   FormatSymbol(fxQuote.TokenPair.BaseToken, fxQuote.TokenPair.QuoteToken),
+		BaseAsset:     strings.ToUpper(fxQuote.TokenPair.BaseToken),
+		QuoteAsset:    strings.ToUpper(fxQuote.TokenPair.QuoteToken),
+		Side:          strings.ToUpper(fxQuote.SideRequested),
+		BuyPrice:      fxQuote.BuyPrice.Value,
+		SellPrice:     fxQuote.SellPrice.Value,
+		Quantity:      fxQuote.QuantityRequested.Value.Value,
+		QuantityAsset: strings.ToUpper(fxQuote.QuantityRequested.Token),
+		ExpiresAt:     expiresAt.AsTime(),
+		Filled:        fxQuote.IsFilled,
+	}
+	if quotedAt != nil {
+		quote.QuotedAt = quotedAt.AsTime()
+	}
+
+	return quote, nil
+}
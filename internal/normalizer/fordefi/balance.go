@@ -0,0 +1,113 @@
+package fordefi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+)
+
+// FordefiOwnedAsset represents one asset held by a Fordefi vault.
+// Balances are reported in the asset's smallest unit (e.g., wei).
+//
+// Reference: https://docs.fordefi.com/api/ (vault assets endpoints)
+type FordefiOwnedAsset struct {
+	AssetInfo FordefiAssetInfo `json:"asset_info"`
+	Balance   string           `json:"balance"` // Integer amount in the smallest unit
+}
+
+// FordefiAssetInfo describes an asset.
+type FordefiAssetInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+	Verified bool   `json:"verified"`
+}
+
+// NormalizeBalance converts a Fordefi owned asset JSON object to a CQC Balance protobuf.
+//
+// The function handles:
+//   - Parsing JSON response
+//   - Converting the smallest-unit balance to asset units using the asset's decimals
+//   - Reporting the full balance as available (custody balances carry no holds)
+//
+// Returns an error if JSON parsing fails, the symbol is missing, or the balance
+// is not an integer.
+func NormalizeBalance(ctx context.Context, raw []byte) (*venuesv1.Balance, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty balance response")
+	}
+
+	var asset FordefiOwnedAsset
+	if err := json.Unmarshal(raw, &asset); err != nil {
+		return nil, fmt.Errorf("failed to parse fordefi balance: %w", err)
+	}
+
+	if asset.AssetInfo.Symbol == "" {
+		return nil, fmt.Errorf("fordefi balance missing asset symbol")
+	}
+
+	amount, err := FromBaseUnits(asset.Balance, asset.AssetInfo.Decimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance for %s: %w", asset.AssetInfo.Symbol, err)
+	}
+	total, err := normalizer.ParseDecimal(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance for %s: %w", asset.AssetInfo.Symbol, err)
+	}
+
+	symbol := strings.ToUpper(asset.AssetInfo.Symbol)
+	available := total
+	var locked float64
+
+	return &venuesv1.Balance{
+		AssetId:   &symbol,
+		Total:     &total,
+		Available: &available,
+		Locked:    &locked,
+	}, nil
+}
+
+// ToBaseUnits converts a decimal amount in asset units (e.g., "1.5" ETH) to an
+// integer string in the smallest unit (e.g., "1500000000000000000" wei).
+// Returns an error if the amount is negative, malformed, or has more
+// fractional digits than decimals.
+func ToBaseUnits(amount string, decimals int) (string, error) {
+	if decimals < 0 {
+		return "", fmt.Errorf("invalid decimals: %d", decimals)
+	}
+
+	value, err := normalizer.ParseExactDecimal(amount)
+	if err != nil {
+		return "", fmt.Errorf("invalid amount: %q", amount)
+	}
+	if value.Sign() < 0 {
+		return "", fmt.Errorf("amount must be non-negative: %q", amount)
+	}
+
+	units := value.Shift(decimals)
+	if !units.IsInteger() {
+		return "", fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+	}
+
+	return units.PlainString(), nil
+}
+
+// FromBaseUnits converts an integer amount in the smallest unit to a decimal
+// string in asset units, without trailing zeros. Empty input is treated as zero.
+func FromBaseUnits(units string, decimals int) (string, error) {
+	if decimals < 0 {
+		return "", fmt.Errorf("invalid decimals: %d", decimals)
+	}
+
+	value, err := normalizer.ParseExactDecimal(units)
+	if err != nil || !value.IsInteger() {
+		return "", fmt.Errorf("invalid base unit amount: %q", units)
+	}
+
+	return value.Shift(-decimals).PlainString(), nil
+}
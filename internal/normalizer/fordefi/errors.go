@@ -0,0 +1,76 @@
+package fordefi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// FordefiError represents an error response from the Fordefi API.
+// Fordefi returns problem-details style errors.
+type FordefiError struct {
+	Title           string `json:"title"`
+	Detail          string `json:"detail"`
+	RequestID       string `json:"request_id"`
+	SystemErrorCode string `json:"system_error_code"`
+}
+
+// venueID identifies Fordefi in classified errors.
+const venueID = "fordefi"
+
+// rateLimitBucket is the Fordefi rate limit every request counts against.
+const rateLimitBucket = "rest"
+
+// NormalizeError converts a Fordefi API error response to a *venueerr.Error.
+// It parses the error body and classifies it based on HTTP status code.
+// Retry-After and rate limit headers are read by venueerr.FromResponse.
+//
+// Error Classification:
+//   - 401/403: venueerr.ErrAuth
+//   - 429: venueerr.ErrRateLimited
+//   - 404: venueerr.ErrNotFound
+//   - 400/409/422 and other 4xx: venueerr.ErrInvalidRequest
+//   - 5xx and other statuses: venueerr.ErrUnavailable
+//   - 400/401/403 whose code or message is one venueerr.ClassifyClockSkew
+//     knows for a rejected timestamp or token validity window: venueerr.ErrClockSkew, overriding the above
+//
+// Returns an error with appropriate classification and original error details.
+func NormalizeError(resp *http.Response, body []byte) error {
+	venueErr := venueerr.FromResponse(venueID, resp)
+	if venueErr.RateLimit != nil {
+		venueErr.RateLimit.Bucket = rateLimitBucket
+	}
+	if len(body) == 0 {
+		venueErr.Message = "no body"
+		return venueErr
+	}
+
+	var fdErr FordefiError
+	if err := json.Unmarshal(body, &fdErr); err != nil {
+		venueErr.Message = string(body)
+		venueerr.ClassifyClockSkew(venueErr, venueErr.Message)
+		return venueErr
+	}
+
+	venueErr.Code = fdErr.SystemErrorCode
+	venueErr.Message = formatErrorMessage(fdErr)
+	venueerr.ClassifyClockSkew(venueErr, fdErr.Detail, fdErr.Title)
+	return venueErr
+}
+
+// formatErrorMessage constructs an error message from Fordefi error fields.
+func formatErrorMessage(fdErr FordefiError) string {
+	msg := fdErr.Title
+
+	if fdErr.Detail != "" {
+		msg = strings.TrimSpace(fmt.Sprintf("%s (%s)", msg, fdErr.Detail))
+	}
+	if fdErr.RequestID != "" {
+		msg = strings.TrimSpace(fmt.Sprintf("%s [request_id: %s]", msg, fdErr.RequestID))
+	}
+
+	return msg
+}
@@ -0,0 +1,256 @@
+package fordefi
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+// TestNormalizeOrder tests transaction to order normalization.
+func TestNormalizeOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pending approval", func(t *testing.T) {
+		order, err := NormalizeOrder(ctx, readFixture(t, "transaction_pending_approval.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "d5f1c2a0-6b1e-4c7a-9f3e-2a8b7c6d5e4f", order.GetVenueOrderId())
+		assert.Equal(t, "cqvx-test-0001", order.GetClientOrderId())
+		assert.Equal(t, "vault-eth-1", order.GetAccountId())
+		assert.Equal(t, "treasury rebalance", order.GetNotes())
+		assert.Equal(t, "ORDER_STATUS_PENDING", order.GetStatus().String())
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), order.GetCreatedAt().AsTime())
+		assert.Nil(t, order.ClosedAt)
+		assert.Nil(t, order.Quantity)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		order, err := NormalizeOrder(ctx, readFixture(t, "transaction_completed.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "ORDER_STATUS_FILLED", order.GetStatus().String())
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 34, 12, 0, time.UTC), order.GetClosedAt().AsTime())
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := NormalizeOrder(ctx, []byte(`{"state":"completed"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		_, err := NormalizeOrder(ctx, []byte{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := NormalizeOrder(ctx, []byte(`{invalid json}`))
+		assert.Error(t, err)
+	})
+}
+
+// TestNormalizeExecutionReport tests transaction to execution report normalization.
+func TestNormalizeExecutionReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pending approval", func(t *testing.T) {
+		report, err := NormalizeExecutionReport(ctx, readFixture(t, "transaction_pending_approval.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "d5f1c2a0-6b1e-4c7a-9f3e-2a8b7c6d5e4f:pending_approval", report.GetExecutionId())
+		assert.Equal(t, "EXECUTION_TYPE_NEW", report.GetExecutionType().String())
+		assert.Equal(t, "pending_approval", report.GetOrderStatus())
+		assert.Equal(t, "evm_transaction", report.GetOrderType())
+		assert.Equal(t, "vault-eth-1", report.GetAccountId())
+		assert.Nil(t, report.TxHash)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		report, err := NormalizeExecutionReport(ctx, readFixture(t, "transaction_completed.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "EXECUTION_TYPE_FILL", report.GetExecutionType().String())
+		assert.Equal(t, "completed", report.GetOrderStatus())
+		assert.Equal(t, "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060", report.GetTxHash())
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 34, 12, 0, time.UTC), report.GetTimestamp().AsTime())
+	})
+}
+
+// TestMapTransactionState tests the approval workflow state mapping.
+func TestMapTransactionState(t *testing.T) {
+	tests := []struct {
+		state string
+		want  venuesv1.OrderStatus
+	}{
+		{StateCreated, venuesv1.OrderStatus_ORDER_STATUS_PENDING},
+		{StatePendingApproval, venuesv1.OrderStatus_ORDER_STATUS_PENDING},
+		{StateApproved, venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED},
+		{StateSigned, venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED},
+		{StatePushedToChain, venuesv1.OrderStatus_ORDER_STATUS_OPEN},
+		{StateMined, venuesv1.OrderStatus_ORDER_STATUS_OPEN},
+		{StateCompleted, venuesv1.OrderStatus_ORDER_STATUS_FILLED},
+		{StateAborted, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED},
+		{StateRejected, venuesv1.OrderStatus_ORDER_STATUS_REJECTED},
+		{StateErrorSigning, venuesv1.OrderStatus_ORDER_STATUS_FAILED},
+		{StateReverted, venuesv1.OrderStatus_ORDER_STATUS_FAILED},
+		{"PUSHED_TO_CHAIN", venuesv1.OrderStatus_ORDER_STATUS_OPEN},
+		{"unknown", venuesv1.OrderStatus_ORDER_STATUS_UNSPECIFIED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			assert.Equal(t, tt.want, MapTransactionState(tt.state))
+		})
+	}
+
+	t.Run("StatesForStatus is the inverse", func(t *testing.T) {
+		for status := range venuesv1.OrderStatus_name {
+			for _, state := range StatesForStatus(venuesv1.OrderStatus(status)) {
+				assert.Equal(t, venuesv1.OrderStatus(status), MapTransactionState(state), state)
+			}
+		}
+	})
+}
+
+// TestNormalizeBalance tests vault balance normalization.
+func TestNormalizeBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid balance", func(t *testing.T) {
+		balance, err := NormalizeBalance(ctx, readFixture(t, "owned_asset.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "USDC", balance.GetAssetId())
+		assert.Equal(t, 1250000.5, balance.GetTotal())
+		assert.Equal(t, 1250000.5, balance.GetAvailable())
+		assert.Zero(t, balance.GetLocked())
+	})
+
+	t.Run("non-integer balance", func(t *testing.T) {
+		_, err := NormalizeBalance(ctx, []byte(`{"asset_info":{"symbol":"ETH","decimals":18},"balance":"1.5"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing symbol", func(t *testing.T) {
+		_, err := NormalizeBalance(ctx, []byte(`{"asset_info":{"decimals":18},"balance":"1"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		_, err := NormalizeBalance(ctx, []byte{})
+		assert.Error(t, err)
+	})
+}
+
+// TestBaseUnits tests exact smallest-unit conversions.
+func TestBaseUnits(t *testing.T) {
+	toTests := []struct {
+		amount   string
+		decimals int
+		want     string
+		wantErr  bool
+	}{
+		{amount: "1.5", decimals: 18, want: "1500000000000000000"},
+		{amount: "0.000001", decimals: 6, want: "1"},
+		{amount: "100", decimals: 0, want: "100"},
+		{amount: "0.1", decimals: 18, want: "100000000000000000"},
+		{amount: "0.0000001", decimals: 6, wantErr: true},
+		{amount: "-1", decimals: 6, wantErr: true},
+		{amount: "abc", decimals: 6, wantErr: true},
+	}
+	for _, tt := range toTests {
+		t.Run("to "+tt.amount, func(t *testing.T) {
+			got, err := ToBaseUnits(tt.amount, tt.decimals)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	fromTests := []struct {
+		units    string
+		decimals int
+		want     string
+	}{
+		{units: "1500000000000000000", decimals: 18, want: "1.5"},
+		{units: "1", decimals: 6, want: "0.000001"},
+		{units: "1000000", decimals: 6, want: "1"},
+		{units: "42", decimals: 0, want: "42"},
+		{units: "", decimals: 18, want: "0"},
+	}
+	for _, tt := range fromTests {
+		t.Run("from "+tt.units, func(t *testing.T) {
+			got, err := FromBaseUnits(tt.units, tt.decimals)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestNormalizeError tests error normalization and classification.
+func TestNormalizeError(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 404}, readFixture(t, "error_not_found.json"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, venueerr.ErrNotFound)
+		assert.Contains(t, err.Error(), "Transaction not found")
+		assert.Contains(t, err.Error(), "req-8f7e6d5c")
+
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.Equal(t, "fordefi", venueErr.Venue)
+		assert.Equal(t, "not_found", venueErr.Code)
+		assert.Equal(t, 404, venueErr.StatusCode)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 401}, []byte(`{"title":"Unauthorized","detail":"invalid signature"}`))
+		assert.ErrorIs(t, err, venueerr.ErrAuth)
+	})
+
+	t.Run("clock skew", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 401}, []byte(`{"title":"Unauthorized","detail":"Request timestamp is too old"}`))
+		assert.ErrorIs(t, err, venueerr.ErrClockSkew)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 409}, []byte(`{"title":"Conflict","detail":"transaction already signed"}`))
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+		assert.False(t, venueerr.IsRetryable(err))
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 429}, []byte(`{"title":"Too many requests"}`))
+		assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+		assert.True(t, venueerr.IsRetryable(err))
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 503}, []byte(`{"title":"Service unavailable"}`))
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+		assert.True(t, venueerr.IsRetryable(err))
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 500}, []byte{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no body")
+	})
+}
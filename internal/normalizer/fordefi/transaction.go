@@ -0,0 +1,271 @@
+// Package fordefi converts Fordefi custody API responses to CQC protobuf types.
+package fordefi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+)
+
+// Fordefi transaction states.
+//
+// A transaction moves through the MPC approval workflow:
+//
+//	pending_approval -> approved -> signed -> pushed_to_chain -> mined -> completed
+//
+// and may leave it early as aborted, rejected or one of the error states.
+const (
+	StateCreated                  = "created"
+	StateQueued                   = "queued"
+	StatePendingApproval          = "pending_approval"
+	StateApproved                 = "approved"
+	StateSigned                   = "signed"
+	StatePushedToChain            = "pushed_to_chain"
+	StateMined                    = "mined"
+	StateCompleted                = "completed"
+	StateAborted                  = "aborted"
+	StateCancelled                = "cancelled"
+	StateRejected                 = "rejected"
+	StateErrorSigning             = "error_signing"
+	StateErrorPushingToBlockchain = "error_pushing_to_blockchain"
+	StateReverted                 = "reverted"
+	StateDropped                  = "dropped"
+	StateStuck                    = "stuck"
+)
+
+// FordefiTransaction represents a Fordefi transaction response.
+// Only the fields used for order normalization are decoded; chain-specific
+// details are kept raw.
+//
+// Reference: https://docs.fordefi.com/api/ (transactions endpoints)
+type FordefiTransaction struct {
+	ID            string               `json:"id"`
+	CreatedAt     string               `json:"created_at"`
+	ModifiedAt    string               `json:"modified_at"`
+	State         string               `json:"state"` // See State* constants
+	Type          string               `json:"type"`  // "evm_transaction", "solana_transaction", etc.
+	Note          string               `json:"note"`
+	Hash          string               `json:"hash"` // On-chain hash once signed
+	IdempotenceID string               `json:"idempotence_id"`
+	Vault         FordefiVaultRef      `json:"vault"`
+	StateChanges  []FordefiStateChange `json:"state_changes"`
+	Details       json.RawMessage      `json:"details"`
+}
+
+// FordefiVaultRef identifies the vault a transaction originates from.
+type FordefiVaultRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FordefiStateChange is one entry of a transaction's state history.
+type FordefiStateChange struct {
+	ChangedAt string `json:"changed_at"`
+	NewState  string `json:"new_state"`
+}
+
+// MapTransactionState maps a Fordefi transaction state to a CQC order status.
+//
+// Mapping:
+//   - created, queued, pending_approval -> PENDING (awaiting approval quorum)
+//   - approved, signed -> SUBMITTED (approved, MPC signing under way or done)
+//   - pushed_to_chain, mined -> OPEN (broadcast, awaiting finality)
+//   - completed -> FILLED
+//   - aborted, cancelled -> CANCELLED
+//   - rejected -> REJECTED (denied by an approver or policy)
+//   - error_signing, error_pushing_to_blockchain, reverted, dropped, stuck -> FAILED
+//   - anything else -> UNSPECIFIED
+func MapTransactionState(state string) venuesv1.OrderStatus {
+	switch strings.ToLower(state) {
+	case StateCreated, StateQueued, StatePendingApproval:
+		return venuesv1.OrderStatus_ORDER_STATUS_PENDING
+	case StateApproved, StateSigned:
+		return venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED
+	case StatePushedToChain, StateMined:
+		return venuesv1.OrderStatus_ORDER_STATUS_OPEN
+	case StateCompleted:
+		return venuesv1.OrderStatus_ORDER_STATUS_FILLED
+	case StateAborted, StateCancelled:
+		return venuesv1.OrderStatus_ORDER_STATUS_CANCELLED
+	case StateRejected:
+		return venuesv1.OrderStatus_ORDER_STATUS_REJECTED
+	case StateErrorSigning, StateErrorPushingToBlockchain, StateReverted, StateDropped, StateStuck:
+		return venuesv1.OrderStatus_ORDER_STATUS_FAILED
+	default:
+		return venuesv1.OrderStatus_ORDER_STATUS_UNSPECIFIED
+	}
+}
+
+// StatesForStatus returns the Fordefi transaction states that map to a CQC
+// order status. It is the inverse of MapTransactionState.
+func StatesForStatus(status venuesv1.OrderStatus) []string {
+	switch status {
+	case venuesv1.OrderStatus_ORDER_STATUS_PENDING:
+		return []string{StateCreated, StateQueued, StatePendingApproval}
+	case venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED:
+		return []string{StateApproved, StateSigned}
+	case venuesv1.OrderStatus_ORDER_STATUS_OPEN:
+		return []string{StatePushedToChain, StateMined}
+	case venuesv1.OrderStatus_ORDER_STATUS_FILLED:
+		return []string{StateCompleted}
+	case venuesv1.OrderStatus_ORDER_STATUS_CANCELLED:
+		return []string{StateAborted, StateCancelled}
+	case venuesv1.OrderStatus_ORDER_STATUS_REJECTED:
+		return []string{StateRejected}
+	case venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+		return []string{StateErrorSigning, StateErrorPushingToBlockchain, StateReverted, StateDropped, StateStuck}
+	default:
+		return nil
+	}
+}
+
+// ParseTransaction parses a Fordefi transaction response.
+func ParseTransaction(raw []byte) (*FordefiTransaction, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty transaction response")
+	}
+
+	var tx FordefiTransaction
+	if err := json.Unmarshal(raw, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse fordefi transaction: %w", err)
+	}
+	if tx.ID == "" {
+		return nil, fmt.Errorf("fordefi transaction missing id")
+	}
+
+	return &tx, nil
+}
+
+// NormalizeOrder converts a Fordefi transaction JSON response to a CQC Order protobuf.
+//
+// The function handles:
+//   - Parsing JSON response
+//   - Mapping the transaction state to a CQC order status (see MapTransactionState)
+//   - Converting created/modified timestamps, and ClosedAt for terminal states
+//   - Using the source vault ID as AccountId and the note as Notes
+//
+// Transfer amounts are chain-specific and are not normalized; Quantity is left unset.
+//
+// Returns an error if JSON parsing fails or required fields are missing.
+func NormalizeOrder(ctx context.Context, raw []byte) (*venuesv1.Order, error) {
+	tx, err := ParseTransaction(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := normalizer.ParseTimestamp(tx.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at: %w", err)
+	}
+	modifiedAt, err := normalizer.ParseTimestamp(tx.ModifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modified_at: %w", err)
+	}
+
+	status := MapTransactionState(tx.State)
+	orderType := venuesv1.OrderType_ORDER_TYPE_MARKET
+	side := venuesv1.OrderSide_ORDER_SIDE_SELL
+
+	order := &venuesv1.Order{
+		OrderId:      &tx.ID,
+		VenueOrderId: &tx.ID,
+		OrderType:    &orderType,
+		Side:         &side,
+		Status:       &status,
+		CreatedAt:    createdAt,
+		UpdatedAt:    modifiedAt,
+	}
+
+	if tx.Vault.ID != "" {
+		order.AccountId = &tx.Vault.ID
+	}
+	if tx.Note != "" {
+		order.Notes = &tx.Note
+	}
+	if tx.IdempotenceID != "" {
+		order.ClientOrderId = &tx.IdempotenceID
+	}
+
+	switch status {
+	case venuesv1.OrderStatus_ORDER_STATUS_FILLED, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED,
+		venuesv1.OrderStatus_ORDER_STATUS_REJECTED, venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+		order.ClosedAt = modifiedAt
+	}
+
+	return order, nil
+}
+
+// NormalizeExecutionReport converts a Fordefi transaction JSON response to a CQC ExecutionReport.
+//
+// Each state change is reported as a separate execution; the execution ID is
+// "{transaction_id}:{state}". Execution types:
+//   - completed -> EXECUTION_TYPE_FILL
+//   - aborted, cancelled -> EXECUTION_TYPE_CANCELLED
+//   - rejected, error states -> EXECUTION_TYPE_REJECTED
+//   - any other state -> EXECUTION_TYPE_NEW
+//
+// OrderStatus carries the raw Fordefi state (e.g., "pending_approval") so
+// consumers can distinguish approval, signing and broadcast progress. TxHash
+// is set once the transaction has been signed.
+//
+// Returns an error if JSON parsing fails or required fields are missing.
+func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.ExecutionReport, error) {
+	tx, err := ParseTransaction(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp, err := normalizer.ParseTimestamp(tx.ModifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modified_at: %w", err)
+	}
+	if timestamp == nil {
+		timestamp, err = normalizer.ParseTimestamp(tx.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at: %w", err)
+		}
+	}
+
+	var executionType venuesv1.ExecutionType
+	switch MapTransactionState(tx.State) {
+	case venuesv1.OrderStatus_ORDER_STATUS_FILLED:
+		executionType = venuesv1.ExecutionType_EXECUTION_TYPE_FILL
+	case venuesv1.OrderStatus_ORDER_STATUS_CANCELLED:
+		executionType = venuesv1.ExecutionType_EXECUTION_TYPE_CANCELLED
+	case venuesv1.OrderStatus_ORDER_STATUS_REJECTED, venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+		executionType = venuesv1.ExecutionType_EXECUTION_TYPE_REJECTED
+	default:
+		executionType = venuesv1.ExecutionType_EXECUTION_TYPE_NEW
+	}
+
+	executionId := tx.ID + ":" + tx.State
+	orderStatus := tx.State
+	side := "SELL"
+
+	report := &venuesv1.ExecutionReport{
+		ExecutionId:   &executionId,
+		OrderId:       &tx.ID,
+		VenueOrderId:  &tx.ID,
+		ExecutionType: &executionType,
+		OrderStatus:   &orderStatus,
+		Side:          &side,
+		OrderType:     &tx.Type,
+		Timestamp:     timestamp,
+	}
+
+	if tx.Vault.ID != "" {
+		report.AccountId = &tx.Vault.ID
+	}
+	if tx.IdempotenceID != "" {
+		report.ClientOrderId = &tx.IdempotenceID
+	}
+	if tx.Hash != "" {
+		report.TxHash = &tx.Hash
+	}
+
+	return report, nil
+}
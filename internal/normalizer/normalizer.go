@@ -94,23 +94,23 @@ type Normalizer interface {
 	// Returns a fully populated CQC Trade or an error if normalization fails.
 	NormalizeTrade(ctx context.Context, raw []byte) (*marketsv1.Trade, error)
 
-	// NormalizeError converts a venue-specific error response to a structured error.
+	// NormalizeError converts a venue-specific error response to a *venueerr.Error.
 	//
 	// This method should parse venue error codes and messages, mapping them to
-	// appropriate error types (e.g., rate limit, invalid request, authentication failure).
+	// one of the venueerr categories so that callers handle every venue alike.
 	//
 	// The raw parameter contains the venue's error response bytes.
 	// Returns an error with proper context and type information.
 	//
 	// Common error types to map:
-	//   - Rate limit errors (temporary, should retry with backoff)
-	//   - Authentication errors (permanent, credentials invalid)
-	//   - Invalid request errors (permanent, bad parameters)
-	//   - Temporary errors (network issues, venue downtime)
+	//   - venueerr.ErrRateLimited (retry after backoff)
+	//   - venueerr.ErrAuth (credentials invalid)
+	//   - venueerr.ErrInvalidOrder, venueerr.ErrInvalidRequest (bad parameters)
+	//   - venueerr.ErrUnavailable (venue errors, downtime)
 	//
 	// Example:
 	//   err := normalizer.NormalizeError(ctx, errorResponseJSON)
-	//   if errors.Is(err, ErrRateLimit) {
+	//   if errors.Is(err, venueerr.ErrRateLimited) {
 	//       // Apply backoff and retry
 	//   }
 	NormalizeError(ctx context.Context, raw []byte) error
@@ -58,18 +58,18 @@ func NormalizeBalance(ctx context.Context, raw []byte) (*venuesv1.Balance, error
 	}
 
 	// Parse decimal fields
-	total := normalizer.ParseDecimalOrZero(primeBalance.Amount)
-	holds := normalizer.ParseDecimalOrZero(primeBalance.Holds)
+	total := normalizer.ParseExactDecimalOrZero(primeBalance.Amount)
+	holds := normalizer.ParseExactDecimalOrZero(primeBalance.Holds)
 
-	// Calculate available balance (total - holds)
-	available := total - holds
+	// Calculate available balance (total - holds) exactly before converting
+	available := total.Sub(holds)
 
 	// Build CQC Balance
 	balance := &venuesv1.Balance{
 		AssetId:   &primeBalance.Symbol,
-		Total:     &total,
-		Available: &available,
-		Locked:    &holds, // Holds are effectively locked
+		Total:     normalizer.Float64Ptr(total.Float64()),
+		Available: normalizer.Float64Ptr(available.Float64()),
+		Locked:    normalizer.Float64Ptr(holds.Float64()), // Holds are effectively locked
 	}
 
 	// Prime-specific custody fields (bonded, unbonding, rewards, etc.)
@@ -92,19 +92,19 @@ func NormalizeWalletBalance(ctx context.Context, raw []byte) (*venuesv1.Balance,
 	}
 
 	// Parse decimal fields
-	total := normalizer.ParseDecimalOrZero(walletBalance.Amount)
-	holds := normalizer.ParseDecimalOrZero(walletBalance.Holds)
+	total := normalizer.ParseExactDecimalOrZero(walletBalance.Amount)
+	holds := normalizer.ParseExactDecimalOrZero(walletBalance.Holds)
 
-	// Calculate available balance (total - holds)
-	available := total - holds
+	// Calculate available balance (total - holds) exactly before converting
+	available := total.Sub(holds)
 
 	// Build CQC Balance with wallet context
 	balance := &venuesv1.Balance{
 		AccountId: &walletBalance.WalletID, // Use wallet ID as account ID
 		AssetId:   &walletBalance.Symbol,
-		Total:     &total,
-		Available: &available,
-		Locked:    &holds,
+		Total:     normalizer.Float64Ptr(total.Float64()),
+		Available: normalizer.Float64Ptr(available.Float64()),
+		Locked:    normalizer.Float64Ptr(holds.Float64()),
 	}
 
 	return balance, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
 	"github.com/Combine-Capital/cqvx/internal/normalizer"
@@ -53,7 +54,11 @@ type PrimeFill struct {
 //   - Converting timestamps to protobuf format
 //   - Parsing decimal values for prices, quantities, and fees
 //   - Mapping Prime fill fields to CQC ExecutionReport fields
-//   - Determining execution type and maker/taker status
+//   - Populating the fee asset and venue trade ID (match ID, or exec ID when
+//     no match ID is reported)
+//
+// Prime fills carry no liquidity indicator, so Liquidity and IsMaker are
+// left unset rather than guessed.
 //
 // Returns an error if JSON parsing fails or required fields are missing.
 func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.ExecutionReport, error) {
@@ -81,11 +86,6 @@ func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.Execut
 	// Determine execution type
 	executionType := venuesv1.ExecutionType_EXECUTION_TYPE_FILL
 
-	// Determine liquidity (maker/taker) - Prime doesn't always provide this explicitly
-	// We can infer from order type: post_only orders are maker, others may be taker
-	// For now, we'll leave it unspecified without explicit information
-	isMaker := false // Default to taker if not specified
-
 	// Parse side
 	side := primeFill.Side
 
@@ -102,12 +102,23 @@ func NormalizeExecutionReport(ctx context.Context, raw []byte) (*venuesv1.Execut
 		Price:            &primeFill.FillPrice,
 		Quantity:         &primeFill.FillQty,
 		Fee:              &primeFill.Fee,
-		TradeId:          &primeFill.MatchID,
-		IsMaker:          &isMaker,
 		VenueExecutionId: &primeFill.FillID,
 		Value:            &value,
 	}
 
+	// Venue trade ID
+	switch {
+	case primeFill.MatchID != "":
+		report.TradeId = &primeFill.MatchID
+	case primeFill.ExecID != 0:
+		tradeId := strconv.FormatInt(primeFill.ExecID, 10)
+		report.TradeId = &tradeId
+	}
+
+	if primeFill.FeeAsset != "" {
+		report.FeeAssetId = &primeFill.FeeAsset
+	}
+
 	// Add client order ID if available
 	if primeFill.ClientOrderID != "" {
 		report.ClientOrderId = &primeFill.ClientOrderID
@@ -2,10 +2,13 @@ package prime
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -102,6 +105,9 @@ func TestNormalizeExecutionReport(t *testing.T) {
 		assert.Equal(t, 0.5, *report.Quantity)
 		assert.Equal(t, 25.00, *report.Fee)
 		assert.Equal(t, "match-abc", *report.TradeId)
+		assert.Equal(t, "USD", *report.FeeAssetId)
+		assert.Nil(t, report.IsMaker)
+		assert.Nil(t, report.Liquidity)
 
 		// Verify calculated value
 		expectedValue := 49950.00 * 0.5
@@ -198,59 +204,95 @@ func TestNormalizeOrderBook(t *testing.T) {
 func TestNormalizeError(t *testing.T) {
 	t.Run("unauthorized error", func(t *testing.T) {
 		body := []byte(`{"message": "Invalid credentials", "code": "UNAUTHORIZED"}`)
-		err := NormalizeError(401, body)
+		err := NormalizeError(&http.Response{StatusCode: 401}, body)
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "prime api error")
+		assert.ErrorIs(t, err, venueerr.ErrAuth)
+		assert.Contains(t, err.Error(), "prime")
 		assert.Contains(t, err.Error(), "UNAUTHORIZED")
 
-		// Should be a permanent error
-		permErr, ok := err.(*PermanentError)
-		assert.True(t, ok, "expected PermanentError")
-		assert.NotNil(t, permErr)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.Equal(t, "prime", venueErr.Venue)
+		assert.Equal(t, "UNAUTHORIZED", venueErr.Code)
+		assert.Equal(t, "Invalid credentials", venueErr.Message)
+		assert.Equal(t, 401, venueErr.StatusCode)
 	})
 
 	t.Run("rate limit error", func(t *testing.T) {
 		body := []byte(`{"message": "Too many requests", "code": "RATE_LIMIT_EXCEEDED"}`)
-		err := NormalizeError(429, body)
+		err := NormalizeError(&http.Response{StatusCode: 429}, body)
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "rate limit error")
+		assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+		assert.Contains(t, err.Error(), "rate limited")
+		assert.True(t, venueerr.IsRetryable(err))
+
+		limit := venueerr.RateLimitOf(err)
+		require.NotNil(t, limit, "rate limit errors always carry a budget")
+		assert.Equal(t, -1, limit.Remaining)
+	})
 
-		// Should be a rate limit error
-		rateLimitErr, ok := err.(*RateLimitError)
-		assert.True(t, ok, "expected RateLimitError")
-		assert.NotNil(t, rateLimitErr)
-		assert.True(t, rateLimitErr.Temporary())
+	t.Run("rate limit headers", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header: http.Header{
+				"X-Ratelimit-Limit":     []string{"25"},
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{"1.5"},
+			},
+		}
+		err := NormalizeError(resp, []byte(`{"message": "Too many requests", "code": "RATE_LIMIT_EXCEEDED"}`))
+
+		limit := venueerr.RateLimitOf(err)
+		require.NotNil(t, limit)
+		assert.Equal(t, 25, limit.Limit)
+		assert.Equal(t, 0, limit.Remaining)
+		assert.WithinDuration(t, time.Now().Add(1500*time.Millisecond), limit.Reset, time.Second)
+
+		// Without Retry-After, callers wait for the window to reset
+		assert.InDelta(t, 1500*time.Millisecond, venueerr.RetryAfter(err), float64(time.Second))
 	})
 
 	t.Run("validation error", func(t *testing.T) {
 		body := []byte(`{"message": "Invalid order", "code": "INVALID_ORDER"}`)
-		err := NormalizeError(400, body)
+		err := NormalizeError(&http.Response{StatusCode: 400}, body)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidOrder)
+		assert.False(t, venueerr.IsRetryable(err))
+	})
 
-		// Should be a permanent error (client error)
-		permErr, ok := err.(*PermanentError)
-		assert.True(t, ok, "expected PermanentError for validation error")
-		assert.NotNil(t, permErr)
+	t.Run("error codes", func(t *testing.T) {
+		tests := []struct {
+			code string
+			want error
+		}{
+			{code: "INSUFFICIENT_FUNDS", want: venueerr.ErrInsufficientFunds},
+			{code: "ORDER_NOT_FOUND", want: venueerr.ErrNotFound},
+			{code: "INVALID_PRODUCT", want: venueerr.ErrInvalidOrder},
+			{code: "INVALID_PORTFOLIO_ID", want: venueerr.ErrInvalidRequest},
+		}
+		for _, tt := range tests {
+			err := NormalizeError(&http.Response{StatusCode: 400}, []byte(`{"message": "rejected", "code": "`+tt.code+`"}`))
+			assert.ErrorIs(t, err, tt.want, tt.code)
+		}
+	})
+
+	t.Run("clock skew", func(t *testing.T) {
+		err := NormalizeError(&http.Response{StatusCode: 401}, []byte(`{"message": "token is not valid yet", "code": "UNAUTHORIZED"}`))
+
+		assert.ErrorIs(t, err, venueerr.ErrClockSkew)
+		assert.True(t, venueerr.IsRetryable(err))
 	})
 
 	t.Run("server error", func(t *testing.T) {
 		body := []byte(`{"message": "Internal server error", "code": "INTERNAL_ERROR"}`)
-		err := NormalizeError(500, body)
-
-		assert.Error(t, err)
+		err := NormalizeError(&http.Response{StatusCode: 500}, body)
 
-		// Should be a temporary error
-		tempErr, ok := err.(*TemporaryError)
-		assert.True(t, ok, "expected TemporaryError")
-		assert.NotNil(t, tempErr)
-		assert.True(t, tempErr.Temporary())
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+		assert.True(t, venueerr.IsRetryable(err))
 	})
 
 	t.Run("empty body", func(t *testing.T) {
-		err := NormalizeError(500, []byte{})
+		err := NormalizeError(&http.Response{StatusCode: 500}, []byte{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no body")
 	})
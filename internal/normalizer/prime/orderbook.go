@@ -67,21 +67,6 @@ func NormalizeOrderBook(ctx context.Context, raw []byte) (*marketsv1.OrderBook,
 		return nil, fmt.Errorf("failed to parse asks: %w", err)
 	}
 
-	// Calculate best bid, best ask, spread, mid price
-	var bestBid, bestAsk, spread, midPrice *float64
-	if len(bids) > 0 && bids[0].Price != nil {
-		bestBid = bids[0].Price
-	}
-	if len(asks) > 0 && asks[0].Price != nil {
-		bestAsk = asks[0].Price
-	}
-	if bestBid != nil && bestAsk != nil {
-		spreadVal := *bestAsk - *bestBid
-		spread = &spreadVal
-		midPriceVal := (*bestBid + *bestAsk) / 2.0
-		midPrice = &midPriceVal
-	}
-
 	// Build CQC OrderBook
 	venueId := "prime"
 	orderBook := &marketsv1.OrderBook{
@@ -90,11 +75,8 @@ func NormalizeOrderBook(ctx context.Context, raw []byte) (*marketsv1.OrderBook,
 		Timestamp:   timestamp,
 		Bids:        bids,
 		Asks:        asks,
-		BestBid:     bestBid,
-		BestAsk:     bestAsk,
-		Spread:      spread,
-		MidPrice:    midPrice,
 	}
+	normalizer.SetTopOfBook(orderBook)
 
 	// Add sequence number if available (useful for maintaining order book state)
 	if primeBook.Sequence > 0 {
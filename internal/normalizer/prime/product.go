@@ -0,0 +1,23 @@
+package prime
+
+// PrimeProduct represents a product (trading pair) from the Coinbase Prime
+// portfolio products endpoint. Increments and sizes are decimal strings.
+//
+// Prime reports no trading status; Permissions lists what the portfolio may
+// do with the product (e.g., "PRODUCT_PERMISSION_TRADE").
+//
+// Reference: https://docs.cdp.coinbase.com/prime/reference/primerestapi_getportfolioproducts
+type PrimeProduct struct {
+	ID             string   `json:"id"`
+	BaseIncrement  string   `json:"base_increment"`
+	QuoteIncrement string   `json:"quote_increment"`
+	PriceIncrement string   `json:"price_increment"`
+	BaseMinSize    string   `json:"base_min_size"`
+	BaseMaxSize    string   `json:"base_max_size"`
+	QuoteMinSize   string   `json:"quote_min_size"`
+	QuoteMaxSize   string   `json:"quote_max_size"`
+	Permissions    []string `json:"permissions"`
+}
+
+// ProductPermissionTrade is the permission required to place orders for a product.
+const ProductPermissionTrade = "PRODUCT_PERMISSION_TRADE"
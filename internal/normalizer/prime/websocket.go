@@ -0,0 +1,128 @@
+package prime
+
+import (
+	"encoding/json"
+	"fmt"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WebSocket channel names used by the Prime feed.
+const (
+	WSChannelOrders     = "orders"
+	WSChannelHeartbeats = "heartbeats"
+)
+
+// WebSocket event types.
+const (
+	WSEventSnapshot = "snapshot"
+	WSEventUpdate   = "update"
+)
+
+// PrimeWSMessage is the envelope of every Prime WebSocket message.
+//
+// Error messages carry Type "error" and a Message instead of a channel and events.
+//
+// Reference: https://docs.cdp.coinbase.com/prime/docs/websocket-feed
+type PrimeWSMessage struct {
+	Channel     string          `json:"channel"`
+	Timestamp   string          `json:"timestamp"`
+	SequenceNum int64           `json:"sequence_num"`
+	Events      json.RawMessage `json:"events"`
+	Type        string          `json:"type"`
+	Message     string          `json:"message"`
+}
+
+// PrimeOrdersEvent is a snapshot or update of portfolio orders from the
+// orders channel. The snapshot lists open orders at subscription time.
+type PrimeOrdersEvent struct {
+	Type   string             `json:"type"`
+	Orders []PrimeStreamOrder `json:"orders"`
+}
+
+// PrimeStreamOrder is the cumulative state of one order on the orders channel.
+type PrimeStreamOrder struct {
+	OrderID       string `json:"order_id"`
+	ClientOrderID string `json:"client_order_id"`
+	CumQty        string `json:"cum_qty"`
+	LeavesQty     string `json:"leaves_qty"`
+	AvgPx         string `json:"avg_px"`
+	Fees          string `json:"fees"`
+	Status        string `json:"status"` // "OPEN", "FILLED", "CANCELLED", "EXPIRED", "FAILED", "PENDING"
+	ProductID     string `json:"product_id"`
+	Side          string `json:"side"`
+	Type          string `json:"type"`
+}
+
+// ParseWSMessage parses a WebSocket message envelope.
+//
+// Returns an error if the message cannot be parsed or is an error message
+// sent by the venue (e.g., a rejected subscription).
+func ParseWSMessage(raw []byte) (*PrimeWSMessage, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty websocket message")
+	}
+
+	var msg PrimeWSMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse prime websocket message: %w", err)
+	}
+
+	if msg.Type == "error" {
+		return nil, fmt.Errorf("prime websocket error: %s", msg.Message)
+	}
+
+	return &msg, nil
+}
+
+// ParseOrdersEvents decodes the events of an orders channel message.
+func ParseOrdersEvents(msg *PrimeWSMessage) ([]PrimeOrdersEvent, error) {
+	var events []PrimeOrdersEvent
+	if err := json.Unmarshal(msg.Events, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse prime orders events: %w", err)
+	}
+	return events, nil
+}
+
+// NormalizeStreamOrder converts an orders channel order to a
+// normalizer.OrderUpdate for an OrderTracker, stamped with the message timestamp.
+//
+// The function handles:
+//   - Parsing cumulative quantity, leaves quantity, average price and fees
+//   - Mapping status, side and order type to CQC enums (EXPIRED is reported as
+//     ORDER_STATUS_EXPIRED and FAILED as ORDER_STATUS_FAILED so that expiries
+//     and failures are distinguishable from rejections)
+//
+// Missing or malformed decimals are treated as zero.
+// Returns an error if the order ID is missing.
+func NormalizeStreamOrder(order PrimeStreamOrder, timestamp *timestamppb.Timestamp) (normalizer.OrderUpdate, error) {
+	if order.OrderID == "" {
+		return normalizer.OrderUpdate{}, fmt.Errorf("prime stream order missing order_id")
+	}
+
+	var status venuesv1.OrderStatus
+	switch order.Status {
+	case "EXPIRED":
+		status = venuesv1.OrderStatus_ORDER_STATUS_EXPIRED
+	case "FAILED":
+		status = venuesv1.OrderStatus_ORDER_STATUS_FAILED
+	default:
+		status = mapOrderStatus(order.Status)
+	}
+
+	return normalizer.OrderUpdate{
+		OrderID:            order.OrderID,
+		ClientOrderID:      order.ClientOrderID,
+		VenueSymbol:        order.ProductID,
+		Side:               mapOrderSide(order.Side),
+		OrderType:          mapOrderType(order.Type),
+		Status:             status,
+		CumulativeQuantity: normalizer.ParseExactDecimalOrZero(order.CumQty),
+		RemainingQuantity:  normalizer.ParseExactDecimalOrZero(order.LeavesQty),
+		AverageFillPrice:   normalizer.ParseExactDecimalOrZero(order.AvgPx),
+		TotalFees:          normalizer.ParseExactDecimalOrZero(order.Fees),
+		Timestamp:          timestamp,
+	}, nil
+}
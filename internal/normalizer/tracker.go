@@ -0,0 +1,204 @@
+package normalizer
+
+import (
+	"fmt"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OrderUpdate is the cumulative state of one order as pushed by a private
+// order stream (e.g., the Coinbase user channel or the Prime orders channel).
+//
+// These streams report running totals rather than individual fills; an
+// OrderTracker turns successive updates into ExecutionReports. Amounts are
+// exact decimals so that fills derived from differences of running totals do
+// not accumulate rounding error.
+type OrderUpdate struct {
+	OrderID            string
+	ClientOrderID      string
+	VenueSymbol        string
+	Side               venuesv1.OrderSide
+	OrderType          venuesv1.OrderType
+	Status             venuesv1.OrderStatus
+	CumulativeQuantity Decimal
+	RemainingQuantity  Decimal
+	AverageFillPrice   Decimal
+	TotalFees          Decimal
+	Timestamp          *timestamppb.Timestamp
+}
+
+// fillPricePlaces is the number of decimal places kept when deriving a fill
+// price from the change in average fill price.
+const fillPricePlaces = 18
+
+// OrderTracker derives ExecutionReports from successive OrderUpdates by
+// diffing each update against the last state seen for the order.
+//
+// Orders are forgotten once they reach a terminal status (filled, cancelled,
+// expired, rejected or failed).
+//
+// Not safe for concurrent use; each subscription owns its own tracker.
+type OrderTracker struct {
+	orders map[string]OrderUpdate
+	seeded bool // a snapshot has been applied
+}
+
+// NewOrderTracker creates an empty OrderTracker.
+func NewOrderTracker() *OrderTracker {
+	return &OrderTracker{orders: make(map[string]OrderUpdate)}
+}
+
+// Seed records the state of an order without reporting it. Use it for the
+// snapshot a stream sends on first connect, which describes orders placed
+// before the subscription started.
+func (t *OrderTracker) Seed(update OrderUpdate) {
+	t.record(update)
+}
+
+// Snapshot applies the order snapshot a stream sends on every (re)connect and
+// returns the ExecutionReports it implies. The first snapshot describes orders
+// placed before the subscription started and is seeded without being
+// reported. Later snapshots follow a reconnect and are diffed like updates, so
+// fills and new orders missed while disconnected are reported; orders that
+// completed while disconnected are not in the snapshot and go unreported.
+func (t *OrderTracker) Snapshot(updates []OrderUpdate) []*venuesv1.ExecutionReport {
+	if !t.seeded {
+		t.seeded = true
+		for _, update := range updates {
+			t.Seed(update)
+		}
+		return nil
+	}
+
+	var reports []*venuesv1.ExecutionReport
+	for _, update := range updates {
+		reports = append(reports, t.Reports(update)...)
+	}
+	return reports
+}
+
+// Len returns the number of orders being tracked.
+func (t *OrderTracker) Len() int {
+	return len(t.orders)
+}
+
+// Reports records an update and returns the ExecutionReports it implies:
+//   - an increase in cumulative quantity -> EXECUTION_TYPE_PARTIAL_FILL, or
+//     EXECUTION_TYPE_FILL once the order is filled, for the quantity filled
+//     since the last update. The fill price and fee are derived from the
+//     change in average price and total fees.
+//   - a previously unseen open or pending order -> EXECUTION_TYPE_NEW
+//   - a change to CANCELLED, EXPIRED, or REJECTED/FAILED -> the matching
+//     execution type, after any fill reported by the same update
+//
+// Updates that change nothing return no reports. Execution IDs are derived
+// from the order ID and cumulative quantity or status, so a report replayed
+// after a reconnect has the same ID as the original.
+func (t *OrderTracker) Reports(update OrderUpdate) []*venuesv1.ExecutionReport {
+	prev, seen := t.orders[update.OrderID]
+	if update.Status == venuesv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		update.Status = prev.Status
+	}
+	if update.Status == venuesv1.OrderStatus_ORDER_STATUS_OPEN && update.CumulativeQuantity.Sign() > 0 {
+		update.Status = venuesv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED
+	}
+
+	var reports []*venuesv1.ExecutionReport
+
+	if update.CumulativeQuantity.Cmp(prev.CumulativeQuantity) > 0 {
+		executionType := venuesv1.ExecutionType_EXECUTION_TYPE_PARTIAL_FILL
+		if update.Status == venuesv1.OrderStatus_ORDER_STATUS_FILLED {
+			executionType = venuesv1.ExecutionType_EXECUTION_TYPE_FILL
+		}
+
+		quantity := update.CumulativeQuantity.Sub(prev.CumulativeQuantity)
+		notional := update.AverageFillPrice.Mul(update.CumulativeQuantity).Sub(prev.AverageFillPrice.Mul(prev.CumulativeQuantity))
+		price := notional.Quo(quantity, fillPricePlaces)
+		fee := update.TotalFees.Sub(prev.TotalFees)
+
+		report := update.report(executionType, fmt.Sprintf("%s:fill:%s", update.OrderID, update.CumulativeQuantity.PlainString()))
+		report.Price = Float64Ptr(price.Float64())
+		report.Quantity = Float64Ptr(quantity.Float64())
+		report.Fee = Float64Ptr(fee.Float64())
+		report.Value = Float64Ptr(notional.Float64())
+		reports = append(reports, report)
+	}
+
+	if !seen || update.Status != prev.Status {
+		var executionType venuesv1.ExecutionType
+		switch update.Status {
+		case venuesv1.OrderStatus_ORDER_STATUS_PENDING,
+			venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED,
+			venuesv1.OrderStatus_ORDER_STATUS_OPEN:
+			if !seen {
+				executionType = venuesv1.ExecutionType_EXECUTION_TYPE_NEW
+			}
+		case venuesv1.OrderStatus_ORDER_STATUS_CANCELLED:
+			executionType = venuesv1.ExecutionType_EXECUTION_TYPE_CANCELLED
+		case venuesv1.OrderStatus_ORDER_STATUS_EXPIRED:
+			executionType = venuesv1.ExecutionType_EXECUTION_TYPE_EXPIRED
+		case venuesv1.OrderStatus_ORDER_STATUS_REJECTED,
+			venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+			executionType = venuesv1.ExecutionType_EXECUTION_TYPE_REJECTED
+		}
+
+		if executionType != venuesv1.ExecutionType_EXECUTION_TYPE_UNSPECIFIED {
+			name := strings.ToLower(strings.TrimPrefix(executionType.String(), "EXECUTION_TYPE_"))
+			reports = append(reports, update.report(executionType, update.OrderID+":"+name))
+		}
+	}
+
+	t.record(update)
+	return reports
+}
+
+// record stores the latest state of an order, dropping it once terminal.
+func (t *OrderTracker) record(update OrderUpdate) {
+	switch update.Status {
+	case venuesv1.OrderStatus_ORDER_STATUS_FILLED,
+		venuesv1.OrderStatus_ORDER_STATUS_CANCELLED,
+		venuesv1.OrderStatus_ORDER_STATUS_EXPIRED,
+		venuesv1.OrderStatus_ORDER_STATUS_REJECTED,
+		venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+		delete(t.orders, update.OrderID)
+	default:
+		t.orders[update.OrderID] = update
+	}
+}
+
+// report builds an ExecutionReport carrying the order's cumulative state.
+// Status, side and type use the CQC enum names without their prefix.
+func (u OrderUpdate) report(executionType venuesv1.ExecutionType, executionId string) *venuesv1.ExecutionReport {
+	orderStatus := strings.TrimPrefix(u.Status.String(), "ORDER_STATUS_")
+	side := strings.TrimPrefix(u.Side.String(), "ORDER_SIDE_")
+	orderType := strings.TrimPrefix(u.OrderType.String(), "ORDER_TYPE_")
+	timestamp := u.Timestamp
+	if timestamp == nil {
+		timestamp = timestamppb.Now()
+	}
+	cumulative := u.CumulativeQuantity.Float64()
+	remaining := u.RemainingQuantity.Float64()
+	average := u.AverageFillPrice.Float64()
+
+	report := &venuesv1.ExecutionReport{
+		ExecutionId:        &executionId,
+		OrderId:            &u.OrderID,
+		VenueOrderId:       &u.OrderID,
+		VenueSymbol:        &u.VenueSymbol,
+		ExecutionType:      &executionType,
+		OrderStatus:        &orderStatus,
+		Side:               &side,
+		OrderType:          &orderType,
+		Timestamp:          timestamp,
+		CumulativeQuantity: &cumulative,
+		RemainingQuantity:  &remaining,
+		AverageFillPrice:   &average,
+	}
+	if u.ClientOrderID != "" {
+		clientOrderId := u.ClientOrderID
+		report.ClientOrderId = &clientOrderId
+	}
+	return report
+}
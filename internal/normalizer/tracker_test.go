@@ -0,0 +1,127 @@
+package normalizer
+
+import (
+	"testing"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trackedOrder(status venuesv1.OrderStatus, cumulative, remaining, average, fees string) OrderUpdate {
+	return OrderUpdate{
+		OrderID:            "order-1",
+		ClientOrderID:      "client-1",
+		VenueSymbol:        "BTC-USD",
+		Side:               venuesv1.OrderSide_ORDER_SIDE_BUY,
+		OrderType:          venuesv1.OrderType_ORDER_TYPE_LIMIT,
+		Status:             status,
+		CumulativeQuantity: MustParseExactDecimal(cumulative),
+		RemainingQuantity:  MustParseExactDecimal(remaining),
+		AverageFillPrice:   MustParseExactDecimal(average),
+		TotalFees:          MustParseExactDecimal(fees),
+	}
+}
+
+// TestOrderTracker tests deriving execution reports from cumulative order updates.
+func TestOrderTracker(t *testing.T) {
+	t.Run("order lifecycle", func(t *testing.T) {
+		tracker := NewOrderTracker()
+
+		reports := tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0"))
+		require.Len(t, reports, 1)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, reports[0].GetExecutionType())
+		assert.Equal(t, "order-1:new", reports[0].GetExecutionId())
+		assert.Equal(t, "OPEN", reports[0].GetOrderStatus())
+		assert.Equal(t, "BUY", reports[0].GetSide())
+		assert.Equal(t, "LIMIT", reports[0].GetOrderType())
+		assert.Equal(t, "client-1", reports[0].GetClientOrderId())
+
+		assert.Empty(t, tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0")), "unchanged update")
+
+		reports = tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0.4", "0.6", "100", "0.4"))
+		require.Len(t, reports, 1)
+		fill := reports[0]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_PARTIAL_FILL, fill.GetExecutionType())
+		assert.Equal(t, "PARTIALLY_FILLED", fill.GetOrderStatus())
+		assert.Equal(t, "order-1:fill:0.4", fill.GetExecutionId())
+		assert.Equal(t, 0.4, fill.GetQuantity())
+		assert.Equal(t, 100.0, fill.GetPrice())
+		assert.Equal(t, 0.4, fill.GetFee())
+		assert.Equal(t, 0.6, fill.GetRemainingQuantity())
+
+		reports = tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_FILLED, "1", "0", "106", "1"))
+		require.Len(t, reports, 1)
+		fill = reports[0]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, fill.GetExecutionType())
+		assert.Equal(t, "FILLED", fill.GetOrderStatus())
+		assert.Equal(t, 0.6, fill.GetQuantity())
+		assert.Equal(t, 110.0, fill.GetPrice(), "price is derived from the change in average price")
+		assert.Equal(t, 0.6, fill.GetFee())
+		assert.Equal(t, 66.0, fill.GetValue())
+		assert.Zero(t, tracker.Len(), "terminal orders are forgotten")
+	})
+
+	t.Run("fills are exact differences of running totals", func(t *testing.T) {
+		tracker := NewOrderTracker()
+		tracker.Seed(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0.1", "0.9", "100", "0.1"))
+
+		// In float64, 0.3 - 0.1 is 0.19999999999999998
+		reports := tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0.30", "0.7", "100", "0.3"))
+		require.Len(t, reports, 1)
+		assert.Equal(t, 0.2, reports[0].GetQuantity())
+		assert.Equal(t, 0.2, reports[0].GetFee())
+		assert.Equal(t, "order-1:fill:0.3", reports[0].GetExecutionId(), "IDs use the plain form of the running total")
+	})
+
+	t.Run("fill and cancel in one update", func(t *testing.T) {
+		tracker := NewOrderTracker()
+		tracker.Seed(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0"))
+
+		reports := tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, "0.5", "0", "100", "0"))
+		require.Len(t, reports, 2)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_PARTIAL_FILL, reports[0].GetExecutionType())
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_CANCELLED, reports[1].GetExecutionType())
+		assert.Equal(t, "order-1:cancelled", reports[1].GetExecutionId())
+	})
+
+	t.Run("seeded orders are not reported as new", func(t *testing.T) {
+		tracker := NewOrderTracker()
+		tracker.Seed(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0"))
+		assert.Equal(t, 1, tracker.Len())
+		assert.Empty(t, tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0")))
+	})
+
+	t.Run("first snapshot is seeded and later snapshots are diffed", func(t *testing.T) {
+		tracker := NewOrderTracker()
+
+		assert.Empty(t, tracker.Snapshot([]OrderUpdate{trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0")}))
+		assert.Equal(t, 1, tracker.Len())
+
+		reports := tracker.Snapshot([]OrderUpdate{trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0.5", "0.5", "100", "0.5")})
+		require.Len(t, reports, 1)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_PARTIAL_FILL, reports[0].GetExecutionType())
+		assert.Equal(t, 0.5, reports[0].GetQuantity())
+	})
+
+	t.Run("rejected and expired", func(t *testing.T) {
+		tracker := NewOrderTracker()
+
+		reports := tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_FAILED, "0", "0", "0", "0"))
+		require.Len(t, reports, 1)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_REJECTED, reports[0].GetExecutionType())
+
+		tracker.Seed(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0"))
+		reports = tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_EXPIRED, "0", "1", "0", "0"))
+		require.Len(t, reports, 1)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_EXPIRED, reports[0].GetExecutionType())
+	})
+
+	t.Run("unspecified status keeps the previous status", func(t *testing.T) {
+		tracker := NewOrderTracker()
+		tracker.Seed(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_OPEN, "0", "1", "0", "0"))
+
+		assert.Empty(t, tracker.Reports(trackedOrder(venuesv1.OrderStatus_ORDER_STATUS_UNSPECIFIED, "0", "1", "0", "0")))
+		assert.Equal(t, 1, tracker.Len())
+	})
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultBalancePollInterval is the delay between polls used by BalancePoller
+// when no positive Interval is set.
+const DefaultBalancePollInterval = 5 * time.Second
+
+// BalancePoller implements SubscribeBalances for venues without a private
+// balance stream by polling balances and reporting only what changed.
+//
+// Balances are keyed by account, asset and balance type. A balance is
+// reported when it first appears or when any of its amounts (total,
+// available, locked, reserved, borrowed, interest) change. A balance that
+// disappears from a poll is reported once with all amounts set to zero.
+type BalancePoller struct {
+	// Fetch returns the current balance of every asset.
+	Fetch func(ctx context.Context) ([]*venuesv1.Balance, error)
+
+	// Interval is the delay between polls (default: DefaultBalancePollInterval).
+	Interval time.Duration
+
+	// Refresh, if set, triggers an immediate poll each time it receives.
+	// Venues use it to re-poll as soon as an order event suggests balances moved.
+	Refresh <-chan struct{}
+
+	// Executions, if set, subscribes to the venue's execution reports for as
+	// long as Run polls, and each report triggers an immediate poll. Venues
+	// without a balance stream use it so that fills and order holds show up
+	// within one round trip. If the subscription fails, Run stops polling and
+	// returns its error.
+	Executions func(ctx context.Context, handler ExecutionHandler) error
+
+	// OnError, if set, is called with each failed poll. Failed polls are
+	// otherwise ignored and retried at the next interval.
+	OnError func(err error)
+}
+
+// Run polls until ctx is cancelled (returning ctx.Err()), handler returns an
+// error (returning that error) or the Executions subscription fails
+// (returning its error).
+//
+// The first successful poll reports every balance, giving the caller a
+// baseline; later polls report only changes.
+func (p *BalancePoller) Run(ctx context.Context, handler BalanceHandler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+	if p.Fetch == nil {
+		return fmt.Errorf("balance fetch function is required")
+	}
+
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultBalancePollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// executed receives a signal per execution report; executionsDone is
+	// closed when the subscription ends, which it only does on failure or
+	// once Run returns and cancels it.
+	var executed chan struct{}
+	var executionsDone chan struct{}
+	var executionsErr error
+	if p.Executions != nil {
+		ctx, cancel := context.WithCancel(ctx)
+		executed = make(chan struct{}, 1)
+		executionsDone = make(chan struct{})
+		go func() {
+			defer close(executionsDone)
+			executionsErr = p.Executions(ctx, func(*venuesv1.ExecutionReport) error {
+				select {
+				case executed <- struct{}{}:
+				default:
+				}
+				return nil
+			})
+		}()
+		defer func() {
+			cancel()
+			<-executionsDone
+		}()
+	}
+
+	var last map[string]*venuesv1.Balance
+	for {
+		balances, err := p.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if p.OnError != nil {
+				p.OnError(err)
+			}
+		} else {
+			var changes []*venuesv1.Balance
+			changes, last = balanceChanges(last, balances)
+			for _, change := range changes {
+				if err := handler(change); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-p.Refresh:
+		case <-executed:
+		case <-executionsDone:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if executionsErr == nil {
+				return fmt.Errorf("execution subscription ended")
+			}
+			return fmt.Errorf("execution subscription failed: %w", executionsErr)
+		}
+	}
+}
+
+// balanceChanges diffs balances against the previous poll, keyed by
+// balanceKey. It returns the new or changed balances in input order, followed
+// by a zeroed copy of each previous balance that is no longer reported, and
+// the keyed balances to diff the next poll against.
+func balanceChanges(previous map[string]*venuesv1.Balance, balances []*venuesv1.Balance) ([]*venuesv1.Balance, map[string]*venuesv1.Balance) {
+	current := make(map[string]*venuesv1.Balance, len(balances))
+	var changes []*venuesv1.Balance
+	for _, balance := range balances {
+		key := balanceKey(balance)
+		current[key] = balance
+		if prev, ok := previous[key]; !ok || !sameAmounts(prev, balance) {
+			changes = append(changes, balance)
+		}
+	}
+
+	var removed []string
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	slices.Sort(removed)
+	for _, key := range removed {
+		gone := proto.Clone(previous[key]).(*venuesv1.Balance)
+		var zero float64
+		gone.Total = &zero
+		gone.Available = &zero
+		gone.Locked = &zero
+		gone.Reserved = nil
+		gone.Borrowed = nil
+		gone.Interest = nil
+		gone.UsdValue = nil
+		changes = append(changes, gone)
+	}
+	return changes, current
+}
+
+// balanceKey identifies a balance by account, asset and balance type.
+func balanceKey(balance *venuesv1.Balance) string {
+	return balance.GetAccountId() + "/" + balance.GetAssetId() + "/" + balance.GetBalanceType().String()
+}
+
+// sameAmounts reports whether two balances hold the same amounts.
+func sameAmounts(a, b *venuesv1.Balance) bool {
+	return a.GetTotal() == b.GetTotal() &&
+		a.GetAvailable() == b.GetAvailable() &&
+		a.GetLocked() == b.GetLocked() &&
+		a.GetReserved() == b.GetReserved() &&
+		a.GetBorrowed() == b.GetBorrowed() &&
+		a.GetInterest() == b.GetInterest()
+}
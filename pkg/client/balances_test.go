@@ -0,0 +1,194 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBalance(asset string, total, available float64) *venuesv1.Balance {
+	account := "account-1"
+	locked := total - available
+	return &venuesv1.Balance{
+		AccountId: &account,
+		AssetId:   &asset,
+		Total:     &total,
+		Available: &available,
+		Locked:    &locked,
+	}
+}
+
+func TestBalancePoller(t *testing.T) {
+	t.Run("reports baseline then changes", func(t *testing.T) {
+		polls := [][]*venuesv1.Balance{
+			{testBalance("BTC", 2, 1.5), testBalance("USD", 1000, 1000)},
+			{testBalance("BTC", 2, 1.5), testBalance("USD", 1000, 1000)},
+			{testBalance("BTC", 2, 2), testBalance("USD", 900, 900), testBalance("ETH", 1, 1)},
+			{testBalance("BTC", 2, 2), testBalance("ETH", 1, 1)},
+		}
+		var calls atomic.Int32
+		poller := &client.BalancePoller{
+			Fetch: func(ctx context.Context) ([]*venuesv1.Balance, error) {
+				n := int(calls.Add(1)) - 1
+				if n >= len(polls) {
+					n = len(polls) - 1
+				}
+				return polls[n], nil
+			},
+			Interval: time.Millisecond,
+		}
+
+		type change struct {
+			asset            string
+			total, available float64
+		}
+		var changes []change
+		errDone := errors.New("done")
+		err := poller.Run(context.Background(), func(balance *venuesv1.Balance) error {
+			changes = append(changes, change{balance.GetAssetId(), balance.GetTotal(), balance.GetAvailable()})
+			if len(changes) == 6 {
+				return errDone
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errDone)
+
+		assert.Equal(t, []change{
+			{"BTC", 2, 1.5}, {"USD", 1000, 1000}, // baseline
+			{"BTC", 2, 2}, {"USD", 900, 900}, {"ETH", 1, 1}, // changed and new
+			{"USD", 0, 0}, // no longer reported
+		}, changes)
+	})
+
+	t.Run("refresh polls immediately", func(t *testing.T) {
+		refresh := make(chan struct{}, 1)
+		var calls atomic.Int32
+		poller := &client.BalancePoller{
+			Fetch: func(ctx context.Context) ([]*venuesv1.Balance, error) {
+				n := calls.Add(1)
+				return []*venuesv1.Balance{testBalance("USD", float64(n), float64(n))}, nil
+			},
+			Interval: time.Hour,
+			Refresh:  refresh,
+		}
+
+		var totals []float64
+		errDone := errors.New("done")
+		err := poller.Run(context.Background(), func(balance *venuesv1.Balance) error {
+			totals = append(totals, balance.GetTotal())
+			if len(totals) == 2 {
+				return errDone
+			}
+			refresh <- struct{}{}
+			return nil
+		})
+		require.ErrorIs(t, err, errDone)
+		assert.Equal(t, []float64{1, 2}, totals)
+	})
+
+	t.Run("execution reports poll immediately", func(t *testing.T) {
+		reports := make(chan *venuesv1.ExecutionReport)
+		var calls atomic.Int32
+		poller := &client.BalancePoller{
+			Fetch: func(ctx context.Context) ([]*venuesv1.Balance, error) {
+				n := calls.Add(1)
+				return []*venuesv1.Balance{testBalance("USD", float64(n), float64(n))}, nil
+			},
+			Interval: time.Hour,
+			Executions: func(ctx context.Context, handler client.ExecutionHandler) error {
+				for {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case report := <-reports:
+						if err := handler(report); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		}
+
+		var totals []float64
+		errDone := errors.New("done")
+		err := poller.Run(context.Background(), func(balance *venuesv1.Balance) error {
+			totals = append(totals, balance.GetTotal())
+			if len(totals) == 2 {
+				return errDone
+			}
+			go func() { reports <- &venuesv1.ExecutionReport{} }()
+			return nil
+		})
+		require.ErrorIs(t, err, errDone)
+		assert.Equal(t, []float64{1, 2}, totals)
+	})
+
+	t.Run("execution subscription failure stops polling", func(t *testing.T) {
+		unauthorized := errors.New("unauthorized")
+		poller := &client.BalancePoller{
+			Fetch: func(ctx context.Context) ([]*venuesv1.Balance, error) {
+				return []*venuesv1.Balance{testBalance("USD", 1, 1)}, nil
+			},
+			Interval: time.Hour,
+			Executions: func(ctx context.Context, handler client.ExecutionHandler) error {
+				return unauthorized
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := poller.Run(ctx, func(*venuesv1.Balance) error { return nil })
+		assert.ErrorIs(t, err, unauthorized)
+	})
+
+	t.Run("failed polls are reported and retried", func(t *testing.T) {
+		unavailable := errors.New("unavailable")
+		var calls atomic.Int32
+		var failures []error
+		poller := &client.BalancePoller{
+			Fetch: func(ctx context.Context) ([]*venuesv1.Balance, error) {
+				if calls.Add(1) == 1 {
+					return nil, unavailable
+				}
+				return []*venuesv1.Balance{testBalance("USD", 1, 1)}, nil
+			},
+			Interval: time.Millisecond,
+			OnError:  func(err error) { failures = append(failures, err) },
+		}
+
+		errDone := errors.New("done")
+		err := poller.Run(context.Background(), func(balance *venuesv1.Balance) error { return errDone })
+		require.ErrorIs(t, err, errDone)
+		assert.Equal(t, []error{unavailable}, failures)
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		poller := &client.BalancePoller{
+			Fetch: func(ctx context.Context) ([]*venuesv1.Balance, error) {
+				return []*venuesv1.Balance{testBalance("USD", 1, 1)}, nil
+			},
+			Interval: time.Millisecond,
+		}
+		err := poller.Run(ctx, func(balance *venuesv1.Balance) error { return nil })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		poller := &client.BalancePoller{}
+		assert.Error(t, poller.Run(context.Background(), func(*venuesv1.Balance) error { return nil }))
+
+		poller.Fetch = func(ctx context.Context) ([]*venuesv1.Balance, error) { return nil, nil }
+		assert.Error(t, poller.Run(context.Background(), nil))
+	})
+}
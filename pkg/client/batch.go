@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+)
+
+// DefaultBatchConcurrency is the number of concurrent single-order calls used
+// by PlaceOrdersConcurrently and CancelOrdersConcurrently when no positive
+// concurrency is given.
+const DefaultBatchConcurrency = 5
+
+// PlaceOrdersConcurrently implements PlaceOrders for venues without a batch
+// order endpoint by calling place once per order, with at most concurrency
+// calls in flight.
+//
+// Results are returned in input order. Orders not yet started when ctx is
+// done are not submitted and report ctx.Err().
+func PlaceOrdersConcurrently(ctx context.Context, orders []*venuesv1.Order, concurrency int, place func(context.Context, *venuesv1.Order) (*venuesv1.ExecutionReport, error)) []PlaceOrderResult {
+	results := make([]PlaceOrderResult, len(orders))
+	forEachConcurrently(ctx, len(orders), concurrency, func(i int, ctxErr error) {
+		results[i].Order = orders[i]
+		if ctxErr != nil {
+			results[i].Err = ctxErr
+			return
+		}
+		results[i].Report, results[i].Err = place(ctx, orders[i])
+	})
+	return results
+}
+
+// CancelOrdersConcurrently implements CancelOrders for venues without a batch
+// cancel endpoint by calling cancel once per order ID, with at most
+// concurrency calls in flight.
+//
+// Results are returned in input order. Orders not yet started when ctx is
+// done are not cancelled and report ctx.Err().
+func CancelOrdersConcurrently(ctx context.Context, orderIDs []string, concurrency int, cancel func(context.Context, string) (*venuesv1.OrderStatus, error)) []CancelOrderResult {
+	results := make([]CancelOrderResult, len(orderIDs))
+	forEachConcurrently(ctx, len(orderIDs), concurrency, func(i int, ctxErr error) {
+		results[i].OrderID = orderIDs[i]
+		if ctxErr != nil {
+			results[i].Err = ctxErr
+			return
+		}
+		results[i].Status, results[i].Err = cancel(ctx, orderIDs[i])
+	})
+	return results
+}
+
+// OrderIDs returns the venue order IDs of orders, skipping orders without one.
+// It is used by CancelAllOrders implementations to cancel the orders matched
+// by a filter.
+func OrderIDs(orders []*venuesv1.Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		id := order.GetVenueOrderId()
+		if id == "" {
+			id = order.GetOrderId()
+		}
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// forEachConcurrently calls fn for each index in [0, n) with at most
+// concurrency calls running at once, and waits for all of them. Once ctx is
+// done, remaining indexes are passed ctx.Err() instead of being run.
+func forEachConcurrently(ctx context.Context, n, concurrency int, fn func(i int, ctxErr error)) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fn(i, ctx.Err())
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			fn(i, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i, nil)
+		}(i)
+	}
+	wg.Wait()
+}
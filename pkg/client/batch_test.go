@@ -0,0 +1,109 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrdersConcurrently(t *testing.T) {
+	orders := make([]*venuesv1.Order, 10)
+	for i := range orders {
+		id := fmt.Sprintf("order-%d", i)
+		orders[i] = &venuesv1.Order{ClientOrderId: &id}
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	rejected := errors.New("rejected")
+	results := client.PlaceOrdersConcurrently(context.Background(), orders, 3, func(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if order.GetClientOrderId() == "order-4" {
+			return nil, rejected
+		}
+		return &venuesv1.ExecutionReport{ClientOrderId: order.ClientOrderId}, nil
+	})
+
+	require.Len(t, results, len(orders))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	for i, result := range results {
+		assert.Same(t, orders[i], result.Order)
+		if i == 4 {
+			assert.ErrorIs(t, result.Err, rejected)
+			assert.Nil(t, result.Report)
+			continue
+		}
+		require.NoError(t, result.Err)
+		assert.Equal(t, orders[i].GetClientOrderId(), result.Report.GetClientOrderId())
+	}
+}
+
+func TestCancelOrdersConcurrently(t *testing.T) {
+	t.Run("results in input order", func(t *testing.T) {
+		ids := []string{"a", "b", "c"}
+		results := client.CancelOrdersConcurrently(context.Background(), ids, 0, func(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error) {
+			if orderID == "b" {
+				return nil, errors.New("already filled")
+			}
+			return venuesv1.OrderStatus_ORDER_STATUS_CANCELLED.Enum(), nil
+		})
+
+		require.Len(t, results, 3)
+		for i, result := range results {
+			assert.Equal(t, ids[i], result.OrderID)
+		}
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *results[0].Status)
+		assert.EqualError(t, results[1].Err, "already filled")
+		assert.NoError(t, results[2].Err)
+	})
+
+	t.Run("cancelled context skips remaining orders", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		results := client.CancelOrdersConcurrently(ctx, []string{"a", "b", "c", "d"}, 1, func(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error) {
+			calls.Add(1)
+			cancel()
+			return venuesv1.OrderStatus_ORDER_STATUS_CANCELLED.Enum(), nil
+		})
+
+		require.Len(t, results, 4)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.NoError(t, results[0].Err)
+		for _, result := range results[1:] {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		results := client.CancelOrdersConcurrently(context.Background(), nil, 0, nil)
+		assert.Empty(t, results)
+	})
+}
+
+func TestOrderIDs(t *testing.T) {
+	venueID := "venue-1"
+	orderID := "order-2"
+	orders := []*venuesv1.Order{
+		{VenueOrderId: &venueID, OrderId: &orderID},
+		{OrderId: &orderID},
+		{},
+	}
+
+	assert.Equal(t, []string{"venue-1", "order-2"}, client.OrderIDs(orders))
+}
@@ -42,6 +42,34 @@ type VenueClient interface {
 	// If the order is already filled or cancelled, may return an error.
 	CancelOrder(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error)
 
+	// AmendOrder modifies an open order in place, preserving queue priority
+	// where the venue allows it and avoiding a cancel-and-replace round trip.
+	// Only the non-nil fields of changes are modified.
+	// Returns a fresh execution report (EXECUTION_TYPE_REPLACED) reflecting the
+	// amended order. Venues without native amendment, or without support for a
+	// specific field, return an *UnsupportedError wrapping ErrUnsupported.
+	AmendOrder(ctx context.Context, orderID string, changes OrderChanges) (*venuesv1.ExecutionReport, error)
+
+	// PlaceOrders submits several orders in one call, atomically where the
+	// venue offers a batch endpoint. Venues without one fall back to bounded
+	// concurrent PlaceOrder calls.
+	// Returns one result per order, in input order; a per-order failure is
+	// reported in its result rather than as the returned error, which is
+	// reserved for failures of the call as a whole.
+	PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]PlaceOrderResult, error)
+
+	// CancelOrders cancels several orders by ID in one call, using the venue's
+	// batch cancel endpoint where available and bounded concurrent CancelOrder
+	// calls otherwise.
+	// Returns one result per order ID, in input order.
+	CancelOrders(ctx context.Context, orderIDs []string) ([]CancelOrderResult, error)
+
+	// CancelAllOrders cancels every resting order matching the filter, for
+	// example all open orders for a symbol. If the filter sets no statuses,
+	// open orders are targeted.
+	// Returns one result per order found; an empty slice means nothing matched.
+	CancelAllOrders(ctx context.Context, filter OrderFilter) ([]CancelOrderResult, error)
+
 	// GetOrder retrieves the current state of a specific order by ID.
 	// Returns the complete order details including fills and status.
 	GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error)
@@ -51,6 +79,12 @@ type VenueClient interface {
 	// Returns a slice of orders matching the filter.
 	GetOrders(ctx context.Context, filter OrderFilter) ([]*venuesv1.Order, error)
 
+	// GetFills retrieves one page of fills (trade executions) matching the
+	// filter, each with its fee amount and asset, liquidity flag and venue
+	// trade ID. Use FillPage.NextCursor with the same filter to page through
+	// results.
+	GetFills(ctx context.Context, filter FillFilter) (*FillPage, error)
+
 	// Account Operations
 
 	// GetBalance retrieves the current account balance.
@@ -63,6 +97,19 @@ type VenueClient interface {
 	// Returns bids and asks with price and quantity information.
 	GetOrderBook(ctx context.Context, symbol string) (*marketsv1.OrderBook, error)
 
+	// GetInstruments retrieves the trading rules of every product listed by
+	// the venue: tick size, quantity increment, size and notional limits, and
+	// trading status.
+	// Results are cached and refreshed on an interval (see InstrumentCache),
+	// so repeated calls do not hit the venue.
+	GetInstruments(ctx context.Context) ([]*Instrument, error)
+
+	// GetInstrument retrieves the trading rules of one product from the same
+	// cache as GetInstruments.
+	// Returns an error wrapping ErrInstrumentNotFound if the venue does not
+	// list the symbol.
+	GetInstrument(ctx context.Context, symbol string) (*Instrument, error)
+
 	// Streaming Operations
 
 	// SubscribeOrderBook establishes a streaming subscription to order book updates.
@@ -81,6 +128,26 @@ type VenueClient interface {
 	// indicating unsupported operation.
 	SubscribeTrades(ctx context.Context, symbol string, handler TradeHandler) error
 
+	// SubscribeExecutions establishes a streaming subscription to execution
+	// reports for the caller's orders: acknowledgements (EXECUTION_TYPE_NEW),
+	// fills, cancels, rejections and expiries across all symbols.
+	// The subscription remains active until the context is cancelled or an error occurs.
+	//
+	// Note: Only venues with a private order stream support this (Coinbase, Prime).
+	// Others return an error indicating unsupported operation.
+	SubscribeExecutions(ctx context.Context, handler ExecutionHandler) error
+
+	// SubscribeBalances establishes a subscription to balance changes across
+	// all assets. The current balances are delivered first, then each balance
+	// whose amounts change. A balance that is no longer reported is delivered
+	// once with zero amounts.
+	// The subscription remains active until the context is cancelled or an error occurs.
+	//
+	// Note: No supported venue pushes balances. Coinbase and Prime re-poll
+	// balances as soon as their private order stream reports activity; other
+	// venues poll on an interval (see BalancePoller).
+	SubscribeBalances(ctx context.Context, handler BalanceHandler) error
+
 	// Health Operations
 
 	// Health performs a health check on the venue connection.
@@ -23,6 +23,22 @@ func (m *mockVenueClient) CancelOrder(ctx context.Context, orderID string) (*ven
 	return nil, nil
 }
 
+func (m *mockVenueClient) AmendOrder(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+	return nil, nil
+}
+
+func (m *mockVenueClient) PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error) {
+	return nil, nil
+}
+
+func (m *mockVenueClient) CancelOrders(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	return nil, nil
+}
+
+func (m *mockVenueClient) CancelAllOrders(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error) {
+	return nil, nil
+}
+
 func (m *mockVenueClient) GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error) {
 	return nil, nil
 }
@@ -31,6 +47,10 @@ func (m *mockVenueClient) GetOrders(ctx context.Context, filter client.OrderFilt
 	return nil, nil
 }
 
+func (m *mockVenueClient) GetFills(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+	return nil, nil
+}
+
 func (m *mockVenueClient) GetBalance(ctx context.Context) (*venuesv1.Balance, error) {
 	return nil, nil
 }
@@ -39,6 +59,14 @@ func (m *mockVenueClient) GetOrderBook(ctx context.Context, symbol string) (*mar
 	return nil, nil
 }
 
+func (m *mockVenueClient) GetInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	return nil, nil
+}
+
+func (m *mockVenueClient) GetInstrument(ctx context.Context, symbol string) (*client.Instrument, error) {
+	return nil, nil
+}
+
 func (m *mockVenueClient) SubscribeOrderBook(ctx context.Context, symbol string, handler client.OrderBookHandler) error {
 	return nil
 }
@@ -47,6 +75,14 @@ func (m *mockVenueClient) SubscribeTrades(ctx context.Context, symbol string, ha
 	return nil
 }
 
+func (m *mockVenueClient) SubscribeExecutions(ctx context.Context, handler client.ExecutionHandler) error {
+	return nil
+}
+
+func (m *mockVenueClient) SubscribeBalances(ctx context.Context, handler client.BalanceHandler) error {
+	return nil
+}
+
 func (m *mockVenueClient) Health(ctx context.Context) error {
 	return nil
 }
@@ -65,12 +101,21 @@ func TestVenueClientMethodSignatures(t *testing.T) {
 	// Test all method signatures compile
 	_, _ = mock.PlaceOrder(ctx, nil)
 	_, _ = mock.CancelOrder(ctx, "test-order-id")
+	_, _ = mock.AmendOrder(ctx, "test-order-id", client.OrderChanges{})
+	_, _ = mock.PlaceOrders(ctx, nil)
+	_, _ = mock.CancelOrders(ctx, []string{"test-order-id"})
+	_, _ = mock.CancelAllOrders(ctx, client.OrderFilter{Symbols: []string{"BTC-USD"}})
 	_, _ = mock.GetOrder(ctx, "test-order-id")
 	_, _ = mock.GetOrders(ctx, client.OrderFilter{})
+	_, _ = mock.GetFills(ctx, client.FillFilter{OrderID: "test-order-id"})
 	_, _ = mock.GetBalance(ctx)
 	_, _ = mock.GetOrderBook(ctx, "BTC-USD")
+	_, _ = mock.GetInstruments(ctx)
+	_, _ = mock.GetInstrument(ctx, "BTC-USD")
 	_ = mock.SubscribeOrderBook(ctx, "BTC-USD", func(ob *marketsv1.OrderBook) error { return nil })
 	_ = mock.SubscribeTrades(ctx, "BTC-USD", func(t *marketsv1.Trade) error { return nil })
+	_ = mock.SubscribeExecutions(ctx, func(r *venuesv1.ExecutionReport) error { return nil })
+	_ = mock.SubscribeBalances(ctx, func(b *venuesv1.Balance) error { return nil })
 	_ = mock.Health(ctx)
 
 	t.Log("All VenueClient method signatures verified")
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultClockSyncInterval is how often ServerClock.Run resynchronizes when
+// no positive Interval is set.
+const DefaultClockSyncInterval = 5 * time.Minute
+
+// dateResolution is the resolution of the HTTP Date header.
+const dateResolution = time.Second
+
+// ServerClock tracks the offset between the local clock and a venue's clock,
+// so that request signers stamp timestamps and token validity windows in the
+// venue's time even when the host clock drifts.
+//
+// Until the first sync the offset is zero and Now returns the local time.
+// Run keeps the offset current by resynchronizing on a timer, and venues call
+// Resync when a request is rejected with venueerr.ErrClockSkew. Venues create
+// one clock per client, pass its Now to their signer and expose it for Run.
+//
+// Thread-safe: All methods can be called concurrently. A nil *ServerClock
+// reports the local time.
+type ServerClock struct {
+	// Fetch returns the venue's current time.
+	Fetch func(ctx context.Context) (time.Time, error)
+
+	// Interval is the delay between syncs made by Run
+	// (default: DefaultClockSyncInterval).
+	Interval time.Duration
+
+	// OnError, if set, is called with each failed sync made by Run.
+	OnError func(err error)
+
+	syncMu sync.Mutex // serializes syncs
+
+	mu     sync.RWMutex
+	offset time.Duration
+	synced time.Time
+}
+
+// Now returns the local time corrected by the last measured offset.
+func (c *ServerClock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Offset returns how far the venue's clock is ahead of the local clock, or
+// zero before the first sync.
+func (c *ServerClock) Offset() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+// Synced returns the local time of the last successful sync, or the zero time
+// if there was none.
+func (c *ServerClock) Synced() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.synced
+}
+
+// Sync fetches the venue's time and updates the offset.
+func (c *ServerClock) Sync(ctx context.Context) error {
+	if c.Fetch == nil {
+		return fmt.Errorf("server time fetch function is required")
+	}
+
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+	return c.sync(ctx)
+}
+
+// Resync syncs unless a sync completed after since, so that concurrent
+// requests rejected for the same skew trigger a single fetch. Callers pass the
+// time their rejected request was signed.
+func (c *ServerClock) Resync(ctx context.Context, since time.Time) error {
+	if c.Fetch == nil {
+		return fmt.Errorf("server time fetch function is required")
+	}
+
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	// Another caller may have synced while we waited
+	if c.Synced().After(since) {
+		return nil
+	}
+	return c.sync(ctx)
+}
+
+// sync fetches the venue's time and updates the offset. The venue is assumed
+// to have read its clock halfway through the round trip. The caller must hold
+// syncMu.
+func (c *ServerClock) sync(ctx context.Context) error {
+	start := time.Now()
+	serverTime, err := c.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch server time: %w", err)
+	}
+	end := time.Now()
+	offset := serverTime.Sub(start.Add(end.Sub(start) / 2))
+
+	c.mu.Lock()
+	c.offset = offset
+	c.synced = end
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *ServerClock) interval() time.Duration {
+	if c.Interval <= 0 {
+		return DefaultClockSyncInterval
+	}
+	return c.Interval
+}
+
+// Run syncs immediately and then every Interval until ctx is cancelled,
+// returning ctx.Err(). Failed syncs are passed to OnError and retried at the
+// next interval; the previous offset stays in effect.
+func (c *ServerClock) Run(ctx context.Context) error {
+	if c.Fetch == nil {
+		return fmt.Errorf("server time fetch function is required")
+	}
+
+	ticker := time.NewTicker(c.interval())
+	defer ticker.Stop()
+
+	for {
+		if err := c.Sync(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if c.OnError != nil {
+				c.OnError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DateHeaderTime returns a ServerClock Fetch function that reads the venue's
+// time from the Date header of an unauthenticated GET request to url, for
+// venues without a server time endpoint. The response status is ignored,
+// since error responses carry the header too.
+//
+// The header has one-second resolution, so half a second is added to the
+// parsed time to center the truncation error.
+func DateHeaderTime(httpClient *http.Client, url string) func(ctx context.Context) (time.Time, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return func(ctx context.Context) (time.Time, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("server time request failed: %w", err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		date := resp.Header.Get("Date")
+		if date == "" {
+			return time.Time{}, fmt.Errorf("server time response has no Date header")
+		}
+		serverTime, err := http.ParseTime(date)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse Date header %q: %w", date, err)
+		}
+		return serverTime.Add(dateResolution / 2), nil
+	}
+}
@@ -0,0 +1,114 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerClock(t *testing.T) {
+	ctx := context.Background()
+	const skew = -90 * time.Second
+
+	var fetches atomic.Int32
+	newClock := func() *client.ServerClock {
+		return &client.ServerClock{
+			Fetch: func(context.Context) (time.Time, error) {
+				fetches.Add(1)
+				return time.Now().Add(skew), nil
+			},
+		}
+	}
+
+	t.Run("sync", func(t *testing.T) {
+		clock := newClock()
+		assert.Zero(t, clock.Offset())
+		assert.True(t, clock.Synced().IsZero())
+		assert.WithinDuration(t, time.Now(), clock.Now(), time.Second, "unsynced clock reports local time")
+
+		require.NoError(t, clock.Sync(ctx))
+		assert.InDelta(t, float64(skew), float64(clock.Offset()), float64(100*time.Millisecond))
+		assert.WithinDuration(t, time.Now().Add(skew), clock.Now(), 100*time.Millisecond)
+		assert.WithinDuration(t, time.Now(), clock.Synced(), time.Second)
+	})
+
+	t.Run("concurrent resyncs are coalesced", func(t *testing.T) {
+		clock := newClock()
+		fetches.Store(0)
+		signedAt := time.Now()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, clock.Resync(ctx, signedAt))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), fetches.Load())
+
+		require.NoError(t, clock.Resync(ctx, time.Now()))
+		assert.Equal(t, int32(2), fetches.Load(), "requests signed after the last sync resync again")
+	})
+
+	t.Run("failed sync keeps offset", func(t *testing.T) {
+		clock := newClock()
+		require.NoError(t, clock.Sync(ctx))
+		clock.Fetch = func(context.Context) (time.Time, error) { return time.Time{}, errors.New("connection refused") }
+
+		assert.ErrorContains(t, clock.Sync(ctx), "connection refused")
+		assert.InDelta(t, float64(skew), float64(clock.Offset()), float64(100*time.Millisecond))
+	})
+
+	t.Run("run", func(t *testing.T) {
+		var errs atomic.Int32
+		clock := &client.ServerClock{
+			Fetch:    func(context.Context) (time.Time, error) { return time.Time{}, errors.New("unavailable") },
+			Interval: 10 * time.Millisecond,
+			OnError:  func(error) { errs.Add(1) },
+		}
+		runCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, clock.Run(runCtx), context.DeadlineExceeded)
+		assert.Greater(t, errs.Load(), int32(1))
+	})
+
+	t.Run("nil clock", func(t *testing.T) {
+		var clock *client.ServerClock
+		assert.Zero(t, clock.Offset())
+		assert.WithinDuration(t, time.Now(), clock.Now(), time.Second)
+	})
+
+	t.Run("missing fetch", func(t *testing.T) {
+		clock := &client.ServerClock{}
+		assert.Error(t, clock.Sync(ctx))
+		assert.Error(t, clock.Resync(ctx, time.Now()))
+		assert.Error(t, clock.Run(ctx))
+	})
+}
+
+func TestDateHeaderTime(t *testing.T) {
+	date := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", date.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	serverTime, err := client.DateHeaderTime(server.Client(), server.URL)(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, date.Add(500*time.Millisecond), serverTime, "error responses carry the time too")
+
+	_, err = client.DateHeaderTime(server.Client(), "http://127.0.0.1:0")(context.Background())
+	assert.Error(t, err)
+}
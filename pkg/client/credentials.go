@@ -0,0 +1,41 @@
+package client
+
+import "github.com/Combine-Capital/cqvx/pkg/credentials"
+
+// The credential types are defined in package credentials, which request
+// signers import without depending on this package; they are re-exported here
+// for venue configurations.
+
+const (
+	// DefaultCredentialPollInterval is credentials.DefaultPollInterval.
+	DefaultCredentialPollInterval = credentials.DefaultPollInterval
+
+	// DefaultCredentialRefreshInterval is credentials.DefaultRefreshInterval.
+	DefaultCredentialRefreshInterval = credentials.DefaultRefreshInterval
+)
+
+type (
+	// Credentials is one version of a venue's API credentials; see
+	// credentials.Credentials.
+	Credentials = credentials.Credentials
+
+	// CredentialProvider supplies a venue's current credentials; see
+	// credentials.Provider.
+	CredentialProvider = credentials.Provider
+
+	// StaticCredentials is a CredentialProvider that never rotates; see
+	// credentials.Static.
+	StaticCredentials = credentials.Static
+
+	// EnvCredentials reads credentials from environment variables; see
+	// credentials.Env.
+	EnvCredentials = credentials.Env
+
+	// FileCredentials reads credentials from files and rereads them when they
+	// change; see credentials.File.
+	FileCredentials = credentials.File
+
+	// CallbackCredentials is a CredentialProvider backed by a function; see
+	// credentials.Callback.
+	CallbackCredentials = credentials.Callback
+)
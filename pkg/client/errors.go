@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupported is returned (wrapped in an UnsupportedError) when a venue does
+// not support the requested operation, for example streaming on FalconX.
+//
+// Callers should test for it with errors.Is:
+//
+//	if errors.Is(err, client.ErrUnsupported) {
+//	    // fall back to polling
+//	}
+var ErrUnsupported = errors.New("operation not supported by venue")
+
+// UnsupportedError describes an operation that a venue does not support.
+type UnsupportedError struct {
+	// Venue is the venue identifier (e.g., "falconx").
+	Venue string
+
+	// Operation is the VenueClient method name (e.g., "SubscribeTrades").
+	Operation string
+
+	// Detail optionally narrows the unsupported part of an operation the venue
+	// otherwise supports (e.g., "display size" for AmendOrder).
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *UnsupportedError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("%s: %s not supported (%s)", e.Venue, e.Operation, e.Detail)
+	}
+	return fmt.Sprintf("%s: %s not supported", e.Venue, e.Operation)
+}
+
+// Unwrap returns ErrUnsupported so that errors.Is(err, ErrUnsupported) matches.
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupported
+}
@@ -0,0 +1,31 @@
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedError(t *testing.T) {
+	err := &client.UnsupportedError{Venue: "falconx", Operation: "SubscribeTrades"}
+
+	assert.Equal(t, "falconx: SubscribeTrades not supported", err.Error())
+	assert.True(t, errors.Is(err, client.ErrUnsupported))
+
+	wrapped := fmt.Errorf("subscribe failed: %w", err)
+	assert.True(t, errors.Is(wrapped, client.ErrUnsupported))
+
+	var unsupported *client.UnsupportedError
+	assert.True(t, errors.As(wrapped, &unsupported))
+	assert.Equal(t, "falconx", unsupported.Venue)
+}
+
+func TestUnsupportedError_Detail(t *testing.T) {
+	err := &client.UnsupportedError{Venue: "coinbase", Operation: "AmendOrder", Detail: "display size"}
+
+	assert.Equal(t, "coinbase: AmendOrder not supported (display size)", err.Error())
+	assert.True(t, errors.Is(err, client.ErrUnsupported))
+}
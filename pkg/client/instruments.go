@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultInstrumentRefreshInterval is the age after which InstrumentCache
+// refetches instruments when no positive Interval is set.
+const DefaultInstrumentRefreshInterval = 5 * time.Minute
+
+// DefaultInstrumentLookupTimeout bounds the fetch PrepareOrder makes for an
+// order whose symbol is not cached when no positive LookupTimeout is set.
+const DefaultInstrumentLookupTimeout = 5 * time.Second
+
+// InstrumentCache implements GetInstruments and GetInstrument for venues by
+// caching the venue's product list and refetching it once it is older than
+// Interval.
+//
+// Pre-trade validation should not wait on the network, so Lookup reads the
+// cache without ever fetching; PrepareOrder only fetches, within
+// LookupTimeout, when the cache is empty or stale and lacks the order's
+// symbol. Run keeps the cache warm by refreshing on a timer; venues create one
+// cache per client and expose it for that purpose.
+//
+// Thread-safe: All methods can be called concurrently. Concurrent refreshes
+// are coalesced into a single fetch.
+type InstrumentCache struct {
+	// Fetch returns every instrument listed by the venue.
+	Fetch func(ctx context.Context) ([]*Instrument, error)
+
+	// Interval is the maximum age of cached instruments
+	// (default: DefaultInstrumentRefreshInterval).
+	Interval time.Duration
+
+	// LookupTimeout bounds the fetch PrepareOrder makes when an order's
+	// symbol is not cached (default: DefaultInstrumentLookupTimeout).
+	LookupTimeout time.Duration
+
+	// OnError, if set, is called with each failed refresh whose error is not
+	// returned to a caller, i.e. in Run and when stale instruments are served.
+	OnError func(err error)
+
+	fetchMu sync.Mutex // serializes fetches
+
+	mu          sync.RWMutex
+	instruments map[string]*Instrument
+	symbols     []string // sorted
+	refreshed   time.Time
+}
+
+// Refresh fetches instruments and replaces the cache with them.
+func (c *InstrumentCache) Refresh(ctx context.Context) error {
+	if c.Fetch == nil {
+		return fmt.Errorf("instrument fetch function is required")
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	return c.refresh(ctx)
+}
+
+// refresh fetches instruments. The caller must hold fetchMu.
+func (c *InstrumentCache) refresh(ctx context.Context) error {
+	list, err := c.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch instruments: %w", err)
+	}
+
+	instruments := make(map[string]*Instrument, len(list))
+	symbols := make([]string, 0, len(list))
+	for _, instrument := range list {
+		if _, ok := instruments[instrument.Symbol]; !ok {
+			symbols = append(symbols, instrument.Symbol)
+		}
+		copied := *instrument
+		instruments[instrument.Symbol] = &copied
+	}
+	sort.Strings(symbols)
+
+	c.mu.Lock()
+	c.instruments = instruments
+	c.symbols = symbols
+	c.refreshed = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// ensureFresh refreshes the cache if it is empty or older than Interval. If a
+// refresh fails while instruments are cached, the stale instruments are kept,
+// the error is passed to OnError and nil is returned.
+func (c *InstrumentCache) ensureFresh(ctx context.Context) error {
+	if c.fresh() {
+		return nil
+	}
+	if c.Fetch == nil {
+		return fmt.Errorf("instrument fetch function is required")
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	// Another caller may have refreshed while we waited
+	if c.fresh() {
+		return nil
+	}
+	err := c.refresh(ctx)
+	if err == nil || c.Updated().IsZero() {
+		return err
+	}
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+	return nil
+}
+
+// fresh reports whether the cache holds instruments younger than Interval.
+func (c *InstrumentCache) fresh() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.refreshed.IsZero() && time.Since(c.refreshed) < c.interval()
+}
+
+func (c *InstrumentCache) interval() time.Duration {
+	if c.Interval <= 0 {
+		return DefaultInstrumentRefreshInterval
+	}
+	return c.Interval
+}
+
+func (c *InstrumentCache) lookupTimeout() time.Duration {
+	if c.LookupTimeout <= 0 {
+		return DefaultInstrumentLookupTimeout
+	}
+	return c.LookupTimeout
+}
+
+// All returns every cached instrument sorted by symbol, refreshing the cache
+// first if it is empty or stale.
+func (c *InstrumentCache) All(ctx context.Context) ([]*Instrument, error) {
+	if err := c.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]*Instrument, 0, len(c.symbols))
+	for _, symbol := range c.symbols {
+		copied := *c.instruments[symbol]
+		out = append(out, &copied)
+	}
+	return out, nil
+}
+
+// Get returns the instrument for symbol, refreshing the cache first if it is
+// empty or stale. Returns an error wrapping ErrInstrumentNotFound if the venue
+// does not list the symbol; newly listed symbols appear after the next refresh.
+func (c *InstrumentCache) Get(ctx context.Context, symbol string) (*Instrument, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	if err := c.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	instrument, ok := c.Lookup(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInstrumentNotFound, symbol)
+	}
+	return instrument, nil
+}
+
+// Lookup returns the cached instrument for symbol without fetching, for use
+// on latency-sensitive paths such as pre-trade validation. It reports false if
+// the symbol is not cached, including before the first refresh.
+func (c *InstrumentCache) Lookup(symbol string) (*Instrument, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	instrument, ok := c.instruments[symbol]
+	if !ok {
+		return nil, false
+	}
+	copied := *instrument
+	return &copied, true
+}
+
+// Updated returns when the cache was last refreshed, or the zero time if it
+// never was.
+func (c *InstrumentCache) Updated() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshed
+}
+
+// Run refreshes the cache immediately and then every Interval until ctx is
+// cancelled, returning ctx.Err(). Failed refreshes are passed to OnError and
+// retried at the next interval; the previous instruments stay cached.
+func (c *InstrumentCache) Run(ctx context.Context) error {
+	if c.Fetch == nil {
+		return fmt.Errorf("instrument fetch function is required")
+	}
+
+	ticker := time.NewTicker(c.interval())
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if c.OnError != nil {
+				c.OnError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInstrument(symbol string, tickSize float64) *client.Instrument {
+	return &client.Instrument{
+		VenueID:       "coinbase",
+		Symbol:        symbol,
+		Status:        client.InstrumentStatusOnline,
+		TickSize:      tickSize,
+		BaseIncrement: 0.00000001,
+	}
+}
+
+func TestInstrumentStatus(t *testing.T) {
+	assert.True(t, client.InstrumentStatusOnline.AcceptsOrders())
+	assert.True(t, client.InstrumentStatusLimitOnly.AcceptsOrders())
+	assert.True(t, client.InstrumentStatusPostOnly.AcceptsOrders())
+	assert.False(t, client.InstrumentStatusCancelOnly.AcceptsOrders())
+	assert.False(t, client.InstrumentStatusOffline.AcceptsOrders())
+	assert.False(t, client.InstrumentStatus("").AcceptsOrders())
+}
+
+func TestInstrumentCache(t *testing.T) {
+	t.Run("fetches once and serves from cache", func(t *testing.T) {
+		var calls atomic.Int32
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				calls.Add(1)
+				return []*client.Instrument{testInstrument("ETH-USD", 0.01), testInstrument("BTC-USD", 0.01)}, nil
+			},
+			Interval: time.Hour,
+		}
+
+		_, ok := cache.Lookup("BTC-USD")
+		assert.False(t, ok, "Lookup never fetches")
+		assert.True(t, cache.Updated().IsZero())
+
+		all, err := cache.All(context.Background())
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, "BTC-USD", all[0].Symbol, "sorted by symbol")
+		assert.Equal(t, "ETH-USD", all[1].Symbol)
+
+		instrument, err := cache.Get(context.Background(), "ETH-USD")
+		require.NoError(t, err)
+		assert.Equal(t, 0.01, instrument.TickSize)
+
+		_, err = cache.Get(context.Background(), "DOGE-EUR")
+		assert.ErrorIs(t, err, client.ErrInstrumentNotFound)
+
+		cached, ok := cache.Lookup("BTC-USD")
+		require.True(t, ok)
+		assert.Equal(t, "coinbase", cached.VenueID)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.False(t, cache.Updated().IsZero())
+	})
+
+	t.Run("returned instruments are copies", func(t *testing.T) {
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				return []*client.Instrument{testInstrument("BTC-USD", 0.01)}, nil
+			},
+		}
+		instrument, err := cache.Get(context.Background(), "BTC-USD")
+		require.NoError(t, err)
+		instrument.TickSize = 100
+
+		again, ok := cache.Lookup("BTC-USD")
+		require.True(t, ok)
+		assert.Equal(t, 0.01, again.TickSize)
+	})
+
+	t.Run("refetches once stale", func(t *testing.T) {
+		var calls atomic.Int32
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				n := calls.Add(1)
+				return []*client.Instrument{testInstrument("BTC-USD", float64(n))}, nil
+			},
+			Interval: 10 * time.Millisecond,
+		}
+
+		first, err := cache.Get(context.Background(), "BTC-USD")
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		second, err := cache.Get(context.Background(), "BTC-USD")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1.0, first.TickSize)
+		assert.Equal(t, 2.0, second.TickSize)
+	})
+
+	t.Run("stale instruments are served when a refresh fails", func(t *testing.T) {
+		unavailable := errors.New("unavailable")
+		var calls atomic.Int32
+		var failures []error
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				if calls.Add(1) > 1 {
+					return nil, unavailable
+				}
+				return []*client.Instrument{testInstrument("BTC-USD", 0.01)}, nil
+			},
+			Interval: 10 * time.Millisecond,
+			OnError:  func(err error) { failures = append(failures, err) },
+		}
+
+		_, err := cache.Get(context.Background(), "BTC-USD")
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		instrument, err := cache.Get(context.Background(), "BTC-USD")
+		require.NoError(t, err)
+		assert.Equal(t, 0.01, instrument.TickSize)
+		require.Len(t, failures, 1)
+		assert.ErrorIs(t, failures[0], unavailable)
+	})
+
+	t.Run("first fetch failure is returned", func(t *testing.T) {
+		unavailable := errors.New("unavailable")
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) { return nil, unavailable },
+		}
+		_, err := cache.Get(context.Background(), "BTC-USD")
+		assert.ErrorIs(t, err, unavailable)
+		_, err = cache.All(context.Background())
+		assert.ErrorIs(t, err, unavailable)
+	})
+
+	t.Run("concurrent callers share one fetch", func(t *testing.T) {
+		var calls atomic.Int32
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				calls.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				return []*client.Instrument{testInstrument("BTC-USD", 0.01)}, nil
+			},
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cache.Get(context.Background(), "BTC-USD")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("run refreshes on a timer", func(t *testing.T) {
+		var calls atomic.Int32
+		cache := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				calls.Add(1)
+				return []*client.Instrument{testInstrument("BTC-USD", 0.01)}, nil
+			},
+			Interval: time.Millisecond,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := cache.Run(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Greater(t, calls.Load(), int32(1))
+
+		_, ok := cache.Lookup("BTC-USD")
+		assert.True(t, ok)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		cache := &client.InstrumentCache{}
+		assert.Error(t, cache.Run(context.Background()))
+		assert.Error(t, cache.Refresh(context.Background()))
+		_, err := cache.Get(context.Background(), "BTC-USD")
+		assert.Error(t, err)
+
+		cache.Fetch = func(ctx context.Context) ([]*client.Instrument, error) { return nil, nil }
+		_, err = cache.Get(context.Background(), "")
+		assert.Error(t, err)
+	})
+}
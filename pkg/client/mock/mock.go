@@ -38,26 +38,49 @@ type Client struct {
 	mu sync.RWMutex
 
 	// Configurable method behaviors - set these to control mock responses
-	OnPlaceOrder         func(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error)
-	OnCancelOrder        func(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error)
-	OnGetOrder           func(ctx context.Context, orderID string) (*venuesv1.Order, error)
-	OnGetOrders          func(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error)
-	OnGetBalance         func(ctx context.Context) (*venuesv1.Balance, error)
-	OnGetOrderBook       func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error)
-	OnSubscribeOrderBook func(ctx context.Context, symbol string, handler client.OrderBookHandler) error
-	OnSubscribeTrades    func(ctx context.Context, symbol string, handler client.TradeHandler) error
-	OnHealth             func(ctx context.Context) error
+	OnPlaceOrder          func(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error)
+	OnCancelOrder         func(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error)
+	OnAmendOrder          func(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error)
+	OnPlaceOrders         func(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error)
+	OnCancelOrders        func(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error)
+	OnCancelAllOrders     func(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error)
+	OnGetOrder            func(ctx context.Context, orderID string) (*venuesv1.Order, error)
+	OnGetOrders           func(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error)
+	OnGetFills            func(ctx context.Context, filter client.FillFilter) (*client.FillPage, error)
+	OnGetBalance          func(ctx context.Context) (*venuesv1.Balance, error)
+	OnGetOrderBook        func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error)
+	OnGetInstruments      func(ctx context.Context) ([]*client.Instrument, error)
+	OnGetInstrument       func(ctx context.Context, symbol string) (*client.Instrument, error)
+	OnSubscribeOrderBook  func(ctx context.Context, symbol string, handler client.OrderBookHandler) error
+	OnSubscribeTrades     func(ctx context.Context, symbol string, handler client.TradeHandler) error
+	OnSubscribeExecutions func(ctx context.Context, handler client.ExecutionHandler) error
+	OnSubscribeBalances   func(ctx context.Context, handler client.BalanceHandler) error
+	OnHealth              func(ctx context.Context) error
+
+	// Scripted stream events - delivered in order by the default subscription
+	// behavior when the matching On* handler is not set
+	ExecutionReports []*venuesv1.ExecutionReport
+	BalanceUpdates   []*venuesv1.Balance
 
 	// Call tracking - tracks arguments for each call
-	placeOrderCalls         []placeOrderCall
-	cancelOrderCalls        []cancelOrderCall
-	getOrderCalls           []getOrderCall
-	getOrdersCalls          []getOrdersCall
-	getBalanceCalls         []getBalanceCall
-	getOrderBookCalls       []getOrderBookCall
-	subscribeOrderBookCalls []subscribeOrderBookCall
-	subscribeTradesCalls    []subscribeTradesCall
-	healthCalls             []healthCall
+	placeOrderCalls          []placeOrderCall
+	cancelOrderCalls         []cancelOrderCall
+	amendOrderCalls          []amendOrderCall
+	placeOrdersCalls         []placeOrdersCall
+	cancelOrdersCalls        []cancelOrdersCall
+	cancelAllOrdersCalls     []cancelAllOrdersCall
+	getOrderCalls            []getOrderCall
+	getOrdersCalls           []getOrdersCall
+	getFillsCalls            []getFillsCall
+	getBalanceCalls          []getBalanceCall
+	getOrderBookCalls        []getOrderBookCall
+	getInstrumentsCalls      []getInstrumentsCall
+	getInstrumentCalls       []getInstrumentCall
+	subscribeOrderBookCalls  []subscribeOrderBookCall
+	subscribeTradesCalls     []subscribeTradesCall
+	subscribeExecutionsCalls []subscribeExecutionsCall
+	subscribeBalancesCalls   []subscribeBalancesCall
+	healthCalls              []healthCall
 }
 
 // Call tracking types
@@ -71,6 +94,27 @@ type cancelOrderCall struct {
 	orderID string
 }
 
+type amendOrderCall struct {
+	ctx     context.Context
+	orderID string
+	changes client.OrderChanges
+}
+
+type placeOrdersCall struct {
+	ctx    context.Context
+	orders []*venuesv1.Order
+}
+
+type cancelOrdersCall struct {
+	ctx      context.Context
+	orderIDs []string
+}
+
+type cancelAllOrdersCall struct {
+	ctx    context.Context
+	filter client.OrderFilter
+}
+
 type getOrderCall struct {
 	ctx     context.Context
 	orderID string
@@ -81,6 +125,11 @@ type getOrdersCall struct {
 	filter client.OrderFilter
 }
 
+type getFillsCall struct {
+	ctx    context.Context
+	filter client.FillFilter
+}
+
 type getBalanceCall struct {
 	ctx context.Context
 }
@@ -90,6 +139,15 @@ type getOrderBookCall struct {
 	symbol string
 }
 
+type getInstrumentsCall struct {
+	ctx context.Context
+}
+
+type getInstrumentCall struct {
+	ctx    context.Context
+	symbol string
+}
+
 type subscribeOrderBookCall struct {
 	ctx     context.Context
 	symbol  string
@@ -102,6 +160,16 @@ type subscribeTradesCall struct {
 	handler client.TradeHandler
 }
 
+type subscribeExecutionsCall struct {
+	ctx     context.Context
+	handler client.ExecutionHandler
+}
+
+type subscribeBalancesCall struct {
+	ctx     context.Context
+	handler client.BalanceHandler
+}
+
 type healthCall struct {
 	ctx context.Context
 }
@@ -147,6 +215,102 @@ func (c *Client) CancelOrder(ctx context.Context, orderID string) (*venuesv1.Ord
 	return &status, nil
 }
 
+// AmendOrder modifies an open order. Calls the configured OnAmendOrder handler if set.
+// If OnAmendOrder is not set, returns a REPLACED ExecutionReport carrying the new
+// price and quantity.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+	c.mu.Lock()
+	c.amendOrderCalls = append(c.amendOrderCalls, amendOrderCall{ctx: ctx, orderID: orderID, changes: changes})
+	n := len(c.amendOrderCalls)
+	handler := c.OnAmendOrder
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx, orderID, changes)
+	}
+
+	// Default behavior: return a replaced execution report
+	executionID := fmt.Sprintf("mock-amend-%d", n)
+	return &venuesv1.ExecutionReport{
+		ExecutionId:   &executionID,
+		OrderId:       &orderID,
+		ExecutionType: venuesv1.ExecutionType_EXECUTION_TYPE_REPLACED.Enum(),
+		OrderStatus:   stringPtr("OPEN"),
+		Price:         changes.Price,
+		Quantity:      changes.Quantity,
+	}, nil
+}
+
+// PlaceOrders submits several orders. Calls the configured OnPlaceOrders handler if set.
+// If OnPlaceOrders is not set, each order is passed to PlaceOrder in turn, so
+// OnPlaceOrder can script per-order outcomes.
+func (c *Client) PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error) {
+	c.mu.Lock()
+	c.placeOrdersCalls = append(c.placeOrdersCalls, placeOrdersCall{ctx: ctx, orders: orders})
+	handler := c.OnPlaceOrders
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx, orders)
+	}
+
+	// Default behavior: place each order individually
+	results := make([]client.PlaceOrderResult, len(orders))
+	for i, order := range orders {
+		report, err := c.PlaceOrder(ctx, order)
+		results[i] = client.PlaceOrderResult{Order: order, Report: report, Err: err}
+	}
+	return results, nil
+}
+
+// CancelOrders cancels several orders. Calls the configured OnCancelOrders handler if set.
+// If OnCancelOrders is not set, each order ID is passed to CancelOrder in turn.
+func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	c.mu.Lock()
+	c.cancelOrdersCalls = append(c.cancelOrdersCalls, cancelOrdersCall{ctx: ctx, orderIDs: orderIDs})
+	handler := c.OnCancelOrders
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx, orderIDs)
+	}
+
+	// Default behavior: cancel each order individually
+	return c.cancelEach(ctx, orderIDs), nil
+}
+
+// CancelAllOrders cancels every order matching the filter. Calls the configured
+// OnCancelAllOrders handler if set.
+// If OnCancelAllOrders is not set, the orders returned by GetOrders for the
+// filter are cancelled with CancelOrder.
+func (c *Client) CancelAllOrders(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error) {
+	c.mu.Lock()
+	c.cancelAllOrdersCalls = append(c.cancelAllOrdersCalls, cancelAllOrdersCall{ctx: ctx, filter: filter})
+	handler := c.OnCancelAllOrders
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx, filter)
+	}
+
+	// Default behavior: cancel each order matched by GetOrders
+	orders, err := c.GetOrders(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.cancelEach(ctx, client.OrderIDs(orders)), nil
+}
+
+// cancelEach cancels each order ID with CancelOrder and collects the results.
+func (c *Client) cancelEach(ctx context.Context, orderIDs []string) []client.CancelOrderResult {
+	results := make([]client.CancelOrderResult, len(orderIDs))
+	for i, orderID := range orderIDs {
+		status, err := c.CancelOrder(ctx, orderID)
+		results[i] = client.CancelOrderResult{OrderID: orderID, Status: status, Err: err}
+	}
+	return results
+}
+
 // GetOrder retrieves order details. Calls the configured OnGetOrder handler if set.
 func (c *Client) GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error) {
 	c.mu.Lock()
@@ -181,6 +345,21 @@ func (c *Client) GetOrders(ctx context.Context, filter client.OrderFilter) ([]*v
 	return []*venuesv1.Order{}, nil
 }
 
+// GetFills retrieves a page of fills. Calls the configured OnGetFills handler if set.
+func (c *Client) GetFills(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+	c.mu.Lock()
+	c.getFillsCalls = append(c.getFillsCalls, getFillsCall{ctx: ctx, filter: filter})
+	handler := c.OnGetFills
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx, filter)
+	}
+
+	// Default behavior: return an empty last page
+	return &client.FillPage{Fills: []*venuesv1.ExecutionReport{}}, nil
+}
+
 // GetBalance retrieves account balance. Calls the configured OnGetBalance handler if set.
 func (c *Client) GetBalance(ctx context.Context) (*venuesv1.Balance, error) {
 	c.mu.Lock()
@@ -215,6 +394,36 @@ func (c *Client) GetOrderBook(ctx context.Context, symbol string) (*marketsv1.Or
 	}, nil
 }
 
+// GetInstruments retrieves instrument metadata. Calls the configured OnGetInstruments handler if set.
+func (c *Client) GetInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	c.mu.Lock()
+	c.getInstrumentsCalls = append(c.getInstrumentsCalls, getInstrumentsCall{ctx: ctx})
+	handler := c.OnGetInstruments
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx)
+	}
+
+	// Default behavior: return an empty slice
+	return []*client.Instrument{}, nil
+}
+
+// GetInstrument retrieves one instrument's metadata. Calls the configured OnGetInstrument handler if set.
+func (c *Client) GetInstrument(ctx context.Context, symbol string) (*client.Instrument, error) {
+	c.mu.Lock()
+	c.getInstrumentCalls = append(c.getInstrumentCalls, getInstrumentCall{ctx: ctx, symbol: symbol})
+	handler := c.OnGetInstrument
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(ctx, symbol)
+	}
+
+	// Default behavior: return an online instrument without trading limits
+	return &client.Instrument{Symbol: symbol, Status: client.InstrumentStatusOnline}, nil
+}
+
 // SubscribeOrderBook subscribes to order book updates. Calls the configured OnSubscribeOrderBook handler if set.
 func (c *Client) SubscribeOrderBook(ctx context.Context, symbol string, handler client.OrderBookHandler) error {
 	c.mu.Lock()
@@ -253,6 +462,72 @@ func (c *Client) SubscribeTrades(ctx context.Context, symbol string, handler cli
 	return nil
 }
 
+// SubscribeExecutions subscribes to execution reports. Calls the configured
+// OnSubscribeExecutions handler if set.
+//
+// Otherwise the scripted ExecutionReports are delivered to handler in order and
+// the call returns nil once all have been delivered. Delivery stops early if
+// ctx is cancelled (returning ctx.Err()) or handler returns an error
+// (returning that error).
+func (c *Client) SubscribeExecutions(ctx context.Context, handler client.ExecutionHandler) error {
+	c.mu.Lock()
+	c.subscribeExecutionsCalls = append(c.subscribeExecutionsCalls, subscribeExecutionsCall{
+		ctx:     ctx,
+		handler: handler,
+	})
+	onSubscribe := c.OnSubscribeExecutions
+	reports := append([]*venuesv1.ExecutionReport(nil), c.ExecutionReports...)
+	c.mu.Unlock()
+
+	if onSubscribe != nil {
+		return onSubscribe(ctx, handler)
+	}
+
+	// Default behavior: deliver the scripted reports
+	for _, report := range reports {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := handler(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubscribeBalances subscribes to balance changes. Calls the configured
+// OnSubscribeBalances handler if set.
+//
+// Otherwise the scripted BalanceUpdates are delivered to handler in order and
+// the call returns nil once all have been delivered. Delivery stops early if
+// ctx is cancelled (returning ctx.Err()) or handler returns an error
+// (returning that error).
+func (c *Client) SubscribeBalances(ctx context.Context, handler client.BalanceHandler) error {
+	c.mu.Lock()
+	c.subscribeBalancesCalls = append(c.subscribeBalancesCalls, subscribeBalancesCall{
+		ctx:     ctx,
+		handler: handler,
+	})
+	onSubscribe := c.OnSubscribeBalances
+	balances := append([]*venuesv1.Balance(nil), c.BalanceUpdates...)
+	c.mu.Unlock()
+
+	if onSubscribe != nil {
+		return onSubscribe(ctx, handler)
+	}
+
+	// Default behavior: deliver the scripted balance updates
+	for _, balance := range balances {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := handler(balance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Health performs a health check. Calls the configured OnHealth handler if set.
 func (c *Client) Health(ctx context.Context) error {
 	c.mu.Lock()
@@ -284,6 +559,34 @@ func (c *Client) CancelOrderCallCount() int {
 	return len(c.cancelOrderCalls)
 }
 
+// AmendOrderCallCount returns the number of times AmendOrder was called.
+func (c *Client) AmendOrderCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.amendOrderCalls)
+}
+
+// PlaceOrdersCallCount returns the number of times PlaceOrders was called.
+func (c *Client) PlaceOrdersCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.placeOrdersCalls)
+}
+
+// CancelOrdersCallCount returns the number of times CancelOrders was called.
+func (c *Client) CancelOrdersCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.cancelOrdersCalls)
+}
+
+// CancelAllOrdersCallCount returns the number of times CancelAllOrders was called.
+func (c *Client) CancelAllOrdersCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.cancelAllOrdersCalls)
+}
+
 // GetOrderCallCount returns the number of times GetOrder was called.
 func (c *Client) GetOrderCallCount() int {
 	c.mu.RLock()
@@ -298,6 +601,13 @@ func (c *Client) GetOrdersCallCount() int {
 	return len(c.getOrdersCalls)
 }
 
+// GetFillsCallCount returns the number of times GetFills was called.
+func (c *Client) GetFillsCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.getFillsCalls)
+}
+
 // GetBalanceCallCount returns the number of times GetBalance was called.
 func (c *Client) GetBalanceCallCount() int {
 	c.mu.RLock()
@@ -312,6 +622,20 @@ func (c *Client) GetOrderBookCallCount() int {
 	return len(c.getOrderBookCalls)
 }
 
+// GetInstrumentsCallCount returns the number of times GetInstruments was called.
+func (c *Client) GetInstrumentsCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.getInstrumentsCalls)
+}
+
+// GetInstrumentCallCount returns the number of times GetInstrument was called.
+func (c *Client) GetInstrumentCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.getInstrumentCalls)
+}
+
 // SubscribeOrderBookCallCount returns the number of times SubscribeOrderBook was called.
 func (c *Client) SubscribeOrderBookCallCount() int {
 	c.mu.RLock()
@@ -326,6 +650,20 @@ func (c *Client) SubscribeTradesCallCount() int {
 	return len(c.subscribeTradesCalls)
 }
 
+// SubscribeExecutionsCallCount returns the number of times SubscribeExecutions was called.
+func (c *Client) SubscribeExecutionsCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.subscribeExecutionsCalls)
+}
+
+// SubscribeBalancesCallCount returns the number of times SubscribeBalances was called.
+func (c *Client) SubscribeBalancesCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.subscribeBalancesCalls)
+}
+
 // HealthCallCount returns the number of times Health was called.
 func (c *Client) HealthCallCount() int {
 	c.mu.RLock()
@@ -358,6 +696,50 @@ func (c *Client) CancelOrderCall(n int) (context.Context, string) {
 	return call.ctx, call.orderID
 }
 
+// AmendOrderCall returns the arguments from the nth AmendOrder call (0-indexed).
+func (c *Client) AmendOrderCall(n int) (context.Context, string, client.OrderChanges) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.amendOrderCalls) {
+		panic(fmt.Sprintf("AmendOrderCall: index %d out of bounds (0-%d)", n, len(c.amendOrderCalls)-1))
+	}
+	call := c.amendOrderCalls[n]
+	return call.ctx, call.orderID, call.changes
+}
+
+// PlaceOrdersCall returns the arguments from the nth PlaceOrders call (0-indexed).
+func (c *Client) PlaceOrdersCall(n int) (context.Context, []*venuesv1.Order) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.placeOrdersCalls) {
+		panic(fmt.Sprintf("PlaceOrdersCall: index %d out of bounds (0-%d)", n, len(c.placeOrdersCalls)-1))
+	}
+	call := c.placeOrdersCalls[n]
+	return call.ctx, call.orders
+}
+
+// CancelOrdersCall returns the arguments from the nth CancelOrders call (0-indexed).
+func (c *Client) CancelOrdersCall(n int) (context.Context, []string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.cancelOrdersCalls) {
+		panic(fmt.Sprintf("CancelOrdersCall: index %d out of bounds (0-%d)", n, len(c.cancelOrdersCalls)-1))
+	}
+	call := c.cancelOrdersCalls[n]
+	return call.ctx, call.orderIDs
+}
+
+// CancelAllOrdersCall returns the arguments from the nth CancelAllOrders call (0-indexed).
+func (c *Client) CancelAllOrdersCall(n int) (context.Context, client.OrderFilter) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.cancelAllOrdersCalls) {
+		panic(fmt.Sprintf("CancelAllOrdersCall: index %d out of bounds (0-%d)", n, len(c.cancelAllOrdersCalls)-1))
+	}
+	call := c.cancelAllOrdersCalls[n]
+	return call.ctx, call.filter
+}
+
 // GetOrderCall returns the arguments from the nth GetOrder call (0-indexed).
 func (c *Client) GetOrderCall(n int) (context.Context, string) {
 	c.mu.RLock()
@@ -380,6 +762,17 @@ func (c *Client) GetOrdersCall(n int) (context.Context, client.OrderFilter) {
 	return call.ctx, call.filter
 }
 
+// GetFillsCall returns the arguments from the nth GetFills call (0-indexed).
+func (c *Client) GetFillsCall(n int) (context.Context, client.FillFilter) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.getFillsCalls) {
+		panic(fmt.Sprintf("GetFillsCall: index %d out of bounds (0-%d)", n, len(c.getFillsCalls)-1))
+	}
+	call := c.getFillsCalls[n]
+	return call.ctx, call.filter
+}
+
 // GetBalanceCall returns the arguments from the nth GetBalance call (0-indexed).
 func (c *Client) GetBalanceCall(n int) context.Context {
 	c.mu.RLock()
@@ -401,6 +794,27 @@ func (c *Client) GetOrderBookCall(n int) (context.Context, string) {
 	return call.ctx, call.symbol
 }
 
+// GetInstrumentsCall returns the arguments from the nth GetInstruments call (0-indexed).
+func (c *Client) GetInstrumentsCall(n int) context.Context {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.getInstrumentsCalls) {
+		panic(fmt.Sprintf("GetInstrumentsCall: index %d out of bounds (0-%d)", n, len(c.getInstrumentsCalls)-1))
+	}
+	return c.getInstrumentsCalls[n].ctx
+}
+
+// GetInstrumentCall returns the arguments from the nth GetInstrument call (0-indexed).
+func (c *Client) GetInstrumentCall(n int) (context.Context, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.getInstrumentCalls) {
+		panic(fmt.Sprintf("GetInstrumentCall: index %d out of bounds (0-%d)", n, len(c.getInstrumentCalls)-1))
+	}
+	call := c.getInstrumentCalls[n]
+	return call.ctx, call.symbol
+}
+
 // SubscribeOrderBookCall returns the arguments from the nth SubscribeOrderBook call (0-indexed).
 func (c *Client) SubscribeOrderBookCall(n int) (context.Context, string, client.OrderBookHandler) {
 	c.mu.RLock()
@@ -423,6 +837,28 @@ func (c *Client) SubscribeTradesCall(n int) (context.Context, string, client.Tra
 	return call.ctx, call.symbol, call.handler
 }
 
+// SubscribeExecutionsCall returns the arguments from the nth SubscribeExecutions call (0-indexed).
+func (c *Client) SubscribeExecutionsCall(n int) (context.Context, client.ExecutionHandler) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.subscribeExecutionsCalls) {
+		panic(fmt.Sprintf("SubscribeExecutionsCall: index %d out of bounds (0-%d)", n, len(c.subscribeExecutionsCalls)-1))
+	}
+	call := c.subscribeExecutionsCalls[n]
+	return call.ctx, call.handler
+}
+
+// SubscribeBalancesCall returns the arguments from the nth SubscribeBalances call (0-indexed).
+func (c *Client) SubscribeBalancesCall(n int) (context.Context, client.BalanceHandler) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n < 0 || n >= len(c.subscribeBalancesCalls) {
+		panic(fmt.Sprintf("SubscribeBalancesCall: index %d out of bounds (0-%d)", n, len(c.subscribeBalancesCalls)-1))
+	}
+	call := c.subscribeBalancesCalls[n]
+	return call.ctx, call.handler
+}
+
 // HealthCall returns the arguments from the nth Health call (0-indexed).
 func (c *Client) HealthCall(n int) context.Context {
 	c.mu.RLock()
@@ -442,22 +878,42 @@ func (c *Client) Reset() {
 	// Clear handlers
 	c.OnPlaceOrder = nil
 	c.OnCancelOrder = nil
+	c.OnAmendOrder = nil
+	c.OnPlaceOrders = nil
+	c.OnCancelOrders = nil
+	c.OnCancelAllOrders = nil
 	c.OnGetOrder = nil
 	c.OnGetOrders = nil
+	c.OnGetFills = nil
 	c.OnGetBalance = nil
 	c.OnGetOrderBook = nil
+	c.OnGetInstruments = nil
+	c.OnGetInstrument = nil
 	c.OnSubscribeOrderBook = nil
 	c.OnSubscribeTrades = nil
+	c.OnSubscribeExecutions = nil
+	c.OnSubscribeBalances = nil
 	c.OnHealth = nil
+	c.ExecutionReports = nil
+	c.BalanceUpdates = nil
 
 	// Clear call history
 	c.placeOrderCalls = nil
 	c.cancelOrderCalls = nil
+	c.amendOrderCalls = nil
+	c.placeOrdersCalls = nil
+	c.cancelOrdersCalls = nil
+	c.cancelAllOrdersCalls = nil
 	c.getOrderCalls = nil
 	c.getOrdersCalls = nil
+	c.getFillsCalls = nil
 	c.getBalanceCalls = nil
 	c.getOrderBookCalls = nil
+	c.getInstrumentsCalls = nil
+	c.getInstrumentCalls = nil
 	c.subscribeOrderBookCalls = nil
 	c.subscribeTradesCalls = nil
+	c.subscribeExecutionsCalls = nil
+	c.subscribeBalancesCalls = nil
 	c.healthCalls = nil
 }
@@ -3,6 +3,7 @@ package mock_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
@@ -121,6 +122,120 @@ func TestCancelOrder_ConfiguredHandler(t *testing.T) {
 	assert.Equal(t, orderID, callOrderID)
 }
 
+// TestAmendOrder_DefaultBehavior tests the default behavior when OnAmendOrder is not configured.
+func TestAmendOrder_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+	price := 51000.0
+
+	report, err := m.AmendOrder(ctx, "test-order-123", client.OrderChanges{Price: &price})
+
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, "test-order-123", report.GetOrderId())
+	assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_REPLACED, report.GetExecutionType())
+	assert.Equal(t, price, report.GetPrice())
+	assert.Equal(t, 1, m.AmendOrderCallCount())
+}
+
+// TestAmendOrder_ConfiguredHandler tests AmendOrder with a configured handler.
+func TestAmendOrder_ConfiguredHandler(t *testing.T) {
+	m := &mock.Client{}
+	unsupported := &client.UnsupportedError{Venue: "mock", Operation: "AmendOrder"}
+
+	m.OnAmendOrder = func(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+		return nil, unsupported
+	}
+
+	ctx := context.Background()
+	quantity := 0.5
+
+	report, err := m.AmendOrder(ctx, "test-order-123", client.OrderChanges{Quantity: &quantity})
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+
+	// Verify call arguments
+	callCtx, callOrderID, callChanges := m.AmendOrderCall(0)
+	assert.Equal(t, ctx, callCtx)
+	assert.Equal(t, "test-order-123", callOrderID)
+	assert.Equal(t, quantity, *callChanges.Quantity)
+}
+
+// TestPlaceOrders_DefaultBehavior tests that PlaceOrders delegates to PlaceOrder by default.
+func TestPlaceOrders_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	rejected := errors.New("insufficient funds")
+	m.OnPlaceOrder = func(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error) {
+		if order.GetQuantity() > 1 {
+			return nil, rejected
+		}
+		return mock.NewExecutionReportBuilder().Build(), nil
+	}
+
+	ctx := context.Background()
+	orders := []*venuesv1.Order{
+		mock.NewOrderBuilder().WithQuantity(0.5).Build(),
+		mock.NewOrderBuilder().WithQuantity(5).Build(),
+	}
+
+	results, err := m.PlaceOrders(ctx, orders)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.NotNil(t, results[0].Report)
+	assert.ErrorIs(t, results[1].Err, rejected)
+	assert.Equal(t, 1, m.PlaceOrdersCallCount())
+	assert.Equal(t, 2, m.PlaceOrderCallCount())
+}
+
+// TestCancelOrders_ConfiguredHandler tests CancelOrders with a configured handler.
+func TestCancelOrders_ConfiguredHandler(t *testing.T) {
+	m := &mock.Client{}
+	m.OnCancelOrders = func(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+		return []client.CancelOrderResult{{OrderID: orderIDs[0], Err: errors.New("unknown order")}}, nil
+	}
+
+	ctx := context.Background()
+
+	results, err := m.CancelOrders(ctx, []string{"order-1"})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.EqualError(t, results[0].Err, "unknown order")
+	assert.Equal(t, 0, m.CancelOrderCallCount())
+
+	callCtx, callOrderIDs := m.CancelOrdersCall(0)
+	assert.Equal(t, ctx, callCtx)
+	assert.Equal(t, []string{"order-1"}, callOrderIDs)
+}
+
+// TestCancelAllOrders_DefaultBehavior tests that CancelAllOrders cancels the orders returned by GetOrders.
+func TestCancelAllOrders_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	m.OnGetOrders = func(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error) {
+		return []*venuesv1.Order{
+			mock.NewOrderBuilder().WithVenueOrderID("order-1").Build(),
+			mock.NewOrderBuilder().WithVenueOrderID("order-2").Build(),
+		}, nil
+	}
+
+	ctx := context.Background()
+	filter := client.OrderFilter{Symbols: []string{"BTC-USD"}}
+
+	results, err := m.CancelAllOrders(ctx, filter)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "order-1", results[0].OrderID)
+	assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *results[1].Status)
+	assert.Equal(t, 2, m.CancelOrderCallCount())
+
+	_, callFilter := m.CancelAllOrdersCall(0)
+	assert.Equal(t, filter, callFilter)
+}
+
 // TestGetOrder_DefaultBehavior tests the default behavior when OnGetOrder is not configured.
 func TestGetOrder_DefaultBehavior(t *testing.T) {
 	m := &mock.Client{}
@@ -200,6 +315,41 @@ func TestGetOrders_ConfiguredHandler(t *testing.T) {
 	assert.Equal(t, 2, len(orders))
 }
 
+// TestGetFills_DefaultBehavior tests the default behavior when OnGetFills is not configured.
+func TestGetFills_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+
+	page, err := m.GetFills(ctx, client.FillFilter{})
+
+	require.NoError(t, err)
+	assert.Empty(t, page.Fills)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, 1, m.GetFillsCallCount())
+}
+
+// TestGetFills_ConfiguredHandler tests GetFills with a configured handler.
+func TestGetFills_ConfiguredHandler(t *testing.T) {
+	m := &mock.Client{}
+	fill := mock.NewExecutionReportBuilder().Build()
+	m.OnGetFills = func(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+		if filter.Cursor == "" {
+			return &client.FillPage{Fills: []*venuesv1.ExecutionReport{fill}, NextCursor: "page-2"}, nil
+		}
+		return &client.FillPage{}, nil
+	}
+
+	ctx := context.Background()
+
+	page, err := m.GetFills(ctx, client.FillFilter{OrderID: "order-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []*venuesv1.ExecutionReport{fill}, page.Fills)
+	assert.Equal(t, "page-2", page.NextCursor)
+
+	_, callFilter := m.GetFillsCall(0)
+	assert.Equal(t, "order-1", callFilter.OrderID)
+}
+
 // TestGetBalance_DefaultBehavior tests the default behavior when OnGetBalance is not configured.
 func TestGetBalance_DefaultBehavior(t *testing.T) {
 	m := &mock.Client{}
@@ -273,6 +423,37 @@ func TestGetOrderBook_ConfiguredHandler(t *testing.T) {
 	assert.Equal(t, 1, len(orderBook.Asks))
 }
 
+// TestGetInstruments_DefaultBehavior tests the default behavior when OnGetInstruments and OnGetInstrument are not configured.
+func TestGetInstruments_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+
+	instruments, err := m.GetInstruments(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, instruments)
+
+	instrument, err := m.GetInstrument(ctx, "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-USD", instrument.Symbol)
+	assert.Equal(t, client.InstrumentStatusOnline, instrument.Status)
+
+	assert.Equal(t, 1, m.GetInstrumentsCallCount())
+	assert.Equal(t, 1, m.GetInstrumentCallCount())
+	_, symbol := m.GetInstrumentCall(0)
+	assert.Equal(t, "BTC-USD", symbol)
+}
+
+// TestGetInstrument_ConfiguredHandler tests GetInstrument with a configured handler.
+func TestGetInstrument_ConfiguredHandler(t *testing.T) {
+	m := &mock.Client{}
+	m.OnGetInstrument = func(ctx context.Context, symbol string) (*client.Instrument, error) {
+		return nil, fmt.Errorf("%w: %s", client.ErrInstrumentNotFound, symbol)
+	}
+
+	_, err := m.GetInstrument(context.Background(), "DOGE-EUR")
+	assert.ErrorIs(t, err, client.ErrInstrumentNotFound)
+}
+
 // TestSubscribeOrderBook_DefaultBehavior tests the default behavior when OnSubscribeOrderBook is not configured.
 func TestSubscribeOrderBook_DefaultBehavior(t *testing.T) {
 	m := &mock.Client{}
@@ -368,6 +549,126 @@ func TestSubscribeTrades_ConfiguredHandler(t *testing.T) {
 	assert.Equal(t, 1, m.SubscribeTradesCallCount())
 }
 
+// TestSubscribeExecutions_DefaultBehavior tests that the default behavior delivers scripted reports.
+func TestSubscribeExecutions_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+
+	err := m.SubscribeExecutions(ctx, func(report *venuesv1.ExecutionReport) error {
+		t.Fatal("no reports are scripted")
+		return nil
+	})
+	require.NoError(t, err)
+
+	m.ExecutionReports = []*venuesv1.ExecutionReport{
+		mock.NewExecutionReportBuilder().WithExecutionID("exec-new").WithExecutionType(venuesv1.ExecutionType_EXECUTION_TYPE_NEW).Build(),
+		mock.NewExecutionReportBuilder().WithExecutionID("exec-fill").Build(),
+		mock.NewExecutionReportBuilder().WithExecutionID("exec-cancel").WithExecutionType(venuesv1.ExecutionType_EXECUTION_TYPE_CANCELLED).Build(),
+	}
+
+	var received []string
+	err = m.SubscribeExecutions(ctx, func(report *venuesv1.ExecutionReport) error {
+		received = append(received, report.GetExecutionId())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exec-new", "exec-fill", "exec-cancel"}, received)
+	assert.Equal(t, 2, m.SubscribeExecutionsCallCount())
+
+	stop := errors.New("stop")
+	received = nil
+	err = m.SubscribeExecutions(ctx, func(report *venuesv1.ExecutionReport) error {
+		received = append(received, report.GetExecutionId())
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"exec-new"}, received, "delivery stops at the first handler error")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = m.SubscribeExecutions(cancelled, func(report *venuesv1.ExecutionReport) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestSubscribeExecutions_ConfiguredHandler tests SubscribeExecutions with a configured handler.
+func TestSubscribeExecutions_ConfiguredHandler(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+
+	m.ExecutionReports = []*venuesv1.ExecutionReport{mock.NewExecutionReportBuilder().Build()}
+	m.OnSubscribeExecutions = func(ctx context.Context, handler client.ExecutionHandler) error {
+		return handler(mock.NewExecutionReportBuilder().WithExecutionID("exec-custom").Build())
+	}
+
+	var received []string
+	err := m.SubscribeExecutions(ctx, func(report *venuesv1.ExecutionReport) error {
+		received = append(received, report.GetExecutionId())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exec-custom"}, received, "configured handler replaces scripted reports")
+
+	_, handler := m.SubscribeExecutionsCall(0)
+	assert.NotNil(t, handler)
+}
+
+// TestSubscribeBalances_DefaultBehavior tests that the default behavior delivers scripted balance updates.
+func TestSubscribeBalances_DefaultBehavior(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+
+	err := m.SubscribeBalances(ctx, func(balance *venuesv1.Balance) error {
+		t.Fatal("no balance updates are scripted")
+		return nil
+	})
+	require.NoError(t, err)
+
+	m.BalanceUpdates = []*venuesv1.Balance{
+		mock.NewBalanceBuilder().WithAssetID("USD").WithAvailable(1000).Build(),
+		mock.NewBalanceBuilder().WithAssetID("USD").WithAvailable(900).Build(),
+	}
+
+	var received []float64
+	err = m.SubscribeBalances(ctx, func(balance *venuesv1.Balance) error {
+		received = append(received, balance.GetAvailable())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1000, 900}, received)
+	assert.Equal(t, 2, m.SubscribeBalancesCallCount())
+
+	stop := errors.New("stop")
+	err = m.SubscribeBalances(ctx, func(balance *venuesv1.Balance) error { return stop })
+	assert.ErrorIs(t, err, stop)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = m.SubscribeBalances(cancelled, func(balance *venuesv1.Balance) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestSubscribeBalances_ConfiguredHandler tests SubscribeBalances with a configured handler.
+func TestSubscribeBalances_ConfiguredHandler(t *testing.T) {
+	m := &mock.Client{}
+	ctx := context.Background()
+
+	m.BalanceUpdates = []*venuesv1.Balance{mock.NewBalanceBuilder().Build()}
+	m.OnSubscribeBalances = func(ctx context.Context, handler client.BalanceHandler) error {
+		return handler(mock.NewBalanceBuilder().WithAssetID("ETH").Build())
+	}
+
+	var received []string
+	err := m.SubscribeBalances(ctx, func(balance *venuesv1.Balance) error {
+		received = append(received, balance.GetAssetId())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETH"}, received, "configured handler replaces scripted updates")
+
+	_, handler := m.SubscribeBalancesCall(0)
+	assert.NotNil(t, handler)
+}
+
 // TestHealth_DefaultBehavior tests the default behavior when OnHealth is not configured.
 func TestHealth_DefaultBehavior(t *testing.T) {
 	m := &mock.Client{}
@@ -407,6 +708,9 @@ func TestReset(t *testing.T) {
 	}
 	_, _ = m.PlaceOrder(ctx, mock.NewOrderBuilder().Build())
 	_, _ = m.GetBalance(ctx)
+	_, _ = m.GetInstrument(ctx, "BTC-USD")
+	m.ExecutionReports = []*venuesv1.ExecutionReport{mock.NewExecutionReportBuilder().Build()}
+	m.BalanceUpdates = []*venuesv1.Balance{mock.NewBalanceBuilder().Build()}
 
 	assert.Equal(t, 1, m.PlaceOrderCallCount())
 	assert.Equal(t, 1, m.GetBalanceCallCount())
@@ -418,7 +722,10 @@ func TestReset(t *testing.T) {
 	// Verify everything is cleared
 	assert.Equal(t, 0, m.PlaceOrderCallCount())
 	assert.Equal(t, 0, m.GetBalanceCallCount())
+	assert.Equal(t, 0, m.GetInstrumentCallCount())
 	assert.Nil(t, m.OnPlaceOrder)
+	assert.Nil(t, m.ExecutionReports)
+	assert.Nil(t, m.BalanceUpdates)
 
 	// Verify default behavior still works after reset
 	_, err := m.PlaceOrder(ctx, mock.NewOrderBuilder().Build())
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+const (
+	// RecoveryTimeout bounds the lookup PlaceOrderIdempotently makes after a
+	// placement with an unknown outcome. The lookup runs even if the caller's
+	// context has expired, since an expired deadline is the usual cause.
+	RecoveryTimeout = 10 * time.Second
+
+	// RecoveryLookback is how long before the placement started the lookup
+	// searches for the order, to allow for clock skew between the client and
+	// the venue.
+	RecoveryLookback = 5 * time.Minute
+
+	// maxPlaceAttempts is the number of times PlaceOrderIdempotently submits
+	// an order: once, and once more if the venue has no record of it.
+	maxPlaceAttempts = 2
+)
+
+// PlaceOrderIdempotently implements PlaceOrder with recovery from lost
+// responses, for venues that deduplicate orders by client order ID.
+//
+// place submits the order carrying clientOrderID. If it fails in a way that
+// leaves the outcome unknown (a transport error or timeout after the request
+// was written, or a 408 or 5xx response), lookup is called to search for the
+// order by clientOrderID among orders created since the given time:
+//   - found: the order was placed; its RecoveredExecutionReport is returned
+//   - not found (lookup returns an error matching venueerr.ErrNotFound): the
+//     order is submitted once more with the same clientOrderID, if ctx is
+//     still live
+//   - otherwise, or if the second attempt also has an unknown outcome: a
+//     *venueerr.Error matching venueerr.ErrUnknownOutcome is returned
+//
+// Errors from which the venue evidently did not act on the order (rejections,
+// rate limits) are returned unchanged, as are errors raised before the request
+// was written, such as ctx expiring while the client-side rate limiter queues
+// the request. Whether the request was written is observed with an
+// httptrace.ClientTrace on the context passed to place, so place must send
+// through a transport that reports it, as http.Transport does. An
+// ErrUnknownOutcome error wraps the
+// placement failure and names the client order ID; callers must resolve it
+// (e.g., with GetOrders) before placing the order again under a new ID.
+func PlaceOrderIdempotently(ctx context.Context, venueID, clientOrderID string, place func(context.Context) (*venuesv1.ExecutionReport, error), lookup func(ctx context.Context, since time.Time) (*venuesv1.Order, error)) (*venuesv1.ExecutionReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	since := time.Now().Add(-RecoveryLookback)
+
+	for attempt := 1; ; attempt++ {
+		var written atomic.Bool
+		placeCtx := httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+			WroteHeaders: func() { written.Store(true) },
+		})
+		report, err := place(placeCtx)
+		if err == nil || !outcomeUnknown(err, written.Load()) {
+			return report, err
+		}
+
+		order, lookupErr := recoverOrder(ctx, since, lookup)
+		if lookupErr == nil {
+			return normalizer.RecoveredExecutionReport(order), nil
+		}
+
+		notFound := errors.Is(lookupErr, venueerr.ErrNotFound)
+		if notFound && attempt < maxPlaceAttempts && ctx.Err() == nil {
+			continue
+		}
+
+		cause := err
+		if !notFound {
+			cause = fmt.Errorf("%w; lookup by client order ID failed: %w", err, lookupErr)
+		}
+		return nil, &venueerr.Error{
+			Venue:    venueID,
+			Category: venueerr.ErrUnknownOutcome,
+			Message:  fmt.Sprintf("order with client order ID %s may have been placed", clientOrderID),
+			Err:      cause,
+		}
+	}
+}
+
+// recoverOrder calls lookup with a context that outlives ctx by at most
+// RecoveryTimeout.
+func recoverOrder(ctx context.Context, since time.Time, lookup func(context.Context, time.Time) (*venuesv1.Order, error)) (*venuesv1.Order, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), RecoveryTimeout)
+	defer cancel()
+	return lookup(ctx, since)
+}
+
+// outcomeUnknown reports whether a failed placement may nevertheless have
+// reached the venue: errors that are not venue responses (transport failures,
+// timeouts, unreadable responses) once the request was written, and 408 or
+// 5xx responses.
+func outcomeUnknown(err error, written bool) bool {
+	var venueErr *venueerr.Error
+	if !errors.As(err, &venueErr) {
+		return written
+	}
+	return venueErr.Category == venueerr.ErrUnavailable && venueErr.StatusCode != 0
+}
+
+// OrderByClientOrderID returns the first of orders whose ClientOrderId is
+// clientOrderID, or nil if there is none. It is used by venue lookups that
+// cannot filter by client order ID on the server.
+func OrderByClientOrderID(orders []*venuesv1.Order, clientOrderID string) *venuesv1.Order {
+	for _, order := range orders {
+		if order.GetClientOrderId() == clientOrderID {
+			return order
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPlaceOrderIdempotently(t *testing.T) {
+	placed := &venuesv1.ExecutionReport{OrderId: proto.String("order-1")}
+	found := &venuesv1.Order{
+		OrderId:       proto.String("order-1"),
+		ClientOrderId: proto.String("client-1"),
+		Status:        venuesv1.OrderStatus_ORDER_STATUS_FILLED.Enum(),
+	}
+	serverError := &venueerr.Error{Venue: "test", Category: venueerr.ErrUnavailable, StatusCode: 502}
+	notFound := &venueerr.Error{Venue: "test", Category: venueerr.ErrNotFound}
+
+	// venue replays results in order for successive place and lookup calls.
+	type venue struct {
+		places  []error
+		lookups []error
+		placed  int
+		looked  int
+	}
+	run := func(ctx context.Context, v *venue) (*venuesv1.ExecutionReport, error) {
+		return client.PlaceOrderIdempotently(ctx, "test", "client-1",
+			func(ctx context.Context) (*venuesv1.ExecutionReport, error) {
+				written(ctx)
+				err := v.places[v.placed]
+				v.placed++
+				if err != nil {
+					return nil, err
+				}
+				return placed, nil
+			},
+			func(ctx context.Context, since time.Time) (*venuesv1.Order, error) {
+				assert.NoError(t, ctx.Err(), "lookup outlives the caller's context")
+				assert.WithinDuration(t, time.Now().Add(-client.RecoveryLookback), since, time.Second)
+				err := v.lookups[v.looked]
+				v.looked++
+				if err != nil {
+					return nil, err
+				}
+				return found, nil
+			})
+	}
+
+	t.Run("placed", func(t *testing.T) {
+		v := &venue{places: []error{nil}}
+		report, err := run(context.Background(), v)
+		require.NoError(t, err)
+		assert.Equal(t, placed, report)
+		assert.Zero(t, v.looked)
+	})
+
+	t.Run("rejection is returned unchanged", func(t *testing.T) {
+		rejected := &venueerr.Error{Category: venueerr.ErrInsufficientFunds, StatusCode: 400}
+		v := &venue{places: []error{rejected}}
+		_, err := run(context.Background(), v)
+		assert.Equal(t, rejected, err)
+		assert.Zero(t, v.looked)
+	})
+
+	t.Run("timeout recovered by lookup", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		v := &venue{places: []error{nil}}
+		_, err := run(ctx, v)
+		assert.ErrorIs(t, err, context.Canceled, "done contexts are not submitted")
+		assert.Zero(t, v.placed)
+
+		v = &venue{places: []error{context.DeadlineExceeded}, lookups: []error{nil}}
+		report, err := run(context.Background(), v)
+		require.NoError(t, err)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+		assert.Equal(t, "order-1", report.GetOrderId())
+		assert.Equal(t, "FILLED", report.GetOrderStatus())
+		assert.Equal(t, 1, v.placed)
+	})
+
+	t.Run("not found is retried", func(t *testing.T) {
+		v := &venue{places: []error{serverError, nil}, lookups: []error{notFound}}
+		report, err := run(context.Background(), v)
+		require.NoError(t, err)
+		assert.Equal(t, placed, report)
+		assert.Equal(t, 2, v.placed)
+	})
+
+	t.Run("unknown outcome", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			venue *venue
+			cause error
+		}{
+			{
+				name:  "retry also fails",
+				venue: &venue{places: []error{serverError, serverError}, lookups: []error{notFound, notFound}},
+				cause: serverError,
+			},
+			{
+				name:  "lookup fails",
+				venue: &venue{places: []error{serverError}, lookups: []error{errors.New("connection reset")}},
+				cause: serverError,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := run(context.Background(), tt.venue)
+				assert.ErrorIs(t, err, venueerr.ErrUnknownOutcome)
+				assert.ErrorIs(t, err, tt.cause)
+				assert.False(t, venueerr.IsRetryable(err))
+				assert.Contains(t, err.Error(), "client-1")
+			})
+		}
+	})
+
+	t.Run("errors before the request is written are returned unchanged", func(t *testing.T) {
+		queued := fmt.Errorf("rate limit wait: %w", context.DeadlineExceeded)
+		looked := false
+		_, err := client.PlaceOrderIdempotently(context.Background(), "test", "client-1",
+			func(context.Context) (*venuesv1.ExecutionReport, error) { return nil, queued },
+			func(context.Context, time.Time) (*venuesv1.Order, error) {
+				looked = true
+				return found, nil
+			})
+		assert.Equal(t, queued, err)
+		assert.False(t, looked)
+	})
+
+	t.Run("expired context is not retried", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		_, err := client.PlaceOrderIdempotently(ctx, "test", "client-1",
+			func(ctx context.Context) (*venuesv1.ExecutionReport, error) {
+				written(ctx)
+				attempts++
+				cancel()
+				return nil, fmt.Errorf("request failed: %w", context.Canceled)
+			},
+			func(context.Context, time.Time) (*venuesv1.Order, error) { return nil, notFound })
+		assert.ErrorIs(t, err, venueerr.ErrUnknownOutcome)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+// written reports to the placement's trace that the request was written, as
+// http.Transport does.
+func written(ctx context.Context) {
+	if trace := httptrace.ContextClientTrace(ctx); trace != nil && trace.WroteHeaders != nil {
+		trace.WroteHeaders()
+	}
+}
+
+func TestOrderByClientOrderID(t *testing.T) {
+	orders := []*venuesv1.Order{
+		{OrderId: proto.String("1"), ClientOrderId: proto.String("a")},
+		{OrderId: proto.String("2")},
+		{OrderId: proto.String("3"), ClientOrderId: proto.String("b")},
+	}
+	assert.Equal(t, "3", client.OrderByClientOrderID(orders, "b").GetOrderId())
+	assert.Nil(t, client.OrderByClientOrderID(orders, "c"))
+}
@@ -15,6 +15,16 @@ var (
 	ErrInvalidTimeRange = errors.New("start time must be before end time")
 )
 
+// Error definitions for order amendment validation
+var (
+	ErrNoOrderChanges     = errors.New("at least one order change is required")
+	ErrInvalidOrderChange = errors.New("order changes must be positive")
+)
+
+// ErrInstrumentNotFound is returned (wrapped) by GetInstrument when the venue
+// does not list the symbol.
+var ErrInstrumentNotFound = errors.New("instrument not found")
+
 // OrderFilter defines filter criteria for querying orders.
 // All fields are optional. If not specified, no filtering is applied for that field.
 type OrderFilter struct {
@@ -74,6 +84,192 @@ func (f *OrderFilter) HasStatusFilter() bool {
 	return len(f.Statuses) > 0
 }
 
+// FillFilter defines filter criteria for querying fills (trade executions).
+// All fields are optional. If not specified, no filtering is applied for that field.
+type FillFilter struct {
+	// OrderID restricts results to the fills of a single order (venue order ID).
+	OrderID string
+
+	// Symbols filters fills by trading pair (e.g., "BTC-USD").
+	// If empty, fills for all symbols are returned.
+	Symbols []string
+
+	// StartTime filters fills executed on or after this time.
+	// If zero, no lower bound is applied.
+	StartTime time.Time
+
+	// EndTime filters fills executed before this time.
+	// If zero, no upper bound is applied.
+	EndTime time.Time
+
+	// Limit specifies the maximum number of fills per page.
+	// If zero, venue-specific default page size is used.
+	Limit int
+
+	// Cursor resumes a previous query from FillPage.NextCursor.
+	// Cursors are opaque and venue-specific; pass them back unchanged with the
+	// same filter. If empty, starts from the first page.
+	Cursor string
+}
+
+// Validate checks if the filter has valid values.
+// Returns an error if the filter configuration is invalid.
+func (f *FillFilter) Validate() error {
+	if f.Limit < 0 {
+		return ErrInvalidLimit
+	}
+	if !f.StartTime.IsZero() && !f.EndTime.IsZero() && f.StartTime.After(f.EndTime) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+// FillPage is one page of fills returned by GetFills.
+type FillPage struct {
+	// Fills are the fills on this page as EXECUTION_TYPE_FILL execution reports,
+	// with fee, fee asset, liquidity and venue trade ID populated where the
+	// venue reports them.
+	Fills []*venuesv1.ExecutionReport
+
+	// NextCursor is passed as FillFilter.Cursor to fetch the next page.
+	// Empty when there are no more pages.
+	NextCursor string
+}
+
+// OrderChanges defines the fields to modify when amending an open order in place.
+// Nil fields are left unchanged. At least one field must be set.
+type OrderChanges struct {
+	// Price is the new limit price.
+	Price *float64
+
+	// Quantity is the new total order size in base currency. Venues apply it to
+	// the original size, so it must exceed the quantity already filled.
+	Quantity *float64
+
+	// StopPrice is the new trigger price for stop orders.
+	StopPrice *float64
+
+	// DisplaySize is the new visible quantity for iceberg orders.
+	DisplaySize *float64
+}
+
+// Validate checks if the changes have valid values.
+// Returns ErrNoOrderChanges if no field is set and ErrInvalidOrderChange if any
+// set field is not positive.
+func (c *OrderChanges) Validate() error {
+	if c.IsEmpty() {
+		return ErrNoOrderChanges
+	}
+	for _, v := range []*float64{c.Price, c.Quantity, c.StopPrice, c.DisplaySize} {
+		if v != nil && *v <= 0 {
+			return ErrInvalidOrderChange
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true if no field is set.
+func (c *OrderChanges) IsEmpty() bool {
+	return c.Price == nil && c.Quantity == nil && c.StopPrice == nil && c.DisplaySize == nil
+}
+
+// PlaceOrderResult is the outcome of one order submitted with PlaceOrders.
+type PlaceOrderResult struct {
+	// Order is the order as submitted.
+	Order *venuesv1.Order
+
+	// Report is the execution report returned by the venue. Nil if Err is set.
+	Report *venuesv1.ExecutionReport
+
+	// Err is the reason this order failed, or nil on success.
+	Err error
+}
+
+// CancelOrderResult is the outcome of one order cancelled with CancelOrders or
+// CancelAllOrders.
+type CancelOrderResult struct {
+	// OrderID is the venue order ID of the order.
+	OrderID string
+
+	// Status is the order status after cancellation. Nil if Err is set.
+	Status *venuesv1.OrderStatus
+
+	// Err is the reason this cancel failed, or nil on success.
+	Err error
+}
+
+// InstrumentStatus is the trading status of an instrument.
+type InstrumentStatus string
+
+const (
+	// InstrumentStatusOnline accepts all order types.
+	InstrumentStatusOnline InstrumentStatus = "online"
+
+	// InstrumentStatusLimitOnly accepts limit orders only.
+	InstrumentStatusLimitOnly InstrumentStatus = "limit_only"
+
+	// InstrumentStatusPostOnly accepts post-only limit orders only.
+	InstrumentStatusPostOnly InstrumentStatus = "post_only"
+
+	// InstrumentStatusCancelOnly accepts cancels but no new orders.
+	InstrumentStatusCancelOnly InstrumentStatus = "cancel_only"
+
+	// InstrumentStatusOffline accepts no orders (trading disabled, view only
+	// or delisted).
+	InstrumentStatusOffline InstrumentStatus = "offline"
+)
+
+// AcceptsOrders reports whether new orders of some type may be placed.
+func (s InstrumentStatus) AcceptsOrders() bool {
+	switch s {
+	case InstrumentStatusOnline, InstrumentStatusLimitOnly, InstrumentStatusPostOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// Instrument is a venue product's trading rules, as returned by
+// GetInstruments. Increments and limits are the venue's decimal values; a
+// zero limit means the venue sets none.
+type Instrument struct {
+	// VenueID is the venue identifier (e.g., "coinbase").
+	VenueID string
+
+	// Symbol is the venue symbol used in orders (e.g., "BTC-USD").
+	Symbol string
+
+	// BaseAsset is the asset being traded (e.g., "BTC").
+	BaseAsset string
+
+	// QuoteAsset is the asset prices are quoted in (e.g., "USD").
+	QuoteAsset string
+
+	// Status is the current trading status.
+	Status InstrumentStatus
+
+	// TickSize is the price increment; limit and stop prices must be a
+	// multiple of it.
+	TickSize float64
+
+	// BaseIncrement is the quantity increment (lot size) in base units;
+	// quantities must be a multiple of it.
+	BaseIncrement float64
+
+	// QuoteIncrement is the increment of order sizes given in quote units,
+	// such as market buys by funds.
+	QuoteIncrement float64
+
+	// MinQuantity and MaxQuantity bound the order quantity in base units.
+	MinQuantity float64
+	MaxQuantity float64
+
+	// MinNotional and MaxNotional bound the order value (price * quantity) in
+	// quote units.
+	MinNotional float64
+	MaxNotional float64
+}
+
 // OrderBookHandler is a callback function for order book update events.
 // Implementations receive order book snapshots or updates as they occur.
 type OrderBookHandler func(orderBook *marketsv1.OrderBook) error
@@ -81,3 +277,12 @@ type OrderBookHandler func(orderBook *marketsv1.OrderBook) error
 // TradeHandler is a callback function for trade events.
 // Implementations receive trade notifications as they occur.
 type TradeHandler func(trade *marketsv1.Trade) error
+
+// ExecutionHandler is a callback function for execution reports on the
+// caller's own orders. Reports may be replayed after a reconnect; deduplicate
+// on ExecutionId.
+type ExecutionHandler func(report *venuesv1.ExecutionReport) error
+
+// BalanceHandler is a callback function for balance changes. Each call carries
+// the full current state of one changed balance, not an arithmetic delta.
+type BalanceHandler func(balance *venuesv1.Balance) error
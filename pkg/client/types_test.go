@@ -253,3 +253,103 @@ func TestOrderFilter_EdgeCases(t *testing.T) {
 		assert.NoError(t, filter.Validate())
 	})
 }
+
+func TestOrderChanges_Validate(t *testing.T) {
+	price := 50000.0
+	zero := 0.0
+	negative := -1.0
+
+	tests := []struct {
+		name    string
+		changes client.OrderChanges
+		errType error
+	}{
+		{
+			name:    "empty changes",
+			changes: client.OrderChanges{},
+			errType: client.ErrNoOrderChanges,
+		},
+		{
+			name:    "valid price change",
+			changes: client.OrderChanges{Price: &price},
+		},
+		{
+			name: "valid all fields",
+			changes: client.OrderChanges{
+				Price:       &price,
+				Quantity:    &price,
+				StopPrice:   &price,
+				DisplaySize: &price,
+			},
+		},
+		{
+			name:    "zero quantity",
+			changes: client.OrderChanges{Quantity: &zero},
+			errType: client.ErrInvalidOrderChange,
+		},
+		{
+			name:    "negative stop price",
+			changes: client.OrderChanges{Price: &price, StopPrice: &negative},
+			errType: client.ErrInvalidOrderChange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.changes.Validate()
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFillFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  client.FillFilter
+		errType error
+	}{
+		{
+			name:   "valid empty filter",
+			filter: client.FillFilter{},
+		},
+		{
+			name: "valid filter with all fields",
+			filter: client.FillFilter{
+				OrderID:   "order-1",
+				Symbols:   []string{"BTC-USD"},
+				StartTime: time.Now().Add(-time.Hour),
+				EndTime:   time.Now(),
+				Limit:     50,
+				Cursor:    "next",
+			},
+		},
+		{
+			name:    "invalid negative limit",
+			filter:  client.FillFilter{Limit: -1},
+			errType: client.ErrInvalidLimit,
+		},
+		{
+			name: "invalid time range",
+			filter: client.FillFilter{
+				StartTime: time.Now(),
+				EndTime:   time.Now().Add(-time.Hour),
+			},
+			errType: client.ErrInvalidTimeRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
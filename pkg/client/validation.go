@@ -0,0 +1,410 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidOrder is wrapped by every OrderValidationError. It is
+// venueerr.ErrInvalidOrder, so orders rejected before submission and orders
+// rejected by the venue are handled alike:
+//
+//	if errors.Is(err, client.ErrInvalidOrder) {
+//	    // fix the order; retrying will not help
+//	}
+//
+// Use errors.As with *OrderValidationError to tell the two apart.
+var ErrInvalidOrder = venueerr.ErrInvalidOrder
+
+// ValidationRule identifies the pre-trade check an order failed.
+type ValidationRule string
+
+const (
+	// ValidationRuleRequired means a field the order type needs is missing or
+	// not positive.
+	ValidationRuleRequired ValidationRule = "required"
+
+	// ValidationRuleConflict means two order fields contradict each other,
+	// such as a post-only market order.
+	ValidationRuleConflict ValidationRule = "conflict"
+
+	// ValidationRuleStatus means the instrument's trading status does not
+	// accept the order.
+	ValidationRuleStatus ValidationRule = "status"
+
+	// ValidationRuleMinQuantity means the quantity is below the minimum.
+	ValidationRuleMinQuantity ValidationRule = "min_quantity"
+
+	// ValidationRuleMaxQuantity means the quantity is above the maximum.
+	ValidationRuleMaxQuantity ValidationRule = "max_quantity"
+
+	// ValidationRuleQuantityIncrement means the quantity is not a multiple of
+	// the base increment.
+	ValidationRuleQuantityIncrement ValidationRule = "quantity_increment"
+
+	// ValidationRuleTickSize means a price is not a multiple of the tick size.
+	ValidationRuleTickSize ValidationRule = "tick_size"
+
+	// ValidationRuleMinNotional means quantity × price is below the minimum
+	// order value.
+	ValidationRuleMinNotional ValidationRule = "min_notional"
+
+	// ValidationRuleMaxNotional means quantity × price is above the maximum
+	// order value.
+	ValidationRuleMaxNotional ValidationRule = "max_notional"
+)
+
+// OrderValidationError describes an order rejected by ValidateOrder before it
+// was sent to the venue.
+//
+// Use errors.As to inspect the failed rule:
+//
+//	var invalid *client.OrderValidationError
+//	if errors.As(err, &invalid) && invalid.Rule == client.ValidationRuleTickSize {
+//	    order = client.RoundOrder(order, instrument)
+//	}
+type OrderValidationError struct {
+	// Symbol is the order's venue symbol, if set.
+	Symbol string
+
+	// Field is the order field that failed (e.g., "price", "quantity").
+	Field string
+
+	// Rule is the check that failed.
+	Rule ValidationRule
+
+	// Detail describes the failure (e.g., "0.0001 is below the minimum 0.001").
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *OrderValidationError) Error() string {
+	if e.Symbol == "" {
+		return fmt.Sprintf("invalid order: %s %s", e.Field, e.Detail)
+	}
+	return fmt.Sprintf("invalid %s order: %s %s", e.Symbol, e.Field, e.Detail)
+}
+
+// Unwrap returns ErrInvalidOrder so that errors.Is(err, ErrInvalidOrder) matches.
+func (e *OrderValidationError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// ValidateOrder checks an order before submission and returns the first
+// failure as an *OrderValidationError. It makes no network calls.
+//
+// The function handles:
+//   - Required fields: VenueSymbol, Side, OrderType and a positive Quantity;
+//     Price for limit types (LIMIT, POST_ONLY, STOP_LIMIT, IOC, FOK, GTC);
+//     StopPrice for STOP_LOSS and STOP_LIMIT; ExpiresAt for GTD time in force
+//   - Conflicts: post-only orders that are market, stop-loss or trailing stop
+//     orders, or that are immediate-or-cancel or fill-or-kill
+//
+// If instrument is non-nil, the order is also checked against its rules:
+//   - Status: the instrument accepts orders, limit-only instruments reject
+//     market orders and post-only instruments reject orders not post-only
+//   - Quantity: within MinQuantity and MaxQuantity, a multiple of BaseIncrement
+//   - Prices: Price and StopPrice are multiples of TickSize
+//   - Notional: Quantity × Price within MinNotional and MaxNotional; market
+//     orders have no price and are not checked
+//
+// Comparisons use exact decimals, so 0.3 is a multiple of a 0.1 tick even
+// though it is not in float64. Zero instrument limits are not enforced.
+func ValidateOrder(order *venuesv1.Order, instrument *Instrument) error {
+	if order == nil {
+		return &OrderValidationError{Field: "order", Rule: ValidationRuleRequired, Detail: "is required"}
+	}
+	v := orderValidator{order: order, instrument: instrument}
+	if err := v.validateFields(); err != nil {
+		return err
+	}
+	if instrument == nil {
+		return nil
+	}
+	return v.validateRules()
+}
+
+// RoundOrder returns a copy of order with prices and quantity rounded to the
+// instrument's increments, so that it passes ValidateOrder's increment checks.
+//
+// Rounding never makes an order more aggressive or larger:
+//   - Buy prices round down and sell prices round up to TickSize
+//   - StopPrice rounds to the nearest TickSize
+//   - Quantity rounds down to BaseIncrement, possibly below MinQuantity
+//
+// Values without a matching increment are left unchanged. The order itself is
+// not modified.
+func RoundOrder(order *venuesv1.Order, instrument *Instrument) *venuesv1.Order {
+	if order == nil || instrument == nil {
+		return order
+	}
+	rounded := proto.Clone(order).(*venuesv1.Order)
+
+	priceMode := normalizer.RoundFloor
+	if order.GetSide() == venuesv1.OrderSide_ORDER_SIDE_SELL {
+		priceMode = normalizer.RoundCeiling
+	}
+	if order.Price != nil {
+		rounded.Price = roundToStep(order.GetPrice(), instrument.TickSize, priceMode)
+	}
+	if order.StopPrice != nil {
+		rounded.StopPrice = roundToStep(order.GetStopPrice(), instrument.TickSize, normalizer.RoundHalfAwayFromZero)
+	}
+	if order.Quantity != nil {
+		rounded.Quantity = roundToStep(order.GetQuantity(), instrument.BaseIncrement, normalizer.RoundFloor)
+	}
+	return rounded
+}
+
+// roundToStep rounds value to a multiple of step, returning value unchanged
+// if either is not finite or step is not positive.
+func roundToStep(value, step float64, mode normalizer.RoundingMode) *float64 {
+	v, err := normalizer.DecimalFromFloat(value)
+	if err != nil {
+		return &value
+	}
+	s, err := normalizer.DecimalFromFloat(step)
+	if err != nil {
+		return &value
+	}
+	rounded := v.RoundToStep(s, mode).Float64()
+	return &rounded
+}
+
+// PrepareOrder validates an order against the instrument for its symbol,
+// rounding it first with RoundOrder if round is set. It is called by
+// PlaceOrder implementations before any request is built.
+//
+// The instrument is read with Lookup, so a warm cache costs no network call.
+// If the symbol is not cached, it is read with Get within LookupTimeout,
+// which fetches only if the cache is empty or stale; an order is never
+// submitted unchecked because the cache was cold. Returns an error wrapping
+// ErrInstrumentNotFound if the venue does not list the symbol, or the fetch
+// error if the instrument could not be loaded. Otherwise returns the order to
+// submit, which is a rounded copy when rounding applied.
+func (c *InstrumentCache) PrepareOrder(ctx context.Context, order *venuesv1.Order, round bool) (*venuesv1.Order, error) {
+	var instrument *Instrument
+	if symbol := order.GetVenueSymbol(); symbol != "" {
+		cached, ok := c.Lookup(symbol)
+		if !ok {
+			lookupCtx, cancel := context.WithTimeout(ctx, c.lookupTimeout())
+			defer cancel()
+			var err error
+			if cached, err = c.Get(lookupCtx, symbol); err != nil {
+				return nil, fmt.Errorf("failed to load instrument for order validation: %w", err)
+			}
+		}
+		instrument = cached
+	}
+	if round {
+		order = RoundOrder(order, instrument)
+	}
+	if err := ValidateOrder(order, instrument); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// orderValidator holds the order and instrument being checked.
+type orderValidator struct {
+	order      *venuesv1.Order
+	instrument *Instrument
+}
+
+// fail returns an OrderValidationError for the order.
+func (v *orderValidator) fail(field string, rule ValidationRule, format string, args ...any) error {
+	return &OrderValidationError{
+		Symbol: v.order.GetVenueSymbol(),
+		Field:  field,
+		Rule:   rule,
+		Detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// validateFields checks required fields and conflicts.
+func (v *orderValidator) validateFields() error {
+	order := v.order
+	orderType := order.GetOrderType()
+
+	if order.GetVenueSymbol() == "" {
+		return v.fail("venue_symbol", ValidationRuleRequired, "is required")
+	}
+	switch order.GetSide() {
+	case venuesv1.OrderSide_ORDER_SIDE_BUY, venuesv1.OrderSide_ORDER_SIDE_SELL:
+	default:
+		return v.fail("side", ValidationRuleRequired, "must be BUY or SELL")
+	}
+	if orderType == venuesv1.OrderType_ORDER_TYPE_UNSPECIFIED {
+		return v.fail("order_type", ValidationRuleRequired, "is required")
+	}
+	if !isPositive(order.GetQuantity()) {
+		return v.fail("quantity", ValidationRuleRequired, "must be positive")
+	}
+	if requiresPrice(orderType) && !isPositive(order.GetPrice()) {
+		return v.fail("price", ValidationRuleRequired, "must be positive for %s orders", orderType)
+	}
+	if requiresStopPrice(orderType) && !isPositive(order.GetStopPrice()) {
+		return v.fail("stop_price", ValidationRuleRequired, "must be positive for %s orders", orderType)
+	}
+	if order.GetTimeInForce() == venuesv1.TimeInForce_TIME_IN_FORCE_GTD && order.GetExpiresAt() == nil {
+		return v.fail("expires_at", ValidationRuleRequired, "is required for GTD orders")
+	}
+
+	if isPostOnly(order) {
+		if isMarket(orderType) {
+			return v.fail("post_only", ValidationRuleConflict, "cannot be combined with %s orders", orderType)
+		}
+		if isImmediate(order) {
+			return v.fail("post_only", ValidationRuleConflict, "cannot be combined with immediate-or-cancel or fill-or-kill orders")
+		}
+	}
+	return nil
+}
+
+// validateRules checks the order against the instrument's status, limits and
+// increments.
+func (v *orderValidator) validateRules() error {
+	order, instrument := v.order, v.instrument
+
+	switch status := instrument.Status; {
+	case !status.AcceptsOrders():
+		return v.fail("venue_symbol", ValidationRuleStatus, "is %s and accepts no new orders", status)
+	case status == InstrumentStatusLimitOnly && isMarket(order.GetOrderType()):
+		return v.fail("order_type", ValidationRuleStatus, "%s is not accepted while the instrument is limit only", order.GetOrderType())
+	case status == InstrumentStatusPostOnly && !isPostOnly(order):
+		return v.fail("post_only", ValidationRuleStatus, "is required while the instrument is post only")
+	}
+
+	quantity, err := v.decimal("quantity", order.GetQuantity())
+	if err != nil {
+		return err
+	}
+	if err := v.checkRange("quantity", quantity, instrument.MinQuantity, instrument.MaxQuantity, ValidationRuleMinQuantity, ValidationRuleMaxQuantity); err != nil {
+		return err
+	}
+	if err := v.checkStep("quantity", quantity, instrument.BaseIncrement, "base increment", ValidationRuleQuantityIncrement); err != nil {
+		return err
+	}
+
+	if order.GetPrice() > 0 {
+		price, err := v.decimal("price", order.GetPrice())
+		if err != nil {
+			return err
+		}
+		if err := v.checkStep("price", price, instrument.TickSize, "tick size", ValidationRuleTickSize); err != nil {
+			return err
+		}
+		if !isMarket(order.GetOrderType()) {
+			notional := quantity.Mul(price)
+			if err := v.checkRange("notional", notional, instrument.MinNotional, instrument.MaxNotional, ValidationRuleMinNotional, ValidationRuleMaxNotional); err != nil {
+				return err
+			}
+		}
+	}
+	if order.GetStopPrice() > 0 {
+		stopPrice, err := v.decimal("stop_price", order.GetStopPrice())
+		if err != nil {
+			return err
+		}
+		if err := v.checkStep("stop_price", stopPrice, instrument.TickSize, "tick size", ValidationRuleTickSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decimal converts an order field to an exact decimal.
+func (v *orderValidator) decimal(field string, value float64) (normalizer.Decimal, error) {
+	d, err := normalizer.DecimalFromFloat(value)
+	if err != nil {
+		return normalizer.Decimal{}, v.fail(field, ValidationRuleRequired, "must be finite")
+	}
+	return d, nil
+}
+
+// checkRange checks value against the instrument limits lower and upper, skipping
+// limits that are zero.
+func (v *orderValidator) checkRange(field string, value normalizer.Decimal, lower, upper float64, minRule, maxRule ValidationRule) error {
+	if limit, err := normalizer.DecimalFromFloat(lower); err == nil && limit.Sign() > 0 && value.Cmp(limit) < 0 {
+		return v.fail(field, minRule, "%s is below the minimum %s", value.PlainString(), limit.PlainString())
+	}
+	if limit, err := normalizer.DecimalFromFloat(upper); err == nil && limit.Sign() > 0 && value.Cmp(limit) > 0 {
+		return v.fail(field, maxRule, "%s is above the maximum %s", value.PlainString(), limit.PlainString())
+	}
+	return nil
+}
+
+// checkStep checks that value is a multiple of the instrument increment step,
+// skipping a zero step.
+func (v *orderValidator) checkStep(field string, value normalizer.Decimal, step float64, name string, rule ValidationRule) error {
+	s, err := normalizer.DecimalFromFloat(step)
+	if err != nil || s.Sign() <= 0 {
+		return nil
+	}
+	if !value.RoundToStep(s, normalizer.RoundFloor).Equal(value) {
+		return v.fail(field, rule, "%s is not a multiple of the %s %s", value.PlainString(), name, s.PlainString())
+	}
+	return nil
+}
+
+// isPositive reports whether f is a positive number (false for NaN).
+func isPositive(f float64) bool {
+	return f > 0 && !math.IsInf(f, 1)
+}
+
+// requiresPrice reports whether orders of type t need a limit price.
+func requiresPrice(t venuesv1.OrderType) bool {
+	switch t {
+	case venuesv1.OrderType_ORDER_TYPE_LIMIT,
+		venuesv1.OrderType_ORDER_TYPE_POST_ONLY,
+		venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT,
+		venuesv1.OrderType_ORDER_TYPE_IOC,
+		venuesv1.OrderType_ORDER_TYPE_FOK,
+		venuesv1.OrderType_ORDER_TYPE_GTC:
+		return true
+	default:
+		return false
+	}
+}
+
+// requiresStopPrice reports whether orders of type t need a stop price.
+func requiresStopPrice(t venuesv1.OrderType) bool {
+	return t == venuesv1.OrderType_ORDER_TYPE_STOP_LOSS || t == venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT
+}
+
+// isMarket reports whether orders of type t execute at the market price.
+func isMarket(t venuesv1.OrderType) bool {
+	switch t {
+	case venuesv1.OrderType_ORDER_TYPE_MARKET,
+		venuesv1.OrderType_ORDER_TYPE_STOP_LOSS,
+		venuesv1.OrderType_ORDER_TYPE_TRAILING_STOP:
+		return true
+	default:
+		return false
+	}
+}
+
+// isPostOnly reports whether the order may only add liquidity.
+func isPostOnly(order *venuesv1.Order) bool {
+	return order.GetPostOnly() || order.GetOrderType() == venuesv1.OrderType_ORDER_TYPE_POST_ONLY
+}
+
+// isImmediate reports whether the order's type or time in force requires it
+// to execute on arrival.
+func isImmediate(order *venuesv1.Order) bool {
+	switch order.GetOrderType() {
+	case venuesv1.OrderType_ORDER_TYPE_IOC, venuesv1.OrderType_ORDER_TYPE_FOK:
+		return true
+	}
+	switch order.GetTimeInForce() {
+	case venuesv1.TimeInForce_TIME_IN_FORCE_IOC, venuesv1.TimeInForce_TIME_IN_FORCE_FOK:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// btcUSD mirrors Coinbase's BTC-USD trading rules.
+func btcUSD() *client.Instrument {
+	return &client.Instrument{
+		VenueID:       "coinbase",
+		Symbol:        "BTC-USD",
+		BaseAsset:     "BTC",
+		QuoteAsset:    "USD",
+		Status:        client.InstrumentStatusOnline,
+		TickSize:      0.01,
+		BaseIncrement: 0.00000001,
+		MinQuantity:   0.0001,
+		MaxQuantity:   3400,
+		MinNotional:   1,
+		MaxNotional:   1000000,
+	}
+}
+
+// limitOrder returns a valid BTC-USD limit buy.
+func limitOrder() *venuesv1.Order {
+	return &venuesv1.Order{
+		VenueSymbol: proto.String("BTC-USD"),
+		Side:        venuesv1.OrderSide_ORDER_SIDE_BUY.Enum(),
+		OrderType:   venuesv1.OrderType_ORDER_TYPE_LIMIT.Enum(),
+		Quantity:    proto.Float64(0.1),
+		Price:       proto.Float64(50000.01),
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *venuesv1.Order, i *client.Instrument)
+		field  string
+		rule   client.ValidationRule
+	}{
+		{name: "valid limit order", modify: func(o *venuesv1.Order, i *client.Instrument) {}},
+		{name: "valid market order", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_MARKET.Enum()
+			o.Price = nil
+		}},
+		{name: "float64 inexact tick", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			a, b := 0.1, 0.2
+			i.TickSize = 0.1
+			o.Price = proto.Float64(a + b) // 0.30000000000000004
+			o.Quantity = proto.Float64(10)
+		}, field: "price", rule: client.ValidationRuleTickSize},
+		{name: "decimal multiple of tick", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			i.TickSize = 0.1
+			o.Price = proto.Float64(0.3)
+			o.Quantity = proto.Float64(10)
+		}},
+		{name: "zero limits are not enforced", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			*i = client.Instrument{Symbol: "BTC-USD", Status: client.InstrumentStatusOnline}
+			o.Price = proto.Float64(50000.123456)
+		}},
+
+		{name: "missing symbol", modify: func(o *venuesv1.Order, i *client.Instrument) { o.VenueSymbol = nil },
+			field: "venue_symbol", rule: client.ValidationRuleRequired},
+		{name: "missing side", modify: func(o *venuesv1.Order, i *client.Instrument) { o.Side = nil },
+			field: "side", rule: client.ValidationRuleRequired},
+		{name: "missing type", modify: func(o *venuesv1.Order, i *client.Instrument) { o.OrderType = nil },
+			field: "order_type", rule: client.ValidationRuleRequired},
+		{name: "zero quantity", modify: func(o *venuesv1.Order, i *client.Instrument) { o.Quantity = proto.Float64(0) },
+			field: "quantity", rule: client.ValidationRuleRequired},
+		{name: "NaN quantity", modify: func(o *venuesv1.Order, i *client.Instrument) { o.Quantity = proto.Float64(math.NaN()) },
+			field: "quantity", rule: client.ValidationRuleRequired},
+		{name: "limit without price", modify: func(o *venuesv1.Order, i *client.Instrument) { o.Price = nil },
+			field: "price", rule: client.ValidationRuleRequired},
+		{name: "stop limit without stop price", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT.Enum()
+		}, field: "stop_price", rule: client.ValidationRuleRequired},
+		{name: "stop loss without stop price", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_STOP_LOSS.Enum()
+			o.Price = nil
+		}, field: "stop_price", rule: client.ValidationRuleRequired},
+		{name: "GTD without expiry", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.TimeInForce = venuesv1.TimeInForce_TIME_IN_FORCE_GTD.Enum()
+		}, field: "expires_at", rule: client.ValidationRuleRequired},
+
+		{name: "post-only market", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_MARKET.Enum()
+			o.PostOnly = proto.Bool(true)
+		}, field: "post_only", rule: client.ValidationRuleConflict},
+		{name: "post-only IOC", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_POST_ONLY.Enum()
+			o.TimeInForce = venuesv1.TimeInForce_TIME_IN_FORCE_IOC.Enum()
+		}, field: "post_only", rule: client.ValidationRuleConflict},
+
+		{name: "offline instrument", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			i.Status = client.InstrumentStatusCancelOnly
+		}, field: "venue_symbol", rule: client.ValidationRuleStatus},
+		{name: "market order on limit-only instrument", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			i.Status = client.InstrumentStatusLimitOnly
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_MARKET.Enum()
+		}, field: "order_type", rule: client.ValidationRuleStatus},
+		{name: "taker order on post-only instrument", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			i.Status = client.InstrumentStatusPostOnly
+		}, field: "post_only", rule: client.ValidationRuleStatus},
+		{name: "below minimum quantity", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.Quantity = proto.Float64(0.00005)
+		}, field: "quantity", rule: client.ValidationRuleMinQuantity},
+		{name: "above maximum quantity", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.Quantity = proto.Float64(3400.00000001)
+			o.Price = proto.Float64(0.01)
+		}, field: "quantity", rule: client.ValidationRuleMaxQuantity},
+		{name: "quantity not a multiple of increment", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.Quantity = proto.Float64(0.123456789)
+		}, field: "quantity", rule: client.ValidationRuleQuantityIncrement},
+		{name: "price not a multiple of tick", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.Price = proto.Float64(50000.005)
+		}, field: "price", rule: client.ValidationRuleTickSize},
+		{name: "stop price not a multiple of tick", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.OrderType = venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT.Enum()
+			o.StopPrice = proto.Float64(49000.001)
+		}, field: "stop_price", rule: client.ValidationRuleTickSize},
+		{name: "below minimum notional", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.Quantity = proto.Float64(0.0001)
+			o.Price = proto.Float64(9999.99)
+		}, field: "notional", rule: client.ValidationRuleMinNotional},
+		{name: "above maximum notional", modify: func(o *venuesv1.Order, i *client.Instrument) {
+			o.Quantity = proto.Float64(20.00000001)
+		}, field: "notional", rule: client.ValidationRuleMaxNotional},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, instrument := limitOrder(), btcUSD()
+			tt.modify(order, instrument)
+
+			err := client.ValidateOrder(order, instrument)
+			if tt.rule == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, client.ErrInvalidOrder)
+			var invalid *client.OrderValidationError
+			require.True(t, errors.As(err, &invalid))
+			assert.Equal(t, tt.field, invalid.Field)
+			assert.Equal(t, tt.rule, invalid.Rule)
+		})
+	}
+
+	t.Run("nil order", func(t *testing.T) {
+		assert.ErrorIs(t, client.ValidateOrder(nil, btcUSD()), client.ErrInvalidOrder)
+	})
+
+	t.Run("no instrument checks fields only", func(t *testing.T) {
+		order := limitOrder()
+		order.Price = proto.Float64(50000.005)
+		assert.NoError(t, client.ValidateOrder(order, nil))
+
+		order.Price = nil
+		assert.ErrorIs(t, client.ValidateOrder(order, nil), client.ErrInvalidOrder)
+	})
+
+	t.Run("GTD with expiry", func(t *testing.T) {
+		order := limitOrder()
+		order.TimeInForce = venuesv1.TimeInForce_TIME_IN_FORCE_GTD.Enum()
+		order.ExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+		assert.NoError(t, client.ValidateOrder(order, btcUSD()))
+	})
+
+	t.Run("error message", func(t *testing.T) {
+		order := limitOrder()
+		order.Quantity = proto.Float64(0.00005)
+		err := client.ValidateOrder(order, btcUSD())
+		assert.EqualError(t, err, "invalid BTC-USD order: quantity 0.00005 is below the minimum 0.0001")
+	})
+}
+
+func TestRoundOrder(t *testing.T) {
+	t.Run("buy rounds price down", func(t *testing.T) {
+		order := limitOrder()
+		order.Price = proto.Float64(50000.019)
+		order.Quantity = proto.Float64(0.123456789)
+
+		rounded := client.RoundOrder(order, btcUSD())
+		assert.Equal(t, 50000.01, rounded.GetPrice())
+		assert.Equal(t, 0.12345678, rounded.GetQuantity())
+		assert.NoError(t, client.ValidateOrder(rounded, btcUSD()))
+
+		assert.Equal(t, 50000.019, order.GetPrice(), "original is unchanged")
+	})
+
+	t.Run("sell rounds price up", func(t *testing.T) {
+		order := limitOrder()
+		order.Side = venuesv1.OrderSide_ORDER_SIDE_SELL.Enum()
+		order.Price = proto.Float64(50000.011)
+		order.OrderType = venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT.Enum()
+		order.StopPrice = proto.Float64(50000.015)
+
+		rounded := client.RoundOrder(order, btcUSD())
+		assert.Equal(t, 50000.02, rounded.GetPrice())
+		assert.Equal(t, 50000.02, rounded.GetStopPrice(), "stop price rounds to nearest")
+	})
+
+	t.Run("unset fields stay unset", func(t *testing.T) {
+		order := limitOrder()
+		order.OrderType = venuesv1.OrderType_ORDER_TYPE_MARKET.Enum()
+		order.Price = nil
+
+		rounded := client.RoundOrder(order, btcUSD())
+		assert.Nil(t, rounded.Price)
+		assert.Nil(t, rounded.StopPrice)
+	})
+
+	t.Run("no instrument", func(t *testing.T) {
+		order := limitOrder()
+		assert.Same(t, order, client.RoundOrder(order, nil))
+		assert.Nil(t, client.RoundOrder(nil, btcUSD()))
+	})
+}
+
+func TestInstrumentCachePrepareOrder(t *testing.T) {
+	ctx := context.Background()
+	var fetches atomic.Int32
+	cache := &client.InstrumentCache{
+		Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+			fetches.Add(1)
+			return []*client.Instrument{btcUSD()}, nil
+		},
+	}
+
+	order := limitOrder()
+	order.Price = proto.Float64(50000.005)
+
+	_, err := cache.PrepareOrder(ctx, order, false)
+	assert.ErrorIs(t, err, client.ErrInvalidOrder, "a cold cache is filled before validating")
+	assert.Equal(t, int32(1), fetches.Load())
+
+	prepared, err := cache.PrepareOrder(ctx, order, true)
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, prepared.GetPrice())
+
+	unlisted := limitOrder()
+	unlisted.VenueSymbol = proto.String("DOGE-USD")
+	_, err = cache.PrepareOrder(ctx, unlisted, false)
+	assert.ErrorIs(t, err, client.ErrInstrumentNotFound)
+	assert.Equal(t, int32(1), fetches.Load(), "a fresh cache is not refetched")
+
+	_, err = cache.PrepareOrder(ctx, nil, true)
+	assert.ErrorIs(t, err, client.ErrInvalidOrder)
+
+	t.Run("fetch failure", func(t *testing.T) {
+		failing := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				return nil, errors.New("products unavailable")
+			},
+		}
+		_, err := failing.PrepareOrder(ctx, limitOrder(), false)
+		assert.ErrorContains(t, err, "products unavailable")
+	})
+
+	t.Run("fetch is bounded", func(t *testing.T) {
+		slow := &client.InstrumentCache{
+			Fetch: func(ctx context.Context) ([]*client.Instrument, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			LookupTimeout: 10 * time.Millisecond,
+		}
+		_, err := slow.PrepareOrder(ctx, limitOrder(), false)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,332 @@
+// Package credentials defines venue API credentials and the providers that
+// supply them to request signers, so that keys can be rotated without
+// restarting the process. It has no dependencies on the rest of the module,
+// so both the signers and the public client package can import it.
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is how often File checks its files for changes when
+	// no positive PollInterval is set.
+	DefaultPollInterval = 10 * time.Second
+
+	// DefaultRefreshInterval is how long Callback reuses fetched credentials
+	// when no positive RefreshInterval is set.
+	DefaultRefreshInterval = time.Minute
+)
+
+// Credentials is one version of a venue's API credentials. Each signer reads
+// the fields its scheme uses.
+type Credentials struct {
+	// KeyID identifies the key: the API key (Coinbase, Fordefi), the API key
+	// name (Prime) or, for bearer tokens, an optional label. It is reported
+	// as the active key ID by signers and venue clients.
+	KeyID string
+
+	// Secret is the secret material: the base64-encoded HMAC secret
+	// (Coinbase), the PEM-encoded private key (Prime, Fordefi) or the bearer
+	// token (FalconX).
+	Secret string
+
+	// Passphrase is the API passphrase (Coinbase); unused by other venues.
+	Passphrase string
+}
+
+// Provider supplies a venue's current credentials, so that keys can be
+// rotated without restarting the process.
+//
+// Signers call Credentials for every request and switch to new credentials
+// atomically when they change: requests already being signed finish with the
+// previous key. If Credentials fails, or returns credentials the signer
+// cannot parse, the signer keeps using the last valid credentials.
+// Implementations must therefore be cheap to call and safe for concurrent
+// use; providers backed by a remote service should cache, as Callback does.
+type Provider interface {
+	// Credentials returns the current credentials.
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// Static is a Provider that never rotates.
+type Static Credentials
+
+// Credentials returns c.
+func (c Static) Credentials(context.Context) (Credentials, error) {
+	return Credentials(c), nil
+}
+
+// Env reads credentials from environment variables on every call.
+// A variable name left empty leaves its field empty.
+//
+// The environment of a running process only changes if the process changes
+// it, so rotation requires a reload hook that calls os.Setenv. For keys
+// rotated from outside the process use File or Callback.
+type Env struct {
+	// KeyIDVar is the variable holding Credentials.KeyID
+	KeyIDVar string
+
+	// SecretVar is the variable holding Credentials.Secret
+	SecretVar string
+
+	// PassphraseVar is the variable holding Credentials.Passphrase
+	PassphraseVar string
+}
+
+// Credentials returns the credentials currently set in the environment.
+// Returns an error if a named variable is unset.
+func (e Env) Credentials(context.Context) (Credentials, error) {
+	var creds Credentials
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{e.KeyIDVar, &creds.KeyID},
+		{e.SecretVar, &creds.Secret},
+		{e.PassphraseVar, &creds.Passphrase},
+	} {
+		if field.name == "" {
+			continue
+		}
+		value, ok := os.LookupEnv(field.name)
+		if !ok {
+			return Credentials{}, fmt.Errorf("environment variable %s is not set", field.name)
+		}
+		*field.value = value
+	}
+	return creds, nil
+}
+
+// File reads credentials from files and rereads them when they change, for
+// secrets mounted into a container or written by a secrets agent.
+// Each file holds one field; surrounding whitespace is trimmed. A path left
+// empty leaves its field empty.
+//
+// Files are checked for changes (modification time and size) at most every
+// PollInterval, when credentials are requested. Replace files atomically
+// (write a new file and rename it over the old one, as Kubernetes does for
+// mounted secrets) so that a half-written key is never read; a rotation that
+// cannot be read is reported to OnError and the previous credentials are
+// kept until the next check.
+//
+// Thread-safe: All methods can be called concurrently.
+type File struct {
+	// KeyIDFile is the file holding Credentials.KeyID
+	KeyIDFile string
+
+	// SecretFile is the file holding Credentials.Secret
+	SecretFile string
+
+	// PassphraseFile is the file holding Credentials.Passphrase
+	PassphraseFile string
+
+	// PollInterval is the minimum delay between checks for changed files
+	// (default: DefaultPollInterval).
+	PollInterval time.Duration
+
+	// OnError, if set, is called with each failed reload whose error is not
+	// returned to a caller because previous credentials were kept.
+	OnError func(err error)
+
+	mu      sync.Mutex
+	creds   Credentials
+	stamps  [3]fileStamp
+	loaded  bool
+	checked time.Time
+}
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	modTime int64 // Unix nanoseconds
+	size    int64
+}
+
+// Credentials returns the credentials read from the files, rereading them if
+// they changed since the last check. Returns an error if the files cannot be
+// read and no credentials were read before.
+func (f *File) Credentials(context.Context) (Credentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.loaded && time.Since(f.checked) < f.pollInterval() {
+		return f.creds, nil
+	}
+	f.checked = time.Now()
+
+	creds, stamps, err := f.read()
+	if err != nil {
+		if !f.loaded {
+			return Credentials{}, err
+		}
+		if f.OnError != nil {
+			f.OnError(err)
+		}
+		return f.creds, nil
+	}
+	f.creds, f.stamps, f.loaded = creds, stamps, true
+	return creds, nil
+}
+
+// read stats the files and, unless they are unchanged since the last read,
+// reads them.
+func (f *File) read() (Credentials, [3]fileStamp, error) {
+	paths := [3]string{f.KeyIDFile, f.SecretFile, f.PassphraseFile}
+
+	var stamps [3]fileStamp
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return Credentials{}, stamps, fmt.Errorf("failed to stat credentials file: %w", err)
+		}
+		stamps[i] = fileStamp{modTime: info.ModTime().UnixNano(), size: info.Size()}
+	}
+	if f.loaded && stamps == f.stamps {
+		return f.creds, stamps, nil
+	}
+
+	var values [3]string
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Credentials{}, stamps, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		values[i] = strings.TrimSpace(string(data))
+	}
+	return Credentials{KeyID: values[0], Secret: values[1], Passphrase: values[2]}, stamps, nil
+}
+
+func (f *File) pollInterval() time.Duration {
+	if f.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return f.PollInterval
+}
+
+// Callback is a Provider backed by a function, such as a secret manager
+// lookup. Fetched credentials are reused for RefreshInterval, so the function
+// is not called for every signed request.
+//
+// Fetch is never called with a lock held or with a caller's context: once
+// credentials have been fetched, expired credentials are refreshed in the
+// background while callers keep receiving the last good credentials, so a
+// slow or failing secret manager never delays signing.
+//
+// Thread-safe: All methods can be called concurrently. Concurrent refreshes
+// are coalesced into a single fetch.
+type Callback struct {
+	// Fetch returns the current credentials. Each call is cancelled after
+	// RefreshInterval.
+	Fetch func(ctx context.Context) (Credentials, error)
+
+	// RefreshInterval is how long fetched credentials are reused
+	// (default: DefaultRefreshInterval).
+	RefreshInterval time.Duration
+
+	// OnError, if set, is called with each failed refresh whose error is not
+	// returned to a caller because previous credentials were kept.
+	OnError func(err error)
+
+	mu      sync.Mutex
+	creds   Credentials
+	fetched time.Time     // zero until the first successful fetch
+	lastErr error         // error of the last failed initial fetch
+	refresh chan struct{} // closed when the fetch in flight completes; nil if none
+}
+
+// Credentials returns the fetched credentials. Once they are older than
+// RefreshInterval, a background refresh is started and the previous
+// credentials are returned until it completes; if it fails, the error is
+// passed to OnError and the refresh is retried after another
+// RefreshInterval.
+//
+// Until credentials have been fetched once, Credentials waits for the fetch
+// in flight, returning early if ctx is done. Returns an error if the initial
+// fetch fails.
+func (c *Callback) Credentials(ctx context.Context) (Credentials, error) {
+	if c.Fetch == nil {
+		return Credentials{}, fmt.Errorf("credentials fetch function is required")
+	}
+
+	c.mu.Lock()
+	if !c.fetched.IsZero() {
+		creds := c.creds
+		if time.Since(c.fetched) >= c.refreshInterval() {
+			c.startRefresh(ctx)
+		}
+		c.mu.Unlock()
+		return creds, nil
+	}
+	done := c.startRefresh(ctx)
+	c.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return Credentials{}, ctx.Err()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fetched.IsZero() {
+		return Credentials{}, c.lastErr
+	}
+	return c.creds, nil
+}
+
+// startRefresh starts a fetch unless one is in flight and returns a channel
+// closed when it completes. The fetch keeps ctx's values but not its
+// cancellation, since its result serves every caller. The caller must hold mu.
+func (c *Callback) startRefresh(ctx context.Context) <-chan struct{} {
+	if c.refresh == nil {
+		c.refresh = make(chan struct{})
+		go c.fetch(context.WithoutCancel(ctx), c.refresh)
+	}
+	return c.refresh
+}
+
+// fetch calls Fetch and stores its result, then closes done.
+func (c *Callback) fetch(ctx context.Context, done chan struct{}) {
+	ctx, cancel := context.WithTimeout(ctx, c.refreshInterval())
+	defer cancel()
+	creds, err := c.Fetch(ctx)
+
+	c.mu.Lock()
+	kept := false
+	switch {
+	case err == nil:
+		c.creds, c.fetched, c.lastErr = creds, time.Now(), nil
+	case c.fetched.IsZero():
+		c.lastErr = fmt.Errorf("failed to fetch credentials: %w", err)
+	default:
+		// Keep the previous credentials and retry after another interval
+		err = fmt.Errorf("failed to refresh credentials: %w", err)
+		c.fetched = time.Now()
+		kept = true
+	}
+	c.refresh = nil
+	c.mu.Unlock()
+	close(done)
+
+	if kept && c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+func (c *Callback) refreshInterval() time.Duration {
+	if c.RefreshInterval <= 0 {
+		return DefaultRefreshInterval
+	}
+	return c.RefreshInterval
+}
@@ -0,0 +1,223 @@
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCredentials(t *testing.T) {
+	creds := credentials.Credentials{KeyID: "key", Secret: "secret", Passphrase: "pass"}
+	got, err := credentials.Static(creds).Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, creds, got)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("CQVX_TEST_KEY_ID", "key-1")
+	t.Setenv("CQVX_TEST_SECRET", "secret-1")
+
+	provider := credentials.Env{KeyIDVar: "CQVX_TEST_KEY_ID", SecretVar: "CQVX_TEST_SECRET"}
+	creds, err := provider.Credentials(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, credentials.Credentials{KeyID: "key-1", Secret: "secret-1"}, creds)
+
+	t.Setenv("CQVX_TEST_SECRET", "secret-2")
+	creds, err = provider.Credentials(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-2", creds.Secret, "variables are read on every call")
+
+	provider.PassphraseVar = "CQVX_TEST_UNSET"
+	_, err = provider.Credentials(ctx)
+	assert.ErrorContains(t, err, "CQVX_TEST_UNSET")
+}
+
+func TestFileCredentials(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	secretFile := filepath.Join(dir, "secret")
+
+	// write replaces a file atomically, as a secrets agent would
+	write := func(path, contents string, modTime time.Time) {
+		tmp := path + ".tmp"
+		require.NoError(t, os.WriteFile(tmp, []byte(contents), 0o600))
+		require.NoError(t, os.Chtimes(tmp, modTime, modTime))
+		require.NoError(t, os.Rename(tmp, path))
+	}
+
+	t.Run("missing files", func(t *testing.T) {
+		provider := &credentials.File{SecretFile: filepath.Join(dir, "missing")}
+		_, err := provider.Credentials(ctx)
+		assert.Error(t, err)
+	})
+
+	start := time.Now().Add(-time.Hour)
+	write(keyFile, "key-1\n", start)
+	write(secretFile, "  secret-1\n", start)
+
+	var errs atomic.Int32
+	provider := &credentials.File{
+		KeyIDFile:    keyFile,
+		SecretFile:   secretFile,
+		PollInterval: 20 * time.Millisecond,
+		OnError:      func(error) { errs.Add(1) },
+	}
+	creds, err := provider.Credentials(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, credentials.Credentials{KeyID: "key-1", Secret: "secret-1"}, creds, "contents are trimmed")
+
+	t.Run("rotation", func(t *testing.T) {
+		write(keyFile, "key-2", start.Add(time.Minute))
+		write(secretFile, "secret-2", start.Add(time.Minute))
+
+		creds, err := provider.Credentials(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", creds.KeyID, "files are not rechecked before PollInterval")
+
+		time.Sleep(30 * time.Millisecond)
+		creds, err = provider.Credentials(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, credentials.Credentials{KeyID: "key-2", Secret: "secret-2"}, creds)
+	})
+
+	t.Run("unreadable rotation keeps previous credentials", func(t *testing.T) {
+		require.NoError(t, os.Remove(secretFile))
+
+		time.Sleep(30 * time.Millisecond)
+		creds, err := provider.Credentials(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "secret-2", creds.Secret)
+		assert.Equal(t, int32(1), errs.Load())
+	})
+}
+
+func TestCallbackCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu      sync.Mutex
+		fetches int
+		next    = credentials.Credentials{KeyID: "key-1"}
+		fail    error
+		release chan struct{} // if set, blocks Fetch until closed
+	)
+	var errs atomic.Int32
+	provider := &credentials.Callback{
+		Fetch: func(context.Context) (credentials.Credentials, error) {
+			mu.Lock()
+			fetches++
+			creds, err, wait := next, fail, release
+			mu.Unlock()
+			if wait != nil {
+				<-wait
+			}
+			return creds, err
+		},
+		RefreshInterval: 20 * time.Millisecond,
+		OnError:         func(error) { errs.Add(1) },
+	}
+	keyID := func() string {
+		creds, err := provider.Credentials(ctx)
+		require.NoError(t, err)
+		return creds.KeyID
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			creds, err := provider.Credentials(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "key-1", creds.KeyID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, fetches, "concurrent initial fetches are coalesced")
+
+	t.Run("refresh", func(t *testing.T) {
+		mu.Lock()
+		next = credentials.Credentials{KeyID: "key-2"}
+		mu.Unlock()
+		time.Sleep(30 * time.Millisecond)
+
+		assert.Equal(t, "key-1", keyID(), "expired credentials are served while refreshing")
+		assert.Eventually(t, func() bool { return keyID() == "key-2" }, time.Second, time.Millisecond)
+	})
+
+	t.Run("slow refresh does not block callers", func(t *testing.T) {
+		mu.Lock()
+		release = make(chan struct{})
+		next = credentials.Credentials{KeyID: "key-3"}
+		fetches = 0
+		mu.Unlock()
+		time.Sleep(30 * time.Millisecond)
+
+		assert.Equal(t, "key-2", keyID())
+		fetching := func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return fetches
+		}
+		require.Eventually(t, func() bool { return fetching() == 1 }, time.Second, time.Millisecond)
+		for range 5 {
+			assert.Equal(t, "key-2", keyID(), "callers are not blocked by the refresh")
+		}
+		mu.Lock()
+		assert.Equal(t, 1, fetches, "one refresh is in flight")
+		close(release)
+		release = nil
+		mu.Unlock()
+		assert.Eventually(t, func() bool { return keyID() == "key-3" }, time.Second, time.Millisecond)
+	})
+
+	t.Run("failed refresh keeps previous credentials", func(t *testing.T) {
+		mu.Lock()
+		fail = errors.New("secret manager unavailable")
+		mu.Unlock()
+		time.Sleep(30 * time.Millisecond)
+
+		assert.Equal(t, "key-3", keyID())
+		assert.Eventually(t, func() bool { return errs.Load() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "key-3", keyID())
+	})
+
+	t.Run("initial failure", func(t *testing.T) {
+		failing := &credentials.Callback{
+			Fetch: func(context.Context) (credentials.Credentials, error) {
+				return credentials.Credentials{}, errors.New("denied")
+			},
+		}
+		_, err := failing.Credentials(ctx)
+		assert.ErrorContains(t, err, "denied")
+
+		_, err = (&credentials.Callback{}).Credentials(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("initial fetch honors the caller's context", func(t *testing.T) {
+		blocked := make(chan struct{})
+		defer close(blocked)
+		slow := &credentials.Callback{
+			Fetch: func(context.Context) (credentials.Credentials, error) {
+				<-blocked
+				return credentials.Credentials{KeyID: "key"}, nil
+			},
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := slow.Credentials(waitCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,464 @@
+// Package orderbook maintains a local L2 order book from a venue snapshot plus
+// incremental updates.
+//
+// Venue streaming clients feed a Book with the snapshot and delta messages of
+// their market data channel; consumers such as a market data service read
+// consistent copies, top-of-book depth and VWAP from it. The Book tracks the
+// venue's sequence numbers, detects lost updates and, when configured with a
+// Resync function (typically a VenueClient's GetOrderBook), replaces itself
+// with a fresh snapshot instead of serving a silently corrupted book.
+//
+// Updates carry absolute quantities: each update sets the quantity at a price
+// level, and a zero quantity removes the level. This is the L2 format of every
+// supported venue.
+//
+// A Book is safe for concurrent use. Typically one goroutine applies messages
+// while any number of goroutines read from it.
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NoSequence marks a snapshot without a venue sequence number. The first
+// update applied after it sets the sequence baseline.
+const NoSequence int64 = -1
+
+// ErrNotSynced is returned when a book is read or updated before a snapshot
+// has been applied, or after a sequence gap that could not be resynced.
+var ErrNotSynced = errors.New("order book is not synced")
+
+// GapError reports that updates were lost between two sequence numbers.
+type GapError struct {
+	Symbol   string
+	Expected int64
+	Got      int64
+}
+
+func (e *GapError) Error() string {
+	return fmt.Sprintf("order book sequence gap for %s: expected %d, got %d", e.Symbol, e.Expected, e.Got)
+}
+
+// Side identifies a side of the book.
+type Side int
+
+const (
+	// Bid is the buy side, best (highest) price first.
+	Bid Side = iota
+	// Ask is the sell side, best (lowest) price first.
+	Ask
+)
+
+// String returns "bid" or "ask".
+func (s Side) String() string {
+	if s == Bid {
+		return "bid"
+	}
+	return "ask"
+}
+
+// Level is an aggregated price level.
+type Level struct {
+	Price    float64
+	Quantity float64
+}
+
+// Update sets the quantity at a price level. A zero quantity removes the level.
+type Update struct {
+	Side     Side
+	Price    float64
+	Quantity float64
+}
+
+// Config configures a Book.
+type Config struct {
+	// VenueID is stamped on copies of the book (e.g., "coinbase")
+	VenueID string
+
+	// Symbol is the venue symbol of the book (e.g., "BTC-USD")
+	Symbol string
+
+	// Resync fetches a fresh snapshot after a sequence gap or when updates
+	// arrive before a snapshot, typically a VenueClient's GetOrderBook. If nil,
+	// ApplyDelta returns the error and the caller must provide a new snapshot
+	// (e.g., by resubscribing).
+	Resync func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error)
+
+	// MonotonicSequence only requires sequence numbers to increase, instead of
+	// to be consecutive. Use it for venues that number messages across
+	// channels on a connection, where the caller checks the connection for
+	// gaps (e.g., Coinbase).
+	MonotonicSequence bool
+}
+
+// Book is a local L2 order book.
+type Book struct {
+	config Config
+
+	mu        sync.RWMutex
+	bids      []Level // best (highest) price first
+	asks      []Level // best (lowest) price first
+	synced    bool
+	sequence  int64
+	timestamp time.Time
+	resyncing bool    // a Config.Resync snapshot is being fetched
+	pending   []delta // deltas received while resyncing, in arrival order
+}
+
+// delta is the updates of one venue message.
+type delta struct {
+	sequence  int64
+	timestamp time.Time
+	updates   []Update
+}
+
+// New creates an empty, unsynced book. Apply a snapshot before reading it.
+func New(config Config) *Book {
+	return &Book{config: config, sequence: NoSequence}
+}
+
+// Symbol returns the venue symbol of the book.
+func (b *Book) Symbol() string {
+	return b.config.Symbol
+}
+
+// Synced reports whether the book holds a complete snapshot plus every update
+// since.
+func (b *Book) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Sequence returns the sequence number of the last snapshot or update
+// applied, or NoSequence if it is unknown.
+func (b *Book) Sequence() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.sequence
+}
+
+// Reset clears the book and marks it unsynced, e.g., when a connection drops.
+func (b *Book) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset()
+}
+
+// ApplySnapshot replaces the book with a snapshot. Levels are applied as
+// updates to an empty book, in order, so zero quantities are ignored and a
+// repeated price keeps its last quantity. Use NoSequence if the snapshot has no
+// sequence number.
+func (b *Book) ApplySnapshot(sequence int64, timestamp time.Time, levels []Update) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.applySnapshot(sequence, timestamp, levels)
+}
+
+// applySnapshot replaces the book with a snapshot. The caller must hold the
+// write lock.
+func (b *Book) applySnapshot(sequence int64, timestamp time.Time, levels []Update) {
+	b.reset()
+	for _, level := range levels {
+		b.set(level)
+	}
+	b.synced = true
+	b.sequence = sequence
+	b.timestamp = timestamp
+}
+
+// ApplyOrderBook replaces the book with a CQC order book snapshot, such as one
+// returned by GetOrderBook. Its Sequence, if set, becomes the baseline for
+// later updates.
+func (b *Book) ApplyOrderBook(book *marketsv1.OrderBook) {
+	sequence, timestamp, levels := snapshotOf(book)
+	b.ApplySnapshot(sequence, timestamp, levels)
+}
+
+// snapshotOf converts a CQC order book to the arguments of ApplySnapshot.
+func snapshotOf(book *marketsv1.OrderBook) (int64, time.Time, []Update) {
+	levels := make([]Update, 0, len(book.GetBids())+len(book.GetAsks()))
+	for _, level := range book.GetBids() {
+		levels = append(levels, Update{Side: Bid, Price: level.GetPrice(), Quantity: level.GetQuantity()})
+	}
+	for _, level := range book.GetAsks() {
+		levels = append(levels, Update{Side: Ask, Price: level.GetPrice(), Quantity: level.GetQuantity()})
+	}
+
+	sequence := NoSequence
+	if book.Sequence != nil {
+		sequence = book.GetSequence()
+	}
+	timestamp := time.Now()
+	if book.Timestamp != nil {
+		timestamp = book.GetTimestamp().AsTime()
+	}
+	return sequence, timestamp, levels
+}
+
+// ApplyDelta applies the updates of one venue message with the given sequence
+// number.
+//
+// The function handles:
+//   - Stale messages (sequence at or before the book's): ignored
+//   - The first message after a snapshot without a sequence: sets the baseline
+//   - Sequence gaps: marks the book unsynced and returns a *GapError
+//   - Updates while unsynced (before the first snapshot or after a gap):
+//     returns ErrNotSynced
+//
+// With Config.Resync, gaps and updates while unsynced instead replace the book
+// with a fresh snapshot. The message, and any message applied while the
+// snapshot is being fetched, is buffered and replayed on top of the snapshot if
+// its sequence is after the snapshot's, so a snapshot older than the message
+// that triggered it does not lose that message. A snapshot without a sequence
+// number is taken to reflect every buffered message. If resyncing fails, the
+// book stays unsynced and the resync error is returned joined with the gap; the
+// next call tries again.
+func (b *Book) ApplyDelta(ctx context.Context, sequence int64, timestamp time.Time, updates []Update) error {
+	d := delta{sequence: sequence, timestamp: timestamp, updates: updates}
+	err := b.applyDelta(d)
+	if err == nil || b.config.Resync == nil {
+		return err
+	}
+	if resyncErr := b.resync(ctx, []delta{d}); resyncErr != nil {
+		return errors.Join(err, resyncErr)
+	}
+	return nil
+}
+
+// Resync replaces the book with a fresh snapshot from Config.Resync, replaying
+// any message applied while the snapshot is being fetched as ApplyDelta does.
+func (b *Book) Resync(ctx context.Context) error {
+	return b.resync(ctx, nil)
+}
+
+// resync fetches a snapshot and replays the buffered deltas, starting with
+// trigger, that are newer than it. If another resync is already in flight,
+// trigger is left for it to replay.
+func (b *Book) resync(ctx context.Context, trigger []delta) error {
+	if b.config.Resync == nil {
+		return fmt.Errorf("order book for %s has no resync function", b.config.Symbol)
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, trigger...)
+	if b.resyncing {
+		b.mu.Unlock()
+		return nil
+	}
+	b.resyncing = true
+	b.mu.Unlock()
+
+	snapshot, err := b.config.Resync(ctx, b.config.Symbol)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pending := b.pending
+	b.resyncing = false
+	b.pending = nil
+	if err != nil {
+		return fmt.Errorf("failed to resync %s order book: %w", b.config.Symbol, err)
+	}
+
+	b.applySnapshot(snapshotOf(snapshot))
+	if b.sequence == NoSequence {
+		return nil
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].sequence < pending[j].sequence })
+	for _, d := range pending {
+		if err := b.apply(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyDelta applies a delta under the lock, or buffers it while a resync is
+// in flight. It returns ErrNotSynced or a *GapError, marking the book
+// unsynced, if the updates cannot be applied.
+func (b *Book) applyDelta(d delta) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.resyncing {
+		b.pending = append(b.pending, d)
+		return nil
+	}
+	return b.apply(d)
+}
+
+// apply applies a delta. The caller must hold the write lock.
+func (b *Book) apply(d delta) error {
+	if !b.synced {
+		return ErrNotSynced
+	}
+
+	if b.sequence != NoSequence {
+		if d.sequence <= b.sequence {
+			return nil
+		}
+		if !b.config.MonotonicSequence && d.sequence != b.sequence+1 {
+			gap := &GapError{Symbol: b.config.Symbol, Expected: b.sequence + 1, Got: d.sequence}
+			b.reset()
+			return gap
+		}
+	}
+
+	for _, update := range d.updates {
+		b.set(update)
+	}
+	b.sequence = d.sequence
+	b.timestamp = d.timestamp
+	return nil
+}
+
+// reset clears the book. The caller must hold the write lock.
+func (b *Book) reset() {
+	b.bids = nil
+	b.asks = nil
+	b.synced = false
+	b.sequence = NoSequence
+	b.timestamp = time.Time{}
+}
+
+// set applies a single update. The caller must hold the write lock.
+func (b *Book) set(update Update) {
+	levels := &b.asks
+	better := func(i int) bool { return (*levels)[i].Price >= update.Price }
+	if update.Side == Bid {
+		levels = &b.bids
+		better = func(i int) bool { return (*levels)[i].Price <= update.Price }
+	}
+
+	i := sort.Search(len(*levels), better)
+	found := i < len(*levels) && (*levels)[i].Price == update.Price
+	switch {
+	case update.Quantity == 0 && found:
+		*levels = append((*levels)[:i], (*levels)[i+1:]...)
+	case update.Quantity == 0:
+	case found:
+		(*levels)[i].Quantity = update.Quantity
+	default:
+		*levels = append(*levels, Level{})
+		copy((*levels)[i+1:], (*levels)[i:])
+		(*levels)[i] = Level{Price: update.Price, Quantity: update.Quantity}
+	}
+}
+
+// Depth returns copies of the best depth levels per side, best price first.
+// A depth of zero returns every level.
+func (b *Book) Depth(depth int) (bids, asks []Level, err error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.synced {
+		return nil, nil, ErrNotSynced
+	}
+	return top(b.bids, depth), top(b.asks, depth), nil
+}
+
+// VWAP returns the volume-weighted average price of taking quantity from one
+// side of the book: Ask prices a buy, Bid prices a sell.
+//
+// If the side holds less than quantity, the average covers the whole side and
+// filled reports how much of quantity it covers. Returns an error if the book
+// is not synced or quantity is not positive.
+func (b *Book) VWAP(side Side, quantity float64) (price, filled float64, err error) {
+	if quantity <= 0 {
+		return 0, 0, fmt.Errorf("quantity must be positive")
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.synced {
+		return 0, 0, ErrNotSynced
+	}
+	levels := b.asks
+	if side == Bid {
+		levels = b.bids
+	}
+
+	// Sum exactly so that the average of identical prices is that price.
+	want := decimal(quantity)
+	var notional, taken normalizer.Decimal
+	for _, level := range levels {
+		if taken.Cmp(want) >= 0 {
+			break
+		}
+		take := decimal(level.Quantity)
+		if remaining := want.Sub(taken); take.Cmp(remaining) > 0 {
+			take = remaining
+		}
+		notional = notional.Add(decimal(level.Price).Mul(take))
+		taken = taken.Add(take)
+	}
+	if taken.IsZero() {
+		return 0, 0, nil
+	}
+	return notional.Quo(taken, 18).Float64(), taken.Float64(), nil
+}
+
+// Snapshot returns a CQC OrderBook copy of the book limited to depth levels per
+// side (zero means all levels), with the best bid and ask, spread and mid
+// price set. The copy shares no memory with the book.
+func (b *Book) Snapshot(depth int) (*marketsv1.OrderBook, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.synced {
+		return nil, ErrNotSynced
+	}
+
+	venueId := b.config.VenueID
+	symbol := b.config.Symbol
+	book := &marketsv1.OrderBook{
+		VenueId:     &venueId,
+		VenueSymbol: &symbol,
+		Timestamp:   timestamppb.New(b.timestamp),
+		Bids:        protoLevels(top(b.bids, depth)),
+		Asks:        protoLevels(top(b.asks, depth)),
+	}
+	if b.sequence != NoSequence {
+		sequence := b.sequence
+		book.Sequence = &sequence
+	}
+	normalizer.SetTopOfBook(book)
+	return book, nil
+}
+
+// top returns a copy of the first depth levels (all levels if depth is zero).
+func top(levels []Level, depth int) []Level {
+	if depth > 0 && len(levels) > depth {
+		levels = levels[:depth]
+	}
+	return append([]Level(nil), levels...)
+}
+
+// protoLevels converts levels to CQC OrderBookLevels.
+func protoLevels(levels []Level) []*marketsv1.OrderBookLevel {
+	out := make([]*marketsv1.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		out = append(out, &marketsv1.OrderBookLevel{
+			Price:    normalizer.Float64Ptr(level.Price),
+			Quantity: normalizer.Float64Ptr(level.Quantity),
+		})
+	}
+	return out
+}
+
+// decimal converts a float64 parsed from a venue's decimal string back to
+// that decimal. Prices and quantities are always finite.
+func decimal(f float64) normalizer.Decimal {
+	d, _ := normalizer.DecimalFromFloat(f)
+	return d
+}
@@ -0,0 +1,316 @@
+package orderbook_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	"github.com/Combine-Capital/cqvx/pkg/orderbook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	snapshotTime = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	updateTime   = snapshotTime.Add(time.Second)
+)
+
+func bid(price, quantity float64) orderbook.Update {
+	return orderbook.Update{Side: orderbook.Bid, Price: price, Quantity: quantity}
+}
+
+func ask(price, quantity float64) orderbook.Update {
+	return orderbook.Update{Side: orderbook.Ask, Price: price, Quantity: quantity}
+}
+
+// snapshotLevels is a book of three bids and two asks in arbitrary order.
+var snapshotLevels = []orderbook.Update{
+	bid(49999.50, 1.2), ask(50000.50, 2.1), bid(49999.99, 0.5), bid(49998.00, 3.0), ask(50000.01, 0.4),
+}
+
+func levels(side []*marketsv1.OrderBookLevel) [][2]float64 {
+	out := make([][2]float64, 0, len(side))
+	for _, level := range side {
+		out = append(out, [2]float64{level.GetPrice(), level.GetQuantity()})
+	}
+	return out
+}
+
+func newBook(t *testing.T, config orderbook.Config) *orderbook.Book {
+	t.Helper()
+	config.VenueID = "coinbase"
+	config.Symbol = "BTC-USD"
+	book := orderbook.New(config)
+	book.ApplySnapshot(10, snapshotTime, snapshotLevels)
+	return book
+}
+
+func TestBook(t *testing.T) {
+	t.Run("snapshot sorts levels best first", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+
+		snapshot, err := book.Snapshot(0)
+		require.NoError(t, err)
+		assert.Equal(t, "coinbase", snapshot.GetVenueId())
+		assert.Equal(t, "BTC-USD", snapshot.GetVenueSymbol())
+		assert.Equal(t, int64(10), snapshot.GetSequence())
+		assert.Equal(t, snapshotTime, snapshot.GetTimestamp().AsTime())
+		assert.Equal(t, [][2]float64{{49999.99, 0.5}, {49999.50, 1.2}, {49998.00, 3.0}}, levels(snapshot.GetBids()))
+		assert.Equal(t, [][2]float64{{50000.01, 0.4}, {50000.50, 2.1}}, levels(snapshot.GetAsks()))
+		assert.Equal(t, 49999.99, snapshot.GetBestBid())
+		assert.Equal(t, 50000.01, snapshot.GetBestAsk())
+		assert.Equal(t, 0.02, snapshot.GetSpread(), "spread is exact")
+		assert.Equal(t, 50000.0, snapshot.GetMidPrice())
+	})
+
+	t.Run("deltas set and remove levels", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+
+		err := book.ApplyDelta(context.Background(), 11, updateTime, []orderbook.Update{
+			bid(49999.99, 0), bid(49999.75, 0.8), ask(50000.01, 0.9), ask(50002, 0),
+		})
+		require.NoError(t, err)
+
+		bids, asks, err := book.Depth(2)
+		require.NoError(t, err)
+		assert.Equal(t, []orderbook.Level{{Price: 49999.75, Quantity: 0.8}, {Price: 49999.50, Quantity: 1.2}}, bids)
+		assert.Equal(t, []orderbook.Level{{Price: 50000.01, Quantity: 0.9}, {Price: 50000.50, Quantity: 2.1}}, asks)
+		assert.Equal(t, int64(11), book.Sequence())
+
+		snapshot, err := book.Snapshot(1)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]float64{{49999.75, 0.8}}, levels(snapshot.GetBids()))
+		assert.Equal(t, updateTime, snapshot.GetTimestamp().AsTime())
+	})
+
+	t.Run("copies share no memory with the book", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+		bids, _, err := book.Depth(0)
+		require.NoError(t, err)
+		bids[0].Quantity = 100
+
+		require.NoError(t, book.ApplyDelta(context.Background(), 11, updateTime, []orderbook.Update{bid(49999.50, 7)}))
+		again, _, err := book.Depth(0)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, again[0].Quantity)
+		assert.Equal(t, 1.2, bids[1].Quantity, "earlier copy is unchanged")
+	})
+
+	t.Run("stale messages are ignored", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+		require.NoError(t, book.ApplyDelta(context.Background(), 10, updateTime, []orderbook.Update{bid(49999.99, 9)}))
+		bids, _, err := book.Depth(1)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, bids[0].Quantity)
+	})
+
+	t.Run("gap without resync unsyncs the book", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+
+		err := book.ApplyDelta(context.Background(), 12, updateTime, []orderbook.Update{bid(49999.99, 9)})
+		var gap *orderbook.GapError
+		require.ErrorAs(t, err, &gap)
+		assert.Equal(t, &orderbook.GapError{Symbol: "BTC-USD", Expected: 11, Got: 12}, gap)
+		assert.False(t, book.Synced())
+
+		_, err = book.Snapshot(0)
+		assert.ErrorIs(t, err, orderbook.ErrNotSynced)
+		_, _, err = book.Depth(0)
+		assert.ErrorIs(t, err, orderbook.ErrNotSynced)
+		err = book.ApplyDelta(context.Background(), 13, updateTime, nil)
+		assert.ErrorIs(t, err, orderbook.ErrNotSynced)
+	})
+
+	t.Run("monotonic sequences only need to increase", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{MonotonicSequence: true})
+		require.NoError(t, book.ApplyDelta(context.Background(), 15, updateTime, []orderbook.Update{bid(49999.99, 9)}))
+		assert.Equal(t, int64(15), book.Sequence())
+		assert.True(t, book.Synced())
+	})
+
+	t.Run("gap resyncs through GetOrderBook", func(t *testing.T) {
+		var calls []string
+		resync := func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+			calls = append(calls, symbol)
+			price, quantity, sequence := 49000.0, 1.0, int64(20)
+			return &marketsv1.OrderBook{
+				Timestamp: timestamppb.New(updateTime),
+				Sequence:  &sequence,
+				Bids:      []*marketsv1.OrderBookLevel{{Price: &price, Quantity: &quantity}},
+			}, nil
+		}
+		book := newBook(t, orderbook.Config{Resync: resync})
+
+		require.NoError(t, book.ApplyDelta(context.Background(), 12, updateTime, []orderbook.Update{bid(49999.99, 9)}))
+		assert.Equal(t, []string{"BTC-USD"}, calls)
+		assert.Equal(t, int64(20), book.Sequence())
+
+		// Updates the snapshot already reflects are dropped; the next applies
+		require.NoError(t, book.ApplyDelta(context.Background(), 20, updateTime, []orderbook.Update{bid(49000, 5)}))
+		require.NoError(t, book.ApplyDelta(context.Background(), 21, updateTime, []orderbook.Update{ask(51000, 2)}))
+
+		snapshot, err := book.Snapshot(0)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]float64{{49000, 1}}, levels(snapshot.GetBids()), "update after gap must not be applied")
+		assert.Equal(t, [][2]float64{{51000, 2}}, levels(snapshot.GetAsks()))
+		assert.Len(t, calls, 1)
+	})
+
+	t.Run("resync replays deltas newer than the snapshot", func(t *testing.T) {
+		var book *orderbook.Book
+		book = newBook(t, orderbook.Config{
+			Resync: func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+				// A delta that arrives while the snapshot is fetched is buffered
+				require.NoError(t, book.ApplyDelta(ctx, 13, updateTime, []orderbook.Update{ask(51000, 3)}))
+
+				// The snapshot is older than the delta that triggered the resync
+				price, quantity, sequence := 49000.0, 1.0, int64(11)
+				return &marketsv1.OrderBook{
+					Timestamp: timestamppb.New(snapshotTime),
+					Sequence:  &sequence,
+					Bids:      []*marketsv1.OrderBookLevel{{Price: &price, Quantity: &quantity}},
+				}, nil
+			},
+		})
+
+		require.NoError(t, book.ApplyDelta(context.Background(), 12, updateTime, []orderbook.Update{bid(49000, 5)}))
+		assert.True(t, book.Synced())
+		assert.Equal(t, int64(13), book.Sequence())
+
+		// The next delta follows the replayed ones without another resync
+		require.NoError(t, book.ApplyDelta(context.Background(), 14, updateTime, []orderbook.Update{bid(48000, 1)}))
+
+		snapshot, err := book.Snapshot(0)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]float64{{49000, 5}, {48000, 1}}, levels(snapshot.GetBids()))
+		assert.Equal(t, [][2]float64{{51000, 3}}, levels(snapshot.GetAsks()))
+		assert.Equal(t, int64(14), snapshot.GetSequence())
+	})
+
+	t.Run("unsequenced resync accepts the next update as baseline", func(t *testing.T) {
+		book := orderbook.New(orderbook.Config{
+			Symbol: "BTC-USD",
+			Resync: func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+				price, quantity := 49000.0, 1.0
+				return &marketsv1.OrderBook{Bids: []*marketsv1.OrderBookLevel{{Price: &price, Quantity: &quantity}}}, nil
+			},
+		})
+
+		// The first update arrives before any snapshot and triggers the resync
+		require.NoError(t, book.ApplyDelta(context.Background(), 500, updateTime, []orderbook.Update{bid(49000, 3)}))
+		assert.Equal(t, orderbook.NoSequence, book.Sequence())
+
+		require.NoError(t, book.ApplyDelta(context.Background(), 502, updateTime, []orderbook.Update{bid(49000, 4)}))
+		require.NoError(t, book.ApplyDelta(context.Background(), 503, updateTime, []orderbook.Update{bid(49000, 5)}))
+		bids, _, err := book.Depth(0)
+		require.NoError(t, err)
+		assert.Equal(t, []orderbook.Level{{Price: 49000, Quantity: 5}}, bids)
+	})
+
+	t.Run("failed resync leaves the book unsynced", func(t *testing.T) {
+		unavailable := errors.New("unavailable")
+		book := newBook(t, orderbook.Config{
+			Resync: func(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+				return nil, unavailable
+			},
+		})
+
+		err := book.ApplyDelta(context.Background(), 12, updateTime, nil)
+		var gap *orderbook.GapError
+		assert.ErrorAs(t, err, &gap)
+		assert.ErrorIs(t, err, unavailable)
+		assert.False(t, book.Synced())
+	})
+
+	t.Run("reset unsyncs the book", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+		book.Reset()
+		assert.False(t, book.Synced())
+		assert.Equal(t, orderbook.NoSequence, book.Sequence())
+	})
+
+	t.Run("concurrent readers see consistent copies", func(t *testing.T) {
+		book := newBook(t, orderbook.Config{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					snapshot, err := book.Snapshot(0)
+					if assert.NoError(t, err) {
+						// Every update moves both sides together
+						assert.Equal(t, snapshot.GetBids()[0].GetQuantity(), snapshot.GetAsks()[0].GetQuantity())
+					}
+				}
+			}()
+		}
+		for seq := int64(11); seq < 211; seq++ {
+			quantity := float64(seq)
+			require.NoError(t, book.ApplyDelta(context.Background(), seq, updateTime, []orderbook.Update{
+				bid(49999.99, quantity), ask(50000.01, quantity),
+			}))
+		}
+		book.ApplySnapshot(300, updateTime, []orderbook.Update{bid(1, 1), ask(2, 1)})
+		wg.Wait()
+	})
+}
+
+func TestBookVWAP(t *testing.T) {
+	book := newBook(t, orderbook.Config{})
+
+	tests := []struct {
+		name     string
+		side     orderbook.Side
+		quantity float64
+		price    float64
+		filled   float64
+	}{
+		{name: "within best ask", side: orderbook.Ask, quantity: 0.4, price: 50000.01, filled: 0.4},
+		// (0.4 * 50000.01 + 0.6 * 50000.50) / 1
+		{name: "across asks", side: orderbook.Ask, quantity: 1, price: 50000.304, filled: 1},
+		// (0.5 * 49999.99 + 1.2 * 49999.50 + 0.3 * 49998) / 2
+		{name: "across bids", side: orderbook.Bid, quantity: 2, price: 49999.3975, filled: 2},
+		// (0.4 * 50000.01 + 2.1 * 50000.50) / 2.5
+		{name: "deeper than the book", side: orderbook.Ask, quantity: 10, price: 50000.4216, filled: 2.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, filled, err := book.VWAP(tt.side, tt.quantity)
+			require.NoError(t, err)
+			assert.Equal(t, tt.price, price)
+			assert.Equal(t, tt.filled, filled)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := book.VWAP(orderbook.Ask, 0)
+		assert.Error(t, err)
+
+		_, _, err = orderbook.New(orderbook.Config{}).VWAP(orderbook.Bid, 1)
+		assert.ErrorIs(t, err, orderbook.ErrNotSynced)
+	})
+}
+
+// BenchmarkBookApplyDelta benchmarks applying a delta to a 1000-level book.
+func BenchmarkBookApplyDelta(b *testing.B) {
+	levels := make([]orderbook.Update, 0, 1000)
+	for i := 0; i < 500; i++ {
+		levels = append(levels, bid(50000-float64(i), 1), ask(50001+float64(i), 1))
+	}
+	book := orderbook.New(orderbook.Config{Symbol: "BTC-USD"})
+	book.ApplySnapshot(0, snapshotTime, levels)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		price := 50000 - float64(i%500)
+		_ = book.ApplyDelta(context.Background(), int64(i+1), updateTime, []orderbook.Update{
+			bid(price, float64(i%3)), bid(price, 1),
+		})
+	}
+}
@@ -0,0 +1,446 @@
+// Package ratelimit keeps venue clients within the venue's REST rate limits.
+//
+// Venues limit requests per bucket: Coinbase Advanced Trade counts public and
+// private endpoints separately, Coinbase Prime limits each portfolio, and some
+// venues charge expensive endpoints several units of the budget. A Limiter
+// models these as token buckets and Rules that map each request to the buckets
+// it counts against and its weight.
+//
+// Middleware plugs a Limiter into the HTTP transport chain in front of
+// auth.Middleware, so requests are signed after they leave the queue:
+//
+//	client := &http.Client{
+//	    Transport: ratelimit.Middleware(limiter, auth.Middleware(signer, http.DefaultTransport)),
+//	}
+//
+// A request that must wait for budget is queued until the budget is available,
+// unless its context deadline would pass first; it is then rejected at once
+// with a *venueerr.Error matching venueerr.ErrRateLimited and ErrLimited,
+// rather than being sent late. When the venue answers 429 anyway, or reports
+// an exhausted budget in its headers, the matching buckets are paused until
+// the venue's reset time.
+//
+// A Limiter is safe for concurrent use.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// DefaultPause is how long buckets are paused after a 429 response that does
+// not say when to retry.
+const DefaultPause = time.Second
+
+// ErrLimited is wrapped by errors for requests rejected by a Limiter before
+// they were sent, to tell them from rate limit errors returned by the venue.
+var ErrLimited = errors.New("client-side rate limit")
+
+// Bucket is a token bucket: Rate units of budget are restored per second, up
+// to Burst.
+type Bucket struct {
+	// Name identifies the bucket in Rules and Usage (e.g., "private").
+	Name string
+
+	// Rate is the sustained number of units allowed per second.
+	Rate float64
+
+	// Burst is the maximum number of units that can be spent at once
+	// (default: Rate, rounded up).
+	Burst float64
+}
+
+// Rule maps requests to the buckets they count against.
+type Rule struct {
+	// Method matches the request method. Empty matches any method.
+	Method string
+
+	// PathPrefix matches the start of the request URL path. Empty matches
+	// any path.
+	PathPrefix string
+
+	// Buckets are the names of the buckets the request counts against.
+	Buckets []string
+
+	// Weight is the number of units the request costs in each bucket
+	// (default: 1).
+	Weight float64
+}
+
+// matches reports whether the rule applies to a request.
+func (r Rule) matches(method, path string) bool {
+	return (r.Method == "" || strings.EqualFold(r.Method, method)) && strings.HasPrefix(path, r.PathPrefix)
+}
+
+// Config describes a venue's rate limits.
+type Config struct {
+	// Venue identifies the venue in rejection errors (e.g., "coinbase").
+	Venue string
+
+	// Buckets are the venue's token buckets.
+	Buckets []Bucket
+
+	// Rules map requests to buckets. The first matching rule applies;
+	// requests that match no rule are not limited.
+	Rules []Rule
+}
+
+// IsZero reports whether the config defines no buckets.
+func (c Config) IsZero() bool {
+	return len(c.Buckets) == 0 && len(c.Rules) == 0
+}
+
+// Validate checks that every bucket has a positive rate and every rule refers
+// to defined buckets with a weight that fits in their burst.
+func (c Config) Validate() error {
+	buckets := make(map[string]Bucket, len(c.Buckets))
+	for _, b := range c.Buckets {
+		if b.Name == "" {
+			return fmt.Errorf("bucket name is required")
+		}
+		if _, ok := buckets[b.Name]; ok {
+			return fmt.Errorf("duplicate bucket %q", b.Name)
+		}
+		if b.Rate <= 0 || math.IsInf(b.Rate, 0) || math.IsNaN(b.Rate) {
+			return fmt.Errorf("bucket %q: rate must be positive", b.Name)
+		}
+		if b.Burst < 0 {
+			return fmt.Errorf("bucket %q: burst must be non-negative", b.Name)
+		}
+		buckets[b.Name] = b.withDefaults()
+	}
+	for i, r := range c.Rules {
+		if len(r.Buckets) == 0 {
+			return fmt.Errorf("rule %d: at least one bucket is required", i)
+		}
+		if r.Weight < 0 {
+			return fmt.Errorf("rule %d: weight must be non-negative", i)
+		}
+		weight := r.withDefaults().Weight
+		for _, name := range r.Buckets {
+			b, ok := buckets[name]
+			if !ok {
+				return fmt.Errorf("rule %d: unknown bucket %q", i, name)
+			}
+			if weight > b.Burst {
+				return fmt.Errorf("rule %d: weight %g exceeds burst %g of bucket %q", i, weight, b.Burst, name)
+			}
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the bucket with default values applied.
+func (b Bucket) withDefaults() Bucket {
+	if b.Burst == 0 {
+		b.Burst = math.Ceil(b.Rate)
+	}
+	return b
+}
+
+// withDefaults returns a copy of the rule with default values applied.
+func (r Rule) withDefaults() Rule {
+	if r.Weight == 0 {
+		r.Weight = 1
+	}
+	return r
+}
+
+// Usage reports the state of a bucket.
+type Usage struct {
+	// Bucket is the bucket name.
+	Bucket string
+
+	// Rate and Burst are the bucket's configured limits.
+	Rate  float64
+	Burst float64
+
+	// Available is the budget that can be spent now without waiting. It is
+	// zero while requests are queued or the bucket is paused.
+	Available float64
+
+	// Utilization is the share of the burst in use, from 0 (idle) to 1
+	// (exhausted). Queued requests and pauses can push it above 1.
+	Utilization float64
+
+	// Waiting is the number of requests queued for the bucket.
+	Waiting int
+}
+
+// Limiter enforces a Config.
+type Limiter struct {
+	venue string
+	rules []Rule
+
+	mu      sync.Mutex
+	buckets map[string]*bucketState
+	order   []string
+}
+
+// bucketState is the current budget of a bucket. Tokens may be negative:
+// queued requests reserve budget that has not been restored yet.
+type bucketState struct {
+	Bucket
+	tokens  float64
+	updated time.Time
+	waiting int
+	paused  time.Time // end of the latest pause
+}
+
+// New creates a Limiter. All buckets start full.
+func New(config Config) (*Limiter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	now := time.Now()
+	l := &Limiter{
+		venue:   config.Venue,
+		rules:   make([]Rule, len(config.Rules)),
+		buckets: make(map[string]*bucketState, len(config.Buckets)),
+	}
+	for i, r := range config.Rules {
+		l.rules[i] = r.withDefaults()
+	}
+	for _, b := range config.Buckets {
+		b = b.withDefaults()
+		l.buckets[b.Name] = &bucketState{Bucket: b, tokens: b.Burst, updated: now}
+		l.order = append(l.order, b.Name)
+	}
+	return l, nil
+}
+
+// rule returns the rule for a request, or false if it is not limited.
+func (l *Limiter) rule(method, path string) (Rule, bool) {
+	for _, r := range l.rules {
+		if r.matches(method, path) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// refill restores the budget earned since the last update. The caller must
+// hold mu.
+func (b *bucketState) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(b.Burst, b.tokens+elapsed.Seconds()*b.Rate)
+		b.updated = now
+	}
+}
+
+// Wait blocks until the request may be sent, spending its weight from every
+// bucket of the first matching rule.
+//
+// If the wait would outlast the context deadline, Wait returns immediately
+// without spending budget; the error matches venueerr.ErrRateLimited and
+// ErrLimited, and its RetryAfter is the wait needed. If the context is
+// canceled while waiting, the reserved budget is returned and ctx.Err() is
+// returned.
+func (l *Limiter) Wait(ctx context.Context, method, path string) error {
+	rule, ok := l.rule(method, path)
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	var wait time.Duration
+	var limiting string
+	for _, name := range rule.Buckets {
+		b := l.buckets[name]
+		b.refill(now)
+		if deficit := rule.Weight - b.tokens; deficit > 0 {
+			if d := time.Duration(deficit / b.Rate * float64(time.Second)); d > wait {
+				wait, limiting = d, name
+			}
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		b := l.buckets[limiting]
+		l.mu.Unlock()
+		return &venueerr.Error{
+			Venue:      l.venue,
+			Category:   venueerr.ErrRateLimited,
+			Message:    fmt.Sprintf("%s %s would wait %s for bucket %q, past the context deadline", method, path, wait.Round(time.Millisecond), limiting),
+			RetryAfter: wait,
+			RateLimit:  &venueerr.RateLimit{Bucket: limiting, Limit: int(b.Burst), Remaining: 0, Reset: now.Add(wait)},
+			Err:        ErrLimited,
+		}
+	}
+
+	for _, name := range rule.Buckets {
+		b := l.buckets[name]
+		b.tokens -= rule.Weight
+		if wait > 0 {
+			b.waiting++
+		}
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			l.done(rule, true)
+			return ctx.Err()
+		}
+
+		// A pause that began while the request was queued outlasts its timer
+		if resume := time.Until(l.pausedUntil(rule)); resume > 0 {
+			timer.Reset(resume)
+			continue
+		}
+		l.done(rule, false)
+		return nil
+	}
+}
+
+// pausedUntil returns the latest end of a pause of the rule's buckets.
+func (l *Limiter) pausedUntil(rule Rule) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var until time.Time
+	for _, name := range rule.Buckets {
+		if paused := l.buckets[name].paused; paused.After(until) {
+			until = paused
+		}
+	}
+	return until
+}
+
+// done removes a queued request, refunding its weight if it was canceled.
+func (l *Limiter) done(rule Rule, refund bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, name := range rule.Buckets {
+		b := l.buckets[name]
+		b.waiting--
+		if refund {
+			b.tokens = math.Min(b.Burst, b.tokens+rule.Weight)
+		}
+	}
+}
+
+// Pause empties the buckets a request counts against until the given time, so
+// that queued and new requests wait for the venue's limit to reset. Requests
+// already queued whose wait would end sooner are held until the pause ends.
+// It is called by Middleware when the venue rejects a request with 429.
+func (l *Limiter) Pause(method, path string, until time.Time) {
+	rule, ok := l.rule(method, path)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	pause := until.Sub(now)
+	if pause <= 0 {
+		return
+	}
+	for _, name := range rule.Buckets {
+		b := l.buckets[name]
+		b.refill(now)
+		// Tokens recover at Rate, so a deficit of Rate*pause lasts until then
+		b.tokens = math.Min(b.tokens, -pause.Seconds()*b.Rate)
+		if until.After(b.paused) {
+			b.paused = until
+		}
+	}
+}
+
+// Utilization returns the usage of every bucket, in configuration order.
+func (l *Limiter) Utilization() []Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	usage := make([]Usage, 0, len(l.order))
+	for _, name := range l.order {
+		b := l.buckets[name]
+		b.refill(now)
+		usage = append(usage, Usage{
+			Bucket:      name,
+			Rate:        b.Rate,
+			Burst:       b.Burst,
+			Available:   math.Max(b.tokens, 0),
+			Utilization: 1 - b.tokens/b.Burst,
+			Waiting:     b.waiting,
+		})
+	}
+	return usage
+}
+
+// Middleware returns an http.RoundTripper that waits for the limiter before
+// forwarding each request to next, and pauses the request's buckets when the
+// venue answers 429 or reports an exhausted budget.
+//
+// If next is nil, http.DefaultTransport is used. A nil limiter returns next
+// unchanged.
+func Middleware(limiter *Limiter, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if limiter == nil {
+		return next
+	}
+	return &limitTransport{limiter: limiter, next: next}
+}
+
+// limitTransport is an http.RoundTripper that applies a Limiter to requests.
+type limitTransport struct {
+	limiter *Limiter
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper by waiting for rate limit budget
+// before forwarding the request.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context(), req.Method, req.URL.Path); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	limit := venueerr.ParseRateLimit(resp.Header, now)
+	var until time.Time
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		until = now.Add(venueerr.ParseRetryAfter(resp.Header, now))
+		if limit != nil && limit.Reset.After(until) {
+			until = limit.Reset
+		}
+		if !until.After(now) {
+			until = now.Add(DefaultPause)
+		}
+	case limit != nil && limit.Remaining == 0:
+		until = limit.Reset
+	}
+	if !until.IsZero() {
+		t.limiter.Pause(req.Method, req.URL.Path, until)
+	}
+	return resp, nil
+}
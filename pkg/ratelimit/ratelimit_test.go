@@ -0,0 +1,264 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLimiter(t *testing.T, config ratelimit.Config) *ratelimit.Limiter {
+	t.Helper()
+	limiter, err := ratelimit.New(config)
+	require.NoError(t, err)
+	return limiter
+}
+
+func usage(t *testing.T, limiter *ratelimit.Limiter, bucket string) ratelimit.Usage {
+	t.Helper()
+	for _, u := range limiter.Utilization() {
+		if u.Bucket == bucket {
+			return u
+		}
+	}
+	t.Fatalf("bucket %q not found", bucket)
+	return ratelimit.Usage{}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := ratelimit.Config{
+		Buckets: []ratelimit.Bucket{{Name: "private", Rate: 30}},
+		Rules:   []ratelimit.Rule{{Buckets: []string{"private"}, Weight: 30}},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.True(t, ratelimit.Config{}.IsZero())
+	assert.False(t, valid.IsZero())
+
+	invalid := []struct {
+		name   string
+		config ratelimit.Config
+	}{
+		{name: "unnamed bucket", config: ratelimit.Config{Buckets: []ratelimit.Bucket{{Rate: 1}}}},
+		{name: "duplicate bucket", config: ratelimit.Config{Buckets: []ratelimit.Bucket{{Name: "a", Rate: 1}, {Name: "a", Rate: 2}}}},
+		{name: "zero rate", config: ratelimit.Config{Buckets: []ratelimit.Bucket{{Name: "a"}}}},
+		{name: "negative burst", config: ratelimit.Config{Buckets: []ratelimit.Bucket{{Name: "a", Rate: 1, Burst: -1}}}},
+		{name: "rule without buckets", config: ratelimit.Config{Rules: []ratelimit.Rule{{}}}},
+		{name: "unknown bucket", config: ratelimit.Config{Rules: []ratelimit.Rule{{Buckets: []string{"a"}}}}},
+		{name: "weight exceeds burst", config: ratelimit.Config{
+			Buckets: []ratelimit.Bucket{{Name: "a", Rate: 10, Burst: 5}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"a"}, Weight: 6}},
+		}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.config.Validate())
+			_, err := ratelimit.New(tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("burst then queue", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 50, Burst: 2}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+		})
+
+		start := time.Now()
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/orders"))
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/orders"))
+		assert.Less(t, time.Since(start), 15*time.Millisecond, "burst is not delayed")
+
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/orders"))
+		assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond, "third request waits for budget")
+	})
+
+	t.Run("rules select buckets and weights", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Buckets: []ratelimit.Bucket{
+				{Name: "private", Rate: 1, Burst: 10},
+				{Name: "public", Rate: 1, Burst: 10},
+			},
+			Rules: []ratelimit.Rule{
+				{PathPrefix: "/market/", Buckets: []string{"public"}},
+				{Method: http.MethodPost, PathPrefix: "/orders", Buckets: []string{"private"}, Weight: 4},
+				{PathPrefix: "/orders", Buckets: []string{"private"}},
+			},
+		})
+
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/market/products"))
+		require.NoError(t, limiter.Wait(ctx, http.MethodPost, "/orders"))
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/orders/123"))
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/time"), "unmatched requests are not limited")
+
+		assert.InDelta(t, 5, usage(t, limiter, "private").Available, 0.1)
+		assert.InDelta(t, 9, usage(t, limiter, "public").Available, 0.1)
+		assert.InDelta(t, 0.5, usage(t, limiter, "private").Utilization, 0.01)
+	})
+
+	t.Run("rejects past deadline", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Venue:   "coinbase",
+			Buckets: []ratelimit.Bucket{{Name: "private", Rate: 1}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"private"}}},
+		})
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/orders"))
+
+		deadlineCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := limiter.Wait(deadlineCtx, http.MethodGet, "/orders")
+		assert.Less(t, time.Since(start), 50*time.Millisecond, "rejected without waiting")
+
+		assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+		assert.ErrorIs(t, err, ratelimit.ErrLimited)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.Equal(t, "coinbase", venueErr.Venue)
+		assert.Zero(t, venueErr.StatusCode)
+		assert.InDelta(t, float64(time.Second), float64(venueErr.RetryAfter), float64(100*time.Millisecond))
+		require.NotNil(t, venueErr.RateLimit)
+		assert.Equal(t, "private", venueErr.RateLimit.Bucket)
+
+		assert.Zero(t, usage(t, limiter, "private").Waiting, "rejected requests spend no budget")
+	})
+
+	t.Run("cancel refunds budget", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 1}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+		})
+		require.NoError(t, limiter.Wait(ctx, http.MethodGet, "/"))
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() { done <- limiter.Wait(cancelCtx, http.MethodGet, "/") }()
+
+		require.Eventually(t, func() bool { return usage(t, limiter, "rest").Waiting == 1 }, time.Second, time.Millisecond)
+		assert.Greater(t, usage(t, limiter, "rest").Utilization, 1.0, "queued requests overdraw the bucket")
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+		u := usage(t, limiter, "rest")
+		assert.Zero(t, u.Waiting)
+		assert.LessOrEqual(t, u.Utilization, 1.0)
+	})
+}
+
+func TestLimiterPause(t *testing.T) {
+	limiter := newLimiter(t, ratelimit.Config{
+		Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 100, Burst: 10}},
+		Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+	})
+
+	limiter.Pause(http.MethodGet, "/", time.Now().Add(30*time.Millisecond))
+	assert.Zero(t, usage(t, limiter, "rest").Available)
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background(), http.MethodGet, "/"))
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+
+	t.Run("holds requests queued before the pause", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 50, Burst: 1}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+		})
+		require.NoError(t, limiter.Wait(context.Background(), http.MethodGet, "/"))
+
+		// The queued request's own wait is 20ms; the pause lasts 100ms
+		start := time.Now()
+		queued := make(chan time.Duration)
+		go func() {
+			assert.NoError(t, limiter.Wait(context.Background(), http.MethodGet, "/"))
+			queued <- time.Since(start)
+		}()
+		require.Eventually(t, func() bool { return usage(t, limiter, "rest").Waiting == 1 }, time.Second, time.Millisecond)
+		limiter.Pause(http.MethodGet, "/", start.Add(100*time.Millisecond))
+
+		assert.GreaterOrEqual(t, <-queued, 100*time.Millisecond)
+	})
+
+	t.Run("canceled while held", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 50, Burst: 1}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+		})
+		require.NoError(t, limiter.Wait(context.Background(), http.MethodGet, "/"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- limiter.Wait(ctx, http.MethodGet, "/") }()
+		require.Eventually(t, func() bool { return usage(t, limiter, "rest").Waiting == 1 }, time.Second, time.Millisecond)
+		limiter.Pause(http.MethodGet, "/", time.Now().Add(time.Minute))
+
+		time.Sleep(40 * time.Millisecond) // past the queued request's own wait
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+		assert.Zero(t, usage(t, limiter, "rest").Waiting)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0.05")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := newLimiter(t, ratelimit.Config{
+		Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 100, Burst: 10}},
+		Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+	})
+	client := &http.Client{Transport: ratelimit.Middleware(limiter, nil)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	start := time.Now()
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "429 pauses the bucket until Retry-After")
+
+	t.Run("rejection", func(t *testing.T) {
+		limiter := newLimiter(t, ratelimit.Config{
+			Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 0.1}},
+			Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+		})
+		client := &http.Client{Transport: ratelimit.Middleware(limiter, nil)}
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		assert.True(t, errors.Is(err, ratelimit.ErrLimited))
+		assert.Equal(t, int32(3), calls.Load(), "rejected request is not sent")
+	})
+
+	t.Run("nil limiter", func(t *testing.T) {
+		assert.Equal(t, http.DefaultTransport, ratelimit.Middleware(nil, nil))
+	})
+}
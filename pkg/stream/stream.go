@@ -0,0 +1,37 @@
+// Package stream defines the WebSocket transport abstraction used by venue
+// clients for streaming market data and private order updates, and the
+// reconnecting Subscription they run over it.
+//
+// Venue clients never dial WebSockets directly. They accept a Dialer in their
+// constructor so that consuming services can inject a pooled, instrumented or
+// fake transport (for tests) without changing venue code.
+package stream
+
+import (
+	"context"
+	"net/http"
+)
+
+// Conn is a single WebSocket connection carrying text or binary messages.
+//
+// Implementations must allow one concurrent reader and one concurrent writer.
+// Close may be called concurrently with ReadMessage to unblock it.
+type Conn interface {
+	// ReadMessage blocks until the next message is received, the context is
+	// cancelled, or the connection fails.
+	ReadMessage(ctx context.Context) ([]byte, error)
+
+	// WriteMessage sends a single message on the connection.
+	WriteMessage(ctx context.Context, data []byte) error
+
+	// Close closes the underlying connection.
+	Close() error
+}
+
+// Dialer opens WebSocket connections.
+type Dialer interface {
+	// Dial connects to the WebSocket endpoint at url using the provided
+	// handshake headers. The context bounds the handshake only; it does not
+	// control the lifetime of the returned connection.
+	Dial(ctx context.Context, url string, header http.Header) (Conn, error)
+}
@@ -0,0 +1,148 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultReconnectMinBackoff is the initial delay before reconnecting a
+	// dropped subscription when no positive MinBackoff is set.
+	DefaultReconnectMinBackoff = 500 * time.Millisecond
+
+	// DefaultReconnectMaxBackoff caps the exponential reconnect delay when no
+	// positive MaxBackoff is set.
+	DefaultReconnectMaxBackoff = 30 * time.Second
+)
+
+// HandlerError wraps an error returned by a caller's handler. Handler errors
+// end a Subscription instead of triggering a reconnect.
+type HandlerError struct {
+	Err error
+}
+
+func (e *HandlerError) Error() string { return e.Err.Error() }
+func (e *HandlerError) Unwrap() error { return e.Err }
+
+// Subscription runs a venue WebSocket subscription over successive
+// connections, reconnecting with exponential backoff whenever a connection
+// fails. Venues supply only how to connect and subscribe, how to decode a
+// message and what to do with it; M is the venue's decoded message type.
+//
+// Messages on a connection must carry consecutive sequence numbers. A gap
+// means messages were lost, so the connection is abandoned and a new one is
+// opened to get a fresh snapshot.
+type Subscription[M any] struct {
+	// Connect dials the feed and sends the subscribe messages. It is called
+	// for every (re)connection, so it is also where per-connection state such
+	// as a local order book is reset.
+	Connect func(ctx context.Context) (Conn, error)
+
+	// Decode parses a raw message and returns its sequence number.
+	Decode func(data []byte) (msg M, sequence int64, err error)
+
+	// Handle processes a decoded message and reports whether it carried data
+	// for the subscription (rather than, say, a heartbeat). Handle returns a
+	// *HandlerError to end the subscription; any other error reconnects.
+	Handle func(ctx context.Context, msg M) (received bool, err error)
+
+	// MinBackoff is the initial delay before reconnecting
+	// (default: DefaultReconnectMinBackoff). The delay doubles after each
+	// failed attempt up to MaxBackoff, and starts afresh once a connection
+	// has delivered data.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the reconnect delay (default: DefaultReconnectMaxBackoff).
+	MaxBackoff time.Duration
+
+	// OnDisconnect, if set, is called with the error that ended each
+	// connection and the delay before the next attempt.
+	OnDisconnect func(err error, backoff time.Duration)
+}
+
+// Run runs the subscription until ctx is cancelled (returning ctx.Err()) or
+// Handle returns a *HandlerError (returning the error it wraps).
+func (s *Subscription[M]) Run(ctx context.Context) error {
+	if s.Connect == nil || s.Decode == nil || s.Handle == nil {
+		return fmt.Errorf("connect, decode and handle functions are required")
+	}
+
+	minBackoff := s.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultReconnectMinBackoff
+	}
+	maxBackoff := s.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultReconnectMaxBackoff
+	}
+	maxBackoff = max(maxBackoff, minBackoff)
+
+	backoff := minBackoff
+	for {
+		received, err := s.runConnection(ctx)
+
+		var hErr *HandlerError
+		if errors.As(err, &hErr) {
+			return hErr.Err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// A connection that delivered data was healthy; start backing off afresh.
+		if received {
+			backoff = minBackoff
+		}
+
+		if s.OnDisconnect != nil {
+			s.OnDisconnect(err, backoff)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// runConnection connects and processes messages until the connection fails.
+// It reports whether any data message was received.
+func (s *Subscription[M]) runConnection(ctx context.Context) (bool, error) {
+	conn, err := s.Connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	received := false
+	lastSequence := int64(-1)
+	for {
+		raw, err := conn.ReadMessage(ctx)
+		if err != nil {
+			return received, err
+		}
+
+		msg, sequence, err := s.Decode(raw)
+		if err != nil {
+			return received, err
+		}
+
+		if lastSequence >= 0 && sequence != lastSequence+1 {
+			return received, fmt.Errorf("websocket sequence gap: expected %d, got %d", lastSequence+1, sequence)
+		}
+		lastSequence = sequence
+
+		data, err := s.Handle(ctx, msg)
+		received = received || data
+		if err != nil {
+			return received, err
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedConn replays a fixed list of messages, then fails.
+type scriptedConn struct {
+	messages []string
+}
+
+func (c *scriptedConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	if len(c.messages) == 0 {
+		return nil, errors.New("connection closed")
+	}
+	msg := c.messages[0]
+	c.messages = c.messages[1:]
+	return []byte(msg), nil
+}
+
+func (c *scriptedConn) WriteMessage(ctx context.Context, data []byte) error { return nil }
+func (c *scriptedConn) Close() error                                        { return nil }
+
+// newSubscription returns a subscription whose nth connection replays
+// connections[n]. Messages are "sequence:payload"; a "heartbeat" payload is
+// not subscription data.
+func newSubscription(connections [][]string, handle func(payload string) error) *stream.Subscription[string] {
+	n := 0
+	return &stream.Subscription[string]{
+		Connect: func(ctx context.Context) (stream.Conn, error) {
+			if n >= len(connections) {
+				return nil, errors.New("unavailable")
+			}
+			n++
+			return &scriptedConn{messages: connections[n-1]}, nil
+		},
+		Decode: func(data []byte) (string, int64, error) {
+			var sequence int64
+			var payload string
+			if _, err := fmt.Sscanf(string(data), "%d:%s", &sequence, &payload); err != nil {
+				return "", 0, err
+			}
+			return payload, sequence, nil
+		},
+		Handle: func(ctx context.Context, payload string) (bool, error) {
+			if payload == "heartbeat" {
+				return false, nil
+			}
+			return true, handle(payload)
+		},
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 4 * time.Millisecond,
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	t.Run("reconnects until a handler fails", func(t *testing.T) {
+		errDone := errors.New("done")
+		var payloads []string
+		sub := newSubscription([][]string{
+			{"0:a", "1:heartbeat", "2:b"},
+			{"7:c"},
+		}, func(payload string) error {
+			payloads = append(payloads, payload)
+			if payload == "c" {
+				return &stream.HandlerError{Err: errDone}
+			}
+			return nil
+		})
+
+		err := sub.Run(context.Background())
+		assert.ErrorIs(t, err, errDone)
+		assert.Equal(t, []string{"a", "b", "c"}, payloads)
+	})
+
+	t.Run("sequence gap reconnects", func(t *testing.T) {
+		errDone := errors.New("done")
+		var payloads []string
+		sub := newSubscription([][]string{
+			{"0:a", "2:lost"},
+			{"5:b"},
+		}, func(payload string) error {
+			payloads = append(payloads, payload)
+			if payload == "b" {
+				return &stream.HandlerError{Err: errDone}
+			}
+			return nil
+		})
+
+		assert.ErrorIs(t, sub.Run(context.Background()), errDone)
+		assert.Equal(t, []string{"a", "b"}, payloads, "message after a gap must not be handled")
+	})
+
+	t.Run("backoff doubles and resets after data", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub := newSubscription([][]string{
+			{}, {}, {}, {}, {"0:a"},
+		}, func(string) error { return nil })
+		var backoffs []time.Duration
+		sub.OnDisconnect = func(err error, backoff time.Duration) {
+			if backoffs = append(backoffs, backoff); len(backoffs) == 6 {
+				cancel()
+			}
+		}
+
+		assert.ErrorIs(t, sub.Run(ctx), context.Canceled)
+		assert.Equal(t, []time.Duration{
+			time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond, // capped
+			time.Millisecond, // reset after the connection delivered data
+			2 * time.Millisecond,
+		}, backoffs)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		sub := &stream.Subscription[string]{}
+		assert.Error(t, sub.Run(context.Background()))
+	})
+}
@@ -0,0 +1,88 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Ensure WebSocketDialer implements the Dialer interface at compile time
+var _ Dialer = (*WebSocketDialer)(nil)
+
+// WebSocketDialer is the default Dialer, backed by gorilla/websocket.
+//
+// Thread-safe: A single WebSocketDialer can be shared by many clients.
+type WebSocketDialer struct {
+	dialer *websocket.Dialer
+}
+
+// NewWebSocketDialer creates a Dialer using websocket.DefaultDialer settings.
+func NewWebSocketDialer() *WebSocketDialer {
+	return &WebSocketDialer{dialer: websocket.DefaultDialer}
+}
+
+// Dial connects to a WebSocket endpoint.
+func (d *WebSocketDialer) Dial(ctx context.Context, url string, header http.Header) (Conn, error) {
+	conn, resp, err := d.dialer.DialContext(ctx, url, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("failed to dial %s: %w (status %d)", url, err, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to dial %s: %w", url, err)
+	}
+	return &webSocketConn{conn: conn}, nil
+}
+
+// webSocketConn adapts a gorilla/websocket connection to the Conn interface.
+type webSocketConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// ReadMessage reads the next data message. Cancelling ctx unblocks the read by
+// expiring the read deadline, after which the connection must be closed.
+func (c *webSocketConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	_, data, err := c.conn.ReadMessage()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// WriteMessage sends a text message, honouring the context deadline if set.
+func (c *webSocketConn) WriteMessage(ctx context.Context, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Close closes the underlying network connection.
+func (c *webSocketConn) Close() error {
+	return c.conn.Close()
+}
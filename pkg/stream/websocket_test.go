@@ -0,0 +1,75 @@
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEchoServer(t *testing.T) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			mt, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(mt, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestWebSocketDialer(t *testing.T) {
+	ctx := context.Background()
+	url := newEchoServer(t)
+
+	conn, err := stream.NewWebSocketDialer().Dial(ctx, url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteMessage(ctx, []byte(`{"type":"subscribe"}`)))
+
+	data, err := conn.ReadMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"subscribe"}`, string(data))
+}
+
+func TestWebSocketDialer_ReadCancelled(t *testing.T) {
+	url := newEchoServer(t)
+
+	conn, err := stream.NewWebSocketDialer().Dial(context.Background(), url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = conn.ReadMessage(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWebSocketDialer_DialError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := stream.NewWebSocketDialer().Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,325 @@
+// Package symbols maps canonical base/quote pairs to and from venue symbols.
+//
+// Venues name the same market differently: Coinbase writes "BTC-USD", FalconX
+// "BTC/USD", and some venues call bitcoin "XBT". Order and market data
+// messages carry the venue's symbol unchanged in VenueSymbol; this package
+// translates between those symbols and a canonical Pair so that consumers can
+// place the same order on several venues and filter by base or quote asset.
+//
+// A Registry holds asset aliases, which apply to every venue (e.g., XBT is
+// BTC), and one Format per venue describing its separator, case, venue-only
+// asset codes and conversions. DefaultRegistry is preconfigured for the
+// supported venues.
+//
+// Example:
+//
+//	symbol, _ := symbols.DefaultRegistry.ToVenue("falconx", symbols.NewPair("XBT", "USD")) // "BTC/USD"
+//	pair, _ := symbols.DefaultRegistry.FromVenue("coinbase", "ETH-USDT")                     // ETH/USDT
+//
+// A Registry is safe for concurrent use.
+package symbols
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSymbol is returned (wrapped) when a symbol cannot be split into a
+// base and quote asset.
+var ErrInvalidSymbol = errors.New("invalid symbol")
+
+// Pair is a canonical trading pair. Assets are upper case canonical codes.
+type Pair struct {
+	// Base is the asset being traded (e.g., "BTC").
+	Base string
+
+	// Quote is the asset prices are quoted in (e.g., "USD").
+	Quote string
+}
+
+// NewPair returns the pair of base and quote, upper cased. Aliases are not
+// resolved; use Registry.Canonical for that.
+func NewPair(base, quote string) Pair {
+	return Pair{Base: normalizeAsset(base), Quote: normalizeAsset(quote)}
+}
+
+// String returns the pair as "BASE/QUOTE" (e.g., "BTC/USD").
+func (p Pair) String() string {
+	return p.Base + "/" + p.Quote
+}
+
+// IsZero reports whether neither asset is set.
+func (p Pair) IsZero() bool {
+	return p.Base == "" && p.Quote == ""
+}
+
+// Format describes how a venue writes symbols.
+type Format struct {
+	// Separator is placed between the base and quote asset (e.g., "-" for
+	// "BTC-USD"). If empty, symbols are concatenated ("BTCUSDT") and
+	// QuoteAssets is used to split them.
+	Separator string
+
+	// Lowercase is set for venues whose symbols are lower case ("btc-usd").
+	Lowercase bool
+
+	// Aliases maps the venue's asset codes to canonical codes where they
+	// differ (e.g., "XXBT": "BTC"). Each canonical code may have one alias.
+	Aliases map[string]string
+
+	// Conversions maps canonical assets the venue trades on another asset's
+	// books to that asset (e.g., "USDC": "USD" at a venue that converts USDC
+	// to USD at 1:1). ToVenue replaces the asset, and Match treats the two as
+	// the same asset.
+	Conversions map[string]string
+
+	// QuoteAssets lists the venue's quote asset codes, used to split symbols
+	// when Separator is empty. Longer codes are tried first.
+	QuoteAssets []string
+}
+
+// Registry maps canonical pairs to and from the symbols of registered venues.
+//
+// Symbols of venues that are not registered are parsed as "BASE-QUOTE",
+// "BASE/QUOTE" or "BASE_QUOTE", and formatted as "BASE/QUOTE".
+type Registry struct {
+	mu      sync.RWMutex
+	aliases map[string]string // alias -> canonical, for all venues
+	venues  map[string]*venueFormat
+}
+
+// venueFormat is a registered Format with its alias map inverted.
+type venueFormat struct {
+	Format
+	venueCodes map[string]string // canonical -> venue code
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		aliases: make(map[string]string),
+		venues:  make(map[string]*venueFormat),
+	}
+}
+
+// DefaultRegistry is the registry used by types.SymbolFilter. It resolves the
+// XBT alias for BTC and knows the formats of the supported venues:
+//   - coinbase: "BTC-USD"; USDC orders trade on the USD books
+//   - prime: "BTC-USD"
+//   - falconx: "BTC/USD"
+//
+// Fordefi orders name a single asset rather than a pair and are not included.
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.RegisterAlias("XBT", "BTC")
+	_ = r.RegisterVenue("coinbase", Format{Separator: "-", Conversions: map[string]string{"USDC": "USD"}})
+	_ = r.RegisterVenue("prime", Format{Separator: "-"})
+	_ = r.RegisterVenue("falconx", Format{Separator: "/"})
+	return r
+}
+
+// RegisterAlias makes alias another name for the canonical asset at every
+// venue (e.g., RegisterAlias("XBT", "BTC")).
+func (r *Registry) RegisterAlias(alias, canonical string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aliases[normalizeAsset(alias)] = normalizeAsset(canonical)
+}
+
+// RegisterVenue sets the symbol format of a venue, replacing any previous one.
+//
+// Returns an error if the venue ID is empty or two venue codes alias the same
+// canonical asset, since ToVenue could not choose between them.
+func (r *Registry) RegisterVenue(venueID string, format Format) error {
+	if venueID == "" {
+		return fmt.Errorf("venue ID is required")
+	}
+
+	vf := &venueFormat{Format: Format{
+		Separator:   format.Separator,
+		Lowercase:   format.Lowercase,
+		Aliases:     make(map[string]string, len(format.Aliases)),
+		Conversions: make(map[string]string, len(format.Conversions)),
+	}, venueCodes: make(map[string]string, len(format.Aliases))}
+
+	for code, canonical := range format.Aliases {
+		code, canonical = normalizeAsset(code), normalizeAsset(canonical)
+		if existing, ok := vf.venueCodes[canonical]; ok && existing != code {
+			return fmt.Errorf("%s: assets %s and %s both alias %s", venueID, existing, code, canonical)
+		}
+		vf.Aliases[code] = canonical
+		vf.venueCodes[canonical] = code
+	}
+	for from, to := range format.Conversions {
+		vf.Conversions[normalizeAsset(from)] = normalizeAsset(to)
+	}
+	for _, quote := range format.QuoteAssets {
+		vf.QuoteAssets = append(vf.QuoteAssets, normalizeAsset(quote))
+	}
+	sort.SliceStable(vf.QuoteAssets, func(i, j int) bool {
+		return len(vf.QuoteAssets[i]) > len(vf.QuoteAssets[j])
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.venues[venueID] = vf
+	return nil
+}
+
+// Canonical returns the canonical code of an asset, resolving aliases
+// registered with RegisterAlias (e.g., "xbt" -> "BTC").
+func (r *Registry) Canonical(asset string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.canonical(asset)
+}
+
+// canonical resolves asset; the caller must hold mu.
+func (r *Registry) canonical(asset string) string {
+	asset = normalizeAsset(asset)
+	if canonical, ok := r.aliases[asset]; ok {
+		return canonical
+	}
+	return asset
+}
+
+// ToVenue returns the venue's symbol for a pair (e.g., BTC/USD is "BTC-USD"
+// at Coinbase). Assets are resolved to canonical codes, then converted and
+// renamed to the venue's codes.
+//
+// Returns an error wrapping ErrInvalidSymbol if either asset is empty.
+func (r *Registry) ToVenue(venueID string, pair Pair) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	base, quote := r.canonical(pair.Base), r.canonical(pair.Quote)
+	if base == "" || quote == "" {
+		return "", fmt.Errorf("%w: %q: base and quote are required", ErrInvalidSymbol, pair.String())
+	}
+
+	vf, ok := r.venues[venueID]
+	if !ok {
+		return Pair{Base: base, Quote: quote}.String(), nil
+	}
+
+	symbol := vf.venueCode(base) + vf.Separator + vf.venueCode(quote)
+	if vf.Lowercase {
+		symbol = strings.ToLower(symbol)
+	}
+	return symbol, nil
+}
+
+// venueCode returns the venue's code for a canonical asset.
+func (vf *venueFormat) venueCode(asset string) string {
+	if converted, ok := vf.Conversions[asset]; ok {
+		asset = converted
+	}
+	if code, ok := vf.venueCodes[asset]; ok {
+		return code
+	}
+	return asset
+}
+
+// FromVenue returns the canonical pair of a venue symbol (e.g., "XBT/USD"
+// is BTC/USD). Conversions are not reversed: Coinbase's "BTC-USD" is BTC/USD
+// even though it also serves BTC/USDC orders.
+//
+// Returns an error wrapping ErrInvalidSymbol if the symbol cannot be split.
+func (r *Registry) FromVenue(venueID, symbol string) (Pair, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	vf := r.venues[venueID]
+	base, quote, ok := vf.split(strings.TrimSpace(symbol))
+	if !ok {
+		return Pair{}, fmt.Errorf("%w: %q", ErrInvalidSymbol, symbol)
+	}
+
+	base, quote = normalizeAsset(base), normalizeAsset(quote)
+	if vf != nil {
+		if canonical, ok := vf.Aliases[base]; ok {
+			base = canonical
+		}
+		if canonical, ok := vf.Aliases[quote]; ok {
+			quote = canonical
+		}
+	}
+	return Pair{Base: r.canonical(base), Quote: r.canonical(quote)}, nil
+}
+
+// split splits a symbol into its venue asset codes. A nil format accepts the
+// common separators.
+func (vf *venueFormat) split(symbol string) (string, string, bool) {
+	switch {
+	case vf == nil:
+		parts := strings.FieldsFunc(symbol, func(r rune) bool { return r == '-' || r == '/' || r == '_' })
+		if len(parts) != 2 {
+			return "", "", false
+		}
+		return parts[0], parts[1], true
+
+	case vf.Separator != "":
+		base, quote, ok := strings.Cut(symbol, vf.Separator)
+		if !ok || base == "" || quote == "" || strings.Contains(quote, vf.Separator) {
+			return "", "", false
+		}
+		return base, quote, true
+
+	default:
+		upper := normalizeAsset(symbol)
+		for _, quote := range vf.QuoteAssets {
+			if len(upper) > len(quote) && strings.HasSuffix(upper, quote) {
+				return symbol[:len(symbol)-len(quote)], symbol[len(symbol)-len(quote):], true
+			}
+		}
+		return "", "", false
+	}
+}
+
+// Match reports whether a venue symbol trades the given base and quote
+// assets. An empty base or quote matches any asset. Aliases are resolved, and
+// a venue's conversions count as the same asset, so a quote of "USDC" matches
+// Coinbase's "BTC-USD".
+//
+// Symbols that cannot be parsed match only if base and quote are both empty.
+func (r *Registry) Match(venueID, symbol, base, quote string) bool {
+	if base == "" && quote == "" {
+		return true
+	}
+	pair, err := r.FromVenue(venueID, symbol)
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	vf := r.venues[venueID]
+	return r.sameAsset(vf, base, pair.Base) && r.sameAsset(vf, quote, pair.Quote)
+}
+
+// sameAsset reports whether the filter asset matches the canonical asset of a
+// symbol at the venue. The caller must hold mu.
+func (r *Registry) sameAsset(vf *venueFormat, filter, asset string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = r.canonical(filter)
+	if filter == asset {
+		return true
+	}
+	if vf == nil {
+		return false
+	}
+	converted, ok := vf.Conversions[filter]
+	return ok && converted == asset
+}
+
+// normalizeAsset trims and upper cases an asset code.
+func normalizeAsset(asset string) string {
+	return strings.ToUpper(strings.TrimSpace(asset))
+}
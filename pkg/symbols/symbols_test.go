@@ -0,0 +1,134 @@
+package symbols_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Combine-Capital/cqvx/pkg/symbols"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPair(t *testing.T) {
+	pair := symbols.NewPair(" btc", "usd ")
+	assert.Equal(t, symbols.Pair{Base: "BTC", Quote: "USD"}, pair)
+	assert.Equal(t, "BTC/USD", pair.String())
+	assert.False(t, pair.IsZero())
+	assert.True(t, symbols.Pair{}.IsZero())
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	r := symbols.DefaultRegistry
+
+	tests := []struct {
+		venue  string
+		pair   symbols.Pair
+		symbol string
+	}{
+		{venue: "coinbase", pair: symbols.NewPair("BTC", "USD"), symbol: "BTC-USD"},
+		{venue: "coinbase", pair: symbols.NewPair("XBT", "USDC"), symbol: "BTC-USD"},
+		{venue: "prime", pair: symbols.NewPair("ETH", "USDC"), symbol: "ETH-USDC"},
+		{venue: "falconx", pair: symbols.NewPair("xbt", "usd"), symbol: "BTC/USD"},
+		{venue: "unknown", pair: symbols.NewPair("SOL", "EUR"), symbol: "SOL/EUR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.venue+" "+tt.pair.String(), func(t *testing.T) {
+			symbol, err := r.ToVenue(tt.venue, tt.pair)
+			require.NoError(t, err)
+			assert.Equal(t, tt.symbol, symbol)
+		})
+	}
+
+	pair, err := r.FromVenue("coinbase", "ETH-USDT")
+	require.NoError(t, err)
+	assert.Equal(t, symbols.NewPair("ETH", "USDT"), pair)
+
+	pair, err = r.FromVenue("falconx", "XBT/USD")
+	require.NoError(t, err)
+	assert.Equal(t, symbols.NewPair("BTC", "USD"), pair, "aliases resolve")
+
+	pair, err = r.FromVenue("", "sol_eur")
+	require.NoError(t, err)
+	assert.Equal(t, symbols.NewPair("SOL", "EUR"), pair)
+
+	_, err = r.FromVenue("coinbase", "BTC/USD")
+	assert.ErrorIs(t, err, symbols.ErrInvalidSymbol, "wrong separator for the venue")
+	_, err = r.FromVenue("", "BTCUSD")
+	assert.ErrorIs(t, err, symbols.ErrInvalidSymbol)
+	_, err = r.ToVenue("coinbase", symbols.NewPair("BTC", ""))
+	assert.ErrorIs(t, err, symbols.ErrInvalidSymbol)
+
+	assert.Equal(t, "BTC", r.Canonical("xbt"))
+	assert.Equal(t, "ETH", r.Canonical("eth"))
+}
+
+func TestRegistryVenueFormat(t *testing.T) {
+	r := symbols.NewRegistry()
+	r.RegisterAlias("XBT", "BTC")
+	require.NoError(t, r.RegisterVenue("kraken", symbols.Format{
+		Aliases:     map[string]string{"XXBT": "BTC", "ZUSD": "USD"},
+		QuoteAssets: []string{"ZUSD", "USDT", "USD"},
+	}))
+	require.NoError(t, r.RegisterVenue("lower", symbols.Format{Separator: "_", Lowercase: true}))
+
+	symbol, err := r.ToVenue("kraken", symbols.NewPair("XBT", "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, "XXBTZUSD", symbol)
+
+	pair, err := r.FromVenue("kraken", "XXBTZUSD")
+	require.NoError(t, err)
+	assert.Equal(t, symbols.NewPair("BTC", "USD"), pair)
+
+	pair, err = r.FromVenue("kraken", "ETHUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, symbols.NewPair("ETH", "USDT"), pair, "longest quote first")
+
+	_, err = r.FromVenue("kraken", "ETHEUR")
+	assert.ErrorIs(t, err, symbols.ErrInvalidSymbol)
+
+	symbol, err = r.ToVenue("lower", symbols.NewPair("ETH", "BTC"))
+	require.NoError(t, err)
+	assert.Equal(t, "eth_btc", symbol)
+
+	pair, err = r.FromVenue("lower", "eth_btc")
+	require.NoError(t, err)
+	assert.Equal(t, symbols.NewPair("ETH", "BTC"), pair)
+
+	assert.Error(t, r.RegisterVenue("", symbols.Format{}))
+	assert.Error(t, r.RegisterVenue("ambiguous", symbols.Format{
+		Aliases: map[string]string{"XBT": "BTC", "XXBT": "BTC"},
+	}))
+}
+
+func TestRegistryMatch(t *testing.T) {
+	r := symbols.DefaultRegistry
+
+	assert.True(t, r.Match("coinbase", "BTC-USD", "", ""))
+	assert.True(t, r.Match("coinbase", "not a symbol", "", ""))
+	assert.True(t, r.Match("coinbase", "BTC-USD", "BTC", "USD"))
+	assert.True(t, r.Match("coinbase", "BTC-USD", "xbt", ""))
+	assert.True(t, r.Match("coinbase", "BTC-USD", "", "USDC"), "USDC trades on USD books")
+	assert.False(t, r.Match("prime", "BTC-USD", "", "USDC"))
+	assert.False(t, r.Match("coinbase", "BTC-USDC", "", "USD"), "conversions apply one way")
+	assert.False(t, r.Match("coinbase", "ETH-USD", "BTC", ""))
+	assert.False(t, r.Match("coinbase", "not a symbol", "BTC", ""))
+}
+
+func TestRegistryConcurrency(t *testing.T) {
+	r := symbols.NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r.RegisterAlias("XBT", "BTC")
+			_ = r.RegisterVenue("coinbase", symbols.Format{Separator: "-"})
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = r.ToVenue("coinbase", symbols.NewPair("XBT", "USD"))
+			_ = r.Match("coinbase", "BTC-USD", "BTC", "")
+		}()
+	}
+	wg.Wait()
+}
@@ -3,7 +3,10 @@ package types
 
 import (
 	"errors"
+	"slices"
 	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/symbols"
 )
 
 // TimeRange represents a time interval for filtering data.
@@ -71,6 +74,7 @@ func (tr *TimeRange) Contains(t time.Time) bool {
 }
 
 // SymbolFilter provides filtering capabilities for trading symbols.
+// All set criteria must match.
 type SymbolFilter struct {
 	// Symbols is a list of trading pair symbols to filter by (e.g., "BTC-USD", "ETH-USD").
 	// If empty, all symbols are included.
@@ -83,6 +87,11 @@ type SymbolFilter struct {
 	// Quote filters by quote currency (e.g., "USD", "USDT").
 	// If empty, no quote currency filtering is applied.
 	Quote string
+
+	// Venue is the venue ID whose symbol format is used to parse symbols for
+	// Base and Quote filtering (e.g., "coinbase"). If empty, symbols are
+	// parsed as "BASE-QUOTE" or "BASE/QUOTE".
+	Venue string
 }
 
 // IsEmpty returns true if no filters are specified.
@@ -91,24 +100,15 @@ func (sf *SymbolFilter) IsEmpty() bool {
 }
 
 // Matches returns true if the given symbol matches the filter criteria.
+//
+// Base and Quote are matched by parsing the symbol with
+// symbols.DefaultRegistry, so aliases and venue conversions apply: a Base of
+// "XBT" matches "BTC-USD", and at Coinbase a Quote of "USDC" matches "BTC-USD".
 func (sf *SymbolFilter) Matches(symbol string) bool {
-	if sf.IsEmpty() {
-		return true
-	}
-
-	// Check explicit symbol list
-	if len(sf.Symbols) > 0 {
-		for _, s := range sf.Symbols {
-			if s == symbol {
-				return true
-			}
-		}
+	if len(sf.Symbols) > 0 && !slices.Contains(sf.Symbols, symbol) {
 		return false
 	}
-
-	// For base/quote filtering, venue implementations would need to parse symbols
-	// This is a simple check that can be extended by venue-specific implementations
-	return true
+	return symbols.DefaultRegistry.Match(sf.Venue, symbol, sf.Base, sf.Quote)
 }
 
 // PaginationParams defines common pagination parameters.
@@ -353,6 +353,54 @@ func TestSymbolFilter_Matches(t *testing.T) {
 			symbol: "BTC-USD",
 			want:   true,
 		},
+		{
+			name:   "base matches",
+			filter: types.SymbolFilter{Base: "BTC"},
+			symbol: "BTC-USD",
+			want:   true,
+		},
+		{
+			name:   "base doesn't match",
+			filter: types.SymbolFilter{Base: "ETH"},
+			symbol: "BTC-USD",
+			want:   false,
+		},
+		{
+			name:   "quote matches slash symbol",
+			filter: types.SymbolFilter{Quote: "usd"},
+			symbol: "ETH/USD",
+			want:   true,
+		},
+		{
+			name:   "base alias matches",
+			filter: types.SymbolFilter{Base: "XBT", Quote: "USD"},
+			symbol: "BTC-USD",
+			want:   true,
+		},
+		{
+			name:   "converted quote matches at venue",
+			filter: types.SymbolFilter{Quote: "USDC", Venue: "coinbase"},
+			symbol: "BTC-USD",
+			want:   true,
+		},
+		{
+			name:   "converted quote doesn't match elsewhere",
+			filter: types.SymbolFilter{Quote: "USDC", Venue: "prime"},
+			symbol: "BTC-USD",
+			want:   false,
+		},
+		{
+			name:   "symbols and base must both match",
+			filter: types.SymbolFilter{Symbols: []string{"BTC-USD"}, Base: "ETH"},
+			symbol: "BTC-USD",
+			want:   false,
+		},
+		{
+			name:   "unparseable symbol doesn't match base",
+			filter: types.SymbolFilter{Base: "BTC"},
+			symbol: "BTCUSD",
+			want:   false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,6 +2,7 @@ package types
 
 import (
 	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
 )
 
 // OrderBookHandler is a callback function invoked when an order book update is received.
@@ -38,24 +39,39 @@ type OrderBookHandler func(orderBook *marketsv1.OrderBook) error
 //	}
 type TradeHandler func(trade *marketsv1.Trade) error
 
-// ExecutionHandler is a callback function for execution report updates.
-// This is used for streaming order status updates from venues that support it.
+// ExecutionHandler is a callback function invoked for each execution report
+// pushed by a private order stream: acknowledgements, fills, cancels,
+// rejections and expiries of the caller's orders.
+//
+// Implementations should:
+//   - Process reports quickly to avoid blocking the streaming connection
+//   - Deduplicate on ExecutionId, as reports may be replayed after a reconnect
+//   - Return an error to signal that the subscription should be terminated
 //
 // Example:
 //
 //	handler := func(report *venuesv1.ExecutionReport) error {
-//	    log.Printf("Order %s: %s", report.OrderId, report.Status)
+//	    log.Printf("Order %s: %s %s", report.GetOrderId(),
+//	        report.GetExecutionType(), report.GetOrderStatus())
 //	    return nil
 //	}
-//
-// Note: Not used in MVP, but included for future venue implementations.
-type ExecutionHandler func(report interface{}) error
+type ExecutionHandler func(report *venuesv1.ExecutionReport) error
 
-// BalanceHandler is a callback function for balance updates.
-// Some venues provide real-time balance updates via streaming.
+// BalanceHandler is a callback function for balance changes. Each call
+// carries the full current state of one balance whose amounts changed.
 //
-// Note: Not used in MVP, but included for future venue implementations.
-type BalanceHandler func(balance interface{}) error
+// Implementations should:
+//   - Process balances quickly to avoid delaying later changes
+//   - Key balances by account, asset and balance type
+//   - Return an error to signal that the subscription should be terminated
+//
+// Example:
+//
+//	handler := func(balance *venuesv1.Balance) error {
+//	    log.Printf("%s available: %f", balance.GetAssetId(), balance.GetAvailable())
+//	    return nil
+//	}
+type BalanceHandler func(balance *venuesv1.Balance) error
 
 // ErrorHandler is a callback function for handling errors during streaming.
 // This allows consumers to implement custom error handling logic.
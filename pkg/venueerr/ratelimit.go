@@ -0,0 +1,141 @@
+package venueerr
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is a venue's rate limit budget as reported in response headers.
+type RateLimit struct {
+	// Bucket names the limit the request counted against (e.g., "private").
+	// Empty if the venue has a single limit.
+	Bucket string
+
+	// Limit is the number of requests allowed per window, or -1 if the venue
+	// did not report it.
+	Limit int
+
+	// Remaining is the number of requests left in the current window, or -1
+	// if the venue did not report it.
+	Remaining int
+
+	// Reset is when the current window ends and the budget is restored. Zero
+	// if the venue did not report it.
+	Reset time.Time
+}
+
+// Rate limit headers, in order of preference. Venues use either the
+// X-RateLimit-* convention or the IETF RateLimit-* fields.
+var (
+	limitHeaders     = []string{"X-RateLimit-Limit", "RateLimit-Limit"}
+	remainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+	resetHeaders     = []string{"X-RateLimit-Reset", "RateLimit-Reset"}
+)
+
+// FromResponse returns the *Error for a failed HTTP response, before its body
+// is parsed. The function handles:
+//   - Category from CategoryForStatus and StatusCode from the response
+//   - RetryAfter from the Retry-After header, or for 429 responses without
+//     one, the time until the rate limit window resets
+//   - RateLimit from the rate limit headers; 429 responses always carry a
+//     RateLimit, with unknown fields if the venue sent no headers
+//
+// Normalizers fill in Venue-specific Code and Message, refine Category, and
+// set RateLimit.Bucket.
+func FromResponse(venue string, resp *http.Response) *Error {
+	now := time.Now()
+	err := &Error{
+		Venue:      venue,
+		Category:   CategoryForStatus(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		RetryAfter: ParseRetryAfter(resp.Header, now),
+		RateLimit:  ParseRateLimit(resp.Header, now),
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if err.RateLimit == nil {
+			err.RateLimit = &RateLimit{Limit: -1, Remaining: -1}
+		}
+		if err.RetryAfter == 0 && !err.RateLimit.Reset.IsZero() {
+			err.RetryAfter = max(err.RateLimit.Reset.Sub(now), 0)
+		}
+	}
+	return err
+}
+
+// ParseRetryAfter returns the wait requested by a Retry-After header, given
+// in seconds or as an HTTP date relative to now. Returns zero if the header is
+// missing, malformed or in the past.
+func ParseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// ParseRateLimit returns the rate limit budget reported by the X-RateLimit-*
+// or RateLimit-* headers, or nil if none are present.
+//
+// Reset values are accepted as Unix seconds, Unix milliseconds, seconds from
+// now, or an HTTP date.
+func ParseRateLimit(header http.Header, now time.Time) *RateLimit {
+	limit, hasLimit := headerInt(header, limitHeaders)
+	remaining, hasRemaining := headerInt(header, remainingHeaders)
+	reset, hasReset := headerReset(header, now)
+	if !hasLimit && !hasRemaining && !hasReset {
+		return nil
+	}
+	return &RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+}
+
+// headerInt returns the first of names that holds an integer, or -1.
+func headerInt(header http.Header, names []string) (int, bool) {
+	for _, name := range names {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+		// RateLimit-Limit may carry a policy after the count ("100, 100;w=60")
+		value, _, _ = strings.Cut(value, ",")
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && n >= 0 {
+			return n, true
+		}
+	}
+	return -1, false
+}
+
+// headerReset returns the reset time of the first reset header present.
+func headerReset(header http.Header, now time.Time) (time.Time, bool) {
+	for _, name := range resetHeaders {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil && n >= 0 {
+			switch {
+			case n >= 1e12:
+				return time.UnixMilli(int64(n)), true
+			case n >= 1e9:
+				return time.Unix(0, int64(n*float64(time.Second))), true
+			default:
+				return now.Add(time.Duration(n * float64(time.Second))), true
+			}
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
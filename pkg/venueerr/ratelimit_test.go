@@ -0,0 +1,116 @@
+package venueerr_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "3", want: 3 * time.Second},
+		{name: "fractional seconds", value: "0.25", want: 250 * time.Millisecond},
+		{name: "http date", value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "negative", value: "-1", want: 0},
+		{name: "malformed", value: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			assert.Equal(t, tt.want, venueerr.ParseRetryAfter(header, now))
+		})
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("no headers", func(t *testing.T) {
+		assert.Nil(t, venueerr.ParseRateLimit(http.Header{}, now))
+	})
+
+	t.Run("x-ratelimit with unix reset", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-RateLimit-Limit", "30")
+		header.Set("X-RateLimit-Remaining", "4")
+		header.Set("X-RateLimit-Reset", "1705312860")
+
+		limit := venueerr.ParseRateLimit(header, now)
+		require.NotNil(t, limit)
+		assert.Equal(t, 30, limit.Limit)
+		assert.Equal(t, 4, limit.Remaining)
+		assert.True(t, limit.Reset.Equal(now.Add(time.Minute)), "reset %v", limit.Reset)
+	})
+
+	t.Run("unix milliseconds reset", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-RateLimit-Reset", "1705312800500")
+
+		limit := venueerr.ParseRateLimit(header, now)
+		require.NotNil(t, limit)
+		assert.Equal(t, -1, limit.Limit)
+		assert.Equal(t, -1, limit.Remaining)
+		assert.True(t, limit.Reset.Equal(now.Add(500*time.Millisecond)), "reset %v", limit.Reset)
+	})
+
+	t.Run("ietf fields with delta reset", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("RateLimit-Limit", "100, 100;w=60")
+		header.Set("RateLimit-Remaining", "0")
+		header.Set("RateLimit-Reset", "12")
+
+		limit := venueerr.ParseRateLimit(header, now)
+		require.NotNil(t, limit)
+		assert.Equal(t, 100, limit.Limit)
+		assert.Equal(t, 0, limit.Remaining)
+		assert.Equal(t, now.Add(12*time.Second), limit.Reset)
+	})
+}
+
+func TestFromResponse(t *testing.T) {
+	t.Run("rate limited", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set("Retry-After", "5")
+		resp.Header.Set("X-RateLimit-Remaining", "0")
+
+		err := venueerr.FromResponse("prime", resp)
+		assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+		assert.Equal(t, "prime", err.Venue)
+		assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+		assert.Equal(t, 5*time.Second, err.RetryAfter)
+		require.NotNil(t, err.RateLimit)
+		assert.Equal(t, 0, err.RateLimit.Remaining)
+	})
+
+	t.Run("retry after on unavailable", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+		resp.Header.Set("Retry-After", "30")
+
+		err := venueerr.FromResponse("coinbase", resp)
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+		assert.Equal(t, 30*time.Second, venueerr.RetryAfter(err))
+		assert.Nil(t, err.RateLimit)
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		err := venueerr.FromResponse("fordefi", &http.Response{StatusCode: http.StatusBadRequest})
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+		assert.Zero(t, err.RetryAfter)
+		assert.Nil(t, venueerr.RateLimitOf(err))
+	})
+}
@@ -0,0 +1,261 @@
+// Package venueerr defines the errors returned by every venue client.
+//
+// Venue APIs report failures in their own formats and codes. Each venue's
+// normalizer converts them into an *Error whose Category is one of the
+// sentinel errors below, so that retry, alerting and order handling code is
+// written once for all venues:
+//
+//	report, err := venue.PlaceOrder(ctx, order)
+//	switch {
+//	case errors.Is(err, venueerr.ErrRateLimited):
+//	    time.Sleep(venueerr.RetryAfter(err))
+//	case errors.Is(err, venueerr.ErrInsufficientFunds):
+//	    // alert; retrying will not help
+//	case venueerr.IsRetryable(err):
+//	    // back off and retry
+//	}
+//
+// Use errors.As to read the venue's own code and HTTP status:
+//
+//	var venueErr *venueerr.Error
+//	if errors.As(err, &venueErr) {
+//	    log.Printf("%s rejected request: %s (status %d)", venueErr.Venue, venueErr.Code, venueErr.StatusCode)
+//	}
+package venueerr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error categories. Every *Error has exactly one of them as its Category. An
+// ErrUnknownOutcome error also wraps the failure that left the outcome
+// unknown, which may itself be an *Error.
+var (
+	// ErrAuth means the credentials were rejected or lack permission.
+	// Not retryable until the credentials are fixed.
+	ErrAuth = errors.New("authentication failed")
+
+	// ErrInsufficientFunds means the account cannot fund the order or
+	// transfer. Not retryable until funds arrive.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrInvalidOrder means the venue rejected the order's parameters (size,
+	// price, product, order type). Not retryable without changing the order.
+	ErrInvalidOrder = errors.New("invalid order")
+
+	// ErrInvalidRequest means the venue rejected a request that is not an
+	// order placement (bad argument, conflicting state). Not retryable.
+	ErrInvalidRequest = errors.New("invalid request")
+
+	// ErrNotFound means the order, quote or other resource does not exist or
+	// is no longer open. Not retryable.
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited means the venue throttled the request. Retryable after
+	// RetryAfter.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrUnavailable means the venue failed or was unreachable before acting
+	// on the request (server errors, maintenance). Retryable with backoff.
+	ErrUnavailable = errors.New("venue unavailable")
+
+	// ErrUnknownOutcome means a state-changing request may or may not have
+	// been applied, for example an order placement that timed out. Do not
+	// retry blindly; query the venue for the result first.
+	ErrUnknownOutcome = errors.New("unknown outcome")
+
+	// ErrClockSkew means the venue rejected the request's timestamp or token
+	// validity window because the local clock disagrees with the venue's.
+	// Retryable once the clock has been resynchronized with the venue.
+	ErrClockSkew = errors.New("clock skew")
+)
+
+// Error is a classified venue error.
+type Error struct {
+	// Venue is the venue identifier (e.g., "coinbase").
+	Venue string
+
+	// Category is one of the category sentinels (e.g., ErrRateLimited).
+	Category error
+
+	// Code is the venue's error code or failure reason, if any
+	// (e.g., "INSUFFICIENT_FUND").
+	Code string
+
+	// Message is the venue's error description.
+	Message string
+
+	// StatusCode is the HTTP status of the response, or zero for errors
+	// reported in a successful response body.
+	StatusCode int
+
+	// RetryAfter is how long the venue asked callers to wait before retrying.
+	// Zero if it did not say.
+	RetryAfter time.Duration
+
+	// RateLimit is the rate limit budget reported with the response. Always
+	// set for ErrRateLimited errors from HTTP responses; nil if the response
+	// carried no rate limit headers.
+	RateLimit *RateLimit
+
+	// Err is the underlying cause, if any.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.Venue != "" {
+		b.WriteString(e.Venue)
+		b.WriteString(": ")
+	}
+	if e.Category != nil {
+		b.WriteString(e.Category.Error())
+	} else {
+		b.WriteString("venue error")
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, " [%s]", e.Code)
+	}
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " (status: %d)", e.StatusCode)
+	}
+	return b.String()
+}
+
+// Unwrap returns the category and the underlying cause, so that errors.Is
+// matches both.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.Category != nil {
+		errs = append(errs, e.Category)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// Temporary reports whether the request may succeed if retried, for code that
+// checks the interface{ Temporary() bool } convention.
+func (e *Error) Temporary() bool {
+	return e.Category == ErrRateLimited || e.Category == ErrUnavailable || e.Category == ErrClockSkew
+}
+
+// CategoryForStatus returns the category of an HTTP error status, for venues
+// whose error bodies carry no more specific code:
+//   - 401, 403: ErrAuth
+//   - 404: ErrNotFound
+//   - 429: ErrRateLimited
+//   - 408, 5xx and other statuses: ErrUnavailable
+//   - other 4xx: ErrInvalidRequest
+func CategoryForStatus(statusCode int) error {
+	switch {
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrAuth
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode == http.StatusRequestTimeout:
+		return ErrUnavailable
+	case statusCode >= 400 && statusCode < 500:
+		return ErrInvalidRequest
+	default:
+		return ErrUnavailable
+	}
+}
+
+// clockSkewCodes are the error codes, in upper case, that venues return when
+// they reject a request timestamp.
+var clockSkewCodes = map[string]bool{
+	"INVALID_TIMESTAMP": true,
+	"TIMESTAMP_EXPIRED": true,
+	"CLOCK_SKEW":        true,
+}
+
+// clockSkewMessages are the complete error messages, in lower case and
+// without trailing punctuation, that venues and JWT validators return when
+// they reject a request timestamp or a token that is not yet or no longer
+// valid.
+var clockSkewMessages = map[string]bool{
+	"request timestamp expired":                        true,
+	"request timestamp is too old":                     true,
+	"request timestamp is too far in the future":       true,
+	"invalid timestamp":                                true,
+	"timestamp expired":                                true,
+	"token is not valid yet":                           true,
+	"token is expired":                                 true,
+	"token used before issued":                         true,
+	"token has invalid claims: token is not valid yet": true,
+	"token has invalid claims: token is expired":       true,
+	"jwt expired":                                      true,
+}
+
+// ClassifyClockSkew sets the category of e to ErrClockSkew if it is a 400, 401
+// or 403 response whose code, or one of the venue's messages, says the request
+// timestamp or token validity window was rejected. Codes and messages must
+// match a known one in full, ignoring case, so that unrelated errors that
+// merely mention a timestamp are left alone. Normalizers pass the message
+// fields of the venue's error body as received, before formatting, and call
+// it after classifying the response by status and code.
+func ClassifyClockSkew(e *Error, messages ...string) {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+	default:
+		return
+	}
+	if clockSkewCodes[strings.ToUpper(strings.TrimSpace(e.Code))] {
+		e.Category = ErrClockSkew
+		return
+	}
+	for _, message := range messages {
+		message = strings.TrimRight(strings.ToLower(strings.TrimSpace(message)), ".!")
+		if clockSkewMessages[message] {
+			e.Category = ErrClockSkew
+			return
+		}
+	}
+}
+
+// IsRetryable reports whether err is a rate limit, unavailability or clock
+// skew error that may succeed if retried. Unknown outcomes are not retryable:
+// the request may already have been applied.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrUnknownOutcome) {
+		return false
+	}
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrClockSkew)
+}
+
+// RetryAfter returns how long the venue asked callers to wait before retrying
+// err, or zero if err carries no such hint.
+func RetryAfter(err error) time.Duration {
+	var venueErr *Error
+	if errors.As(err, &venueErr) {
+		return venueErr.RetryAfter
+	}
+	return 0
+}
+
+// RateLimitOf returns the rate limit budget carried by err, or nil if it has
+// none.
+func RateLimitOf(err error) *RateLimit {
+	var venueErr *Error
+	if errors.As(err, &venueErr) {
+		return venueErr.RateLimit
+	}
+	return nil
+}
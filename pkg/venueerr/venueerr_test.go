@@ -0,0 +1,135 @@
+package venueerr_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := &venueerr.Error{
+		Venue:      "coinbase",
+		Category:   venueerr.ErrRateLimited,
+		Code:       "rate_limit",
+		Message:    "Too many requests",
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: 2 * time.Second,
+		Err:        cause,
+	}
+
+	assert.Equal(t, "coinbase: rate limited [rate_limit]: Too many requests: connection reset (status: 429)", err.Error())
+	assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, venueerr.ErrUnavailable)
+	assert.True(t, err.Temporary())
+
+	wrapped := fmt.Errorf("failed to place order: %w", err)
+	assert.ErrorIs(t, wrapped, venueerr.ErrRateLimited)
+	assert.True(t, venueerr.IsRetryable(wrapped))
+	assert.Equal(t, 2*time.Second, venueerr.RetryAfter(wrapped))
+
+	var venueErr *venueerr.Error
+	require.ErrorAs(t, wrapped, &venueErr)
+	assert.Equal(t, "rate_limit", venueErr.Code)
+
+	minimal := &venueerr.Error{Category: venueerr.ErrNotFound}
+	assert.Equal(t, "not found", minimal.Error())
+	assert.False(t, minimal.Temporary())
+	assert.Zero(t, venueerr.RetryAfter(minimal))
+}
+
+func TestCategoryForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusBadRequest, want: venueerr.ErrInvalidRequest},
+		{status: http.StatusUnauthorized, want: venueerr.ErrAuth},
+		{status: http.StatusForbidden, want: venueerr.ErrAuth},
+		{status: http.StatusNotFound, want: venueerr.ErrNotFound},
+		{status: http.StatusRequestTimeout, want: venueerr.ErrUnavailable},
+		{status: http.StatusConflict, want: venueerr.ErrInvalidRequest},
+		{status: http.StatusUnprocessableEntity, want: venueerr.ErrInvalidRequest},
+		{status: http.StatusTooManyRequests, want: venueerr.ErrRateLimited},
+		{status: http.StatusInternalServerError, want: venueerr.ErrUnavailable},
+		{status: http.StatusServiceUnavailable, want: venueerr.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, venueerr.CategoryForStatus(tt.status), "status %d", tt.status)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, venueerr.IsRetryable(&venueerr.Error{Category: venueerr.ErrUnavailable}))
+	assert.True(t, venueerr.IsRetryable(venueerr.ErrRateLimited))
+	assert.False(t, venueerr.IsRetryable(&venueerr.Error{Category: venueerr.ErrInsufficientFunds}))
+	assert.False(t, venueerr.IsRetryable(fmt.Errorf("%w: order placement timed out", venueerr.ErrUnknownOutcome)))
+	assert.False(t, venueerr.IsRetryable(&venueerr.Error{
+		Category: venueerr.ErrUnknownOutcome,
+		Err:      &venueerr.Error{Category: venueerr.ErrUnavailable, StatusCode: 504},
+	}), "unknown outcomes are not retryable even if caused by a retryable error")
+	assert.True(t, venueerr.IsRetryable(&venueerr.Error{Category: venueerr.ErrClockSkew}))
+	assert.False(t, venueerr.IsRetryable(errors.New("other")))
+	assert.False(t, venueerr.IsRetryable(nil))
+}
+
+func TestClassifyClockSkew(t *testing.T) {
+	tests := []struct {
+		name string
+		err  venueerr.Error
+		want error
+	}{
+		{
+			name: "expired timestamp",
+			err:  venueerr.Error{Category: venueerr.ErrAuth, StatusCode: http.StatusUnauthorized, Message: "Request timestamp expired"},
+			want: venueerr.ErrClockSkew,
+		},
+		{
+			name: "token not yet valid",
+			err:  venueerr.Error{Category: venueerr.ErrAuth, StatusCode: http.StatusUnauthorized, Code: "UNAUTHORIZED", Message: "token is not valid yet"},
+			want: venueerr.ErrClockSkew,
+		},
+		{
+			name: "skew reported as bad request",
+			err:  venueerr.Error{Category: venueerr.ErrInvalidRequest, StatusCode: http.StatusBadRequest, Code: "INVALID_TIMESTAMP"},
+			want: venueerr.ErrClockSkew,
+		},
+		{
+			name: "message mentioning nbf",
+			err:  venueerr.Error{Category: venueerr.ErrAuth, StatusCode: http.StatusUnauthorized, Message: "missing nbf claim"},
+			want: venueerr.ErrAuth,
+		},
+		{
+			name: "known phrase inside a longer message",
+			err:  venueerr.Error{Category: venueerr.ErrInvalidRequest, StatusCode: http.StatusBadRequest, Message: "order rejected: quote timestamp expired, request a new quote"},
+			want: venueerr.ErrInvalidRequest,
+		},
+		{
+			name: "invalid signature",
+			err:  venueerr.Error{Category: venueerr.ErrAuth, StatusCode: http.StatusUnauthorized, Message: "invalid signature"},
+			want: venueerr.ErrAuth,
+		},
+		{
+			name: "other status",
+			err:  venueerr.Error{Category: venueerr.ErrUnavailable, StatusCode: http.StatusServiceUnavailable, Message: "clock skew"},
+			want: venueerr.ErrUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err
+			venueerr.ClassifyClockSkew(&err, err.Message)
+			assert.Equal(t, tt.want, err.Category)
+		})
+	}
+
+	skew := &venueerr.Error{Category: venueerr.ErrClockSkew}
+	assert.True(t, skew.Temporary())
+}
@@ -0,0 +1,79 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	cbnorm "github.com/Combine-Capital/cqvx/internal/normalizer/coinbase"
+)
+
+// accountsPageSize is the maximum page size accepted by GET /accounts.
+const accountsPageSize = 250
+
+// GetBalance returns the balance of the configured BalanceCurrency (default "USD").
+//
+// Coinbase holds one account per currency, so a single Balance cannot describe
+// every asset; use GetBalances for the full set. If the account holds no
+// BalanceCurrency account, a zero balance for that currency is returned.
+func (c *Client) GetBalance(ctx context.Context) (*venuesv1.Balance, error) {
+	balances, err := c.GetBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		if strings.EqualFold(balance.GetAssetId(), c.config.BalanceCurrency) {
+			return balance, nil
+		}
+	}
+
+	venueId := VenueID
+	asset := c.config.BalanceCurrency
+	balanceType := venuesv1.BalanceType_BALANCE_TYPE_SPOT
+	var zero float64
+	return &venuesv1.Balance{
+		VenueId:     &venueId,
+		AssetId:     &asset,
+		BalanceType: &balanceType,
+		Total:       &zero,
+		Available:   &zero,
+		Locked:      &zero,
+	}, nil
+}
+
+// GetBalances returns one Balance per Coinbase account (currency).
+// All pages of GET /accounts are fetched.
+func (c *Client) GetBalances(ctx context.Context) ([]*venuesv1.Balance, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", accountsPageSize))
+
+	var balances []*venuesv1.Balance
+	for {
+		raw, err := c.do(ctx, http.MethodGet, "/accounts", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := cbnorm.NormalizeAccountBalances(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, page...)
+
+		var resp cbnorm.CoinbaseAccountsResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse coinbase accounts response: %w", err)
+		}
+		if !resp.HasNext || resp.Cursor == "" {
+			break
+		}
+		query.Set("cursor", resp.Cursor)
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,1416 @@
+package coinbase_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/Combine-Capital/cqvx/pkg/venues/coinbase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testAPIKey     = "test-api-key"
+	testSecret     = "dGVzdC1zZWNyZXQta2V5" // base64("test-secret-key")
+	testPassphrase = "test-passphrase"
+)
+
+// recordedRequest captures a request received by the test server.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  map[string][]string
+	Body   []byte
+	Signed bool
+}
+
+// testServer serves recorded Coinbase payloads and verifies request signatures.
+type testServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	routes   map[string]http.HandlerFunc
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ts := &testServer{t: t, routes: make(map[string]http.HandlerFunc)}
+	ts.server = httptest.NewServer(http.HandlerFunc(ts.serveHTTP))
+	t.Cleanup(ts.server.Close)
+	return ts
+}
+
+func (ts *testServer) handle(method, path string, handler http.HandlerFunc) {
+	ts.routes[method+" "+path] = handler
+}
+
+func (ts *testServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(ts.t, err)
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	ts.mu.Lock()
+	ts.requests = append(ts.requests, recordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+		Signed: r.Header.Get("CB-ACCESS-KEY") != "",
+	})
+	ts.mu.Unlock()
+
+	// Verify HMAC authentication headers; only the public server time
+	// endpoint may be called unsigned
+	if r.Header.Get("CB-ACCESS-KEY") != "" || r.URL.Path != "/api/v3/brokerage/time" {
+		assert.Equal(ts.t, testAPIKey, r.Header.Get("CB-ACCESS-KEY"))
+		assert.Equal(ts.t, testPassphrase, r.Header.Get("CB-ACCESS-PASSPHRASE"))
+		timestamp := r.Header.Get("CB-ACCESS-TIMESTAMP")
+		assert.NotEmpty(ts.t, timestamp)
+		assert.Equal(ts.t, expectedSignature(timestamp, r.Method, strings.TrimSuffix(r.URL.Path+"?"+r.URL.RawQuery, "?"), string(body)), r.Header.Get("CB-ACCESS-SIGN"))
+	}
+
+	handler, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+func (ts *testServer) client(t *testing.T) *coinbase.Client {
+	t.Helper()
+	c, err := coinbase.NewClient(coinbase.Config{
+		APIKey:     testAPIKey,
+		Secret:     testSecret,
+		Passphrase: testPassphrase,
+		BaseURL:    ts.server.URL,
+	}, ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+	return c
+}
+
+// tradingClient returns a client whose instrument cache holds products.json,
+// as PlaceOrder validates orders against it, and forgets the products request.
+func (ts *testServer) tradingClient(t *testing.T) *coinbase.Client {
+	t.Helper()
+	if _, ok := ts.routes[http.MethodGet+" /api/v3/brokerage/products"]; !ok {
+		ts.handle(http.MethodGet, "/api/v3/brokerage/products", serveFile(t, http.StatusOK, "products.json"))
+	}
+	c := ts.client(t)
+	_, err := c.GetInstruments(context.Background())
+	require.NoError(t, err)
+
+	ts.mu.Lock()
+	ts.requests = nil
+	ts.mu.Unlock()
+	return c
+}
+
+func expectedSignature(timestamp, method, path, body string) string {
+	key, _ := base64.StdEncoding.DecodeString(testSecret)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp + method + path + body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// serveFile returns a handler that writes a testdata fixture with the given status.
+func serveFile(t *testing.T, status int, name string) http.HandlerFunc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	}
+}
+
+// serveSequence returns a handler that serves handlers in order, repeating the last.
+func serveSequence(handlers ...http.HandlerFunc) http.HandlerFunc {
+	var mu sync.Mutex
+	next := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		handler := handlers[next]
+		if next < len(handlers)-1 {
+			next++
+		}
+		mu.Unlock()
+		handler(w, r)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  coinbase.Config
+		wantErr bool
+	}{
+		{
+			name:   "valid config",
+			config: coinbase.Config{APIKey: testAPIKey, Secret: testSecret, Passphrase: testPassphrase},
+		},
+		{
+			name:    "missing API key",
+			config:  coinbase.Config{Secret: testSecret, Passphrase: testPassphrase},
+			wantErr: true,
+		},
+		{
+			name:    "missing passphrase",
+			config:  coinbase.Config{APIKey: testAPIKey, Secret: testSecret},
+			wantErr: true,
+		},
+		{
+			name:    "secret not base64",
+			config:  coinbase.Config{APIKey: testAPIKey, Secret: "not base64!", Passphrase: testPassphrase},
+			wantErr: true,
+		},
+		{
+			name:    "negative order book depth",
+			config:  coinbase.Config{APIKey: testAPIKey, Secret: testSecret, Passphrase: testPassphrase, OrderBookDepth: -1},
+			wantErr: true,
+		},
+		{
+			name: "invalid rate limits",
+			config: coinbase.Config{APIKey: testAPIKey, Secret: testSecret, Passphrase: testPassphrase,
+				RateLimits: ratelimit.Config{Rules: []ratelimit.Rule{{Buckets: []string{"missing"}}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := coinbase.NewClient(tt.config, nil, nil, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, c)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default buckets", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", serveFile(t, http.StatusOK, "accounts.json"))
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+
+		usage := c.RateLimiter().Utilization()
+		require.Len(t, usage, 2)
+		assert.Equal(t, "private", usage[0].Bucket)
+		assert.Equal(t, 30.0, usage[0].Rate)
+		assert.Greater(t, usage[0].Utilization, 0.0)
+		assert.Equal(t, "public", usage[1].Bucket)
+		assert.Zero(t, usage[1].Utilization)
+	})
+
+	t.Run("rejects requests that would miss their deadline", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", serveFile(t, http.StatusOK, "accounts.json"))
+		c, err := coinbase.NewClient(coinbase.Config{
+			APIKey:     testAPIKey,
+			Secret:     testSecret,
+			Passphrase: testPassphrase,
+			BaseURL:    ts.server.URL,
+			RateLimits: ratelimit.Config{
+				Buckets: []ratelimit.Bucket{{Name: "private", Rate: 0.1}},
+				Rules:   []ratelimit.Rule{{Buckets: []string{"private"}}},
+			},
+		}, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		_, err = c.GetBalance(ctx)
+		require.NoError(t, err)
+
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err = c.GetBalance(deadlineCtx)
+		assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+		assert.ErrorIs(t, err, ratelimit.ErrLimited)
+		assert.Len(t, ts.requests, 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c, err := coinbase.NewClient(coinbase.Config{
+			APIKey:           testAPIKey,
+			Secret:           testSecret,
+			Passphrase:       testPassphrase,
+			DisableRateLimit: true,
+		}, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, c.RateLimiter())
+	})
+}
+
+func TestClockSkew(t *testing.T) {
+	ctx := context.Background()
+	const skew = time.Hour
+	serverTime := func() time.Time { return time.Now().Add(skew) }
+
+	// checkTimestamp rejects requests signed more than 30 seconds away from
+	// the server clock, as Coinbase does.
+	checkTimestamp := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			timestamp, err := strconv.ParseInt(r.Header.Get("CB-ACCESS-TIMESTAMP"), 10, 64)
+			require.NoError(t, err)
+			if drift := serverTime().Unix() - timestamp; drift > 30 || drift < -30 {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error": "unauthorized", "message": "request timestamp expired"}`))
+				return
+			}
+			next(w, r)
+		}
+	}
+	serveTime := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"epochMillis": "%d"}`, serverTime().UnixMilli())
+	}
+
+	t.Run("resyncs and retries", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/time", serveTime)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", checkTimestamp(serveFile(t, http.StatusOK, "accounts.json")))
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		require.Len(t, ts.requests, 3)
+		assert.Equal(t, "/api/v3/brokerage/time", ts.requests[1].Path)
+		assert.False(t, ts.requests[1].Signed, "server time is fetched unsigned")
+		assert.InDelta(t, float64(skew), float64(c.Clock().Offset()), float64(time.Second))
+
+		_, err = c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Len(t, ts.requests, 4, "later requests are signed with the corrected clock")
+	})
+
+	t.Run("sync bypasses the rate limiter", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/time", serveTime)
+		c, err := coinbase.NewClient(coinbase.Config{
+			APIKey:     testAPIKey,
+			Secret:     testSecret,
+			Passphrase: testPassphrase,
+			BaseURL:    ts.server.URL,
+			RateLimits: ratelimit.Config{
+				Buckets: []ratelimit.Bucket{{Name: "all", Rate: 0.1}},
+				Rules:   []ratelimit.Rule{{Buckets: []string{"all"}}},
+			},
+		}, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		for range 3 {
+			deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+			require.NoError(t, c.Clock().Sync(deadlineCtx))
+			cancel()
+		}
+		assert.Len(t, ts.requests, 3)
+		assert.Zero(t, c.RateLimiter().Utilization()[0].Utilization)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", checkTimestamp(serveFile(t, http.StatusOK, "accounts.json")))
+		c, err := coinbase.NewClient(coinbase.Config{
+			APIKey:           testAPIKey,
+			Secret:           testSecret,
+			Passphrase:       testPassphrase,
+			BaseURL:          ts.server.URL,
+			DisableClockSync: true,
+		}, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, c.Clock())
+
+		_, err = c.GetBalance(ctx)
+		assert.ErrorIs(t, err, venueerr.ErrClockSkew)
+		assert.True(t, venueerr.IsRetryable(err))
+		assert.Len(t, ts.requests, 1)
+	})
+}
+
+func TestPlaceOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("limit order", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveFile(t, http.StatusOK, "create_order.json"))
+		c := ts.tradingClient(t)
+
+		report, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			ClientOrderId: strPtr("cqvx-test-0001"),
+			VenueSymbol:   strPtr("BTC-USD"),
+			Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:      floatPtr(0.01),
+			Price:         floatPtr(50000),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "11111111-1111-1111-1111-111111111111", report.GetVenueOrderId())
+		assert.Equal(t, "cqvx-test-0001", report.GetClientOrderId())
+		assert.Equal(t, "coinbase", report.GetVenueId())
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+		assert.Equal(t, 50000.0, report.GetPrice())
+		assert.Equal(t, 0.01, report.GetQuantity())
+
+		require.Len(t, ts.requests, 1)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		assert.Equal(t, "cqvx-test-0001", body["client_order_id"])
+		assert.Equal(t, "BTC-USD", body["product_id"])
+		assert.Equal(t, "BUY", body["side"])
+		assert.Equal(t, map[string]interface{}{
+			"limit_limit_gtc": map[string]interface{}{
+				"base_size":   "0.01",
+				"limit_price": "50000",
+				"post_only":   false,
+			},
+		}, body["order_configuration"])
+	})
+
+	t.Run("generates client order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveFile(t, http.StatusOK, "create_order.json"))
+		c := ts.tradingClient(t)
+
+		order := &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			Quantity:    floatPtr(0.5),
+		}
+		_, err := c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+		_, err = c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+
+		var body, again map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		require.NoError(t, json.Unmarshal(ts.requests[1].Body, &again))
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, body["client_order_id"])
+		assert.NotEqual(t, body["client_order_id"], again["client_order_id"], "identical orders placed separately get distinct IDs")
+		assert.Equal(t, map[string]interface{}{
+			"market_market_ioc": map[string]interface{}{"base_size": "0.5"},
+		}, body["order_configuration"])
+	})
+
+	t.Run("rejected order", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveFile(t, http.StatusOK, "create_order_rejected.json"))
+		c := ts.tradingClient(t)
+
+		_, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:    floatPtr(100),
+			Price:       floatPtr(50000),
+		})
+		require.Error(t, err)
+
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrInsufficientFunds)
+		assert.Equal(t, "INSUFFICIENT_FUND", venueErr.Code)
+		assert.Contains(t, err.Error(), "INSUFFICIENT_FUND")
+	})
+
+	t.Run("unknown outcome recovery", func(t *testing.T) {
+		badGateway := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":"UNKNOWN","message":"upstream timed out"}`))
+		}
+		order := func(clientOrderID string) *venuesv1.Order {
+			return &venuesv1.Order{
+				ClientOrderId: strPtr(clientOrderID),
+				VenueSymbol:   strPtr("BTC-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+				OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+				Quantity:      floatPtr(0.02),
+				Price:         floatPtr(48000),
+			}
+		}
+		paths := func(ts *testServer) []string {
+			var paths []string
+			for _, req := range ts.requests {
+				paths = append(paths, req.Method+" "+req.Path)
+			}
+			return paths
+		}
+
+		t.Run("order found", func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.handle(http.MethodPost, "/api/v3/brokerage/orders", badGateway)
+			ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", serveSequence(
+				serveFile(t, http.StatusOK, "orders_page1.json"),
+				serveFile(t, http.StatusOK, "orders_page2.json"),
+			))
+			c := ts.tradingClient(t)
+
+			report, err := c.PlaceOrder(ctx, order("cqvx-test-0003"))
+			require.NoError(t, err)
+			assert.Equal(t, "44444444-4444-4444-4444-444444444444", report.GetVenueOrderId())
+			assert.Equal(t, "cqvx-test-0003", report.GetClientOrderId())
+			assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+			assert.Equal(t, "CANCELLED", report.GetOrderStatus())
+
+			assert.Equal(t, []string{
+				"POST /api/v3/brokerage/orders",
+				"GET /api/v3/brokerage/orders/historical/batch",
+				"GET /api/v3/brokerage/orders/historical/batch",
+			}, paths(ts), "the order is not resubmitted")
+			assert.Equal(t, []string{"BTC-USD"}, ts.requests[1].Query["product_ids"])
+			assert.NotEmpty(t, ts.requests[1].Query["start_date"])
+		})
+
+		t.Run("order not found is resubmitted", func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveSequence(
+				badGateway,
+				serveFile(t, http.StatusOK, "create_order.json"),
+			))
+			ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", serveFile(t, http.StatusOK, "orders_page2.json"))
+			c := ts.tradingClient(t)
+
+			report, err := c.PlaceOrder(ctx, order("cqvx-test-0001"))
+			require.NoError(t, err)
+			assert.Equal(t, "11111111-1111-1111-1111-111111111111", report.GetVenueOrderId())
+
+			assert.Equal(t, []string{
+				"POST /api/v3/brokerage/orders",
+				"GET /api/v3/brokerage/orders/historical/batch",
+				"POST /api/v3/brokerage/orders",
+			}, paths(ts))
+			assert.JSONEq(t, string(ts.requests[0].Body), string(ts.requests[2].Body), "resubmitted with the same client order ID")
+		})
+
+		t.Run("generated ID is reused on resubmission", func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveSequence(
+				badGateway,
+				serveFile(t, http.StatusOK, "create_order.json"),
+			))
+			ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", serveFile(t, http.StatusOK, "orders_page2.json"))
+			c := ts.tradingClient(t)
+
+			_, err := c.PlaceOrder(ctx, order(""))
+			require.NoError(t, err)
+
+			var first, resubmitted map[string]interface{}
+			require.NoError(t, json.Unmarshal(ts.requests[0].Body, &first))
+			require.NoError(t, json.Unmarshal(ts.requests[2].Body, &resubmitted))
+			assert.NotEmpty(t, first["client_order_id"])
+			assert.Equal(t, first["client_order_id"], resubmitted["client_order_id"])
+		})
+
+		t.Run("outcome unknown", func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.handle(http.MethodPost, "/api/v3/brokerage/orders", badGateway)
+			ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", serveFile(t, http.StatusOK, "orders_page2.json"))
+			c := ts.tradingClient(t)
+
+			_, err := c.PlaceOrder(ctx, order("cqvx-test-0009"))
+			assert.ErrorIs(t, err, venueerr.ErrUnknownOutcome)
+			assert.ErrorIs(t, err, venueerr.ErrUnavailable, "wraps the placement failure")
+			assert.False(t, venueerr.IsRetryable(err))
+			assert.Contains(t, err.Error(), "cqvx-test-0009")
+			assert.Len(t, ts.requests, 4, "placed twice, looked up after each")
+		})
+	})
+
+	t.Run("order configurations", func(t *testing.T) {
+		expiresAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			name   string
+			order  *venuesv1.Order
+			config map[string]interface{}
+		}{
+			{
+				name: "post only limit",
+				order: &venuesv1.Order{
+					OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_POST_ONLY),
+					Side:      sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+					Price:     floatPtr(100.5),
+				},
+				config: map[string]interface{}{
+					"limit_limit_gtc": map[string]interface{}{"base_size": "1", "limit_price": "100.5", "post_only": true},
+				},
+			},
+			{
+				name: "limit GTD",
+				order: &venuesv1.Order{
+					OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+					TimeInForce: tifPtr(venuesv1.TimeInForce_TIME_IN_FORCE_GTD),
+					Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+					Price:       floatPtr(100),
+					ExpiresAt:   timestamppb.New(expiresAt),
+				},
+				config: map[string]interface{}{
+					"limit_limit_gtd": map[string]interface{}{
+						"base_size": "1", "limit_price": "100", "end_time": "2024-02-01T00:00:00Z", "post_only": false,
+					},
+				},
+			},
+			{
+				name: "limit FOK",
+				order: &venuesv1.Order{
+					OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+					TimeInForce: tifPtr(venuesv1.TimeInForce_TIME_IN_FORCE_FOK),
+					Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+					Price:       floatPtr(100),
+				},
+				config: map[string]interface{}{
+					"limit_limit_fok": map[string]interface{}{"base_size": "1", "limit_price": "100"},
+				},
+			},
+			{
+				name: "limit IOC",
+				order: &venuesv1.Order{
+					OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+					TimeInForce: tifPtr(venuesv1.TimeInForce_TIME_IN_FORCE_IOC),
+					Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+					Price:       floatPtr(100),
+				},
+				config: map[string]interface{}{
+					"sor_limit_ioc": map[string]interface{}{"base_size": "1", "limit_price": "100"},
+				},
+			},
+			{
+				name: "stop limit sell",
+				order: &venuesv1.Order{
+					OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT),
+					Side:      sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+					Price:     floatPtr(95),
+					StopPrice: floatPtr(96),
+				},
+				config: map[string]interface{}{
+					"stop_limit_stop_limit_gtc": map[string]interface{}{
+						"base_size": "1", "limit_price": "95", "stop_price": "96", "stop_direction": "STOP_DIRECTION_STOP_DOWN",
+					},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ts := newTestServer(t)
+				ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveFile(t, http.StatusOK, "create_order.json"))
+				c := ts.tradingClient(t)
+
+				tt.order.VenueSymbol = strPtr("BTC-USD")
+				tt.order.Quantity = floatPtr(1)
+				_, err := c.PlaceOrder(ctx, tt.order)
+				require.NoError(t, err)
+
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+				assert.Equal(t, tt.config, body["order_configuration"])
+			})
+		}
+	})
+
+	t.Run("invalid orders are not sent", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			order *venuesv1.Order
+		}{
+			{name: "nil order", order: nil},
+			{name: "missing symbol", order: &venuesv1.Order{
+				Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET), Quantity: floatPtr(1),
+			}},
+			{name: "missing side", order: &venuesv1.Order{
+				VenueSymbol: strPtr("BTC-USD"), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET), Quantity: floatPtr(1),
+			}},
+			{name: "zero quantity", order: &venuesv1.Order{
+				VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			}},
+			{name: "limit without price", order: &venuesv1.Order{
+				VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT), Quantity: floatPtr(1),
+			}},
+			{name: "GTD without expiry", order: &venuesv1.Order{
+				VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+				TimeInForce: tifPtr(venuesv1.TimeInForce_TIME_IN_FORCE_GTD), Quantity: floatPtr(1), Price: floatPtr(1),
+			}},
+			{name: "unsupported type", order: &venuesv1.Order{
+				VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_TRAILING_STOP), Quantity: floatPtr(1),
+			}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ts := newTestServer(t)
+				c := ts.tradingClient(t)
+
+				_, err := c.PlaceOrder(ctx, tt.order)
+				assert.Error(t, err)
+				assert.Empty(t, ts.requests)
+			})
+		}
+	})
+
+	t.Run("checked against cached product", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/products", serveFile(t, http.StatusOK, "products.json"))
+		c := ts.client(t)
+
+		// The cold cache is filled before the first order is validated
+		_, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:    floatPtr(0.01),
+			Price:       floatPtr(50000.005),
+		})
+		var invalid *client.OrderValidationError
+		require.True(t, errors.As(err, &invalid))
+		assert.Equal(t, client.ValidationRuleTickSize, invalid.Rule)
+
+		_, err = c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("OLD-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			Quantity:    floatPtr(1),
+		})
+		require.True(t, errors.As(err, &invalid))
+		assert.Equal(t, client.ValidationRuleStatus, invalid.Rule)
+		assert.Len(t, ts.requests, 1, "only the products request is sent")
+	})
+
+	t.Run("rounds to product increments", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/products", serveFile(t, http.StatusOK, "products.json"))
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders", serveFile(t, http.StatusOK, "create_order.json"))
+		c, err := coinbase.NewClient(coinbase.Config{
+			APIKey:      testAPIKey,
+			Secret:      testSecret,
+			Passphrase:  testPassphrase,
+			BaseURL:     ts.server.URL,
+			RoundOrders: true,
+		}, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+		_, err = c.GetInstruments(ctx)
+		require.NoError(t, err)
+
+		report, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:    floatPtr(0.123456789),
+			Price:       floatPtr(50000.001),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "coinbase", report.GetVenueId())
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[1].Body, &body))
+		assert.Equal(t, map[string]interface{}{
+			"limit_limit_gtc": map[string]interface{}{
+				"base_size":   "0.12345678",
+				"limit_price": "50000.01",
+				"post_only":   false,
+			},
+		}, body["order_configuration"])
+	})
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", serveFile(t, http.StatusOK, "cancel_orders.json"))
+		c := ts.client(t)
+
+		status, err := c.CancelOrder(ctx, "11111111-1111-1111-1111-111111111111")
+		require.NoError(t, err)
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *status)
+		assert.JSONEq(t, `{"order_ids":["11111111-1111-1111-1111-111111111111"]}`, string(ts.requests[0].Body))
+	})
+
+	t.Run("cancel rejected", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"results":[{"success":false,"failure_reason":"UNKNOWN_CANCEL_ORDER","order_id":"missing"}]}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.CancelOrder(ctx, "missing")
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrNotFound)
+		assert.Equal(t, "UNKNOWN_CANCEL_ORDER", venueErr.Code)
+		assert.Contains(t, err.Error(), "UNKNOWN_CANCEL_ORDER")
+	})
+
+	t.Run("empty order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).CancelOrder(ctx, "")
+		assert.Error(t, err)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+// serveBatchCancel returns a batch_cancel handler that accepts every requested
+// order except those listed in rejected.
+func serveBatchCancel(t *testing.T, rejected ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			OrderIDs []string `json:"order_ids"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var results []map[string]interface{}
+		for _, id := range req.OrderIDs {
+			result := map[string]interface{}{"order_id": id, "success": true, "failure_reason": "UNKNOWN_CANCEL_FAILURE_REASON"}
+			if slices.Contains(rejected, id) {
+				result["success"] = false
+				result["failure_reason"] = "UNKNOWN_CANCEL_ORDER"
+			}
+			results = append(results, result)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}
+}
+
+func TestPlaceOrders(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodPost, "/api/v3/brokerage/orders", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["client_order_id"] == "cqvx-test-0002" {
+			serveFile(t, http.StatusOK, "create_order_rejected.json")(w, r)
+			return
+		}
+		serveFile(t, http.StatusOK, "create_order.json")(w, r)
+	})
+	c := ts.tradingClient(t)
+
+	orders := []*venuesv1.Order{
+		{
+			ClientOrderId: strPtr("cqvx-test-0001"),
+			VenueSymbol:   strPtr("BTC-USD"),
+			Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:      floatPtr(0.01),
+			Price:         floatPtr(50000),
+		},
+		{
+			ClientOrderId: strPtr("cqvx-test-0002"),
+			VenueSymbol:   strPtr("BTC-USD"),
+			Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:      floatPtr(100),
+			Price:         floatPtr(49000),
+		},
+		{
+			VenueSymbol: strPtr("BTC-USD"),
+		},
+	}
+
+	results, err := c.PlaceOrders(ctx, orders)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Same(t, orders[0], results[0].Order)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", results[0].Report.GetVenueOrderId())
+
+	var venueErr *venueerr.Error
+	require.ErrorAs(t, results[1].Err, &venueErr)
+	assert.ErrorIs(t, results[1].Err, venueerr.ErrInsufficientFunds)
+	assert.Equal(t, "INSUFFICIENT_FUND", venueErr.Code)
+
+	assert.Error(t, results[2].Err)
+	assert.Len(t, ts.requests, 2)
+}
+
+func TestCancelOrders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("per-order results", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", serveBatchCancel(t, "missing"))
+		c := ts.client(t)
+
+		results, err := c.CancelOrders(ctx, []string{"11111111-1111-1111-1111-111111111111", "missing"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, "11111111-1111-1111-1111-111111111111", results[0].OrderID)
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *results[0].Status)
+
+		assert.Equal(t, "missing", results[1].OrderID)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, results[1].Err, &venueErr)
+		assert.ErrorIs(t, results[1].Err, venueerr.ErrNotFound)
+		assert.Equal(t, "UNKNOWN_CANCEL_ORDER", venueErr.Code)
+
+		require.Len(t, ts.requests, 1)
+	})
+
+	t.Run("splits large batches", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", serveBatchCancel(t))
+		c := ts.client(t)
+
+		ids := make([]string, 150)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("order-%03d", i)
+		}
+
+		results, err := c.CancelOrders(ctx, ids)
+		require.NoError(t, err)
+		require.Len(t, results, 150)
+		for i, result := range results {
+			assert.Equal(t, ids[i], result.OrderID)
+			assert.NoError(t, result.Err)
+		}
+		assert.Len(t, ts.requests, 2)
+	})
+
+	t.Run("failed request fails its orders", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"UNAVAILABLE","message":"service unavailable"}`))
+		})
+		c := ts.client(t)
+
+		results, err := c.CancelOrders(ctx, []string{"a", "b"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Error(t, results[0].Err)
+		assert.Error(t, results[1].Err)
+	})
+
+	t.Run("empty order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).CancelOrders(ctx, []string{"a", ""})
+		assert.Error(t, err)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", serveFile(t, http.StatusOK, "open_orders.json"))
+	ts.handle(http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", serveBatchCancel(t))
+	c := ts.client(t)
+
+	results, err := c.CancelAllOrders(ctx, client.OrderFilter{Symbols: []string{"BTC-USD"}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", results[0].OrderID)
+	assert.Equal(t, "55555555-5555-5555-5555-555555555555", results[1].OrderID)
+
+	require.Len(t, ts.requests, 2)
+	assert.Equal(t, []string{"BTC-USD"}, ts.requests[0].Query["product_ids"])
+	assert.Equal(t, []string{"OPEN"}, ts.requests[0].Query["order_status"])
+	assert.JSONEq(t, `{"order_ids":["11111111-1111-1111-1111-111111111111","55555555-5555-5555-5555-555555555555"]}`, string(ts.requests[1].Body))
+}
+
+func TestAmendOrder(t *testing.T) {
+	ctx := context.Background()
+	orderPath := "/api/v3/brokerage/orders/historical/11111111-1111-1111-1111-111111111111"
+
+	t.Run("price and size", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/edit", serveFile(t, http.StatusOK, "edit_order.json"))
+		ts.handle(http.MethodGet, orderPath, serveFile(t, http.StatusOK, "order_edited.json"))
+		c := ts.client(t)
+
+		report, err := c.AmendOrder(ctx, "11111111-1111-1111-1111-111111111111", client.OrderChanges{
+			Price:    floatPtr(50500),
+			Quantity: floatPtr(0.02),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, ts.requests, 2)
+		assert.JSONEq(t, `{"order_id":"11111111-1111-1111-1111-111111111111","price":"50500","size":"0.02"}`, string(ts.requests[0].Body))
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_REPLACED, report.GetExecutionType())
+		assert.Equal(t, "11111111-1111-1111-1111-111111111111", report.GetOrderId())
+		assert.Equal(t, "coinbase", report.GetVenueId())
+		assert.Equal(t, "OPEN", report.GetOrderStatus())
+		assert.Equal(t, 50500.0, report.GetPrice())
+		assert.Equal(t, 0.02, report.GetQuantity())
+		assert.Equal(t, 0.005, report.GetCumulativeQuantity())
+	})
+
+	t.Run("price only reads current size", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/edit", serveFile(t, http.StatusOK, "edit_order.json"))
+		ts.handle(http.MethodGet, orderPath, serveSequence(
+			serveFile(t, http.StatusOK, "order.json"),
+			serveFile(t, http.StatusOK, "order_edited.json"),
+		))
+		c := ts.client(t)
+
+		_, err := c.AmendOrder(ctx, "11111111-1111-1111-1111-111111111111", client.OrderChanges{Price: floatPtr(50500)})
+		require.NoError(t, err)
+
+		require.Len(t, ts.requests, 3)
+		assert.Equal(t, http.MethodGet, ts.requests[0].Method)
+		assert.JSONEq(t, `{"order_id":"11111111-1111-1111-1111-111111111111","price":"50500","size":"0.01"}`, string(ts.requests[1].Body))
+	})
+
+	t.Run("edit rejected", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v3/brokerage/orders/edit", serveFile(t, http.StatusOK, "edit_order_rejected.json"))
+		c := ts.client(t)
+
+		_, err := c.AmendOrder(ctx, "11111111-1111-1111-1111-111111111111", client.OrderChanges{
+			Price:    floatPtr(50500),
+			Quantity: floatPtr(0.02),
+		})
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrNotFound)
+		assert.Equal(t, "ORDER_NOT_FOUND_OR_ALREADY_DONE", venueErr.Code)
+		assert.Contains(t, err.Error(), "ORDER_NOT_FOUND_OR_ALREADY_DONE")
+	})
+
+	t.Run("display size unsupported", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).AmendOrder(ctx, "11111111-1111-1111-1111-111111111111", client.OrderChanges{DisplaySize: floatPtr(0.001)})
+
+		var unsupported *client.UnsupportedError
+		require.True(t, errors.As(err, &unsupported))
+		assert.Equal(t, "display size", unsupported.Detail)
+		assert.Empty(t, ts.requests)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).AmendOrder(ctx, "11111111-1111-1111-1111-111111111111", client.OrderChanges{})
+		assert.ErrorIs(t, err, client.ErrNoOrderChanges)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+func TestGetOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/11111111-1111-1111-1111-111111111111",
+			serveFile(t, http.StatusOK, "order.json"))
+		c := ts.client(t)
+
+		order, err := c.GetOrder(ctx, "11111111-1111-1111-1111-111111111111")
+		require.NoError(t, err)
+
+		assert.Equal(t, "11111111-1111-1111-1111-111111111111", order.GetVenueOrderId())
+		assert.Equal(t, "cqvx-test-0001", order.GetClientOrderId())
+		assert.Equal(t, "coinbase", order.GetVenueId())
+		assert.Equal(t, "BTC-USD", order.GetVenueSymbol())
+		assert.Equal(t, venuesv1.OrderSide_ORDER_SIDE_BUY, order.GetSide())
+		assert.Equal(t, venuesv1.OrderType_ORDER_TYPE_LIMIT, order.GetOrderType())
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_OPEN, order.GetStatus())
+		assert.Equal(t, 0.01, order.GetQuantity())
+		assert.Equal(t, 50000.0, order.GetPrice())
+		assert.Equal(t, 0.005, order.GetFilledQuantity())
+		assert.Equal(t, 49995.50, order.GetAverageFillPrice())
+		assert.Equal(t, 1.49, order.GetTotalFees())
+		assert.NotNil(t, order.GetCreatedAt())
+		assert.NotNil(t, order.GetUpdatedAt())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/missing", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"NOT_FOUND","message":"order with this orderID was not found"}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.GetOrder(ctx, "missing")
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrNotFound)
+		assert.Equal(t, "NOT_FOUND", venueErr.Code)
+	})
+}
+
+func TestGetOrders(t *testing.T) {
+	ctx := context.Background()
+
+	paged := func(t *testing.T) http.HandlerFunc {
+		page1 := serveFile(t, http.StatusOK, "orders_page1.json")
+		page2 := serveFile(t, http.StatusOK, "orders_page2.json")
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("cursor") == "page-2" {
+				page2(w, r)
+				return
+			}
+			page1(w, r)
+		}
+	}
+
+	t.Run("follows cursor pagination", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", paged(t))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{})
+		require.NoError(t, err)
+		require.Len(t, orders, 3)
+		assert.Equal(t, "11111111-1111-1111-1111-111111111111", orders[0].GetVenueOrderId())
+		assert.Equal(t, "33333333-3333-3333-3333-333333333333", orders[1].GetVenueOrderId())
+		assert.Equal(t, "44444444-4444-4444-4444-444444444444", orders[2].GetVenueOrderId())
+		assert.Len(t, ts.requests, 2)
+	})
+
+	t.Run("maps filter to query", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", paged(t))
+		c := ts.client(t)
+
+		_, err := c.GetOrders(ctx, client.OrderFilter{
+			Symbols: []string{"BTC-USD", "ETH-USD"},
+			Statuses: []venuesv1.OrderStatus{
+				venuesv1.OrderStatus_ORDER_STATUS_OPEN,
+				venuesv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED,
+				venuesv1.OrderStatus_ORDER_STATUS_FILLED,
+			},
+			StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			Limit:     2,
+		})
+		require.NoError(t, err)
+
+		query := ts.requests[0].Query
+		assert.Equal(t, []string{"BTC-USD", "ETH-USD"}, query["product_ids"])
+		assert.Equal(t, []string{"OPEN", "FILLED"}, query["order_status"])
+		assert.Equal(t, []string{"2024-01-01T00:00:00Z"}, query["start_date"])
+		assert.Equal(t, []string{"2024-01-31T00:00:00Z"}, query["end_date"])
+		assert.Equal(t, []string{"2"}, query["limit"])
+	})
+
+	t.Run("applies limit and offset", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/batch", paged(t))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "33333333-3333-3333-3333-333333333333", orders[0].GetVenueOrderId())
+		assert.Len(t, ts.requests, 1, "second page should not be fetched")
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).GetOrders(ctx, client.OrderFilter{Limit: -1})
+		assert.ErrorIs(t, err, client.ErrInvalidLimit)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+func TestGetFills(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normalizes fills", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/fills", serveFile(t, http.StatusOK, "fills.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 2)
+		assert.Equal(t, "789100", page.NextCursor)
+
+		maker := page.Fills[0]
+		assert.Equal(t, "22222-2222222-22222222", maker.GetExecutionId())
+		assert.Equal(t, "0000-000000-000000", maker.GetVenueOrderId())
+		assert.Equal(t, "1111-11111-111111", maker.GetTradeId())
+		assert.Equal(t, coinbase.VenueID, maker.GetVenueId())
+		assert.Equal(t, 50000.0, maker.GetPrice())
+		assert.Equal(t, 0.25, maker.GetQuantity())
+		assert.Equal(t, 7.5, maker.GetFee())
+		assert.Equal(t, "USD", maker.GetFeeAssetId())
+		assert.Equal(t, "MAKER", maker.GetLiquidity())
+		assert.True(t, maker.GetIsMaker())
+
+		taker := page.Fills[1]
+		assert.Equal(t, "4444-44444-444444", taker.GetTradeId())
+		assert.Equal(t, "TAKER", taker.GetLiquidity())
+		require.NotNil(t, taker.IsMaker)
+		assert.False(t, taker.GetIsMaker())
+
+		assert.Equal(t, []string{"100"}, ts.requests[0].Query["limit"])
+	})
+
+	t.Run("maps filter to query", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/orders/historical/fills", serveFile(t, http.StatusOK, "fills.json"))
+		c := ts.client(t)
+
+		_, err := c.GetFills(ctx, client.FillFilter{
+			OrderID:   "0000-000000-000000",
+			Symbols:   []string{"BTC-USD", "ETH-USD"},
+			StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			Limit:     25,
+			Cursor:    "789100",
+		})
+		require.NoError(t, err)
+
+		query := ts.requests[0].Query
+		assert.Equal(t, []string{"0000-000000-000000"}, query["order_ids"])
+		assert.Equal(t, []string{"BTC-USD", "ETH-USD"}, query["product_ids"])
+		assert.Equal(t, []string{"2024-01-01T00:00:00Z"}, query["start_sequence_timestamp"])
+		assert.Equal(t, []string{"2024-01-31T00:00:00Z"}, query["end_sequence_timestamp"])
+		assert.Equal(t, []string{"25"}, query["limit"])
+		assert.Equal(t, []string{"789100"}, query["cursor"])
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).GetFills(ctx, client.FillFilter{
+			StartTime: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorIs(t, err, client.ErrInvalidTimeRange)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+func TestGetBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all balances", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", serveFile(t, http.StatusOK, "accounts.json"))
+		c := ts.client(t)
+
+		balances, err := c.GetBalances(ctx)
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+
+		btc := balances[0]
+		assert.Equal(t, "BTC", btc.GetAssetId())
+		assert.Equal(t, 1.5, btc.GetTotal())
+		assert.Equal(t, 1.25, btc.GetAvailable())
+		assert.Equal(t, 0.25, btc.GetLocked())
+		assert.Equal(t, []string{"250"}, ts.requests[0].Query["limit"])
+	})
+
+	t.Run("configured currency", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", serveFile(t, http.StatusOK, "accounts.json"))
+		c := ts.client(t)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "USD", balance.GetAssetId())
+		assert.Equal(t, 10000.50, balance.GetAvailable())
+		assert.Equal(t, 251.50, balance.GetLocked())
+	})
+
+	t.Run("currency not held", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", serveFile(t, http.StatusOK, "accounts.json"))
+		c, err := coinbase.NewClient(coinbase.Config{
+			APIKey:          testAPIKey,
+			Secret:          testSecret,
+			Passphrase:      testPassphrase,
+			BaseURL:         ts.server.URL,
+			BalanceCurrency: "EUR",
+		}, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "EUR", balance.GetAssetId())
+		assert.Zero(t, balance.GetTotal())
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"UNAUTHORIZED","message":"invalid signature"}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrAuth)
+		assert.Equal(t, "UNAUTHORIZED", venueErr.Code)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "1")
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"RATE_LIMIT_EXCEEDED","message":"too many requests"}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		assert.ErrorIs(t, err, venueerr.ErrRateLimited)
+		assert.Equal(t, time.Second, venueerr.RetryAfter(err))
+
+		limit := venueerr.RateLimitOf(err)
+		require.NotNil(t, limit)
+		assert.Equal(t, venueerr.RateLimit{Bucket: "private", Limit: 30, Remaining: 0}, *limit)
+	})
+}
+
+func TestGetOrderBook(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/api/v3/brokerage/product_book", serveFile(t, http.StatusOK, "product_book.json"))
+
+	c, err := coinbase.NewClient(coinbase.Config{
+		APIKey:         testAPIKey,
+		Secret:         testSecret,
+		Passphrase:     testPassphrase,
+		BaseURL:        ts.server.URL,
+		OrderBookDepth: 3,
+	}, ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+
+	book, err := c.GetOrderBook(ctx, "BTC-USD")
+	require.NoError(t, err)
+
+	assert.Equal(t, "BTC-USD", book.GetVenueSymbol())
+	assert.Equal(t, "coinbase", book.GetVenueId())
+	require.Len(t, book.GetBids(), 3)
+	require.Len(t, book.GetAsks(), 3)
+	assert.Equal(t, 49999.99, book.GetBestBid())
+	assert.Equal(t, 50000.01, book.GetBestAsk())
+	assert.Equal(t, 0.5, book.GetBids()[0].GetQuantity())
+
+	query := ts.requests[0].Query
+	assert.Equal(t, []string{"BTC-USD"}, query["product_id"])
+	assert.Equal(t, []string{"3"}, query["limit"])
+}
+
+func TestGetInstruments(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normalizes and caches products", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/products", serveFile(t, http.StatusOK, "products.json"))
+		c := ts.client(t)
+
+		instruments, err := c.GetInstruments(ctx)
+		require.NoError(t, err)
+		require.Len(t, instruments, 3)
+		assert.Equal(t, "BTC-USD", instruments[0].Symbol)
+		assert.Equal(t, "ETH-BTC", instruments[1].Symbol)
+		assert.Equal(t, "OLD-USD", instruments[2].Symbol)
+
+		btc := instruments[0]
+		assert.Equal(t, "coinbase", btc.VenueID)
+		assert.Equal(t, "BTC", btc.BaseAsset)
+		assert.Equal(t, "USD", btc.QuoteAsset)
+		assert.Equal(t, client.InstrumentStatusOnline, btc.Status)
+		assert.Equal(t, 0.01, btc.TickSize)
+		assert.Equal(t, 0.00000001, btc.BaseIncrement)
+		assert.Equal(t, 0.00000001, btc.MinQuantity)
+		assert.Equal(t, 3400.0, btc.MaxQuantity)
+		assert.Equal(t, 1.0, btc.MinNotional)
+		assert.Equal(t, 150000000.0, btc.MaxNotional)
+
+		assert.Equal(t, client.InstrumentStatusPostOnly, instruments[1].Status)
+		assert.Equal(t, 0.00022, instruments[1].MinQuantity)
+		assert.Equal(t, client.InstrumentStatusOffline, instruments[2].Status)
+		assert.Zero(t, instruments[2].MaxQuantity)
+
+		eth, err := c.GetInstrument(ctx, "ETH-BTC")
+		require.NoError(t, err)
+		assert.Equal(t, 0.00001, eth.TickSize)
+
+		_, err = c.GetInstrument(ctx, "DOGE-EUR")
+		assert.ErrorIs(t, err, client.ErrInstrumentNotFound)
+
+		cached, ok := c.Instruments().Lookup("BTC-USD")
+		require.True(t, ok)
+		assert.Equal(t, 0.01, cached.TickSize)
+		assert.Len(t, ts.requests, 1, "products are fetched once")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/products", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"UNAVAILABLE","message":"service unavailable"}`))
+		})
+		_, err := ts.client(t).GetInstrument(ctx, "BTC-USD")
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+	})
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("healthy", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/time", serveFile(t, http.StatusOK, "time.json"))
+		assert.NoError(t, ts.client(t).Health(ctx))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v3/brokerage/time", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"UNAVAILABLE","message":"service unavailable"}`))
+		})
+		err := ts.client(t).Health(ctx)
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+	})
+}
+
+func strPtr(s string) *string     { return &s }
+func floatPtr(f float64) *float64 { return &f }
+func sidePtr(s venuesv1.OrderSide) *venuesv1.OrderSide {
+	return &s
+}
+func typePtr(t venuesv1.OrderType) *venuesv1.OrderType {
+	return &t
+}
+func tifPtr(t venuesv1.TimeInForce) *venuesv1.TimeInForce {
+	return &t
+}
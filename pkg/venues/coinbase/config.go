@@ -0,0 +1,216 @@
+package coinbase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+)
+
+const (
+	// VenueID is the CQC venue identifier for Coinbase Advanced Trade.
+	VenueID = "coinbase"
+
+	// DefaultBaseURL is the Coinbase Advanced Trade REST API base URL.
+	DefaultBaseURL = "https://api.coinbase.com"
+
+	// DefaultWebSocketURL is the Advanced Trade market data WebSocket endpoint.
+	DefaultWebSocketURL = "wss://advanced-trade-ws.coinbase.com"
+
+	// DefaultBalanceCurrency is the currency reported by GetBalance when none is configured.
+	DefaultBalanceCurrency = "USD"
+
+	// DefaultReconnectMinBackoff is the initial delay before reconnecting a dropped stream.
+	DefaultReconnectMinBackoff = stream.DefaultReconnectMinBackoff
+
+	// DefaultReconnectMaxBackoff caps the exponential reconnect delay.
+	DefaultReconnectMaxBackoff = stream.DefaultReconnectMaxBackoff
+
+	// DefaultBalancePollInterval is the delay between balance polls made by
+	// SubscribeBalances when no order activity triggers an earlier one.
+	DefaultBalancePollInterval = 30 * time.Second
+
+	// DefaultInstrumentRefreshInterval is the maximum age of the cached
+	// product list served by GetInstruments and GetInstrument.
+	DefaultInstrumentRefreshInterval = 5 * time.Minute
+
+	// DefaultClockSyncInterval is the delay between server time syncs made by
+	// the client's clock.
+	DefaultClockSyncInterval = 5 * time.Minute
+)
+
+// Config contains configuration for the Coinbase Advanced Trade client.
+type Config struct {
+	// APIKey is the Coinbase API key (CB-ACCESS-KEY header)
+	APIKey string
+
+	// Secret is the base64-encoded API secret used for HMAC signing
+	Secret string
+
+	// Passphrase is the API passphrase (CB-ACCESS-PASSPHRASE header)
+	Passphrase string
+
+	// Credentials, if set, supplies the API key (KeyID), secret and
+	// passphrase in place of APIKey, Secret and Passphrase, which must then be
+	// empty. Rotated credentials are picked up without restarting; see
+	// client.FileCredentials and client.CallbackCredentials.
+	Credentials client.CredentialProvider
+
+	// BaseURL is the REST API base URL (default: DefaultBaseURL)
+	BaseURL string
+
+	// WebSocketURL is the market data WebSocket URL (default: DefaultWebSocketURL)
+	WebSocketURL string
+
+	// BalanceCurrency is the currency whose balance GetBalance returns (default: "USD").
+	// Use GetBalances to retrieve balances for every currency.
+	BalanceCurrency string
+
+	// OrderBookDepth is the number of levels requested per side by GetOrderBook.
+	// Zero uses the venue default.
+	OrderBookDepth int
+
+	// ReconnectMinBackoff is the initial delay before reconnecting a dropped
+	// stream (default: DefaultReconnectMinBackoff). The delay doubles after each
+	// failed attempt up to ReconnectMaxBackoff.
+	ReconnectMinBackoff time.Duration
+
+	// ReconnectMaxBackoff caps the reconnect delay (default: DefaultReconnectMaxBackoff)
+	ReconnectMaxBackoff time.Duration
+
+	// BalancePollInterval is the delay between balance polls made by
+	// SubscribeBalances (default: DefaultBalancePollInterval). Order activity
+	// on the private stream triggers an immediate poll; the interval catches
+	// transfers and other changes the stream does not report.
+	BalancePollInterval time.Duration
+
+	// InstrumentRefreshInterval is the maximum age of the cached product list
+	// served by GetInstruments and GetInstrument
+	// (default: DefaultInstrumentRefreshInterval).
+	InstrumentRefreshInterval time.Duration
+
+	// RoundOrders rounds order prices to the tick size and quantities down to
+	// the base increment (see client.RoundOrder) before PlaceOrder validates
+	// them. If false, misaligned orders fail validation with a
+	// *client.OrderValidationError.
+	RoundOrders bool
+
+	// RateLimits are the client-side rate limits applied to REST requests
+	// (default: DefaultRateLimits()). Requests that would exceed them are
+	// queued, or rejected if their context deadline would pass first.
+	RateLimits ratelimit.Config
+
+	// DisableRateLimit sends REST requests without client-side rate limiting.
+	DisableRateLimit bool
+
+	// ClockSyncInterval is the delay between server time syncs made by
+	// Clock().Run (default: DefaultClockSyncInterval).
+	//
+	// The client does not sync on its own: run the clock for the lifetime of
+	// the client with go c.Clock().Run(ctx). Otherwise requests are signed
+	// with the local clock until Coinbase first rejects one for clock skew, which
+	// triggers a one-off resync.
+	ClockSyncInterval time.Duration
+
+	// DisableClockSync signs requests with the local clock, uncorrected for
+	// skew, and returns requests rejected with venueerr.ErrClockSkew without
+	// resyncing and retrying them.
+	DisableClockSync bool
+}
+
+// Validate checks that the configuration has the fields required to connect.
+func (c Config) Validate() error {
+	switch {
+	case c.Credentials != nil:
+		if c.APIKey != "" || c.Secret != "" || c.Passphrase != "" {
+			return fmt.Errorf("static credentials and credential provider are mutually exclusive")
+		}
+	case c.APIKey == "":
+		return fmt.Errorf("API key is required")
+	case c.Secret == "":
+		return fmt.Errorf("secret is required")
+	case c.Passphrase == "":
+		return fmt.Errorf("passphrase is required")
+	}
+	if c.OrderBookDepth < 0 {
+		return fmt.Errorf("order book depth must be non-negative")
+	}
+	if c.ReconnectMinBackoff < 0 || c.ReconnectMaxBackoff < 0 {
+		return fmt.Errorf("reconnect backoff must be non-negative")
+	}
+	if c.ReconnectMaxBackoff > 0 && c.ReconnectMinBackoff > c.ReconnectMaxBackoff {
+		return fmt.Errorf("reconnect min backoff must not exceed max backoff")
+	}
+	if c.BalancePollInterval < 0 {
+		return fmt.Errorf("balance poll interval must be non-negative")
+	}
+	if c.InstrumentRefreshInterval < 0 {
+		return fmt.Errorf("instrument refresh interval must be non-negative")
+	}
+	if c.ClockSyncInterval < 0 {
+		return fmt.Errorf("clock sync interval must be non-negative")
+	}
+	if !c.RateLimits.IsZero() {
+		if err := c.RateLimits.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limits: %w", err)
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the config with default values applied.
+func (c Config) withDefaults() Config {
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultBaseURL
+	}
+	if c.WebSocketURL == "" {
+		c.WebSocketURL = DefaultWebSocketURL
+	}
+	if c.BalanceCurrency == "" {
+		c.BalanceCurrency = DefaultBalanceCurrency
+	}
+	if c.ReconnectMinBackoff == 0 {
+		c.ReconnectMinBackoff = DefaultReconnectMinBackoff
+	}
+	if c.ReconnectMaxBackoff == 0 {
+		c.ReconnectMaxBackoff = DefaultReconnectMaxBackoff
+	}
+	if c.ReconnectMaxBackoff < c.ReconnectMinBackoff {
+		c.ReconnectMaxBackoff = c.ReconnectMinBackoff
+	}
+	if c.BalancePollInterval == 0 {
+		c.BalancePollInterval = DefaultBalancePollInterval
+	}
+	if c.InstrumentRefreshInterval == 0 {
+		c.InstrumentRefreshInterval = DefaultInstrumentRefreshInterval
+	}
+	if c.ClockSyncInterval == 0 {
+		c.ClockSyncInterval = DefaultClockSyncInterval
+	}
+	if c.RateLimits.IsZero() {
+		c.RateLimits = DefaultRateLimits()
+	}
+	if c.RateLimits.Venue == "" {
+		c.RateLimits.Venue = VenueID
+	}
+	return c
+}
+
+// DefaultRateLimits returns the Advanced Trade REST limits: 30 requests per
+// second for authenticated endpoints and 10 per second for public market data
+// endpoints, which Coinbase counts separately.
+func DefaultRateLimits() ratelimit.Config {
+	return ratelimit.Config{
+		Venue: VenueID,
+		Buckets: []ratelimit.Bucket{
+			{Name: "private", Rate: 30},
+			{Name: "public", Rate: 10},
+		},
+		Rules: []ratelimit.Rule{
+			{PathPrefix: apiPrefix + "/market/", Buckets: []string{"public"}},
+			{Buckets: []string{"private"}},
+		},
+	}
+}
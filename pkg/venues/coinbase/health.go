@@ -0,0 +1,66 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cbnorm "github.com/Combine-Capital/cqvx/internal/normalizer/coinbase"
+)
+
+// Health checks that the Coinbase Advanced Trade API is reachable by fetching
+// the server time.
+func (c *Client) Health(ctx context.Context) error {
+	if _, err := c.do(ctx, http.MethodGet, "/time", nil, nil); err != nil {
+		return fmt.Errorf("coinbase health check failed: %w", err)
+	}
+	return nil
+}
+
+// serverTimeResponse is the body of GET /time.
+type serverTimeResponse struct {
+	EpochMillis string `json:"epochMillis"`
+}
+
+// fetchServerTime returns Coinbase's current time for the client's clock.
+// GET /time is public, so the request is sent unsigned on the caller's HTTP
+// client: it is neither stamped with the clock being corrected nor delayed by
+// the rate limiter, and a skew rejection cannot trigger a resync from within a
+// sync.
+func (c *Client) fetchServerTime(ctx context.Context) (time.Time, error) {
+	endpoint := strings.TrimRight(c.config.BaseURL, "/") + apiPrefix + "/time"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.publicHTTP.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("coinbase server time request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read coinbase response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return time.Time{}, cbnorm.NormalizeError(resp, raw)
+	}
+
+	var body serverTimeResponse
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode server time: %w", err)
+	}
+	millis, err := strconv.ParseInt(body.EpochMillis, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid server time %q: %w", body.EpochMillis, err)
+	}
+	return time.UnixMilli(millis), nil
+}
@@ -0,0 +1,119 @@
+package coinbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	cbnorm "github.com/Combine-Capital/cqvx/internal/normalizer/coinbase"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+)
+
+// GetInstruments retrieves the trading rules of every Coinbase product.
+//
+// The product list is cached for Config.InstrumentRefreshInterval; if a
+// refresh fails while products are cached, the cached products are returned
+// and the failure is logged.
+func (c *Client) GetInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	return c.instruments.All(ctx)
+}
+
+// GetInstrument retrieves the trading rules of one product (e.g., "BTC-USD")
+// from the same cache as GetInstruments.
+func (c *Client) GetInstrument(ctx context.Context, symbol string) (*client.Instrument, error) {
+	return c.instruments.Get(ctx, symbol)
+}
+
+// Instruments returns the client's instrument cache. Pre-trade checks can
+// read it with Lookup without a network call, and Run keeps it refreshed in
+// the background so that PlaceOrder never waits for a fetch.
+func (c *Client) Instruments() *client.InstrumentCache {
+	return c.instruments
+}
+
+// fetchInstruments lists all products.
+func (c *Client) fetchInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	raw, err := c.do(ctx, http.MethodGet, "/products", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := cbnorm.ParseProducts(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	instruments := make([]*client.Instrument, 0, len(products))
+	for _, product := range products {
+		instrument, err := normalizeInstrument(product)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product %s: %w", product.ProductID, err)
+		}
+		instruments = append(instruments, instrument)
+	}
+	return instruments, nil
+}
+
+// normalizeInstrument converts a Coinbase product to an Instrument.
+//
+// The price increment is the tick size. Quote min and max sizes bound the
+// order value, so they become the notional limits.
+func normalizeInstrument(product cbnorm.CoinbaseProduct) (*client.Instrument, error) {
+	base, quote := product.BaseCurrencyID, product.QuoteCurrencyID
+	if base == "" || quote == "" {
+		base, quote, _ = strings.Cut(product.ProductID, "-")
+	}
+
+	instrument := &client.Instrument{
+		VenueID:    VenueID,
+		Symbol:     product.ProductID,
+		BaseAsset:  base,
+		QuoteAsset: quote,
+		Status:     instrumentStatus(product),
+	}
+
+	tickSize := product.PriceIncrement
+	if tickSize == "" {
+		tickSize = product.QuoteIncrement
+	}
+	fields := []struct {
+		name  string
+		value string
+		dest  *float64
+	}{
+		{"price_increment", tickSize, &instrument.TickSize},
+		{"base_increment", product.BaseIncrement, &instrument.BaseIncrement},
+		{"quote_increment", product.QuoteIncrement, &instrument.QuoteIncrement},
+		{"base_min_size", product.BaseMinSize, &instrument.MinQuantity},
+		{"base_max_size", product.BaseMaxSize, &instrument.MaxQuantity},
+		{"quote_min_size", product.QuoteMinSize, &instrument.MinNotional},
+		{"quote_max_size", product.QuoteMaxSize, &instrument.MaxNotional},
+	}
+	for _, field := range fields {
+		value, err := normalizer.ParseDecimal(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field.name, err)
+		}
+		*field.dest = value
+	}
+	return instrument, nil
+}
+
+// instrumentStatus maps a product's status and trading flags to an
+// InstrumentStatus, most restrictive first.
+func instrumentStatus(product cbnorm.CoinbaseProduct) client.InstrumentStatus {
+	switch {
+	case product.Status != "online", product.TradingDisabled, product.IsDisabled, product.ViewOnly:
+		return client.InstrumentStatusOffline
+	case product.CancelOnly:
+		return client.InstrumentStatusCancelOnly
+	case product.PostOnly:
+		return client.InstrumentStatusPostOnly
+	case product.LimitOnly:
+		return client.InstrumentStatusLimitOnly
+	default:
+		return client.InstrumentStatusOnline
+	}
+}
@@ -0,0 +1,32 @@
+package coinbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	cbnorm "github.com/Combine-Capital/cqvx/internal/normalizer/coinbase"
+)
+
+// GetOrderBook retrieves an L2 order book snapshot for a product (e.g., "BTC-USD").
+// The number of levels per side is controlled by Config.OrderBookDepth.
+func (c *Client) GetOrderBook(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+
+	query := url.Values{}
+	query.Set("product_id", symbol)
+	if c.config.OrderBookDepth > 0 {
+		query.Set("limit", fmt.Sprintf("%d", c.config.OrderBookDepth))
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, "/product_book", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cbnorm.NormalizeOrderBook(ctx, raw)
+}
@@ -0,0 +1,354 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	cbnorm "github.com/Combine-Capital/cqvx/internal/normalizer/coinbase"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/orderbook"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+)
+
+// subscribeRequest is a WebSocket subscribe message. Private channels carry
+// an API key and a signature over the subscription itself.
+type subscribeRequest struct {
+	Type       string   `json:"type"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+	Channel    string   `json:"channel"`
+	APIKey     string   `json:"api_key,omitempty"`
+	Timestamp  string   `json:"timestamp,omitempty"`
+	Signature  string   `json:"signature,omitempty"`
+}
+
+// subscription describes a single-channel WebSocket subscription.
+type subscription struct {
+	// channel is the channel to subscribe to (e.g., "level2")
+	channel string
+
+	// messageChannel is the channel name carried by data messages (e.g., "l2_data")
+	messageChannel string
+
+	// symbol is the product to subscribe to; empty subscribes to all products
+	symbol string
+
+	// authenticated signs the subscribe request (required for the user channel)
+	authenticated bool
+
+	// onConnect is called after every (re)connection, before any message is read.
+	onConnect func()
+
+	// onMessage is called for every data message on messageChannel.
+	onMessage func(ctx context.Context, msg *cbnorm.CoinbaseWSMessage) error
+}
+
+// SubscribeOrderBook streams level2 order book updates for a product.
+//
+// The client keeps a local L2 book (see package orderbook) built from the
+// channel snapshot plus incremental updates and calls handler with a full copy
+// of the book (limited to Config.OrderBookDepth levels per side) after every
+// message.
+//
+// If the connection drops or a sequence gap is detected, the client reconnects
+// with exponential backoff, resubscribes and rebuilds the book from the new
+// snapshot. The call blocks until ctx is cancelled (returning ctx.Err()) or
+// handler returns an error (returning that error).
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbol string, handler client.OrderBookHandler) error {
+	if symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	book := orderbook.New(orderbook.Config{
+		VenueID: VenueID,
+		Symbol:  symbol,
+		// Sequence numbers are shared with heartbeats; the subscription checks
+		// the connection for gaps and reconnects to get a fresh snapshot.
+		MonotonicSequence: true,
+	})
+
+	return c.subscribe(ctx, subscription{
+		channel:        cbnorm.WSChannelLevel2,
+		messageChannel: cbnorm.WSChannelL2Data,
+		symbol:         symbol,
+		onConnect:      book.Reset,
+		onMessage: func(ctx context.Context, msg *cbnorm.CoinbaseWSMessage) error {
+			events, err := cbnorm.ParseL2Events(msg)
+			if err != nil {
+				return err
+			}
+
+			// Apply all events of a message at its sequence number; a snapshot
+			// event replaces any levels before it.
+			var updates []orderbook.Update
+			snapshot := false
+			for _, event := range events {
+				if event.ProductID != "" && event.ProductID != symbol {
+					continue
+				}
+				levels, err := cbnorm.NormalizeL2Updates(event.Updates)
+				if err != nil {
+					return err
+				}
+				if event.Type == cbnorm.WSEventSnapshot {
+					updates = updates[:0]
+					snapshot = true
+				}
+				updates = append(updates, bookUpdates(levels)...)
+			}
+
+			timestamp := normalizer.ParseTimestampOrNow(msg.Timestamp).AsTime()
+			if snapshot {
+				book.ApplySnapshot(msg.SequenceNum, timestamp, updates)
+			} else if len(updates) > 0 {
+				if err := book.ApplyDelta(ctx, msg.SequenceNum, timestamp, updates); err != nil {
+					return fmt.Errorf("failed to apply level2 update: %w", err)
+				}
+			}
+
+			if !book.Synced() {
+				return nil
+			}
+			copied, err := book.Snapshot(c.config.OrderBookDepth)
+			if err != nil {
+				return err
+			}
+			if err := handler(copied); err != nil {
+				return &stream.HandlerError{Err: err}
+			}
+			return nil
+		},
+	})
+}
+
+// bookUpdates converts parsed level2 updates to order book updates.
+func bookUpdates(levels []cbnorm.L2Level) []orderbook.Update {
+	updates := make([]orderbook.Update, 0, len(levels))
+	for _, level := range levels {
+		side := orderbook.Ask
+		if level.Bid {
+			side = orderbook.Bid
+		}
+		updates = append(updates, orderbook.Update{Side: side, Price: level.Price, Quantity: level.Quantity})
+	}
+	return updates
+}
+
+// SubscribeTrades streams public trades for a product from the market_trades channel.
+//
+// The trades replayed in the channel's initial snapshot were published before the
+// subscription started and are not delivered, so reconnects never redeliver
+// trades from a snapshot. The call blocks until ctx is cancelled (returning
+// ctx.Err()) or handler returns an error (returning that error).
+func (c *Client) SubscribeTrades(ctx context.Context, symbol string, handler client.TradeHandler) error {
+	if symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	return c.subscribe(ctx, subscription{
+		channel:        cbnorm.WSChannelMarketTrades,
+		messageChannel: cbnorm.WSChannelMarketTrades,
+		symbol:         symbol,
+		onConnect:      func() {},
+		onMessage: func(ctx context.Context, msg *cbnorm.CoinbaseWSMessage) error {
+			trades, err := cbnorm.NormalizeMarketTrades(ctx, msg, false)
+			if err != nil {
+				return err
+			}
+			for _, trade := range trades {
+				if trade.GetVenueSymbol() != symbol {
+					continue
+				}
+				if err := handler(trade); err != nil {
+					return &stream.HandlerError{Err: err}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// SubscribeExecutions streams execution reports for the caller's orders from
+// the authenticated user channel, across all products.
+//
+// The user channel pushes cumulative order state, which is diffed against the
+// last state seen for each order to report acknowledgements, fills (with the
+// quantity, price and fee of each fill), cancels, rejections and expiries.
+// The snapshot sent on first connect is recorded without being reported. After
+// a reconnect the new snapshot is diffed instead, so fills and new orders
+// missed while disconnected are reported; orders that completed while
+// disconnected are not in the snapshot and should be reconciled with GetOrder.
+//
+// The call blocks until ctx is cancelled (returning ctx.Err()) or handler
+// returns an error (returning that error).
+func (c *Client) SubscribeExecutions(ctx context.Context, handler client.ExecutionHandler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	tracker := normalizer.NewOrderTracker()
+
+	return c.subscribe(ctx, subscription{
+		channel:        cbnorm.WSChannelUser,
+		messageChannel: cbnorm.WSChannelUser,
+		authenticated:  true,
+		onConnect:      func() {},
+		onMessage: func(ctx context.Context, msg *cbnorm.CoinbaseWSMessage) error {
+			events, err := cbnorm.ParseUserEvents(msg)
+			if err != nil {
+				return err
+			}
+
+			timestamp := normalizer.ParseTimestampOrNow(msg.Timestamp)
+			for _, event := range events {
+				updates := make([]normalizer.OrderUpdate, 0, len(event.Orders))
+				for _, cbOrder := range event.Orders {
+					update, err := cbnorm.NormalizeUserOrder(cbOrder, timestamp)
+					if err != nil {
+						return err
+					}
+					updates = append(updates, update)
+				}
+
+				var reports []*venuesv1.ExecutionReport
+				if event.Type == cbnorm.WSEventSnapshot {
+					reports = tracker.Snapshot(updates)
+				} else {
+					for _, update := range updates {
+						reports = append(reports, tracker.Reports(update)...)
+					}
+				}
+				for _, report := range reports {
+					venueId := VenueID
+					report.VenueId = &venueId
+					if err := handler(report); err != nil {
+						return &stream.HandlerError{Err: err}
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// SubscribeBalances reports balance changes across all currencies.
+//
+// Coinbase has no balance stream, so balances are polled with GetBalances.
+// Order activity on the user channel (see SubscribeExecutions) triggers an
+// immediate poll, so fills and order holds show up within one round trip;
+// BalancePollInterval bounds how long transfers and other changes not tied to
+// orders take to appear. The current balances are reported first, then only
+// balances whose amounts change.
+//
+// Failed polls are logged and retried. The call blocks until ctx is cancelled
+// (returning ctx.Err()), handler returns an error (returning that error) or
+// the user channel subscription fails (returning its error).
+func (c *Client) SubscribeBalances(ctx context.Context, handler client.BalanceHandler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	poller := &client.BalancePoller{
+		Fetch:      c.GetBalances,
+		Interval:   c.config.BalancePollInterval,
+		Executions: c.SubscribeExecutions,
+		OnError: func(err error) {
+			c.logger.WarnContext(ctx, "coinbase balance poll failed", "error", err)
+		},
+	}
+	return poller.Run(ctx, handler)
+}
+
+// signSubscribe authenticates a private channel subscribe request the way the
+// Advanced Trade feed expects: the API key, a timestamp and an HMAC over the
+// timestamp, channel and comma-joined product IDs (see
+// auth.HMACSigner.SignSubscription).
+func (c *Client) signSubscribe(ctx context.Context, req *subscribeRequest) error {
+	result, err := c.signer.SignSubscription(ctx, req.Channel, req.ProductIDs)
+	if err != nil {
+		return fmt.Errorf("failed to sign %s subscription: %w", req.Channel, err)
+	}
+	req.APIKey = result.APIKey
+	req.Timestamp = result.Timestamp
+	req.Signature = result.Signature
+	return nil
+}
+
+// subscribe runs a subscription, reconnecting with exponential backoff until ctx
+// is cancelled or a handler fails (see stream.Subscription).
+func (c *Client) subscribe(ctx context.Context, sub subscription) error {
+	run := &stream.Subscription[*cbnorm.CoinbaseWSMessage]{
+		Connect: func(ctx context.Context) (stream.Conn, error) {
+			return c.connect(ctx, sub)
+		},
+		Decode: func(data []byte) (*cbnorm.CoinbaseWSMessage, int64, error) {
+			msg, err := cbnorm.ParseWSMessage(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			return msg, msg.SequenceNum, nil
+		},
+		Handle: func(ctx context.Context, msg *cbnorm.CoinbaseWSMessage) (bool, error) {
+			if msg.Channel != sub.messageChannel {
+				return false, nil
+			}
+			return true, sub.onMessage(ctx, msg)
+		},
+		MinBackoff: c.config.ReconnectMinBackoff,
+		MaxBackoff: c.config.ReconnectMaxBackoff,
+		OnDisconnect: func(err error, backoff time.Duration) {
+			c.logger.WarnContext(ctx, "coinbase websocket disconnected, reconnecting",
+				"channel", sub.channel, "symbol", sub.symbol, "error", err, "backoff", backoff)
+		},
+	}
+	return run.Run(ctx)
+}
+
+// connect dials the feed and subscribes to the channel and to heartbeats,
+// which keep the connection's sequence numbers advancing on quiet channels.
+func (c *Client) connect(ctx context.Context, sub subscription) (stream.Conn, error) {
+	conn, err := c.wsDialer.Dial(ctx, c.config.WebSocketURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	channelReq := subscribeRequest{Type: "subscribe", Channel: sub.channel}
+	if sub.symbol != "" {
+		channelReq.ProductIDs = []string{sub.symbol}
+	}
+	if sub.authenticated {
+		if err := c.signSubscribe(ctx, &channelReq); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	requests := []subscribeRequest{
+		channelReq,
+		{Type: "subscribe", Channel: cbnorm.WSChannelHeartbeats},
+	}
+	for _, req := range requests {
+		payload, err := json.Marshal(req)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to encode subscribe request: %w", err)
+		}
+		if err := conn.WriteMessage(ctx, payload); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to subscribe to %s: %w", req.Channel, err)
+		}
+	}
+
+	sub.onConnect()
+	c.logger.DebugContext(ctx, "coinbase websocket subscribed", "channel", sub.channel, "symbol", sub.symbol)
+	return conn, nil
+}
@@ -0,0 +1,575 @@
+package coinbase_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/venues/coinbase"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wsStandIn is a local stand-in for the Coinbase Advanced Trade WebSocket feed.
+// Each accepted connection reads the client's subscribe messages and then runs
+// the script for that connection (indexed from zero).
+type wsStandIn struct {
+	t      *testing.T
+	server *httptest.Server
+	script func(conn *wsScriptConn, index int)
+
+	mu         sync.Mutex
+	subscribes []map[string]interface{}
+	conns      int
+}
+
+// wsScriptConn is the server side of a stand-in connection.
+type wsScriptConn struct {
+	t    *testing.T
+	conn *websocket.Conn
+	seq  int64
+}
+
+// send writes a message on channel with the next sequence number.
+func (c *wsScriptConn) send(channel string, events string) {
+	msg := fmt.Sprintf(`{"channel":%q,"client_id":"","timestamp":"2024-01-15T10:30:00.123456789Z","sequence_num":%d,"events":%s}`,
+		channel, c.seq, events)
+	c.seq++
+	_ = c.conn.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+// skip advances the sequence number without sending, simulating a lost message.
+func (c *wsScriptConn) skip() {
+	c.seq++
+}
+
+// waitClosed blocks until the client closes the connection.
+func (c *wsScriptConn) waitClosed() {
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func newWSStandIn(t *testing.T, script func(conn *wsScriptConn, index int)) *wsStandIn {
+	t.Helper()
+	s := &wsStandIn{t: t, script: script}
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Expect the channel subscription followed by heartbeats
+		for i := 0; i < 2; i++ {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var sub map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &sub))
+			s.mu.Lock()
+			s.subscribes = append(s.subscribes, sub)
+			s.mu.Unlock()
+		}
+
+		s.mu.Lock()
+		index := s.conns
+		s.conns++
+		s.mu.Unlock()
+
+		s.script(&wsScriptConn{t: t, conn: conn}, index)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *wsStandIn) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func (s *wsStandIn) client(t *testing.T, depth int) *coinbase.Client {
+	t.Helper()
+	c, err := coinbase.NewClient(coinbase.Config{
+		APIKey:              testAPIKey,
+		Secret:              testSecret,
+		Passphrase:          testPassphrase,
+		WebSocketURL:        "ws" + strings.TrimPrefix(s.server.URL, "http"),
+		OrderBookDepth:      depth,
+		ReconnectMinBackoff: time.Millisecond,
+		ReconnectMaxBackoff: 10 * time.Millisecond,
+	}, nil, nil, nil)
+	require.NoError(t, err)
+	return c
+}
+
+const (
+	l2Snapshot = `[{"type":"snapshot","product_id":"BTC-USD","updates":[
+		{"side":"bid","event_time":"1970-01-01T00:00:00Z","price_level":"49999.99","new_quantity":"0.5"},
+		{"side":"bid","event_time":"1970-01-01T00:00:00Z","price_level":"49999.50","new_quantity":"1.2"},
+		{"side":"bid","event_time":"1970-01-01T00:00:00Z","price_level":"49998.00","new_quantity":"3.0"},
+		{"side":"offer","event_time":"1970-01-01T00:00:00Z","price_level":"50000.01","new_quantity":"0.4"},
+		{"side":"offer","event_time":"1970-01-01T00:00:00Z","price_level":"50000.50","new_quantity":"2.1"}
+	]}]`
+	l2Update = `[{"type":"update","product_id":"BTC-USD","updates":[
+		{"side":"bid","event_time":"2024-01-15T10:30:00.1Z","price_level":"49999.99","new_quantity":"0"},
+		{"side":"bid","event_time":"2024-01-15T10:30:00.1Z","price_level":"49999.75","new_quantity":"0.8"},
+		{"side":"offer","event_time":"2024-01-15T10:30:00.1Z","price_level":"50000.01","new_quantity":"0.9"}
+	]}]`
+	l2Resnapshot = `[{"type":"snapshot","product_id":"BTC-USD","updates":[
+		{"side":"bid","event_time":"1970-01-01T00:00:00Z","price_level":"49000.00","new_quantity":"1.0"},
+		{"side":"offer","event_time":"1970-01-01T00:00:00Z","price_level":"51000.00","new_quantity":"1.0"}
+	]}]`
+	heartbeat = `[{"current_time":"2024-01-15T10:30:00Z","heartbeat_counter":1}]`
+)
+
+// levels flattens an order book side into [price, quantity] pairs.
+func levels(side []*marketsv1.OrderBookLevel) [][2]float64 {
+	out := make([][2]float64, 0, len(side))
+	for _, level := range side {
+		out = append(out, [2]float64{level.GetPrice(), level.GetQuantity()})
+	}
+	return out
+}
+
+// collectBooks subscribes and stops once n books have been received.
+func collectBooks(t *testing.T, c *coinbase.Client, n int) ([]*marketsv1.OrderBook, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var books []*marketsv1.OrderBook
+	errDone := errors.New("done")
+	err := c.SubscribeOrderBook(ctx, "BTC-USD", func(book *marketsv1.OrderBook) error {
+		books = append(books, book)
+		if len(books) == n {
+			return errDone
+		}
+		return nil
+	})
+	if errors.Is(err, errDone) {
+		err = nil
+	}
+	return books, err
+}
+
+func TestSubscribeOrderBook(t *testing.T) {
+	t.Run("snapshot and updates", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.send("subscriptions", `[{"subscriptions":{"level2":["BTC-USD"]}}]`)
+			conn.send("l2_data", l2Snapshot)
+			conn.send("heartbeats", heartbeat)
+			conn.send("l2_data", l2Update)
+			conn.waitClosed()
+		})
+
+		books, err := collectBooks(t, s.client(t, 0), 2)
+		require.NoError(t, err)
+		require.Len(t, books, 2)
+
+		snapshot := books[0]
+		assert.Equal(t, "coinbase", snapshot.GetVenueId())
+		assert.Equal(t, "BTC-USD", snapshot.GetVenueSymbol())
+		assert.Equal(t, [][2]float64{{49999.99, 0.5}, {49999.50, 1.2}, {49998.00, 3.0}}, levels(snapshot.GetBids()))
+		assert.Equal(t, [][2]float64{{50000.01, 0.4}, {50000.50, 2.1}}, levels(snapshot.GetAsks()))
+		assert.Equal(t, int64(1), snapshot.GetSequence())
+
+		updated := books[1]
+		assert.Equal(t, [][2]float64{{49999.75, 0.8}, {49999.50, 1.2}, {49998.00, 3.0}}, levels(updated.GetBids()))
+		assert.Equal(t, [][2]float64{{50000.01, 0.9}, {50000.50, 2.1}}, levels(updated.GetAsks()))
+		assert.Equal(t, 49999.75, updated.GetBestBid())
+		assert.Equal(t, 50000.01, updated.GetBestAsk())
+		assert.InDelta(t, 0.26, updated.GetSpread(), 1e-9)
+		assert.Equal(t, int64(3), updated.GetSequence())
+
+		require.Len(t, s.subscribes, 2)
+		assert.Equal(t, map[string]interface{}{
+			"type": "subscribe", "product_ids": []interface{}{"BTC-USD"}, "channel": "level2",
+		}, s.subscribes[0])
+		assert.Equal(t, map[string]interface{}{"type": "subscribe", "channel": "heartbeats"}, s.subscribes[1])
+	})
+
+	t.Run("depth limit", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.send("l2_data", l2Snapshot)
+			conn.waitClosed()
+		})
+
+		books, err := collectBooks(t, s.client(t, 1), 1)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]float64{{49999.99, 0.5}}, levels(books[0].GetBids()))
+		assert.Equal(t, [][2]float64{{50000.01, 0.4}}, levels(books[0].GetAsks()))
+	})
+
+	t.Run("reconnects and rebuilds book", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				conn.send("l2_data", l2Snapshot)
+				return // drop the connection
+			}
+			conn.send("l2_data", l2Resnapshot)
+			conn.waitClosed()
+		})
+
+		books, err := collectBooks(t, s.client(t, 0), 2)
+		require.NoError(t, err)
+		require.Len(t, books, 2)
+
+		// The rebuilt book contains only the new snapshot's levels
+		assert.Equal(t, [][2]float64{{49000, 1}}, levels(books[1].GetBids()))
+		assert.Equal(t, [][2]float64{{51000, 1}}, levels(books[1].GetAsks()))
+		assert.Equal(t, 2, s.connections())
+		assert.Len(t, s.subscribes, 4, "client should resubscribe after reconnecting")
+	})
+
+	t.Run("sequence gap forces resnapshot", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				conn.send("l2_data", l2Snapshot)
+				conn.skip()
+				conn.send("l2_data", l2Update)
+				conn.waitClosed()
+				return
+			}
+			conn.send("l2_data", l2Resnapshot)
+			conn.waitClosed()
+		})
+
+		books, err := collectBooks(t, s.client(t, 0), 2)
+		require.NoError(t, err)
+		require.Len(t, books, 2)
+		assert.Equal(t, [][2]float64{{49000, 1}}, levels(books[1].GetBids()), "update after gap must not be applied")
+		assert.Equal(t, 2, s.connections())
+	})
+
+	t.Run("update before snapshot reconnects", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				conn.send("l2_data", l2Update)
+				conn.waitClosed()
+				return
+			}
+			conn.send("l2_data", l2Snapshot)
+			conn.waitClosed()
+		})
+
+		books, err := collectBooks(t, s.client(t, 0), 1)
+		require.NoError(t, err)
+		assert.Equal(t, 49999.99, books[0].GetBestBid())
+		assert.Equal(t, 2, s.connections())
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.waitClosed()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := s.client(t, 0).SubscribeOrderBook(ctx, "BTC-USD", func(*marketsv1.OrderBook) error { return nil })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {})
+		c := s.client(t, 0)
+
+		assert.Error(t, c.SubscribeOrderBook(context.Background(), "", func(*marketsv1.OrderBook) error { return nil }))
+		assert.Error(t, c.SubscribeOrderBook(context.Background(), "BTC-USD", nil))
+		assert.Zero(t, s.connections())
+	})
+}
+
+func TestSubscribeTrades(t *testing.T) {
+	const tradesSnapshot = `[{"type":"snapshot","trades":[
+		{"trade_id":"100","product_id":"BTC-USD","price":"49990.00","size":"0.1","side":"BUY","time":"2024-01-15T10:29:59Z"}
+	]}]`
+	const tradesUpdate = `[{"type":"update","trades":[
+		{"trade_id":"101","product_id":"BTC-USD","price":"50000.00","size":"0.25","side":"SELL","time":"2024-01-15T10:30:00.5Z"},
+		{"trade_id":"102","product_id":"BTC-USD","price":"50001.00","size":"0.05","side":"BUY","time":"2024-01-15T10:30:00.6Z"}
+	]}]`
+
+	t.Run("delivers live trades", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.send("market_trades", tradesSnapshot)
+			conn.send("market_trades", tradesUpdate)
+			conn.waitClosed()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var trades []*marketsv1.Trade
+		errDone := errors.New("done")
+		err := s.client(t, 0).SubscribeTrades(ctx, "BTC-USD", func(trade *marketsv1.Trade) error {
+			trades = append(trades, trade)
+			if len(trades) == 2 {
+				return errDone
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, errDone)
+
+		require.Len(t, trades, 2)
+		assert.Equal(t, "101", trades[0].GetTradeId())
+		assert.Equal(t, "coinbase", trades[0].GetVenueId())
+		assert.Equal(t, "BTC-USD", trades[0].GetVenueSymbol())
+		assert.Equal(t, 50000.0, trades[0].GetPrice())
+		assert.Equal(t, 0.25, trades[0].GetQuantity())
+		assert.Equal(t, marketsv1.TradeSide_TRADE_SIDE_SELL, trades[0].GetSide())
+		assert.Equal(t, "102", trades[1].GetTradeId())
+
+		assert.Equal(t, "market_trades", s.subscribes[0]["channel"])
+	})
+
+	t.Run("reconnects after drop", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				return // drop the connection immediately
+			}
+			conn.send("market_trades", tradesSnapshot)
+			conn.send("market_trades", tradesUpdate)
+			conn.waitClosed()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		errDone := errors.New("done")
+		var first *marketsv1.Trade
+		err := s.client(t, 0).SubscribeTrades(ctx, "BTC-USD", func(trade *marketsv1.Trade) error {
+			first = trade
+			return errDone
+		})
+		assert.ErrorIs(t, err, errDone)
+		assert.Equal(t, "101", first.GetTradeId())
+		assert.Equal(t, 2, s.connections())
+	})
+
+	t.Run("venue error message reconnects", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				_ = conn.conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"error","message":"failure to subscribe"}`))
+				conn.waitClosed()
+				return
+			}
+			conn.send("market_trades", tradesUpdate)
+			conn.waitClosed()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		errDone := errors.New("done")
+		err := s.client(t, 0).SubscribeTrades(ctx, "BTC-USD", func(*marketsv1.Trade) error { return errDone })
+		assert.ErrorIs(t, err, errDone)
+		assert.Equal(t, 2, s.connections())
+	})
+}
+
+func TestSubscribeExecutions(t *testing.T) {
+	const (
+		userSnapshot = `[{"type":"snapshot","orders":[
+			{"order_id":"order-resting","client_order_id":"client-resting","cumulative_quantity":"0","leaves_quantity":"2","avg_price":"0","total_fees":"0","status":"OPEN","product_id":"ETH-USD","order_side":"SELL","order_type":"Limit"}
+		]}]`
+		userAck = `[{"type":"update","orders":[
+			{"order_id":"order-1","client_order_id":"client-1","cumulative_quantity":"0","leaves_quantity":"1","avg_price":"0","total_fees":"0","status":"OPEN","product_id":"BTC-USD","order_side":"BUY","order_type":"Limit"}
+		]}]`
+		userPartial = `[{"type":"update","orders":[
+			{"order_id":"order-1","client_order_id":"client-1","cumulative_quantity":"0.4","leaves_quantity":"0.6","avg_price":"50000","total_fees":"12","status":"OPEN","product_id":"BTC-USD","order_side":"BUY","order_type":"Limit"}
+		]}]`
+		userFilled = `[{"type":"update","orders":[
+			{"order_id":"order-1","client_order_id":"client-1","cumulative_quantity":"1","leaves_quantity":"0","avg_price":"50030","total_fees":"30.018","status":"FILLED","product_id":"BTC-USD","order_side":"BUY","order_type":"Limit"}
+		]}]`
+		userCancelled = `[{"type":"update","orders":[
+			{"order_id":"order-resting","client_order_id":"client-resting","cumulative_quantity":"0","leaves_quantity":"2","avg_price":"0","total_fees":"0","status":"CANCELLED","product_id":"ETH-USD","order_side":"SELL","order_type":"Limit"}
+		]}]`
+		userResnapshot = `[{"type":"snapshot","orders":[
+			{"order_id":"order-resting","client_order_id":"client-resting","cumulative_quantity":"0.5","leaves_quantity":"1.5","avg_price":"2500","total_fees":"1.25","status":"OPEN","product_id":"ETH-USD","order_side":"SELL","order_type":"Limit"}
+		]}]`
+	)
+
+	collect := func(t *testing.T, c *coinbase.Client, n int) []*venuesv1.ExecutionReport {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var reports []*venuesv1.ExecutionReport
+		errDone := errors.New("done")
+		err := c.SubscribeExecutions(ctx, func(report *venuesv1.ExecutionReport) error {
+			reports = append(reports, report)
+			if len(reports) == n {
+				return errDone
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errDone)
+		return reports
+	}
+
+	t.Run("acks fills and cancels", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.send("subscriptions", `[{"subscriptions":{"user":["9f8c2f04-4a5b-5b6c-8d7e-0f1a2b3c4d5e"]}}]`)
+			conn.send("user", userSnapshot)
+			conn.send("heartbeats", heartbeat)
+			conn.send("user", userAck)
+			conn.send("user", userPartial)
+			conn.send("user", userFilled)
+			conn.send("user", userCancelled)
+			conn.waitClosed()
+		})
+
+		reports := collect(t, s.client(t, 0), 4)
+
+		ack := reports[0]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, ack.GetExecutionType())
+		assert.Equal(t, "coinbase", ack.GetVenueId())
+		assert.Equal(t, "order-1", ack.GetVenueOrderId())
+		assert.Equal(t, "client-1", ack.GetClientOrderId())
+		assert.Equal(t, "BTC-USD", ack.GetVenueSymbol())
+		assert.Equal(t, "BUY", ack.GetSide())
+		assert.Equal(t, "LIMIT", ack.GetOrderType())
+
+		partial := reports[1]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_PARTIAL_FILL, partial.GetExecutionType())
+		assert.Equal(t, "PARTIALLY_FILLED", partial.GetOrderStatus())
+		assert.InDelta(t, 0.4, partial.GetQuantity(), 1e-9)
+		assert.InDelta(t, 50000, partial.GetPrice(), 1e-6)
+		assert.InDelta(t, 12, partial.GetFee(), 1e-9)
+
+		filled := reports[2]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, filled.GetExecutionType())
+		assert.Equal(t, "FILLED", filled.GetOrderStatus())
+		assert.InDelta(t, 0.6, filled.GetQuantity(), 1e-9)
+		assert.InDelta(t, 50050, filled.GetPrice(), 1e-6)
+		assert.InDelta(t, 18.018, filled.GetFee(), 1e-9)
+		assert.InDelta(t, 1, filled.GetCumulativeQuantity(), 1e-9)
+
+		cancelled := reports[3]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_CANCELLED, cancelled.GetExecutionType())
+		assert.Equal(t, "order-resting", cancelled.GetVenueOrderId())
+
+		// The user channel is subscribed for all products, so the signature
+		// covers only the timestamp and channel.
+		require.Len(t, s.subscribes, 2)
+		timestamp, ok := s.subscribes[0]["timestamp"].(string)
+		require.True(t, ok)
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write([]byte(timestamp + "user"))
+		assert.Equal(t, map[string]interface{}{
+			"type":      "subscribe",
+			"channel":   "user",
+			"api_key":   testAPIKey,
+			"timestamp": timestamp,
+			"signature": hex.EncodeToString(mac.Sum(nil)),
+		}, s.subscribes[0])
+		assert.Equal(t, map[string]interface{}{"type": "subscribe", "channel": "heartbeats"}, s.subscribes[1])
+	})
+
+	t.Run("resnapshot reports missed fills", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				conn.send("user", userSnapshot)
+				return // drop the connection
+			}
+			conn.send("user", userResnapshot)
+			conn.waitClosed()
+		})
+
+		reports := collect(t, s.client(t, 0), 1)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_PARTIAL_FILL, reports[0].GetExecutionType())
+		assert.Equal(t, "order-resting", reports[0].GetVenueOrderId())
+		assert.InDelta(t, 0.5, reports[0].GetQuantity(), 1e-9)
+		assert.InDelta(t, 2500, reports[0].GetPrice(), 1e-9)
+		assert.Equal(t, 2, s.connections())
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {})
+		assert.Error(t, s.client(t, 0).SubscribeExecutions(context.Background(), nil))
+		assert.Zero(t, s.connections())
+	})
+}
+
+func TestSubscribeBalances(t *testing.T) {
+	const userFilled = `[{"type":"update","orders":[
+		{"order_id":"order-1","client_order_id":"client-1","cumulative_quantity":"0.005","leaves_quantity":"0","avg_price":"50000","total_fees":"1.5","status":"FILLED","product_id":"BTC-USD","order_side":"BUY","order_type":"Limit"}
+	]}]`
+
+	// After the fill the BTC account grows and the USD hold is released.
+	data, err := os.ReadFile(filepath.Join("testdata", "accounts.json"))
+	require.NoError(t, err)
+	afterFill := strings.NewReplacer(`"value": "1.25"`, `"value": "1.255"`, `"value": "251.50"`, `"value": "0"`).Replace(string(data))
+
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/api/v3/brokerage/accounts", serveSequence(
+		serveFile(t, http.StatusOK, "accounts.json"),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(afterFill))
+		},
+	))
+
+	s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+		conn.send("user", `[{"type":"snapshot","orders":[]}]`)
+		conn.send("user", userFilled)
+		conn.waitClosed()
+	})
+
+	c, err := coinbase.NewClient(coinbase.Config{
+		APIKey:              testAPIKey,
+		Secret:              testSecret,
+		Passphrase:          testPassphrase,
+		BaseURL:             ts.server.URL,
+		WebSocketURL:        "ws" + strings.TrimPrefix(s.server.URL, "http"),
+		ReconnectMinBackoff: time.Millisecond,
+		ReconnectMaxBackoff: 10 * time.Millisecond,
+		BalancePollInterval: time.Hour,
+	}, ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var balances []*venuesv1.Balance
+	errDone := errors.New("done")
+	err = c.SubscribeBalances(ctx, func(balance *venuesv1.Balance) error {
+		balances = append(balances, balance)
+		if len(balances) == 4 {
+			return errDone
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errDone)
+
+	// The first poll reports every balance; the poll triggered by the fill
+	// reports only the two that changed.
+	assert.Equal(t, "BTC", balances[0].GetAssetId())
+	assert.Equal(t, "USD", balances[1].GetAssetId())
+	assert.Equal(t, "BTC", balances[2].GetAssetId())
+	assert.Equal(t, 1.255, balances[2].GetAvailable())
+	assert.Equal(t, "USD", balances[3].GetAssetId())
+	assert.Equal(t, 0.0, balances[3].GetLocked())
+	assert.Equal(t, 10000.50, balances[3].GetTotal())
+}
@@ -0,0 +1,700 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/idgen"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	cbnorm "github.com/Combine-Capital/cqvx/internal/normalizer/coinbase"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// defaultOrdersPageSize is the page size used when listing orders without a limit.
+const defaultOrdersPageSize = 100
+
+// maxBatchCancelSize is the maximum number of order IDs Coinbase accepts in one
+// POST /orders/batch_cancel request.
+const maxBatchCancelSize = 100
+
+// createOrderRequest is the request body for POST /orders.
+type createOrderRequest struct {
+	ClientOrderID      string                            `json:"client_order_id"`
+	ProductID          string                            `json:"product_id"`
+	Side               string                            `json:"side"`
+	OrderConfiguration map[string]map[string]interface{} `json:"order_configuration"`
+}
+
+// cancelOrdersRequest is the request body for POST /orders/batch_cancel.
+type cancelOrdersRequest struct {
+	OrderIDs []string `json:"order_ids"`
+}
+
+// cancelOrdersResponse is the response from POST /orders/batch_cancel.
+type cancelOrdersResponse struct {
+	Results []cancelOrderResult `json:"results"`
+}
+
+// cancelOrderResult is the per-order outcome of a batch cancel.
+type cancelOrderResult struct {
+	Success       bool   `json:"success"`
+	FailureReason string `json:"failure_reason"`
+	OrderID       string `json:"order_id"`
+}
+
+// editOrderRequest is the request body for POST /orders/edit.
+type editOrderRequest struct {
+	OrderID   string `json:"order_id"`
+	Price     string `json:"price"`
+	Size      string `json:"size"`
+	StopPrice string `json:"stop_price,omitempty"`
+}
+
+// editOrderResponse is the response from POST /orders/edit.
+type editOrderResponse struct {
+	Success bool                   `json:"success"`
+	Errors  []cbnorm.CoinbaseError `json:"errors"`
+}
+
+// getOrderResponse is the response from GET /orders/historical/{order_id}.
+type getOrderResponse struct {
+	Order json.RawMessage `json:"order"`
+}
+
+// listOrdersResponse is the response from GET /orders/historical/batch.
+type listOrdersResponse struct {
+	Orders  []json.RawMessage `json:"orders"`
+	HasNext bool              `json:"has_next"`
+	Cursor  string            `json:"cursor"`
+}
+
+// listFillsResponse is the response from GET /orders/historical/fills.
+// An empty cursor marks the last page.
+type listFillsResponse struct {
+	Fills  []json.RawMessage `json:"fills"`
+	Cursor string            `json:"cursor"`
+}
+
+// PlaceOrder submits a new order to Coinbase Advanced Trade.
+//
+// The order must set VenueSymbol, Side and Quantity; limit and stop-limit
+// orders must also set Price (and StopPrice). Supported combinations:
+//   - MARKET -> market_market_ioc
+//   - LIMIT with GTC (default), GTD, FOK or IOC time in force
+//   - POST_ONLY -> post-only limit GTC
+//   - STOP_LIMIT with GTC (default) or GTD time in force
+//
+// GTD orders take their end time from ExpiresAt. If ClientOrderId is empty a
+// random one is generated for this call and reused if the order is resubmitted
+// after a failure, so Coinbase deduplicates the retry; identical orders placed
+// by separate calls are placed separately.
+//
+// The order is first checked with client.ValidateOrder against the cached
+// product (see GetInstruments); if the product is not cached and the cache is
+// empty or stale, the products are fetched first, within
+// client.DefaultInstrumentLookupTimeout. Invalid orders return a
+// *client.OrderValidationError, and orders for products Coinbase does not
+// list an error matching client.ErrInstrumentNotFound. Orders rejected by the venue return a
+// *venueerr.Error matching venueerr.ErrInvalidOrder, or a more specific
+// category such as venueerr.ErrInsufficientFunds.
+//
+// If the request times out or Coinbase returns a 5xx error, the order is
+// looked up by client order ID before it is resubmitted (see
+// client.PlaceOrderIdempotently). If its outcome still cannot be determined,
+// a *venueerr.Error matching venueerr.ErrUnknownOutcome is returned; resolve
+// it with FindOrderByClientOrderID before placing the order again.
+func (c *Client) PlaceOrder(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error) {
+	order, err := c.instruments.PrepareOrder(ctx, order, c.config.RoundOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := buildCreateOrderRequest(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.PlaceOrderIdempotently(ctx, VenueID, req.ClientOrderID,
+		func(ctx context.Context) (*venuesv1.ExecutionReport, error) {
+			return c.createOrder(ctx, order, req)
+		},
+		func(ctx context.Context, since time.Time) (*venuesv1.Order, error) {
+			return c.FindOrderByClientOrderID(ctx, req.ProductID, req.ClientOrderID, since)
+		})
+}
+
+// createOrder submits a create order request once.
+func (c *Client) createOrder(ctx context.Context, order *venuesv1.Order, req *createOrderRequest) (*venuesv1.ExecutionReport, error) {
+	raw, err := c.do(ctx, http.MethodPost, "/orders", nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := cbnorm.NormalizeCreateOrderResponse(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.GetClientOrderId() == "" {
+		report.ClientOrderId = &req.ClientOrderID
+	}
+	orderType := order.GetOrderType().String()
+	report.OrderType = &orderType
+
+	return report, nil
+}
+
+// FindOrderByClientOrderID returns the order on the product with the given
+// client order ID, searching orders created since the given time.
+//
+// Coinbase cannot filter orders by client order ID, so the product's orders
+// in the time range are listed and matched client-side; keep the range short.
+// Returns a *venueerr.Error matching venueerr.ErrNotFound if there is no such
+// order.
+func (c *Client) FindOrderByClientOrderID(ctx context.Context, symbol, clientOrderID string, since time.Time) (*venuesv1.Order, error) {
+	if clientOrderID == "" {
+		return nil, fmt.Errorf("client order ID is required")
+	}
+
+	orders, err := c.GetOrders(ctx, client.OrderFilter{Symbols: []string{symbol}, StartTime: since})
+	if err != nil {
+		return nil, err
+	}
+	if order := client.OrderByClientOrderID(orders, clientOrderID); order != nil {
+		return order, nil
+	}
+	return nil, &venueerr.Error{
+		Venue:    VenueID,
+		Category: venueerr.ErrNotFound,
+		Message:  fmt.Sprintf("no %s order with client order ID %s since %s", symbol, clientOrderID, since.UTC().Format(time.RFC3339)),
+	}
+}
+
+// CancelOrder cancels an open order by its Coinbase order ID.
+//
+// Returns ORDER_STATUS_CANCELLED once the venue accepts the cancel request.
+// A cancel rejected by the venue returns a *venueerr.Error whose Code is the
+// failure reason; unknown orders match venueerr.ErrNotFound.
+func (c *Client) CancelOrder(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	results, err := c.batchCancel(ctx, []string{orderID})
+	if err != nil {
+		return nil, err
+	}
+	return results[0].Status, results[0].Err
+}
+
+// PlaceOrders submits several orders. Coinbase Advanced Trade has no batch
+// order endpoint, so orders are placed with concurrent PlaceOrder calls
+// (at most client.DefaultBatchConcurrency at a time) and are not atomic.
+func (c *Client) PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error) {
+	return client.PlaceOrdersConcurrently(ctx, orders, client.DefaultBatchConcurrency, c.PlaceOrder), nil
+}
+
+// CancelOrders cancels several orders with POST /orders/batch_cancel, split
+// into requests of at most 100 order IDs.
+//
+// Each result reports ORDER_STATUS_CANCELLED or a *venueerr.Error as returned
+// by CancelOrder. A request that fails outright returns its error for
+// every order in that request.
+func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	for _, orderID := range orderIDs {
+		if orderID == "" {
+			return nil, fmt.Errorf("order ID is required")
+		}
+	}
+
+	results := make([]client.CancelOrderResult, 0, len(orderIDs))
+	for start := 0; start < len(orderIDs); start += maxBatchCancelSize {
+		end := min(start+maxBatchCancelSize, len(orderIDs))
+		batch, err := c.batchCancel(ctx, orderIDs[start:end])
+		if err != nil {
+			for _, orderID := range orderIDs[start:end] {
+				results = append(results, client.CancelOrderResult{OrderID: orderID, Err: err})
+			}
+			continue
+		}
+		results = append(results, batch...)
+	}
+	return results, nil
+}
+
+// CancelAllOrders cancels every order matching the filter. Open orders are
+// targeted when the filter sets no statuses. Matching orders are listed with
+// GetOrders and cancelled with CancelOrders.
+func (c *Client) CancelAllOrders(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error) {
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_OPEN}
+	}
+
+	orders, err := c.GetOrders(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders to cancel: %w", err)
+	}
+	return c.CancelOrders(ctx, client.OrderIDs(orders))
+}
+
+// batchCancel sends one POST /orders/batch_cancel request and maps the
+// per-order outcomes back onto orderIDs, in order.
+func (c *Client) batchCancel(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	raw, err := c.do(ctx, http.MethodPost, "/orders/batch_cancel", nil, cancelOrdersRequest{OrderIDs: orderIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp cancelOrdersResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase cancel response: %w", err)
+	}
+
+	byID := make(map[string]cancelOrderResult, len(resp.Results))
+	for _, result := range resp.Results {
+		byID[result.OrderID] = result
+	}
+
+	results := make([]client.CancelOrderResult, len(orderIDs))
+	for i, orderID := range orderIDs {
+		results[i].OrderID = orderID
+		result, ok := byID[orderID]
+		switch {
+		case !ok:
+			results[i].Err = fmt.Errorf("coinbase cancel response missing result for order %s", orderID)
+		case !result.Success:
+			results[i].Err = cbnorm.NewRejectionError(cbnorm.CoinbaseError{
+				Error:   result.FailureReason,
+				Message: fmt.Sprintf("cancel order %s failed", orderID),
+			}, venueerr.ErrInvalidRequest)
+		default:
+			results[i].Status = venuesv1.OrderStatus_ORDER_STATUS_CANCELLED.Enum()
+		}
+	}
+	return results, nil
+}
+
+// AmendOrder edits an open order in place via POST /orders/edit, keeping its
+// queue position where Coinbase allows it (size decreases at the same price).
+//
+// Coinbase requires both price and size on every edit, so when changes omit
+// either the current value is read from the order first. StopPrice applies to
+// stop-limit orders. Coinbase has no iceberg orders, so a DisplaySize change
+// returns a *client.UnsupportedError. An edit rejected by the venue returns a
+// *venueerr.Error whose Code is the failure reason.
+//
+// The returned REPLACED execution report reflects the order as re-read after
+// the edit.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+	if err := changes.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order changes: %w", err)
+	}
+	if changes.DisplaySize != nil {
+		return nil, &client.UnsupportedError{Venue: VenueID, Operation: "AmendOrder", Detail: "display size"}
+	}
+
+	req := editOrderRequest{OrderID: orderID}
+	if changes.Price == nil || changes.Quantity == nil {
+		current, err := c.GetOrder(ctx, orderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order %s: %w", orderID, err)
+		}
+		req.Price = normalizer.FormatDecimal(current.GetPrice())
+		req.Size = normalizer.FormatDecimal(current.GetQuantity())
+	}
+	if changes.Price != nil {
+		req.Price = normalizer.FormatDecimal(*changes.Price)
+	}
+	if changes.Quantity != nil {
+		req.Size = normalizer.FormatDecimal(*changes.Quantity)
+	}
+	if changes.StopPrice != nil {
+		req.StopPrice = normalizer.FormatDecimal(*changes.StopPrice)
+	}
+
+	raw, err := c.do(ctx, http.MethodPost, "/orders/edit", nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp editOrderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase edit response: %w", err)
+	}
+	if !resp.Success {
+		reason := "unknown reason"
+		for _, editErr := range resp.Errors {
+			if editErr.EditFailure != "" {
+				reason = editErr.EditFailure
+				break
+			}
+			if editErr.PreviewFailure != "" {
+				reason = editErr.PreviewFailure
+			}
+		}
+		return nil, cbnorm.NewRejectionError(cbnorm.CoinbaseError{
+			Error:   reason,
+			Message: fmt.Sprintf("edit order %s failed", orderID),
+		}, venueerr.ErrInvalidRequest)
+	}
+
+	amended, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get amended order %s: %w", orderID, err)
+	}
+
+	return normalizer.ReplacedExecutionReport(amended), nil
+}
+
+// GetOrder retrieves a single order by its Coinbase order ID.
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, "/orders/historical/"+url.PathEscape(orderID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp getOrderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase order response: %w", err)
+	}
+
+	return c.normalizeOrder(ctx, resp.Order)
+}
+
+// GetOrders lists orders matching the filter.
+//
+// Symbols, statuses and the time range are passed to the venue. Coinbase
+// paginates with cursors, so Offset is applied by skipping results client-side;
+// pages are fetched until Offset+Limit orders are collected or no pages remain.
+func (c *Client) GetOrders(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order filter: %w", err)
+	}
+
+	query := ordersQuery(filter)
+	pageSize := defaultOrdersPageSize
+	if filter.Limit > 0 && filter.Limit+filter.Offset < pageSize {
+		pageSize = filter.Limit + filter.Offset
+	}
+	query.Set("limit", fmt.Sprintf("%d", pageSize))
+
+	var orders []*venuesv1.Order
+	for {
+		raw, err := c.do(ctx, http.MethodGet, "/orders/historical/batch", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp listOrdersResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse coinbase orders response: %w", err)
+		}
+
+		for _, rawOrder := range resp.Orders {
+			order, err := c.normalizeOrder(ctx, rawOrder)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+
+		if filter.Limit > 0 && len(orders) >= filter.Offset+filter.Limit {
+			break
+		}
+		if !resp.HasNext || resp.Cursor == "" {
+			break
+		}
+		query.Set("cursor", resp.Cursor)
+	}
+
+	if filter.Offset >= len(orders) {
+		return []*venuesv1.Order{}, nil
+	}
+	orders = orders[filter.Offset:]
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
+	return orders, nil
+}
+
+// GetFills lists fills matching the filter, newest first.
+//
+// The order ID, symbols and time range are passed to the venue, which
+// filters on each fill's sequence timestamp. One page of at most Limit fills
+// (defaultOrdersPageSize when unset) is returned; pass NextCursor back as
+// Cursor to fetch the following page.
+func (c *Client) GetFills(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fill filter: %w", err)
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, "/orders/historical/fills", fillsQuery(filter), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listFillsResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse coinbase fills response: %w", err)
+	}
+
+	page := &client.FillPage{
+		Fills:      make([]*venuesv1.ExecutionReport, 0, len(resp.Fills)),
+		NextCursor: resp.Cursor,
+	}
+	for _, rawFill := range resp.Fills {
+		report, err := cbnorm.NormalizeExecutionReport(ctx, rawFill)
+		if err != nil {
+			return nil, err
+		}
+		venueId := VenueID
+		report.VenueId = &venueId
+		page.Fills = append(page.Fills, report)
+	}
+
+	return page, nil
+}
+
+// normalizeOrder normalizes a raw Coinbase order and stamps the venue ID.
+func (c *Client) normalizeOrder(ctx context.Context, raw []byte) (*venuesv1.Order, error) {
+	order, err := cbnorm.NormalizeOrder(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	venueId := VenueID
+	order.VenueId = &venueId
+	return order, nil
+}
+
+// buildCreateOrderRequest converts a CQC order into a Coinbase create order request.
+//
+// An empty ClientOrderId is replaced with a random UUID rather than one derived
+// from the order's contents: two genuinely separate orders with the same
+// symbol, side, size and price would otherwise share an ID, and Coinbase would
+// silently merge the second into the first. PlaceOrder builds the request once,
+// so the generated ID is still reused when that placement is retried.
+func buildCreateOrderRequest(order *venuesv1.Order) (*createOrderRequest, error) {
+	if order == nil {
+		return nil, fmt.Errorf("order is required")
+	}
+	if order.GetVenueSymbol() == "" {
+		return nil, fmt.Errorf("order venue symbol is required")
+	}
+	if order.GetQuantity() <= 0 {
+		return nil, fmt.Errorf("order quantity must be positive")
+	}
+
+	var side string
+	switch order.GetSide() {
+	case venuesv1.OrderSide_ORDER_SIDE_BUY:
+		side = "BUY"
+	case venuesv1.OrderSide_ORDER_SIDE_SELL:
+		side = "SELL"
+	default:
+		return nil, fmt.Errorf("order side is required")
+	}
+
+	config, err := buildOrderConfiguration(order)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOrderID := order.GetClientOrderId()
+	if clientOrderID == "" {
+		clientOrderID, err = idgen.NewUUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client order ID: %w", err)
+		}
+	}
+
+	return &createOrderRequest{
+		ClientOrderID:      clientOrderID,
+		ProductID:          order.GetVenueSymbol(),
+		Side:               side,
+		OrderConfiguration: config,
+	}, nil
+}
+
+// buildOrderConfiguration selects the Coinbase order_configuration variant for
+// the order's type and time in force.
+func buildOrderConfiguration(order *venuesv1.Order) (map[string]map[string]interface{}, error) {
+	size := normalizer.FormatDecimal(order.GetQuantity())
+	tif := order.GetTimeInForce()
+
+	limitPrice := func() (string, error) {
+		if order.GetPrice() <= 0 {
+			return "", fmt.Errorf("%s order requires a positive price", order.GetOrderType())
+		}
+		return normalizer.FormatDecimal(order.GetPrice()), nil
+	}
+	endTime := func() (string, error) {
+		if order.GetExpiresAt() == nil {
+			return "", fmt.Errorf("GTD order requires expires_at")
+		}
+		return order.GetExpiresAt().AsTime().UTC().Format(time.RFC3339), nil
+	}
+
+	switch order.GetOrderType() {
+	case venuesv1.OrderType_ORDER_TYPE_MARKET:
+		return map[string]map[string]interface{}{
+			"market_market_ioc": {"base_size": size},
+		}, nil
+
+	case venuesv1.OrderType_ORDER_TYPE_LIMIT, venuesv1.OrderType_ORDER_TYPE_POST_ONLY:
+		price, err := limitPrice()
+		if err != nil {
+			return nil, err
+		}
+		postOnly := order.GetPostOnly() || order.GetOrderType() == venuesv1.OrderType_ORDER_TYPE_POST_ONLY
+
+		switch tif {
+		case venuesv1.TimeInForce_TIME_IN_FORCE_UNSPECIFIED, venuesv1.TimeInForce_TIME_IN_FORCE_GTC:
+			return map[string]map[string]interface{}{
+				"limit_limit_gtc": {"base_size": size, "limit_price": price, "post_only": postOnly},
+			}, nil
+		case venuesv1.TimeInForce_TIME_IN_FORCE_GTD:
+			end, err := endTime()
+			if err != nil {
+				return nil, err
+			}
+			return map[string]map[string]interface{}{
+				"limit_limit_gtd": {"base_size": size, "limit_price": price, "end_time": end, "post_only": postOnly},
+			}, nil
+		case venuesv1.TimeInForce_TIME_IN_FORCE_FOK:
+			return map[string]map[string]interface{}{
+				"limit_limit_fok": {"base_size": size, "limit_price": price},
+			}, nil
+		case venuesv1.TimeInForce_TIME_IN_FORCE_IOC:
+			return map[string]map[string]interface{}{
+				"sor_limit_ioc": {"base_size": size, "limit_price": price},
+			}, nil
+		}
+
+	case venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT:
+		price, err := limitPrice()
+		if err != nil {
+			return nil, err
+		}
+		if order.GetStopPrice() <= 0 {
+			return nil, fmt.Errorf("%s order requires a positive stop price", order.GetOrderType())
+		}
+		direction := "STOP_DIRECTION_STOP_DOWN"
+		if order.GetSide() == venuesv1.OrderSide_ORDER_SIDE_BUY {
+			direction = "STOP_DIRECTION_STOP_UP"
+		}
+		params := map[string]interface{}{
+			"base_size":      size,
+			"limit_price":    price,
+			"stop_price":     normalizer.FormatDecimal(order.GetStopPrice()),
+			"stop_direction": direction,
+		}
+
+		switch tif {
+		case venuesv1.TimeInForce_TIME_IN_FORCE_UNSPECIFIED, venuesv1.TimeInForce_TIME_IN_FORCE_GTC:
+			return map[string]map[string]interface{}{"stop_limit_stop_limit_gtc": params}, nil
+		case venuesv1.TimeInForce_TIME_IN_FORCE_GTD:
+			end, err := endTime()
+			if err != nil {
+				return nil, err
+			}
+			params["end_time"] = end
+			return map[string]map[string]interface{}{"stop_limit_stop_limit_gtd": params}, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported coinbase order type: %s", order.GetOrderType())
+	}
+
+	return nil, fmt.Errorf("unsupported time in force %s for %s order", tif, order.GetOrderType())
+}
+
+// ordersQuery builds the list orders query parameters for a filter.
+func ordersQuery(filter client.OrderFilter) url.Values {
+	query := url.Values{}
+	for _, symbol := range filter.Symbols {
+		query.Add("product_ids", symbol)
+	}
+
+	seen := make(map[string]bool)
+	for _, status := range filter.Statuses {
+		cbStatus := venueOrderStatus(status)
+		if cbStatus == "" || seen[cbStatus] {
+			continue
+		}
+		seen[cbStatus] = true
+		query.Add("order_status", cbStatus)
+	}
+
+	if !filter.StartTime.IsZero() {
+		query.Set("start_date", filter.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !filter.EndTime.IsZero() {
+		query.Set("end_date", filter.EndTime.UTC().Format(time.RFC3339))
+	}
+
+	return query
+}
+
+// fillsQuery builds the list fills query parameters for a filter.
+func fillsQuery(filter client.FillFilter) url.Values {
+	query := url.Values{}
+	if filter.OrderID != "" {
+		query.Set("order_ids", filter.OrderID)
+	}
+	for _, symbol := range filter.Symbols {
+		query.Add("product_ids", symbol)
+	}
+	if !filter.StartTime.IsZero() {
+		query.Set("start_sequence_timestamp", filter.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !filter.EndTime.IsZero() {
+		query.Set("end_sequence_timestamp", filter.EndTime.UTC().Format(time.RFC3339))
+	}
+
+	limit := defaultOrdersPageSize
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	query.Set("limit", fmt.Sprintf("%d", limit))
+
+	if filter.Cursor != "" {
+		query.Set("cursor", filter.Cursor)
+	}
+
+	return query
+}
+
+// venueOrderStatus maps a CQC order status to the Coinbase order_status filter value.
+// Returns an empty string for statuses that have no Coinbase equivalent.
+func venueOrderStatus(status venuesv1.OrderStatus) string {
+	switch status {
+	case venuesv1.OrderStatus_ORDER_STATUS_PENDING, venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED:
+		return "PENDING"
+	case venuesv1.OrderStatus_ORDER_STATUS_OPEN, venuesv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED:
+		return "OPEN"
+	case venuesv1.OrderStatus_ORDER_STATUS_FILLED:
+		return "FILLED"
+	case venuesv1.OrderStatus_ORDER_STATUS_CANCELLED:
+		return "CANCELLED"
+	case venuesv1.OrderStatus_ORDER_STATUS_EXPIRED:
+		return "EXPIRED"
+	case venuesv1.OrderStatus_ORDER_STATUS_REJECTED, venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+		return "FAILED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,58 @@
+package falconx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	fxnorm "github.com/Combine-Capital/cqvx/internal/normalizer/falconx"
+)
+
+// GetBalance returns the balance of the configured BalanceCurrency (default "USD").
+//
+// FalconX reports one net balance per token; use GetBalances for the full set.
+// If no balance is reported for BalanceCurrency, a zero balance is returned.
+func (c *Client) GetBalance(ctx context.Context) (*venuesv1.Balance, error) {
+	balances, err := c.GetBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		if strings.EqualFold(balance.GetAssetId(), c.config.BalanceCurrency) {
+			return balance, nil
+		}
+	}
+
+	venueId := VenueID
+	asset := c.config.BalanceCurrency
+	var zero float64
+	return &venuesv1.Balance{
+		VenueId:   &venueId,
+		AssetId:   &asset,
+		Total:     &zero,
+		Available: &zero,
+		Locked:    &zero,
+	}, nil
+}
+
+// GetBalances returns the net balance of every token.
+// Balances may be negative when trading on credit before settlement.
+func (c *Client) GetBalances(ctx context.Context) ([]*venuesv1.Balance, error) {
+	raw, err := c.do(ctx, http.MethodGet, "/v1/balances", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := fxnorm.NormalizeBalances(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		venueId := VenueID
+		balance.VenueId = &venueId
+	}
+	return balances, nil
+}
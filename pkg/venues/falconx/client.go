@@ -0,0 +1,161 @@
+// Package falconx implements the VenueClient interface for FalconX.
+//
+// FalconX is an RFQ venue: there is no order book and no resting orders.
+// PlaceOrder runs the full request-for-quote flow (quote, expiry check,
+// execute, settlement polling). Requests are authenticated with a bearer token
+// via auth.Middleware and responses are normalized to CQC types by
+// internal/normalizer/falconx.
+package falconx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	fxnorm "github.com/Combine-Capital/cqvx/internal/normalizer/falconx"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+)
+
+// Ensure Client implements the VenueClient interface at compile time
+var _ client.VenueClient = (*Client)(nil)
+
+// Client is a FalconX venue client.
+//
+// Thread-safe: All methods can be called concurrently.
+type Client struct {
+	config     Config
+	signer     *auth.BearerSigner
+	httpClient *http.Client
+	wsDialer   stream.Dialer
+	logger     *slog.Logger
+	limiter    *ratelimit.Limiter
+}
+
+// NewClient creates a new FalconX client.
+//
+// The httpClient is used for all REST calls; its transport is wrapped with
+// bearer token authentication. The caller's client is not modified. If
+// httpClient is nil, http.DefaultClient is used.
+//
+// FalconX has no streaming API; wsDialer is accepted for constructor parity
+// with other venues. If wsDialer is nil, stream.NewWebSocketDialer() is used.
+// If logger is nil, logging is disabled.
+func NewClient(config Config, httpClient *http.Client, wsDialer stream.Dialer, logger *slog.Logger) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid falconx config: %w", err)
+	}
+	config = config.withDefaults()
+
+	var c *Client
+	signer, err := auth.NewBearerSigner(auth.BearerConfig{
+		Token:       config.Token,
+		Credentials: config.Credentials,
+		OnCredentialError: func(err error) {
+			c.logger.Warn("falconx credential rotation failed", "error", err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create falconx signer: %w", err)
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	signed := *httpClient
+	var limiter *ratelimit.Limiter
+	if !config.DisableRateLimit {
+		limiter, err = ratelimit.New(config.RateLimits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create falconx rate limiter: %w", err)
+		}
+	}
+	signed.Transport = ratelimit.Middleware(limiter, auth.Middleware(signer, httpClient.Transport))
+
+	if wsDialer == nil {
+		wsDialer = stream.NewWebSocketDialer()
+	}
+
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	c = &Client{
+		config:     config,
+		signer:     signer,
+		httpClient: &signed,
+		limiter:    limiter,
+		wsDialer:   wsDialer,
+		logger:     logger.With("venue", VenueID),
+	}
+	return c, nil
+}
+
+// RateLimiter returns the client-side rate limiter applied to REST requests,
+// for reading its utilization, or nil if Config.DisableRateLimit is set.
+func (c *Client) RateLimiter() *ratelimit.Limiter {
+	return c.limiter
+}
+
+// ActiveKeyID returns the label of the bearer token requests are currently signed with, or an empty string for unlabeled tokens.
+// It changes when Config.Credentials rotates the credentials.
+func (c *Client) ActiveKeyID() string {
+	return c.signer.ActiveKeyID()
+}
+
+// do performs an authenticated REST request against the FalconX API and
+// returns the raw response body.
+//
+// Non-2xx responses are converted to classified errors by fxnorm.NormalizeError.
+// In-body failures on 2xx responses are detected by the normalizers.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}) ([]byte, error) {
+	endpoint := strings.TrimRight(c.config.BaseURL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("falconx request %s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read falconx response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.DebugContext(ctx, "falconx request failed",
+			"method", method, "path", path, "status", resp.StatusCode)
+		return nil, fxnorm.NormalizeError(resp, respBody)
+	}
+
+	return respBody, nil
+}
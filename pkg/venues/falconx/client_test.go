@@ -0,0 +1,753 @@
+package falconx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/Combine-Capital/cqvx/pkg/venues/falconx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken   = "test-falconx-token"
+	testQuoteID = "00c884b056f949338788dfb59e495377"
+)
+
+// recordedRequest captures a request received by the test server.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// testServer serves recorded FalconX payloads and verifies the bearer token.
+type testServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	routes   map[string]http.HandlerFunc
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ts := &testServer{t: t, routes: make(map[string]http.HandlerFunc)}
+	ts.server = httptest.NewServer(http.HandlerFunc(ts.serveHTTP))
+	t.Cleanup(ts.server.Close)
+	return ts
+}
+
+func (ts *testServer) handle(method, path string, handler http.HandlerFunc) {
+	ts.routes[method+" "+path] = handler
+}
+
+func (ts *testServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(ts.t, err)
+
+	ts.mu.Lock()
+	ts.requests = append(ts.requests, recordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	ts.mu.Unlock()
+
+	assert.Equal(ts.t, "Bearer "+testToken, r.Header.Get("Authorization"))
+
+	handler, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+// paths returns the method and path of every recorded request.
+func (ts *testServer) paths() []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	paths := make([]string, len(ts.requests))
+	for i, req := range ts.requests {
+		paths[i] = req.Method + " " + req.Path
+	}
+	return paths
+}
+
+func (ts *testServer) config() falconx.Config {
+	return falconx.Config{
+		Token:                  testToken,
+		BaseURL:                ts.server.URL,
+		SettlementPollInterval: 5 * time.Millisecond,
+		SettlementTimeout:      time.Second,
+	}
+}
+
+func (ts *testServer) client(t *testing.T) *falconx.Client {
+	t.Helper()
+	c, err := falconx.NewClient(ts.config(), ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+	return c
+}
+
+// serveFile returns a handler that writes a testdata fixture with the given status.
+func serveFile(t *testing.T, status int, name string) http.HandlerFunc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return serveJSON(status, data)
+}
+
+func serveJSON(status int, data []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	}
+}
+
+// serveQuote returns a handler that serves a quote fixture whose expiry is
+// ttl from the time of the request.
+func serveQuote(t *testing.T, name string, ttl time.Duration) http.HandlerFunc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return func(w http.ResponseWriter, r *http.Request) {
+		var quote map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &quote))
+		quote["t_expiry"] = time.Now().Add(ttl).UTC().Format(time.RFC3339Nano)
+		body, err := json.Marshal(quote)
+		require.NoError(t, err)
+		serveJSON(http.StatusOK, body)(w, r)
+	}
+}
+
+// serveSequence returns a handler that serves handlers in order, repeating the last.
+func serveSequence(handlers ...http.HandlerFunc) http.HandlerFunc {
+	var mu sync.Mutex
+	next := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		handler := handlers[next]
+		if next < len(handlers)-1 {
+			next++
+		}
+		mu.Unlock()
+		handler(w, r)
+	}
+}
+
+func marketBuy() *venuesv1.Order {
+	return &venuesv1.Order{
+		ClientOrderId: strPtr("cqvx-test-0001"),
+		VenueSymbol:   strPtr("BTC/USD"),
+		Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+		OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+		Quantity:      floatPtr(2),
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  falconx.Config
+		wantErr bool
+	}{
+		{name: "valid config", config: falconx.Config{Token: testToken}},
+		{name: "missing token", config: falconx.Config{}, wantErr: true},
+		{name: "credential provider", config: falconx.Config{Credentials: client.StaticCredentials{Secret: testToken}}},
+		{name: "credential provider with token", config: falconx.Config{Token: testToken, Credentials: client.StaticCredentials{Secret: testToken}}, wantErr: true},
+		{name: "negative expiry margin", config: falconx.Config{Token: testToken, QuoteExpiryMargin: -1}, wantErr: true},
+		{name: "negative poll interval", config: falconx.Config{Token: testToken, SettlementPollInterval: -1}, wantErr: true},
+		{name: "negative settlement timeout", config: falconx.Config{Token: testToken, SettlementTimeout: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := falconx.NewClient(tt.config, nil, nil, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, c)
+		})
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filled on execute", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveFile(t, http.StatusOK, "quote_executed.json"))
+		c := ts.client(t)
+
+		report, err := c.PlaceOrder(ctx, marketBuy())
+		require.NoError(t, err)
+
+		assert.Equal(t, "falconx", report.GetVenueId())
+		assert.Equal(t, testQuoteID, report.GetVenueOrderId())
+		assert.Equal(t, "cqvx-test-0001", report.GetClientOrderId())
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, report.GetExecutionType())
+		assert.Equal(t, "FILLED", report.GetOrderStatus())
+		assert.Equal(t, "BUY", report.GetSide())
+		assert.Equal(t, 50125.5, report.GetPrice())
+		assert.Equal(t, 2.0, report.GetQuantity())
+
+		require.Equal(t, []string{"POST /v1/quotes", "POST /v1/quotes/execute"}, ts.paths())
+
+		var quoteBody map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &quoteBody))
+		assert.Equal(t, map[string]interface{}{
+			"token_pair":      map[string]interface{}{"base_token": "BTC", "quote_token": "USD"},
+			"quantity":        map[string]interface{}{"token": "BTC", "value": "2"},
+			"side":            "buy",
+			"client_order_id": "cqvx-test-0001",
+		}, quoteBody)
+
+		var executeBody map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[1].Body, &executeBody))
+		assert.Equal(t, map[string]interface{}{"fx_quote_id": testQuoteID, "side": "buy"}, executeBody)
+	})
+
+	t.Run("polls until settled", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveSequence(
+			serveQuote(t, "quote.json", 10*time.Second),
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"status":"failure","error":{"code":"SERVICE_UNAVAILABLE","reason":"try again"}}`))
+			},
+			serveFile(t, http.StatusOK, "quote_executed.json"),
+		))
+		c := ts.client(t)
+
+		report, err := c.PlaceOrder(ctx, marketBuy())
+		require.NoError(t, err)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, report.GetExecutionType())
+
+		assert.Equal(t, []string{
+			"POST /v1/quotes",
+			"POST /v1/quotes/execute",
+			"GET /v1/quotes/" + testQuoteID,
+			"GET /v1/quotes/" + testQuoteID,
+			"GET /v1/quotes/" + testQuoteID,
+		}, ts.paths())
+	})
+
+	t.Run("settlement timeout", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveQuote(t, "quote.json", 10*time.Second))
+		config := ts.config()
+		config.SettlementTimeout = 50 * time.Millisecond
+		c, err := falconx.NewClient(config, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		_, err = c.PlaceOrder(ctx, marketBuy())
+		require.ErrorIs(t, err, falconx.ErrSettlementTimeout)
+		assert.ErrorIs(t, err, venueerr.ErrUnknownOutcome)
+		assert.Contains(t, err.Error(), testQuoteID)
+	})
+
+	t.Run("cancelled while awaiting settlement", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveQuote(t, "quote.json", 10*time.Second))
+		c := ts.client(t)
+
+		cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.PlaceOrder(cancelCtx, marketBuy())
+		require.ErrorIs(t, err, venueerr.ErrUnknownOutcome)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), testQuoteID)
+	})
+
+	serveUnavailable := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"status":"failure","error":{"code":"SERVICE_UNAVAILABLE","reason":"upstream timeout"}}`))
+	}
+
+	t.Run("execute failure resolved as executed", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveUnavailable)
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveFile(t, http.StatusOK, "quote_executed.json"))
+		c := ts.client(t)
+
+		report, err := c.PlaceOrder(ctx, marketBuy())
+		require.NoError(t, err)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, report.GetExecutionType())
+		assert.Equal(t, testQuoteID, report.GetVenueOrderId())
+		assert.Equal(t, []string{
+			"POST /v1/quotes",
+			"POST /v1/quotes/execute",
+			"GET /v1/quotes/" + testQuoteID,
+		}, ts.paths())
+	})
+
+	t.Run("execute failure resolved as not executed", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveUnavailable)
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveQuote(t, "quote.json", 10*time.Second))
+		c := ts.client(t)
+
+		_, err := c.PlaceOrder(ctx, marketBuy())
+		require.ErrorIs(t, err, venueerr.ErrUnavailable)
+		assert.NotErrorIs(t, err, venueerr.ErrUnknownOutcome)
+		assert.Len(t, ts.paths(), 3)
+	})
+
+	t.Run("unresolved execute failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveUnavailable)
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveUnavailable)
+		c := ts.client(t)
+
+		_, err := c.PlaceOrder(ctx, marketBuy())
+		require.ErrorIs(t, err, venueerr.ErrUnknownOutcome)
+		assert.False(t, venueerr.IsRetryable(err))
+		assert.Contains(t, err.Error(), testQuoteID)
+	})
+
+	t.Run("rejected execute is not resolved", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveJSON(http.StatusBadRequest,
+			[]byte(`{"status":"failure","error":{"code":"QUOTE_EXPIRED","reason":"quote expired"}}`)))
+		c := ts.client(t)
+
+		_, err := c.PlaceOrder(ctx, marketBuy())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, venueerr.ErrUnknownOutcome)
+		assert.Equal(t, []string{"POST /v1/quotes", "POST /v1/quotes/execute"}, ts.paths())
+	})
+
+	t.Run("expired quote is not executed", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 100*time.Millisecond))
+		c := ts.client(t)
+
+		_, err := c.PlaceOrder(ctx, marketBuy())
+		require.ErrorIs(t, err, falconx.ErrQuoteExpired)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+		assert.Equal(t, []string{"POST /v1/quotes"}, ts.paths())
+	})
+
+	t.Run("limit price guard", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveFile(t, http.StatusOK, "quote_executed.json"))
+		c := ts.client(t)
+
+		order := marketBuy()
+		order.OrderType = typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT)
+		order.Price = floatPtr(50000)
+		_, err := c.PlaceOrder(ctx, order)
+		require.ErrorIs(t, err, falconx.ErrLimitPriceExceeded)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidOrder)
+		assert.Equal(t, []string{"POST /v1/quotes"}, ts.paths())
+
+		order.Price = floatPtr(50200)
+		report, err := c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+		assert.Equal(t, 50125.5, report.GetPrice())
+	})
+
+	t.Run("generates client order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveFile(t, http.StatusOK, "quote_executed.json"))
+		c := ts.client(t)
+
+		order := marketBuy()
+		order.ClientOrderId = nil
+		order.VenueSymbol = strPtr("btc-usd")
+		_, err := c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, body["client_order_id"])
+		assert.Equal(t, map[string]interface{}{"base_token": "BTC", "quote_token": "USD"}, body["token_pair"])
+	})
+
+	t.Run("resolves asset aliases", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveQuote(t, "quote.json", 10*time.Second))
+		ts.handle(http.MethodPost, "/v1/quotes/execute", serveFile(t, http.StatusOK, "quote_executed.json"))
+		c := ts.client(t)
+
+		order := marketBuy()
+		order.VenueSymbol = strPtr("XBT/USD")
+		_, err := c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		assert.Equal(t, map[string]interface{}{"base_token": "BTC", "quote_token": "USD"}, body["token_pair"])
+	})
+
+	t.Run("quote rejected in body", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/v1/quotes", serveJSON(http.StatusOK,
+			[]byte(`{"status":"failure","error":{"code":"LIQUIDITY_UNAVAILABLE","reason":"no liquidity"}}`)))
+		c := ts.client(t)
+
+		_, err := c.PlaceOrder(ctx, marketBuy())
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+		assert.Equal(t, "LIQUIDITY_UNAVAILABLE", venueErr.Code)
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		invalid := []struct {
+			name   string
+			mutate func(*venuesv1.Order)
+		}{
+			{name: "missing symbol", mutate: func(o *venuesv1.Order) { o.VenueSymbol = nil }},
+			{name: "malformed symbol", mutate: func(o *venuesv1.Order) { o.VenueSymbol = strPtr("BTCUSD") }},
+			{name: "missing side", mutate: func(o *venuesv1.Order) { o.Side = nil }},
+			{name: "zero quantity", mutate: func(o *venuesv1.Order) { o.Quantity = floatPtr(0) }},
+			{name: "market with price", mutate: func(o *venuesv1.Order) { o.Price = floatPtr(50000) }},
+			{name: "limit without price", mutate: func(o *venuesv1.Order) {
+				o.OrderType = typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT)
+			}},
+			{name: "stop limit", mutate: func(o *venuesv1.Order) {
+				o.OrderType = typePtr(venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT)
+			}},
+		}
+
+		ts := newTestServer(t)
+		c := ts.client(t)
+		for _, tt := range invalid {
+			t.Run(tt.name, func(t *testing.T) {
+				order := marketBuy()
+				tt.mutate(order)
+				_, err := c.PlaceOrder(ctx, order)
+				assert.Error(t, err)
+			})
+		}
+		assert.Empty(t, ts.paths())
+	})
+}
+
+func TestGetOrder(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveFile(t, http.StatusOK, "quote_executed.json"))
+	c := ts.client(t)
+
+	order, err := c.GetOrder(ctx, testQuoteID)
+	require.NoError(t, err)
+	assert.Equal(t, "falconx", order.GetVenueId())
+	assert.Equal(t, testQuoteID, order.GetVenueOrderId())
+	assert.Equal(t, "BTC/USD", order.GetVenueSymbol())
+	assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_FILLED, order.GetStatus())
+	assert.Equal(t, 2.0, order.GetFilledQuantity())
+
+	_, err = c.GetOrder(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestGetOrders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("time range", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes", serveFile(t, http.StatusOK, "quotes.json"))
+		c := ts.client(t)
+
+		start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+		orders, err := c.GetOrders(ctx, client.OrderFilter{StartTime: start, EndTime: end})
+		require.NoError(t, err)
+		require.Len(t, orders, 3)
+		for _, order := range orders {
+			assert.Equal(t, "falconx", order.GetVenueId())
+		}
+
+		query := ts.requests[0].Query
+		assert.Equal(t, "2024-01-15T00:00:00Z", query.Get("t_start"))
+		assert.Equal(t, "2024-01-16T00:00:00Z", query.Get("t_end"))
+	})
+
+	t.Run("default window", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes", serveFile(t, http.StatusOK, "quotes.json"))
+		c := ts.client(t)
+
+		_, err := c.GetOrders(ctx, client.OrderFilter{})
+		require.NoError(t, err)
+
+		query := ts.requests[0].Query
+		start, err := time.Parse(time.RFC3339, query.Get("t_start"))
+		require.NoError(t, err)
+		end, err := time.Parse(time.RFC3339, query.Get("t_end"))
+		require.NoError(t, err)
+		assert.Equal(t, falconx.DefaultOrderHistoryWindow, end.Sub(start))
+	})
+
+	t.Run("client-side filters", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes", serveFile(t, http.StatusOK, "quotes.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{
+			Symbols:  []string{"ETH-USD"},
+			Statuses: []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_FILLED},
+		})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "5b2f1e7a9c3d4b8e8f0a1b2c3d4e5f60", orders[0].GetVenueOrderId())
+	})
+
+	t.Run("offset and limit", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes", serveFile(t, http.StatusOK, "quotes.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "7d1c4a2b9e8f4c3d8a7b6c5d4e3f2a1b", orders[0].GetVenueOrderId())
+	})
+}
+
+func TestGetBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all balances", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/balances", serveFile(t, http.StatusOK, "balances.json"))
+		c := ts.client(t)
+
+		balances, err := c.GetBalances(ctx)
+		require.NoError(t, err)
+		require.Len(t, balances, 3)
+		assert.Equal(t, "falconx", balances[0].GetVenueId())
+		assert.Equal(t, "BTC", balances[0].GetAssetId())
+		assert.Equal(t, 10.5, balances[0].GetTotal())
+	})
+
+	t.Run("configured currency", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/balances", serveFile(t, http.StatusOK, "balances.json"))
+		c := ts.client(t)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "USD", balance.GetAssetId())
+		assert.Equal(t, -25000.75, balance.GetTotal())
+	})
+
+	t.Run("currency not held", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/balances", serveFile(t, http.StatusOK, "balances.json"))
+		config := ts.config()
+		config.BalanceCurrency = "EUR"
+		c, err := falconx.NewClient(config, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "EUR", balance.GetAssetId())
+		assert.Zero(t, balance.GetTotal())
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/balances", serveJSON(http.StatusUnauthorized,
+			[]byte(`{"status":"failure","error":{"code":"UNAUTHORIZED","reason":"invalid token"}}`)))
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrAuth)
+		assert.Equal(t, "UNAUTHORIZED", venueErr.Code)
+	})
+}
+
+func TestSubscribeBalances(t *testing.T) {
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/v1/balances", serveSequence(
+		serveFile(t, http.StatusOK, "balances.json"),
+		serveJSON(http.StatusServiceUnavailable, []byte(`{"status":"failure","error":{"code":"UNAVAILABLE","reason":"maintenance"}}`)),
+		serveJSON(http.StatusOK, []byte(`[
+			{"token": "BTC", "balance": 10.5, "platform": "api"},
+			{"token": "USD", "balance": -20000, "platform": "api"},
+			{"token": "ETH", "balance": 0, "platform": "api"}
+		]`)),
+	))
+	config := ts.config()
+	config.BalancePollInterval = time.Millisecond
+	c, err := falconx.NewClient(config, ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var balances []*venuesv1.Balance
+	errDone := errors.New("done")
+	err = c.SubscribeBalances(ctx, func(balance *venuesv1.Balance) error {
+		balances = append(balances, balance)
+		if len(balances) == 4 {
+			return errDone
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errDone)
+
+	// The failed poll is skipped; only the changed USD balance follows the baseline.
+	assert.Equal(t, "USD", balances[3].GetAssetId())
+	assert.Equal(t, -20000.0, balances[3].GetTotal())
+	assert.Equal(t, "falconx", balances[3].GetVenueId())
+}
+
+func TestGetFills(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("filled quotes only", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes", serveFile(t, http.StatusOK, "quotes.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{StartTime: start, EndTime: end})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 2)
+		assert.Empty(t, page.NextCursor)
+
+		fill := page.Fills[0]
+		assert.Equal(t, "falconx", fill.GetVenueId())
+		assert.Equal(t, testQuoteID, fill.GetTradeId())
+		assert.Equal(t, "USD", fill.GetFeeAssetId())
+		assert.Equal(t, "TAKER", fill.GetLiquidity())
+		require.NotNil(t, fill.Fee)
+		assert.Zero(t, fill.GetFee())
+		assert.Equal(t, "5b2f1e7a9c3d4b8e8f0a1b2c3d4e5f60", page.Fills[1].GetTradeId())
+
+		query := ts.requests[0].Query
+		assert.Equal(t, "2024-01-15T00:00:00Z", query.Get("t_start"))
+		assert.Equal(t, "2024-01-16T00:00:00Z", query.Get("t_end"))
+	})
+
+	t.Run("symbols and offset cursor", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes", serveFile(t, http.StatusOK, "quotes.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{EndTime: end, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 1)
+		assert.Equal(t, "1", page.NextCursor)
+
+		page, err = c.GetFills(ctx, client.FillFilter{EndTime: end, Limit: 1, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 1)
+		assert.Equal(t, "5b2f1e7a9c3d4b8e8f0a1b2c3d4e5f60", page.Fills[0].GetTradeId())
+		assert.Empty(t, page.NextCursor)
+
+		page, err = c.GetFills(ctx, client.FillFilter{EndTime: end, Symbols: []string{"eth-usd"}})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 1)
+		assert.Equal(t, "ETH/USD", page.Fills[0].GetVenueSymbol())
+
+		_, err = c.GetFills(ctx, client.FillFilter{Cursor: "next"})
+		assert.Error(t, err)
+	})
+
+	t.Run("order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/quotes/"+testQuoteID, serveFile(t, http.StatusOK, "quote_executed.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{OrderID: testQuoteID})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 1)
+		assert.Equal(t, 2.0, page.Fills[0].GetQuantity())
+	})
+}
+
+func TestUnsupported(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := ts.client(t)
+
+	_, err := c.CancelOrder(ctx, testQuoteID)
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.AmendOrder(ctx, testQuoteID, client.OrderChanges{Price: floatPtr(50000)})
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.CancelOrders(ctx, []string{testQuoteID})
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.CancelAllOrders(ctx, client.OrderFilter{})
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.GetOrderBook(ctx, "BTC/USD")
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.GetInstruments(ctx)
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.GetInstrument(ctx, "BTC/USD")
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeOrderBook(ctx, "BTC/USD", nil), client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeTrades(ctx, "BTC/USD", nil), client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeExecutions(ctx, nil), client.ErrUnsupported)
+	assert.Empty(t, ts.paths())
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("healthy", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/get_trading_pairs", serveFile(t, http.StatusOK, "trading_pairs.json"))
+		assert.NoError(t, ts.client(t).Health(ctx))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/v1/get_trading_pairs", serveJSON(http.StatusServiceUnavailable,
+			[]byte(`{"status":"failure","error":{"code":"SERVICE_UNAVAILABLE","reason":"maintenance"}}`)))
+		err := ts.client(t).Health(ctx)
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+	})
+}
+
+func strPtr(s string) *string     { return &s }
+func floatPtr(f float64) *float64 { return &f }
+func sidePtr(s venuesv1.OrderSide) *venuesv1.OrderSide {
+	return &s
+}
+func typePtr(t venuesv1.OrderType) *venuesv1.OrderType {
+	return &t
+}
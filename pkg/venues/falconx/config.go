@@ -0,0 +1,153 @@
+package falconx
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+)
+
+const (
+	// VenueID is the CQC venue identifier for FalconX.
+	VenueID = "falconx"
+
+	// DefaultBaseURL is the FalconX REST API base URL.
+	DefaultBaseURL = "https://api.falconx.io"
+
+	// DefaultBalanceCurrency is the currency reported by GetBalance when none is configured.
+	DefaultBalanceCurrency = "USD"
+
+	// DefaultQuoteExpiryMargin is how long before expiry a quote is considered too stale to execute.
+	DefaultQuoteExpiryMargin = 250 * time.Millisecond
+
+	// DefaultSettlementPollInterval is the delay between quote status polls while awaiting settlement.
+	DefaultSettlementPollInterval = 500 * time.Millisecond
+
+	// DefaultSettlementTimeout bounds how long PlaceOrder waits for an executed quote to settle.
+	DefaultSettlementTimeout = 30 * time.Second
+
+	// DefaultOrderHistoryWindow is the quote history queried by GetOrders when the filter has no time range.
+	DefaultOrderHistoryWindow = 24 * time.Hour
+
+	// DefaultBalancePollInterval is the delay between balance polls made by SubscribeBalances.
+	DefaultBalancePollInterval = 5 * time.Second
+)
+
+// Config contains configuration for the FalconX client.
+type Config struct {
+	// Token is the FalconX API bearer token
+	Token string
+
+	// Credentials, if set, supplies the bearer token (Secret) in place of
+	// Token, which must then be empty. KeyID optionally labels the token.
+	// Rotated tokens are picked up without restarting; see
+	// client.FileCredentials and client.CallbackCredentials.
+	Credentials client.CredentialProvider
+
+	// BaseURL is the REST API base URL (default: DefaultBaseURL)
+	BaseURL string
+
+	// BalanceCurrency is the currency whose balance GetBalance returns (default: "USD").
+	// Use GetBalances to retrieve balances for every token.
+	BalanceCurrency string
+
+	// QuoteExpiryMargin rejects quotes that expire within this margin instead of
+	// executing them, leaving time for the execute request to reach FalconX
+	// (default: DefaultQuoteExpiryMargin)
+	QuoteExpiryMargin time.Duration
+
+	// SettlementPollInterval is the delay between quote status polls
+	// (default: DefaultSettlementPollInterval)
+	SettlementPollInterval time.Duration
+
+	// SettlementTimeout bounds how long PlaceOrder polls an executed quote
+	// before giving up (default: DefaultSettlementTimeout)
+	SettlementTimeout time.Duration
+
+	// BalancePollInterval is the delay between balance polls made by
+	// SubscribeBalances (default: DefaultBalancePollInterval)
+	BalancePollInterval time.Duration
+
+	// RateLimits are the client-side rate limits applied to REST requests
+	// (default: DefaultRateLimits()). Requests that would exceed them are
+	// queued, or rejected if their context deadline would pass first.
+	RateLimits ratelimit.Config
+
+	// DisableRateLimit sends REST requests without client-side rate limiting.
+	DisableRateLimit bool
+}
+
+// Validate checks that the configuration has the fields required to connect.
+func (c Config) Validate() error {
+	switch {
+	case c.Credentials != nil:
+		if c.Token != "" {
+			return fmt.Errorf("static credentials and credential provider are mutually exclusive")
+		}
+	case c.Token == "":
+		return fmt.Errorf("token is required")
+	}
+	if c.QuoteExpiryMargin < 0 {
+		return fmt.Errorf("quote expiry margin must be non-negative")
+	}
+	if c.SettlementPollInterval < 0 {
+		return fmt.Errorf("settlement poll interval must be non-negative")
+	}
+	if c.SettlementTimeout < 0 {
+		return fmt.Errorf("settlement timeout must be non-negative")
+	}
+	if c.BalancePollInterval < 0 {
+		return fmt.Errorf("balance poll interval must be non-negative")
+	}
+	if !c.RateLimits.IsZero() {
+		if err := c.RateLimits.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limits: %w", err)
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the config with default values applied.
+func (c Config) withDefaults() Config {
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultBaseURL
+	}
+	if c.BalanceCurrency == "" {
+		c.BalanceCurrency = DefaultBalanceCurrency
+	}
+	if c.QuoteExpiryMargin == 0 {
+		c.QuoteExpiryMargin = DefaultQuoteExpiryMargin
+	}
+	if c.SettlementPollInterval == 0 {
+		c.SettlementPollInterval = DefaultSettlementPollInterval
+	}
+	if c.SettlementTimeout == 0 {
+		c.SettlementTimeout = DefaultSettlementTimeout
+	}
+	if c.BalancePollInterval == 0 {
+		c.BalancePollInterval = DefaultBalancePollInterval
+	}
+	if c.RateLimits.IsZero() {
+		c.RateLimits = DefaultRateLimits()
+	}
+	if c.RateLimits.Venue == "" {
+		c.RateLimits.Venue = VenueID
+	}
+	return c
+}
+
+// DefaultRateLimits returns the FalconX REST limits: 10 requests per second
+// per API key, with quote requests weighted double since each one asks
+// liquidity providers to price the order.
+func DefaultRateLimits() ratelimit.Config {
+	return ratelimit.Config{
+		Venue:   VenueID,
+		Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 10}},
+		Rules: []ratelimit.Rule{
+			{Method: http.MethodPost, PathPrefix: "/v1/quotes", Buckets: []string{"rest"}, Weight: 2},
+			{Buckets: []string{"rest"}},
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package falconx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Health checks that the FalconX API is reachable and the token is accepted
+// by listing the tradable token pairs.
+func (c *Client) Health(ctx context.Context) error {
+	if _, err := c.do(ctx, http.MethodGet, "/v1/get_trading_pairs", nil, nil); err != nil {
+		return fmt.Errorf("falconx health check failed: %w", err)
+	}
+	return nil
+}
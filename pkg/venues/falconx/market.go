@@ -0,0 +1,25 @@
+package falconx
+
+import (
+	"context"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+)
+
+// GetOrderBook is not supported: FalconX is an RFQ venue and publishes no
+// order book. Prices are only available as executable quotes.
+func (c *Client) GetOrderBook(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetOrderBook"}
+}
+
+// GetInstruments is not supported: FalconX quotes any size on request and
+// publishes no tick size, lot size or trading status for its pairs.
+func (c *Client) GetInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetInstruments"}
+}
+
+// GetInstrument is not supported; see GetInstruments.
+func (c *Client) GetInstrument(ctx context.Context, symbol string) (*client.Instrument, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetInstrument"}
+}
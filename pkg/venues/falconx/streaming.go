@@ -0,0 +1,41 @@
+package falconx
+
+import (
+	"context"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+)
+
+// SubscribeOrderBook is not supported: FalconX has no order book or streaming API.
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbol string, handler client.OrderBookHandler) error {
+	return &client.UnsupportedError{Venue: VenueID, Operation: "SubscribeOrderBook"}
+}
+
+// SubscribeTrades is not supported: FalconX has no public trade feed.
+func (c *Client) SubscribeTrades(ctx context.Context, symbol string, handler client.TradeHandler) error {
+	return &client.UnsupportedError{Venue: VenueID, Operation: "SubscribeTrades"}
+}
+
+// SubscribeExecutions is not supported: FalconX has no private order stream.
+// Quotes execute synchronously, so PlaceOrder already returns the fill.
+func (c *Client) SubscribeExecutions(ctx context.Context, handler client.ExecutionHandler) error {
+	return &client.UnsupportedError{Venue: VenueID, Operation: "SubscribeExecutions"}
+}
+
+// SubscribeBalances reports balance changes by polling GetBalances every
+// BalancePollInterval, since FalconX has no balance stream. The current
+// balances are reported first, then only balances whose amounts change.
+//
+// Failed polls are logged and retried at the next interval. The call blocks
+// until ctx is cancelled (returning ctx.Err()) or handler returns an error
+// (returning that error).
+func (c *Client) SubscribeBalances(ctx context.Context, handler client.BalanceHandler) error {
+	poller := &client.BalancePoller{
+		Fetch:    c.GetBalances,
+		Interval: c.config.BalancePollInterval,
+		OnError: func(err error) {
+			c.logger.WarnContext(ctx, "falconx balance poll failed", "error", err)
+		},
+	}
+	return poller.Run(ctx, handler)
+}
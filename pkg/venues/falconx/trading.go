@@ -0,0 +1,548 @@
+package falconx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/idgen"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	fxnorm "github.com/Combine-Capital/cqvx/internal/normalizer/falconx"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/symbols"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// PlaceOrder failures detected by the client rather than reported by FalconX.
+// They are returned as the Err of a *venueerr.Error, so errors.Is matches both
+// them and the error's category.
+var (
+	// ErrQuoteExpired is returned, with category venueerr.ErrInvalidRequest,
+	// when a quote expires (or is within QuoteExpiryMargin of expiring) before
+	// it can be executed. The quote is not executed.
+	ErrQuoteExpired = errors.New("falconx quote expired")
+
+	// ErrLimitPriceExceeded is returned, with category
+	// venueerr.ErrInvalidOrder, when a LIMIT order's quote is worse than the
+	// order price. The quote is not executed.
+	ErrLimitPriceExceeded = errors.New("falconx quote exceeds limit price")
+
+	// ErrSettlementTimeout is returned, with category
+	// venueerr.ErrUnknownOutcome, when an executed quote is not reported as
+	// filled within SettlementTimeout. The trade may still settle; check
+	// GetOrder with the quote ID before retrying.
+	ErrSettlementTimeout = errors.New("falconx quote settlement timed out")
+)
+
+// quoteRequest is the request body for POST /v1/quotes.
+type quoteRequest struct {
+	TokenPair     tokenPair `json:"token_pair"`
+	Quantity      quantity  `json:"quantity"`
+	Side          string    `json:"side"`
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+}
+
+type tokenPair struct {
+	BaseToken  string `json:"base_token"`
+	QuoteToken string `json:"quote_token"`
+}
+
+type quantity struct {
+	Token string `json:"token"`
+	Value string `json:"value"`
+}
+
+// executeRequest is the request body for POST /v1/quotes/execute.
+type executeRequest struct {
+	FxQuoteID string `json:"fx_quote_id"`
+	Side      string `json:"side"`
+}
+
+// PlaceOrder executes an order through the FalconX RFQ flow:
+//  1. Request a one-sided quote for the order's side and base quantity
+//  2. Check the quote has not expired (allowing QuoteExpiryMargin) and, for
+//     LIMIT orders, that the quoted price is no worse than the order price
+//  3. Execute the quote
+//  4. Poll the quote until FalconX reports it filled, up to SettlementTimeout
+//
+// The order must set VenueSymbol ("BASE/QUOTE" or "BASE-QUOTE"), Side and
+// Quantity (in the base token). OrderType must be MARKET or LIMIT; other
+// types return an error. If ClientOrderId is empty a random one is generated
+// for each call.
+//
+// If the execute request fails in a way that may have reached FalconX (a
+// transport error or timeout, or a 408 or 5xx response), the quote is fetched
+// to find out whether it was executed, as client.PlaceOrderIdempotently does
+// for orders, and settlement is awaited if it was.
+//
+// Returns an EXECUTION_TYPE_FILL report on success. Returns a *venueerr.Error
+// naming the quote ID that wraps ErrQuoteExpired or ErrLimitPriceExceeded
+// (nothing executed), or that matches venueerr.ErrUnknownOutcome when the
+// quote may have been executed but its fill was not confirmed: the execute
+// failure could not be resolved, settlement timed out (wrapping
+// ErrSettlementTimeout) or ctx ended while awaiting settlement (wrapping
+// ctx.Err()). Resolve unknown outcomes with GetOrder before placing the order
+// again.
+func (c *Client) PlaceOrder(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error) {
+	req, err := buildQuoteRequest(order)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := c.requestQuote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Expired(time.Now().Add(c.config.QuoteExpiryMargin)) {
+		return nil, &venueerr.Error{
+			Venue:    VenueID,
+			Category: venueerr.ErrInvalidRequest,
+			Message:  fmt.Sprintf("quote %s expired at %s", quote.ID, quote.ExpiresAt.Format(time.RFC3339Nano)),
+			Err:      ErrQuoteExpired,
+		}
+	}
+
+	side := strings.ToUpper(req.Side)
+	price := quote.Price(side)
+	if price <= 0 {
+		return nil, fmt.Errorf("falconx quote %s has no %s price", quote.ID, side)
+	}
+	if order.GetOrderType() == venuesv1.OrderType_ORDER_TYPE_LIMIT {
+		limit := order.GetPrice()
+		if (side == "BUY" && price > limit) || (side == "SELL" && price < limit) {
+			return nil, &venueerr.Error{
+				Venue:    VenueID,
+				Category: venueerr.ErrInvalidOrder,
+				Message: fmt.Sprintf("quote %s %s price %s, limit %s",
+					quote.ID, side, normalizer.FormatDecimal(price), normalizer.FormatDecimal(limit)),
+				Err: ErrLimitPriceExceeded,
+			}
+		}
+	}
+
+	raw, err := c.do(ctx, http.MethodPost, "/v1/quotes/execute", nil, executeRequest{
+		FxQuoteID: quote.ID,
+		Side:      req.Side,
+	})
+	if err != nil {
+		raw, err = c.resolveExecute(ctx, quote.ID, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err = c.awaitSettlement(ctx, quote.ID, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := fxnorm.NormalizeExecutionReport(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	venueId := VenueID
+	report.VenueId = &venueId
+	if report.GetClientOrderId() == "" {
+		report.ClientOrderId = &req.ClientOrderID
+	}
+
+	c.logger.InfoContext(ctx, "falconx quote executed",
+		"quote_id", quote.ID, "side", side, "price", report.GetPrice(), "quantity", report.GetQuantity())
+
+	return report, nil
+}
+
+// requestQuote requests a quote without executing it.
+func (c *Client) requestQuote(ctx context.Context, req *quoteRequest) (*fxnorm.Quote, error) {
+	raw, err := c.do(ctx, http.MethodPost, "/v1/quotes", nil, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request falconx quote: %w", err)
+	}
+	return fxnorm.NormalizeQuote(ctx, raw)
+}
+
+// resolveExecute handles a failed execute request. Failures from which
+// FalconX evidently did not act are returned wrapped. Otherwise the quote is
+// fetched, with a context that outlives ctx by at most client.RecoveryTimeout:
+// if it was executed its raw body is returned, and if not the execute failure
+// is returned. If it cannot be fetched, the outcome is unknown.
+func (c *Client) resolveExecute(ctx context.Context, quoteID string, execErr error) ([]byte, error) {
+	execErr = fmt.Errorf("failed to execute falconx quote %s: %w", quoteID, execErr)
+	var venueErr *venueerr.Error
+	if errors.As(execErr, &venueErr) && (venueErr.Category != venueerr.ErrUnavailable || venueErr.StatusCode == 0) {
+		return nil, execErr
+	}
+
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), client.RecoveryTimeout)
+	defer cancel()
+
+	raw, err := c.do(lookupCtx, http.MethodGet, "/v1/quotes/"+url.PathEscape(quoteID), nil, nil)
+	if err == nil {
+		var fxQuote *fxnorm.FalconXQuote
+		if fxQuote, err = fxnorm.ParseQuote(raw); err == nil {
+			if fxQuote.IsFilled || fxQuote.SideExecuted != "" {
+				c.logger.InfoContext(ctx, "falconx quote execution recovered", "quote_id", quoteID, "error", execErr)
+				return raw, nil
+			}
+			return nil, execErr
+		}
+	}
+
+	return nil, &venueerr.Error{
+		Venue:    VenueID,
+		Category: venueerr.ErrUnknownOutcome,
+		Message:  fmt.Sprintf("quote %s may have been executed", quoteID),
+		Err:      fmt.Errorf("%w; lookup of the quote failed: %w", execErr, err),
+	}
+}
+
+// awaitSettlement polls a quote until it is reported filled, starting from the
+// execute response. Returns the raw body of the filled quote, or an
+// ErrUnknownOutcome error if the fill is not confirmed.
+func (c *Client) awaitSettlement(ctx context.Context, quoteID string, raw []byte) ([]byte, error) {
+	fxQuote, err := fxnorm.ParseQuote(raw)
+	if err != nil {
+		return nil, err
+	}
+	if fxQuote.IsFilled {
+		return raw, nil
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, c.config.SettlementTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(c.config.SettlementPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pollCtx.Done():
+			if ctx.Err() != nil {
+				return nil, unsettledError(quoteID, "its settlement was not awaited", ctx.Err())
+			}
+			return nil, unsettledError(quoteID, fmt.Sprintf("it was not reported filled within %s", c.config.SettlementTimeout), ErrSettlementTimeout)
+		case <-ticker.C:
+		}
+
+		raw, err := c.do(pollCtx, http.MethodGet, "/v1/quotes/"+url.PathEscape(quoteID), nil, nil)
+		if err != nil {
+			if pollCtx.Err() != nil {
+				continue
+			}
+			if venueerr.IsRetryable(err) {
+				c.logger.DebugContext(ctx, "falconx settlement poll failed", "quote_id", quoteID, "error", err)
+				continue
+			}
+			return nil, unsettledError(quoteID, "polling its settlement failed", err)
+		}
+
+		fxQuote, err := fxnorm.ParseQuote(raw)
+		if err != nil {
+			return nil, unsettledError(quoteID, "polling its settlement failed", err)
+		}
+		if fxQuote.IsFilled {
+			return raw, nil
+		}
+	}
+}
+
+// unsettledError returns the ErrUnknownOutcome error for an executed quote
+// whose fill could not be confirmed.
+func unsettledError(quoteID, reason string, err error) *venueerr.Error {
+	return &venueerr.Error{
+		Venue:    VenueID,
+		Category: venueerr.ErrUnknownOutcome,
+		Message:  fmt.Sprintf("quote %s was executed but %s", quoteID, reason),
+		Err:      err,
+	}
+}
+
+// CancelOrder is not supported: FalconX quotes execute immediately and in
+// full, so there is never a resting order to cancel.
+func (c *Client) CancelOrder(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "CancelOrder"}
+}
+
+// PlaceOrders executes several orders as independent quote-and-execute
+// round trips. FalconX has no batch endpoint, so orders are placed with
+// concurrent PlaceOrder calls (at most client.DefaultBatchConcurrency at a
+// time) and are not atomic.
+func (c *Client) PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error) {
+	return client.PlaceOrdersConcurrently(ctx, orders, client.DefaultBatchConcurrency, c.PlaceOrder), nil
+}
+
+// CancelOrders is not supported: FalconX quotes execute immediately and in
+// full, so there is never a resting order to cancel.
+func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "CancelOrders"}
+}
+
+// CancelAllOrders is not supported: FalconX quotes execute immediately and in
+// full, so there is never a resting order to cancel.
+func (c *Client) CancelAllOrders(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "CancelAllOrders"}
+}
+
+// AmendOrder is not supported: FalconX quotes execute immediately and in
+// full, so there is never a resting order to amend.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "AmendOrder"}
+}
+
+// GetOrder retrieves a quote by its FalconX quote ID, reported as an Order.
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, "/v1/quotes/"+url.PathEscape(orderID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := fxnorm.NormalizeOrder(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	venueId := VenueID
+	order.VenueId = &venueId
+	return order, nil
+}
+
+// GetOrders lists executed and unexecuted quotes, reported as Orders.
+//
+// FalconX requires a time range; if the filter has none, the last
+// DefaultOrderHistoryWindow is queried. Symbols (either "BASE/QUOTE" or
+// "BASE-QUOTE"), statuses, offset and limit are applied client-side.
+func (c *Client) GetOrders(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order filter: %w", err)
+	}
+
+	rawQuotes, err := c.listQuotes(ctx, filter.StartTime, filter.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	wantSymbols := symbolSet(filter.Symbols)
+	statuses := make(map[venuesv1.OrderStatus]bool, len(filter.Statuses))
+	for _, status := range filter.Statuses {
+		statuses[status] = true
+	}
+
+	orders := make([]*venuesv1.Order, 0, len(rawQuotes))
+	for _, rawQuote := range rawQuotes {
+		order, err := fxnorm.NormalizeOrder(ctx, rawQuote)
+		if err != nil {
+			return nil, err
+		}
+		if len(wantSymbols) > 0 && !wantSymbols[order.GetVenueSymbol()] {
+			continue
+		}
+		if len(statuses) > 0 && !statuses[order.GetStatus()] {
+			continue
+		}
+		venueId := VenueID
+		order.VenueId = &venueId
+		orders = append(orders, order)
+	}
+
+	if filter.Offset >= len(orders) {
+		return []*venuesv1.Order{}, nil
+	}
+	orders = orders[filter.Offset:]
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
+	return orders, nil
+}
+
+// GetFills lists filled quotes as fills.
+//
+// Each filled quote is a single fill with a zero fee in the quote asset, as
+// FalconX embeds its spread in the quoted price. With an order ID only that
+// quote is fetched; otherwise the time range is queried as in GetOrders.
+// Symbols are applied client-side. FalconX does not paginate, so the cursor
+// is an offset into the queried range: set EndTime when paging so the range
+// does not move between calls.
+func (c *Client) GetFills(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fill filter: %w", err)
+	}
+
+	offset := 0
+	if filter.Cursor != "" {
+		n, err := strconv.Atoi(filter.Cursor)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid falconx fill cursor %q", filter.Cursor)
+		}
+		offset = n
+	}
+
+	var rawQuotes []json.RawMessage
+	if filter.OrderID != "" {
+		raw, err := c.do(ctx, http.MethodGet, "/v1/quotes/"+url.PathEscape(filter.OrderID), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		rawQuotes = []json.RawMessage{raw}
+	} else {
+		var err error
+		rawQuotes, err = c.listQuotes(ctx, filter.StartTime, filter.EndTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wantSymbols := symbolSet(filter.Symbols)
+	fills := make([]*venuesv1.ExecutionReport, 0, len(rawQuotes))
+	for _, rawQuote := range rawQuotes {
+		report, err := fxnorm.NormalizeExecutionReport(ctx, rawQuote)
+		if err != nil {
+			return nil, err
+		}
+		if report.GetExecutionType() != venuesv1.ExecutionType_EXECUTION_TYPE_FILL {
+			continue
+		}
+		if len(wantSymbols) > 0 && !wantSymbols[report.GetVenueSymbol()] {
+			continue
+		}
+		at := report.GetTimestamp().AsTime()
+		if (!filter.StartTime.IsZero() && at.Before(filter.StartTime)) || (!filter.EndTime.IsZero() && at.After(filter.EndTime)) {
+			continue
+		}
+		venueId := VenueID
+		report.VenueId = &venueId
+		fills = append(fills, report)
+	}
+
+	page := &client.FillPage{Fills: []*venuesv1.ExecutionReport{}}
+	if offset >= len(fills) {
+		return page, nil
+	}
+	fills = fills[offset:]
+	if filter.Limit > 0 && len(fills) > filter.Limit {
+		fills = fills[:filter.Limit]
+		page.NextCursor = strconv.Itoa(offset + filter.Limit)
+	}
+	page.Fills = fills
+
+	return page, nil
+}
+
+// listQuotes fetches the raw quotes in a time range. FalconX requires a range;
+// an unset end defaults to now and an unset start to DefaultOrderHistoryWindow
+// before the end.
+func (c *Client) listQuotes(ctx context.Context, start, end time.Time) ([]json.RawMessage, error) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-DefaultOrderHistoryWindow)
+	}
+
+	query := url.Values{}
+	query.Set("t_start", start.UTC().Format(time.RFC3339))
+	query.Set("t_end", end.UTC().Format(time.RFC3339))
+
+	raw, err := c.do(ctx, http.MethodGet, "/v1/quotes", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawQuotes []json.RawMessage
+	if err := json.Unmarshal(raw, &rawQuotes); err != nil {
+		return nil, fmt.Errorf("failed to parse falconx quotes response: %w", err)
+	}
+	return rawQuotes, nil
+}
+
+// symbolSet converts "BASE/QUOTE" or "BASE-QUOTE" symbols into a set of
+// FalconX venue symbols. Malformed symbols are ignored.
+func symbolSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, symbol := range list {
+		if base, quote, err := splitSymbol(symbol); err == nil {
+			set[fxnorm.FormatSymbol(base, quote)] = true
+		}
+	}
+	return set
+}
+
+// buildQuoteRequest validates a CQC order and converts it into a quote request.
+// An empty ClientOrderId gets a random UUID, so that repeated identical orders
+// remain distinct quotes in FalconX's records.
+func buildQuoteRequest(order *venuesv1.Order) (*quoteRequest, error) {
+	if order == nil {
+		return nil, fmt.Errorf("order is required")
+	}
+
+	base, quote, err := splitSymbol(order.GetVenueSymbol())
+	if err != nil {
+		return nil, err
+	}
+	if order.GetQuantity() <= 0 {
+		return nil, fmt.Errorf("order quantity must be positive")
+	}
+
+	req := &quoteRequest{
+		TokenPair:     tokenPair{BaseToken: base, QuoteToken: quote},
+		Quantity:      quantity{Token: base, Value: normalizer.FormatDecimal(order.GetQuantity())},
+		ClientOrderID: order.GetClientOrderId(),
+	}
+
+	switch order.GetSide() {
+	case venuesv1.OrderSide_ORDER_SIDE_BUY:
+		req.Side = "buy"
+	case venuesv1.OrderSide_ORDER_SIDE_SELL:
+		req.Side = "sell"
+	default:
+		return nil, fmt.Errorf("order side is required")
+	}
+
+	switch order.GetOrderType() {
+	case venuesv1.OrderType_ORDER_TYPE_MARKET:
+		if order.GetPrice() > 0 {
+			return nil, fmt.Errorf("MARKET order must not set a price")
+		}
+	case venuesv1.OrderType_ORDER_TYPE_LIMIT:
+		if order.GetPrice() <= 0 {
+			return nil, fmt.Errorf("LIMIT order requires a positive price")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported falconx order type: %s (RFQ supports MARKET and LIMIT)", order.GetOrderType())
+	}
+
+	if req.ClientOrderID == "" {
+		id, err := idgen.NewUUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client order ID: %w", err)
+		}
+		req.ClientOrderID = id
+	}
+
+	return req, nil
+}
+
+// splitSymbol splits a "BASE/QUOTE" or "BASE-QUOTE" symbol into upper-case
+// canonical tokens, resolving aliases such as XBT with symbols.DefaultRegistry.
+func splitSymbol(symbol string) (base, quote string, err error) {
+	if symbol == "" {
+		return "", "", fmt.Errorf("order venue symbol is required")
+	}
+	pair, err := symbols.DefaultRegistry.FromVenue("", symbol)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid falconx symbol %q: expected BASE/QUOTE", symbol)
+	}
+	return pair.Base, pair.Quote, nil
+}
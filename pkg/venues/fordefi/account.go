@@ -0,0 +1,86 @@
+package fordefi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	fdnorm "github.com/Combine-Capital/cqvx/internal/normalizer/fordefi"
+)
+
+// ownedAssetsResponse is the response body of GET /api/v1/vaults/{id}/assets.
+type ownedAssetsResponse struct {
+	OwnedAssets []json.RawMessage `json:"owned_assets"`
+}
+
+// GetBalance returns the configured vault's balance of BalanceCurrency (default "USDC").
+//
+// Use GetBalances for every asset in the vault. If the vault holds none of
+// BalanceCurrency, a zero balance is returned.
+func (c *Client) GetBalance(ctx context.Context) (*venuesv1.Balance, error) {
+	balances, err := c.GetBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		if strings.EqualFold(balance.GetAssetId(), c.config.BalanceCurrency) {
+			return balance, nil
+		}
+	}
+
+	venueId := VenueID
+	asset := c.config.BalanceCurrency
+	vaultID := c.config.VaultID
+	var zero float64
+	return &venuesv1.Balance{
+		AccountId: &vaultID,
+		VenueId:   &venueId,
+		AssetId:   &asset,
+		Total:     &zero,
+		Available: &zero,
+		Locked:    &zero,
+	}, nil
+}
+
+// GetBalances returns the balance of every asset in the configured vault.
+func (c *Client) GetBalances(ctx context.Context) ([]*venuesv1.Balance, error) {
+	return c.GetVaultBalances(ctx, c.config.VaultID)
+}
+
+// GetVaultBalances returns the balance of every asset in a vault.
+// Balances are converted from the asset's smallest unit to asset units;
+// AccountId is set to the vault ID.
+func (c *Client) GetVaultBalances(ctx context.Context, vaultID string) ([]*venuesv1.Balance, error) {
+	if vaultID == "" {
+		return nil, fmt.Errorf("vault ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, "/api/v1/vaults/"+url.PathEscape(vaultID)+"/assets", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ownedAssetsResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse fordefi vault assets response: %w", err)
+	}
+
+	balances := make([]*venuesv1.Balance, 0, len(resp.OwnedAssets))
+	for _, rawAsset := range resp.OwnedAssets {
+		balance, err := fdnorm.NormalizeBalance(ctx, rawAsset)
+		if err != nil {
+			return nil, err
+		}
+		venueId := VenueID
+		id := vaultID
+		balance.VenueId = &venueId
+		balance.AccountId = &id
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
@@ -0,0 +1,216 @@
+// Package fordefi implements the VenueClient interface for Fordefi.
+//
+// Fordefi is an MPC custody platform rather than a trading venue. Orders map
+// onto outgoing vault transactions: PlaceOrder creates a transfer, which then
+// moves through Fordefi's approval workflow (pending approval, approved,
+// signed, pushed to chain, completed) reported as CQC order statuses.
+// Requests are signed by auth.MPCSigner via auth.Middleware and responses are
+// normalized to CQC types by internal/normalizer/fordefi.
+package fordefi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	fdnorm "github.com/Combine-Capital/cqvx/internal/normalizer/fordefi"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// Ensure Client implements the VenueClient interface at compile time
+var _ client.VenueClient = (*Client)(nil)
+
+// Client is a Fordefi venue client.
+//
+// Thread-safe: All methods can be called concurrently if the configured
+// SignerFunc, if any, is thread-safe.
+type Client struct {
+	config     Config
+	signer     *auth.MPCSigner
+	httpClient *http.Client
+	wsDialer   stream.Dialer
+	logger     *slog.Logger
+	limiter    *ratelimit.Limiter
+	clock      *client.ServerClock
+}
+
+// NewClient creates a new Fordefi client.
+//
+// The httpClient is used for all REST calls; its transport is wrapped with
+// MPC request signing. The caller's client is not modified. If httpClient is
+// nil, http.DefaultClient is used.
+//
+// Fordefi has no market data streams; wsDialer is accepted for constructor
+// parity with other venues. If wsDialer is nil, stream.NewWebSocketDialer()
+// is used. If logger is nil, logging is disabled.
+func NewClient(config Config, httpClient *http.Client, wsDialer stream.Dialer, logger *slog.Logger) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fordefi config: %w", err)
+	}
+	config = config.withDefaults()
+
+	var clock *client.ServerClock
+	var now func() time.Time
+	if !config.DisableClockSync {
+		clock = &client.ServerClock{Interval: config.ClockSyncInterval}
+		now = clock.Now
+	}
+
+	var c *Client
+	signer, err := auth.NewMPCSigner(auth.MPCConfig{
+		APIKey:      config.APIKey,
+		PrivateKey:  config.PrivateKey,
+		SignerFunc:  config.SignerFunc,
+		Now:         now,
+		Credentials: config.Credentials,
+		OnCredentialError: func(err error) {
+			c.logger.Warn("fordefi credential rotation failed", "error", err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fordefi signer: %w", err)
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	signed := *httpClient
+	var limiter *ratelimit.Limiter
+	if !config.DisableRateLimit {
+		limiter, err = ratelimit.New(config.RateLimits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fordefi rate limiter: %w", err)
+		}
+	}
+	signed.Transport = ratelimit.Middleware(limiter, auth.Middleware(signer, httpClient.Transport))
+
+	if wsDialer == nil {
+		wsDialer = stream.NewWebSocketDialer()
+	}
+
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	c = &Client{
+		config:     config,
+		signer:     signer,
+		httpClient: &signed,
+		limiter:    limiter,
+		clock:      clock,
+		wsDialer:   wsDialer,
+		logger:     logger.With("venue", VenueID),
+	}
+	if clock != nil {
+		clock.Fetch = client.DateHeaderTime(httpClient, config.BaseURL)
+		clock.OnError = func(err error) {
+			c.logger.Warn("fordefi clock sync failed", "error", err)
+		}
+	}
+	return c, nil
+}
+
+// RateLimiter returns the client-side rate limiter applied to REST requests,
+// for reading its utilization, or nil if Config.DisableRateLimit is set.
+func (c *Client) RateLimiter() *ratelimit.Limiter {
+	return c.limiter
+}
+
+// ActiveKeyID returns the API user access token requests are currently signed with.
+// It changes when Config.Credentials rotates the credentials.
+func (c *Client) ActiveKeyID() string {
+	return c.signer.ActiveKeyID()
+}
+
+// Clock returns the clock whose offset from Fordefi's time corrects request
+// timestamps, or nil if Config.DisableClockSync is set. It is resynced when
+// Fordefi rejects a request for clock skew. The client never runs it:
+// callers that want it kept synced in the background start Run themselves,
+// e.g. go c.Clock().Run(ctx), and cancel ctx when done with the client.
+func (c *Client) Clock() *client.ServerClock {
+	return c.clock
+}
+
+// do performs a signed REST request against the Fordefi API and returns the
+// raw response body. Extra headers (e.g., x-idempotence-id) are set on the
+// request before signing.
+//
+// Non-2xx responses are converted to classified errors by fdnorm.NormalizeError.
+// A request rejected with venueerr.ErrClockSkew is retried once after
+// resyncing the client's clock, unless Config.DisableClockSync is set.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, headers map[string]string) ([]byte, error) {
+	signedAt := time.Now()
+	respBody, err := c.send(ctx, method, path, query, body, headers)
+	if err == nil || c.clock == nil || !errors.Is(err, venueerr.ErrClockSkew) {
+		return respBody, err
+	}
+
+	// Fordefi rejected the request timestamp: correct the clock and retry once
+	if syncErr := c.clock.Resync(ctx, signedAt); syncErr != nil {
+		c.logger.WarnContext(ctx, "fordefi clock resync failed", "error", syncErr)
+		return nil, err
+	}
+	c.logger.InfoContext(ctx, "fordefi clock resynced after skew rejection",
+		"offset", c.clock.Offset())
+	return c.send(ctx, method, path, query, body, headers)
+}
+
+// send performs a single signed request for do, without clock skew recovery.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}, headers map[string]string) ([]byte, error) {
+	endpoint := strings.TrimRight(c.config.BaseURL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fordefi request %s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fordefi response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.DebugContext(ctx, "fordefi request failed",
+			"method", method, "path", path, "status", resp.StatusCode)
+		return nil, fdnorm.NormalizeError(resp, respBody)
+	}
+
+	return respBody, nil
+}
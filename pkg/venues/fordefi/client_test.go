@@ -0,0 +1,736 @@
+package fordefi_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/Combine-Capital/cqvx/pkg/venues/fordefi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAPIKey        = "test-fordefi-api-key"
+	testVaultID       = "vault-eth-1"
+	testTransactionID = "d5f1c2a0-6b1e-4c7a-9f3e-2a8b7c6d5e4f"
+	testDestination   = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	testUSDCContract  = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+)
+
+// recordedRequest captures a request received by the test server.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// testServer serves recorded Fordefi payloads and verifies request signatures.
+type testServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	keyPEM   string
+	pubPEM   string
+	routes   map[string]http.HandlerFunc
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	ts := &testServer{
+		t:      t,
+		keyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})),
+		pubPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})),
+		routes: make(map[string]http.HandlerFunc),
+	}
+	ts.server = httptest.NewServer(http.HandlerFunc(ts.serveHTTP))
+	t.Cleanup(ts.server.Close)
+	return ts
+}
+
+func (ts *testServer) handle(method, path string, handler http.HandlerFunc) {
+	ts.routes[method+" "+path] = handler
+}
+
+func (ts *testServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(ts.t, err)
+
+	ts.mu.Lock()
+	ts.requests = append(ts.requests, recordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	ts.mu.Unlock()
+
+	// Verify the signature covers this request's path, timestamp and body
+	assert.Equal(ts.t, "Bearer "+testAPIKey, r.Header.Get("Authorization"))
+	timestamp := r.Header.Get("X-Timestamp")
+	assert.NotEmpty(ts.t, timestamp)
+	payload := auth.MPCSignaturePayload(r.URL.Path, timestamp, body)
+	assert.NoError(ts.t, auth.VerifyMPCSignature(ts.pubPEM, payload, r.Header.Get("X-Signature")))
+
+	handler, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+// paths returns the method and path of every recorded request.
+func (ts *testServer) paths() []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	paths := make([]string, len(ts.requests))
+	for i, req := range ts.requests {
+		paths[i] = req.Method + " " + req.Path
+	}
+	return paths
+}
+
+func (ts *testServer) config() fordefi.Config {
+	return fordefi.Config{
+		APIKey:     testAPIKey,
+		PrivateKey: ts.keyPEM,
+		VaultID:    testVaultID,
+		BaseURL:    ts.server.URL,
+		Assets: map[string]fordefi.Asset{
+			"ETH":  {Chain: "ethereum_mainnet", Type: fordefi.AssetTypeNative, Decimals: 18},
+			"usdc": {Chain: "ethereum_mainnet", Type: fordefi.AssetTypeERC20, Contract: testUSDCContract, Decimals: 6},
+		},
+		ApprovalPollInterval: 5 * time.Millisecond,
+	}
+}
+
+func (ts *testServer) client(t *testing.T) *fordefi.Client {
+	t.Helper()
+	c, err := fordefi.NewClient(ts.config(), ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+	return c
+}
+
+// serveFile returns a handler that writes a testdata fixture with the given status.
+func serveFile(t *testing.T, status int, name string) http.HandlerFunc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return serveJSON(status, data)
+}
+
+func serveJSON(status int, data []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	}
+}
+
+// serveSequence returns a handler that serves handlers in order, repeating the last.
+func serveSequence(handlers ...http.HandlerFunc) http.HandlerFunc {
+	var mu sync.Mutex
+	next := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		handler := handlers[next]
+		if next < len(handlers)-1 {
+			next++
+		}
+		mu.Unlock()
+		handler(w, r)
+	}
+}
+
+func ethTransfer() *venuesv1.Order {
+	return &venuesv1.Order{
+		ClientOrderId: strPtr("cqvx-test-0001"),
+		VenueSymbol:   strPtr("ETH"),
+		AccountId:     strPtr(testDestination),
+		Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+		OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+		Quantity:      floatPtr(1.5),
+		Notes:         strPtr("treasury rebalance"),
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	valid := fordefi.Config{APIKey: testAPIKey, SignerFunc: auth.DefaultMPCSignerFunc, VaultID: testVaultID}
+	keyPEM := newTestServer(t).keyPEM
+
+	tests := []struct {
+		name    string
+		mutate  func(*fordefi.Config)
+		wantErr bool
+	}{
+		{name: "valid config", mutate: func(c *fordefi.Config) {}},
+		{name: "missing API key", mutate: func(c *fordefi.Config) { c.APIKey = "" }, wantErr: true},
+		{name: "missing signer", mutate: func(c *fordefi.Config) { c.SignerFunc = nil }, wantErr: true},
+		{name: "private key", mutate: func(c *fordefi.Config) {
+			c.SignerFunc = nil
+			c.PrivateKey = keyPEM
+		}},
+		{name: "private key and signer", mutate: func(c *fordefi.Config) { c.PrivateKey = keyPEM }, wantErr: true},
+		{name: "malformed private key", mutate: func(c *fordefi.Config) {
+			c.SignerFunc = nil
+			c.PrivateKey = "not a pem key"
+		}, wantErr: true},
+		{name: "missing vault", mutate: func(c *fordefi.Config) { c.VaultID = "" }, wantErr: true},
+		{name: "negative approval timeout", mutate: func(c *fordefi.Config) { c.ApprovalTimeout = -1 }, wantErr: true},
+		{name: "asset without chain", mutate: func(c *fordefi.Config) {
+			c.Assets = map[string]fordefi.Asset{"ETH": {Type: fordefi.AssetTypeNative, Decimals: 18}}
+		}, wantErr: true},
+		{name: "erc20 without contract", mutate: func(c *fordefi.Config) {
+			c.Assets = map[string]fordefi.Asset{"USDC": {Chain: "ethereum_mainnet", Type: fordefi.AssetTypeERC20, Decimals: 6}}
+		}, wantErr: true},
+		{name: "unknown asset type", mutate: func(c *fordefi.Config) {
+			c.Assets = map[string]fordefi.Asset{"SOL": {Chain: "solana_mainnet", Type: "spl", Decimals: 9}}
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.mutate(&config)
+			c, err := fordefi.NewClient(config, nil, nil, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, c)
+		})
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("native transfer", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions", serveFile(t, http.StatusCreated, "transaction_created.json"))
+		c := ts.client(t)
+
+		report, err := c.PlaceOrder(ctx, ethTransfer())
+		require.NoError(t, err)
+
+		assert.Equal(t, "fordefi", report.GetVenueId())
+		assert.Equal(t, testTransactionID, report.GetVenueOrderId())
+		assert.Equal(t, "cqvx-test-0001", report.GetClientOrderId())
+		assert.Equal(t, testVaultID, report.GetAccountId())
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+		assert.Equal(t, "pending_approval", report.GetOrderStatus())
+
+		require.Equal(t, []string{"POST /api/v1/transactions"}, ts.paths())
+		assert.Equal(t, "cqvx-test-0001", ts.requests[0].Header.Get("x-idempotence-id"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		assert.Equal(t, map[string]interface{}{
+			"vault_id":    testVaultID,
+			"signer_type": "api_signer",
+			"type":        "evm_transaction",
+			"note":        "treasury rebalance",
+			"details": map[string]interface{}{
+				"type":  "evm_transfer",
+				"to":    testDestination,
+				"value": map[string]interface{}{"type": "value", "value": "1500000000000000000"},
+				"asset_identifier": map[string]interface{}{
+					"type":    "evm",
+					"details": map[string]interface{}{"type": "native", "chain": "ethereum_mainnet"},
+				},
+			},
+		}, body)
+	})
+
+	t.Run("erc20 transfer", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions", serveFile(t, http.StatusCreated, "transaction_created.json"))
+		c := ts.client(t)
+
+		order := ethTransfer()
+		order.VenueSymbol = strPtr("usdc")
+		order.Quantity = floatPtr(2500.25)
+		_, err := c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+
+		var body struct {
+			Details struct {
+				Value           map[string]interface{} `json:"value"`
+				AssetIdentifier map[string]interface{} `json:"asset_identifier"`
+			} `json:"details"`
+		}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		assert.Equal(t, "2500250000", body.Details.Value["value"])
+		assert.Equal(t, map[string]interface{}{
+			"type": "erc20",
+			"token": map[string]interface{}{
+				"chain":    "ethereum_mainnet",
+				"hex_repr": testUSDCContract,
+			},
+		}, body.Details.AssetIdentifier["details"])
+	})
+
+	t.Run("awaits approval", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions", serveFile(t, http.StatusCreated, "transaction_created.json"))
+		ts.handle(http.MethodGet, "/api/v1/transactions/"+testTransactionID, serveSequence(
+			serveFile(t, http.StatusOK, "transaction_created.json"),
+			serveJSON(http.StatusServiceUnavailable, []byte(`{"title":"Service unavailable"}`)),
+			serveFile(t, http.StatusOK, "transaction_signed.json"),
+		))
+		config := ts.config()
+		config.ApprovalTimeout = time.Second
+		c, err := fordefi.NewClient(config, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		report, err := c.PlaceOrder(ctx, ethTransfer())
+		require.NoError(t, err)
+		assert.Equal(t, "signed", report.GetOrderStatus())
+		assert.Equal(t, "fordefi", report.GetVenueId())
+		assert.NotEmpty(t, report.GetTxHash())
+
+		assert.Equal(t, []string{
+			"POST /api/v1/transactions",
+			"GET /api/v1/transactions/" + testTransactionID,
+			"GET /api/v1/transactions/" + testTransactionID,
+			"GET /api/v1/transactions/" + testTransactionID,
+		}, ts.paths())
+	})
+
+	t.Run("approval timeout returns pending", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions", serveFile(t, http.StatusCreated, "transaction_created.json"))
+		ts.handle(http.MethodGet, "/api/v1/transactions/"+testTransactionID, serveFile(t, http.StatusOK, "transaction_created.json"))
+		config := ts.config()
+		config.ApprovalTimeout = 50 * time.Millisecond
+		c, err := fordefi.NewClient(config, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		report, err := c.PlaceOrder(ctx, ethTransfer())
+		require.NoError(t, err)
+		assert.Equal(t, "pending_approval", report.GetOrderStatus())
+	})
+
+	t.Run("generates idempotence ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions", serveJSON(http.StatusCreated,
+			[]byte(`{"id":"`+testTransactionID+`","state":"pending_approval","type":"evm_transaction"}`)))
+		c := ts.client(t)
+
+		order := ethTransfer()
+		order.ClientOrderId = nil
+		report, err := c.PlaceOrder(ctx, order)
+		require.NoError(t, err)
+
+		id := ts.requests[0].Header.Get("x-idempotence-id")
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+		assert.Equal(t, id, report.GetClientOrderId())
+		assert.Equal(t, testVaultID, report.GetAccountId())
+	})
+
+	t.Run("rejected by policy", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions", serveJSON(http.StatusUnprocessableEntity,
+			[]byte(`{"title":"Transaction blocked by policy","detail":"destination not allowlisted","system_error_code":"policy_violation"}`)))
+		c := ts.client(t)
+
+		_, err := c.PlaceOrder(ctx, ethTransfer())
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+		assert.Equal(t, "policy_violation", venueErr.Code)
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		invalid := []struct {
+			name   string
+			mutate func(*venuesv1.Order)
+		}{
+			{name: "missing symbol", mutate: func(o *venuesv1.Order) { o.VenueSymbol = nil }},
+			{name: "unknown asset", mutate: func(o *venuesv1.Order) { o.VenueSymbol = strPtr("BTC") }},
+			{name: "missing destination", mutate: func(o *venuesv1.Order) { o.AccountId = nil }},
+			{name: "zero quantity", mutate: func(o *venuesv1.Order) { o.Quantity = floatPtr(0) }},
+			{name: "too many decimals", mutate: func(o *venuesv1.Order) {
+				o.VenueSymbol = strPtr("USDC")
+				o.Quantity = floatPtr(0.0000001)
+			}},
+			{name: "buy side", mutate: func(o *venuesv1.Order) { o.Side = sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY) }},
+			{name: "limit order", mutate: func(o *venuesv1.Order) {
+				o.OrderType = typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT)
+			}},
+		}
+
+		ts := newTestServer(t)
+		c := ts.client(t)
+		for _, tt := range invalid {
+			t.Run(tt.name, func(t *testing.T) {
+				order := ethTransfer()
+				tt.mutate(order)
+				_, err := c.PlaceOrder(ctx, order)
+				assert.Error(t, err)
+			})
+		}
+		assert.Empty(t, ts.paths())
+	})
+}
+
+func TestCreateTransaction(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodPost, "/api/v1/transactions", serveFile(t, http.StatusCreated, "transaction_created.json"))
+	c := ts.client(t)
+
+	details := json.RawMessage(`{"type":"evm_raw_transaction","chain":"ethereum_mainnet","to":"0x0","data":{"type":"hex","hex_data":"0x"}}`)
+	report, err := c.CreateTransaction(ctx, fordefi.TransactionRequest{
+		VaultID:       "vault-other",
+		Type:          fordefi.TransactionTypeEVM,
+		Details:       details,
+		IdempotenceID: "treasury-0001",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testTransactionID, report.GetVenueOrderId())
+
+	assert.Equal(t, "treasury-0001", ts.requests[0].Header.Get("x-idempotence-id"))
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+	assert.JSONEq(t, `"vault-other"`, string(body["vault_id"]))
+	assert.JSONEq(t, string(details), string(body["details"]))
+	assert.NotContains(t, body, "note")
+
+	_, err = c.CreateTransaction(ctx, fordefi.TransactionRequest{Details: details})
+	assert.Error(t, err)
+	_, err = c.CreateTransaction(ctx, fordefi.TransactionRequest{Type: fordefi.TransactionTypeEVM})
+	assert.Error(t, err)
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("aborted", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions/"+testTransactionID+"/abort", serveJSON(http.StatusNoContent, nil))
+		c := ts.client(t)
+
+		status, err := c.CancelOrder(ctx, testTransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *status)
+	})
+
+	t.Run("already signed", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/api/v1/transactions/"+testTransactionID+"/abort", serveJSON(http.StatusConflict,
+			[]byte(`{"title":"Transaction cannot be aborted","detail":"state is signed"}`)))
+		c := ts.client(t)
+
+		_, err := c.CancelOrder(ctx, testTransactionID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+	})
+
+	t.Run("missing ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).CancelOrder(ctx, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestCancelOrders(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodPost, "/api/v1/transactions/tx-1/abort", serveJSON(http.StatusNoContent, nil))
+	ts.handle(http.MethodPost, "/api/v1/transactions/tx-2/abort", serveJSON(http.StatusConflict,
+		[]byte(`{"title":"Transaction cannot be aborted","detail":"state is signed"}`)))
+	c := ts.client(t)
+
+	results, err := c.CancelOrders(ctx, []string{"tx-1", "tx-2"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "tx-1", results[0].OrderID)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *results[0].Status)
+
+	assert.Equal(t, "tx-2", results[1].OrderID)
+	require.Error(t, results[1].Err)
+	assert.ErrorIs(t, results[1].Err, venueerr.ErrInvalidRequest)
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	ctx := context.Background()
+
+	// Serve only the pending transaction, as Fordefi filters by state server-side
+	data, err := os.ReadFile(filepath.Join("testdata", "transactions.json"))
+	require.NoError(t, err)
+	var page map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &page))
+	transactions := page["transactions"].([]interface{})
+	page["transactions"] = transactions[2:]
+	page["total"] = 1
+	pending, err := json.Marshal(page)
+	require.NoError(t, err)
+
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/api/v1/transactions", serveJSON(http.StatusOK, pending))
+	ts.handle(http.MethodPost, "/api/v1/transactions/1f2e3d4c-5b6a-4978-8877-665544332211/abort", serveJSON(http.StatusNoContent, nil))
+	c := ts.client(t)
+
+	results, err := c.CancelAllOrders(ctx, client.OrderFilter{})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "1f2e3d4c-5b6a-4978-8877-665544332211", results[0].OrderID)
+	assert.NoError(t, results[0].Err)
+
+	assert.Equal(t, []string{"created", "queued", "pending_approval"}, ts.requests[0].Query["states"])
+}
+
+func TestGetOrder(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/api/v1/transactions/"+testTransactionID, serveFile(t, http.StatusOK, "transaction_signed.json"))
+	c := ts.client(t)
+
+	order, err := c.GetOrder(ctx, testTransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, "fordefi", order.GetVenueId())
+	assert.Equal(t, testTransactionID, order.GetVenueOrderId())
+	assert.Equal(t, testVaultID, order.GetAccountId())
+	assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED, order.GetStatus())
+
+	_, err = c.GetOrder(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestGetOrders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("server-side filters", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/transactions", serveFile(t, http.StatusOK, "transactions.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{
+			Statuses:  []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_PENDING},
+			StartTime: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		require.Len(t, orders, 3)
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_FILLED, orders[0].GetStatus())
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, orders[1].GetStatus())
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_PENDING, orders[2].GetStatus())
+		for _, order := range orders {
+			assert.Equal(t, "fordefi", order.GetVenueId())
+		}
+
+		query := ts.requests[0].Query
+		assert.Equal(t, testVaultID, query.Get("vault_ids"))
+		assert.Equal(t, []string{"created", "queued", "pending_approval"}, query["states"])
+		assert.Equal(t, "2024-01-15T00:00:00Z", query.Get("created_after"))
+		assert.Equal(t, "2024-01-16T00:00:00Z", query.Get("created_before"))
+		assert.Equal(t, "1", query.Get("page"))
+	})
+
+	t.Run("pages until total", func(t *testing.T) {
+		ts := newTestServer(t)
+		data, err := os.ReadFile(filepath.Join("testdata", "transactions.json"))
+		require.NoError(t, err)
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &page))
+		page["total"] = fordefi.DefaultPageSize + 1
+		first, err := json.Marshal(page)
+		require.NoError(t, err)
+		ts.handle(http.MethodGet, "/api/v1/transactions", serveSequence(
+			serveJSON(http.StatusOK, first),
+			serveJSON(http.StatusOK, data),
+		))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{})
+		require.NoError(t, err)
+		assert.Len(t, orders, 6)
+		require.Len(t, ts.requests, 2)
+		assert.Equal(t, "2", ts.requests[1].Query.Get("page"))
+	})
+
+	t.Run("offset and limit", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/transactions", serveFile(t, http.StatusOK, "transactions.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "8a3e9b71-2c4d-4f5e-a6b7-c8d9e0f1a2b3", orders[0].GetVenueOrderId())
+	})
+
+	t.Run("unmapped status", func(t *testing.T) {
+		ts := newTestServer(t)
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{
+			Statuses: []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_EXPIRED},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+		assert.Empty(t, ts.paths())
+	})
+
+	t.Run("symbols rejected", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).GetOrders(ctx, client.OrderFilter{Symbols: []string{"ETH"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all balances", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/"+testVaultID+"/assets", serveFile(t, http.StatusOK, "vault_assets.json"))
+		c := ts.client(t)
+
+		balances, err := c.GetBalances(ctx)
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+		assert.Equal(t, "fordefi", balances[0].GetVenueId())
+		assert.Equal(t, testVaultID, balances[0].GetAccountId())
+		assert.Equal(t, "ETH", balances[0].GetAssetId())
+		assert.Equal(t, 12.5, balances[0].GetTotal())
+	})
+
+	t.Run("configured currency", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/"+testVaultID+"/assets", serveFile(t, http.StatusOK, "vault_assets.json"))
+		c := ts.client(t)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "USDC", balance.GetAssetId())
+		assert.Equal(t, 1250000.5, balance.GetTotal())
+		assert.Equal(t, 1250000.5, balance.GetAvailable())
+	})
+
+	t.Run("currency not held", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/"+testVaultID+"/assets", serveFile(t, http.StatusOK, "vault_assets.json"))
+		config := ts.config()
+		config.BalanceCurrency = "DAI"
+		c, err := fordefi.NewClient(config, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "DAI", balance.GetAssetId())
+		assert.Equal(t, testVaultID, balance.GetAccountId())
+		assert.Zero(t, balance.GetTotal())
+	})
+
+	t.Run("other vault", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/vault-cold/assets", serveFile(t, http.StatusOK, "vault_assets.json"))
+		c := ts.client(t)
+
+		balances, err := c.GetVaultBalances(ctx, "vault-cold")
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+		assert.Equal(t, "vault-cold", balances[1].GetAccountId())
+
+		_, err = c.GetVaultBalances(ctx, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/"+testVaultID+"/assets", serveJSON(http.StatusUnauthorized,
+			[]byte(`{"title":"Unauthorized","detail":"invalid signature","system_error_code":"unauthorized"}`)))
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrAuth)
+		assert.Equal(t, "unauthorized", venueErr.Code)
+	})
+}
+
+func TestUnsupported(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := ts.client(t)
+
+	_, err := c.GetOrderBook(ctx, "ETH")
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.GetInstruments(ctx)
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.GetInstrument(ctx, "ETH")
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.AmendOrder(ctx, "tx-1", client.OrderChanges{Quantity: floatPtr(1)})
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	_, err = c.GetFills(ctx, client.FillFilter{})
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeOrderBook(ctx, "ETH", nil), client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeTrades(ctx, "ETH", nil), client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeExecutions(ctx, nil), client.ErrUnsupported)
+	assert.Empty(t, ts.paths())
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("healthy", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/"+testVaultID, serveFile(t, http.StatusOK, "vault.json"))
+		assert.NoError(t, ts.client(t).Health(ctx))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/api/v1/vaults/"+testVaultID, serveJSON(http.StatusServiceUnavailable,
+			[]byte(`{"title":"Service unavailable"}`)))
+		err := ts.client(t).Health(ctx)
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+	})
+}
+
+func strPtr(s string) *string     { return &s }
+func floatPtr(f float64) *float64 { return &f }
+func sidePtr(s venuesv1.OrderSide) *venuesv1.OrderSide {
+	return &s
+}
+func typePtr(t venuesv1.OrderType) *venuesv1.OrderType {
+	return &t
+}
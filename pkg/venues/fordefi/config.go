@@ -0,0 +1,226 @@
+package fordefi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+)
+
+const (
+	// VenueID is the CQC venue identifier for Fordefi.
+	VenueID = "fordefi"
+
+	// DefaultBaseURL is the Fordefi REST API base URL.
+	DefaultBaseURL = "https://api.fordefi.com"
+
+	// DefaultBalanceCurrency is the asset reported by GetBalance when none is configured.
+	DefaultBalanceCurrency = "USDC"
+
+	// DefaultApprovalPollInterval is the delay between transaction status polls
+	// while PlaceOrder awaits approval.
+	DefaultApprovalPollInterval = 2 * time.Second
+
+	// DefaultPageSize is the page size used when listing transactions.
+	DefaultPageSize = 100
+
+	// DefaultBalancePollInterval is the delay between balance polls made by SubscribeBalances.
+	DefaultBalancePollInterval = 5 * time.Second
+
+	// DefaultClockSyncInterval is the delay between server time syncs made by
+	// the client's clock.
+	DefaultClockSyncInterval = 5 * time.Minute
+)
+
+// Asset types supported by PlaceOrder transfers.
+const (
+	AssetTypeNative = "native"
+	AssetTypeERC20  = "erc20"
+)
+
+// Asset describes how an asset symbol maps to an on-chain asset for transfers.
+type Asset struct {
+	// Chain is the Fordefi chain identifier (e.g., "ethereum_mainnet")
+	Chain string
+
+	// Type is AssetTypeNative for the chain's gas token or AssetTypeERC20 for tokens
+	Type string
+
+	// Contract is the token contract address (required for AssetTypeERC20)
+	Contract string
+
+	// Decimals is the number of decimal places of the asset's smallest unit
+	// (e.g., 18 for ETH, 6 for USDC)
+	Decimals int
+}
+
+// Config contains configuration for the Fordefi client.
+type Config struct {
+	// APIKey is the Fordefi API user access token
+	APIKey string
+
+	// PrivateKey is the PEM-encoded P-256 private key paired with the API user.
+	// Requests are signed with Fordefi's ECDSA request signing scheme.
+	PrivateKey string
+
+	// SignerFunc delegates request signing to an external service instead of
+	// PrivateKey. Exactly one of PrivateKey or SignerFunc must be set.
+	// See auth.MPCConfig.
+	SignerFunc func(ctx context.Context, message []byte) (string, error)
+
+	// Credentials, if set, supplies the API user access token (KeyID) and,
+	// without SignerFunc, the PEM-encoded private key (Secret) in place of
+	// APIKey and PrivateKey, which must then be empty. Rotated credentials are
+	// picked up without restarting; see client.FileCredentials and
+	// client.CallbackCredentials.
+	Credentials client.CredentialProvider
+
+	// VaultID is the default vault for transfers, balances and health checks
+	VaultID string
+
+	// BaseURL is the REST API base URL (default: DefaultBaseURL)
+	BaseURL string
+
+	// BalanceCurrency is the asset whose balance GetBalance returns (default: "USDC").
+	// Use GetBalances to retrieve balances for every asset in the vault.
+	BalanceCurrency string
+
+	// Assets maps order VenueSymbol values (e.g., "ETH", "USDC") to on-chain
+	// assets. PlaceOrder only accepts symbols present in this map.
+	Assets map[string]Asset
+
+	// ApprovalTimeout makes PlaceOrder wait up to this long for the transaction
+	// to clear the approval workflow. Zero returns as soon as the transaction
+	// is created.
+	ApprovalTimeout time.Duration
+
+	// ApprovalPollInterval is the delay between transaction status polls
+	// while awaiting approval (default: DefaultApprovalPollInterval)
+	ApprovalPollInterval time.Duration
+
+	// BalancePollInterval is the delay between balance polls made by
+	// SubscribeBalances (default: DefaultBalancePollInterval)
+	BalancePollInterval time.Duration
+
+	// RateLimits are the client-side rate limits applied to REST requests
+	// (default: DefaultRateLimits()). Requests that would exceed them are
+	// queued, or rejected if their context deadline would pass first.
+	RateLimits ratelimit.Config
+
+	// DisableRateLimit sends REST requests without client-side rate limiting.
+	DisableRateLimit bool
+
+	// ClockSyncInterval is the delay between server time syncs made by
+	// Clock().Run (default: DefaultClockSyncInterval). Fordefi has no server
+	// time endpoint, so the time is read from a response's Date header.
+	//
+	// The client does not sync on its own: run the clock for the lifetime of
+	// the client with go c.Clock().Run(ctx). Otherwise requests are signed
+	// with the local clock until Fordefi first rejects one for clock skew, which
+	// triggers a one-off resync.
+	ClockSyncInterval time.Duration
+
+	// DisableClockSync signs requests with the local clock, uncorrected for
+	// skew, and returns requests rejected with venueerr.ErrClockSkew without
+	// resyncing and retrying them.
+	DisableClockSync bool
+}
+
+// Validate checks that the configuration has the fields required to connect.
+func (c Config) Validate() error {
+	switch {
+	case c.Credentials != nil:
+		if c.APIKey != "" || c.PrivateKey != "" {
+			return fmt.Errorf("static credentials and credential provider are mutually exclusive")
+		}
+	case c.APIKey == "":
+		return fmt.Errorf("API key is required")
+	case c.PrivateKey == "" && c.SignerFunc == nil:
+		return fmt.Errorf("private key or signer function is required")
+	case c.PrivateKey != "" && c.SignerFunc != nil:
+		return fmt.Errorf("private key and signer function are mutually exclusive")
+	}
+	if c.VaultID == "" {
+		return fmt.Errorf("vault ID is required")
+	}
+	if c.ApprovalTimeout < 0 {
+		return fmt.Errorf("approval timeout must be non-negative")
+	}
+	if c.ApprovalPollInterval < 0 {
+		return fmt.Errorf("approval poll interval must be non-negative")
+	}
+	if c.BalancePollInterval < 0 {
+		return fmt.Errorf("balance poll interval must be non-negative")
+	}
+	for symbol, asset := range c.Assets {
+		if asset.Chain == "" {
+			return fmt.Errorf("asset %s: chain is required", symbol)
+		}
+		switch asset.Type {
+		case AssetTypeNative:
+		case AssetTypeERC20:
+			if asset.Contract == "" {
+				return fmt.Errorf("asset %s: contract is required for erc20 assets", symbol)
+			}
+		default:
+			return fmt.Errorf("asset %s: unsupported type %q", symbol, asset.Type)
+		}
+		if asset.Decimals < 0 {
+			return fmt.Errorf("asset %s: decimals must be non-negative", symbol)
+		}
+	}
+	if c.ClockSyncInterval < 0 {
+		return fmt.Errorf("clock sync interval must be non-negative")
+	}
+	if !c.RateLimits.IsZero() {
+		if err := c.RateLimits.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limits: %w", err)
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the config with default values applied.
+// Asset symbols are upper-cased.
+func (c Config) withDefaults() Config {
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultBaseURL
+	}
+	if c.BalanceCurrency == "" {
+		c.BalanceCurrency = DefaultBalanceCurrency
+	}
+	if c.ApprovalPollInterval == 0 {
+		c.ApprovalPollInterval = DefaultApprovalPollInterval
+	}
+	if c.BalancePollInterval == 0 {
+		c.BalancePollInterval = DefaultBalancePollInterval
+	}
+	assets := make(map[string]Asset, len(c.Assets))
+	for symbol, asset := range c.Assets {
+		assets[strings.ToUpper(symbol)] = asset
+	}
+	c.Assets = assets
+	if c.ClockSyncInterval == 0 {
+		c.ClockSyncInterval = DefaultClockSyncInterval
+	}
+	if c.RateLimits.IsZero() {
+		c.RateLimits = DefaultRateLimits()
+	}
+	if c.RateLimits.Venue == "" {
+		c.RateLimits.Venue = VenueID
+	}
+	return c
+}
+
+// DefaultRateLimits returns the Fordefi REST limits: 10 requests per second
+// per API user.
+func DefaultRateLimits() ratelimit.Config {
+	return ratelimit.Config{
+		Venue:   VenueID,
+		Buckets: []ratelimit.Bucket{{Name: "rest", Rate: 10}},
+		Rules:   []ratelimit.Rule{{Buckets: []string{"rest"}}},
+	}
+}
@@ -0,0 +1,17 @@
+package fordefi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Health checks that the Fordefi API is reachable, the request signature is
+// accepted and the configured vault is visible to the API user.
+func (c *Client) Health(ctx context.Context) error {
+	if _, err := c.do(ctx, http.MethodGet, "/api/v1/vaults/"+url.PathEscape(c.config.VaultID), nil, nil, nil); err != nil {
+		return fmt.Errorf("fordefi health check failed: %w", err)
+	}
+	return nil
+}
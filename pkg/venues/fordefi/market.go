@@ -0,0 +1,25 @@
+package fordefi
+
+import (
+	"context"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+)
+
+// GetOrderBook is not supported: Fordefi is a custody platform and publishes
+// no market data.
+func (c *Client) GetOrderBook(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetOrderBook"}
+}
+
+// GetInstruments is not supported: Fordefi is a custody platform and lists no
+// tradable instruments.
+func (c *Client) GetInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetInstruments"}
+}
+
+// GetInstrument is not supported; see GetInstruments.
+func (c *Client) GetInstrument(ctx context.Context, symbol string) (*client.Instrument, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetInstrument"}
+}
@@ -0,0 +1,463 @@
+package fordefi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/idgen"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	fdnorm "github.com/Combine-Capital/cqvx/internal/normalizer/fordefi"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// Transaction types accepted by CreateTransaction.
+const (
+	TransactionTypeEVM    = "evm_transaction"
+	TransactionTypeSolana = "solana_transaction"
+)
+
+// TransactionRequest describes a transaction to create in a Fordefi vault.
+// Details carries the chain-specific transaction body verbatim, so any
+// transaction Fordefi supports can be created through CreateTransaction.
+type TransactionRequest struct {
+	// VaultID is the source vault (default: Config.VaultID)
+	VaultID string
+
+	// Type is the Fordefi transaction type (e.g., TransactionTypeEVM)
+	Type string
+
+	// Details is the chain-specific "details" object of the request body
+	Details json.RawMessage
+
+	// Note is an optional free-text note shown to approvers
+	Note string
+
+	// IdempotenceID deduplicates retried requests; a random ID is generated if empty
+	IdempotenceID string
+}
+
+// createTransactionRequest is the request body for POST /api/v1/transactions.
+type createTransactionRequest struct {
+	VaultID    string          `json:"vault_id"`
+	SignerType string          `json:"signer_type"`
+	Type       string          `json:"type"`
+	Note       string          `json:"note,omitempty"`
+	Details    json.RawMessage `json:"details"`
+}
+
+// evmTransferDetails is the "details" object of an EVM transfer.
+type evmTransferDetails struct {
+	Type            string          `json:"type"`
+	To              string          `json:"to"`
+	Value           transferValue   `json:"value"`
+	AssetIdentifier assetIdentifier `json:"asset_identifier"`
+}
+
+type transferValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type assetIdentifier struct {
+	Type    string       `json:"type"`
+	Details assetDetails `json:"details"`
+}
+
+type assetDetails struct {
+	Type  string     `json:"type"`
+	Chain string     `json:"chain,omitempty"`
+	Token *tokenInfo `json:"token,omitempty"`
+}
+
+type tokenInfo struct {
+	Chain   string `json:"chain"`
+	HexRepr string `json:"hex_repr"`
+}
+
+// transactionsResponse is the response body of GET /api/v1/transactions.
+type transactionsResponse struct {
+	Transactions []json.RawMessage `json:"transactions"`
+	Total        int               `json:"total"`
+	Page         int               `json:"page"`
+	Size         int               `json:"size"`
+}
+
+// PlaceOrder creates an outgoing transfer from the configured vault.
+//
+// The order is interpreted as a custody movement rather than a trade:
+//   - VenueSymbol is the asset symbol, which must be present in Config.Assets
+//   - AccountId is the destination address
+//   - Quantity is the amount in asset units, converted exactly to the
+//     asset's smallest unit
+//   - Side must be SELL (assets leave the vault); OrderType must be MARKET or unset
+//   - ClientOrderId is sent as the idempotence ID; if empty a random one is
+//     generated for each call, so identical transfers are not deduplicated
+//
+// The transaction enters Fordefi's approval workflow. By default PlaceOrder
+// returns as soon as it is created (typically "pending_approval"); with
+// Config.ApprovalTimeout set it polls until the transaction leaves the
+// approval stage, the timeout elapses, or it fails.
+func (c *Client) PlaceOrder(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error) {
+	req, err := c.buildTransferRequest(order)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := c.CreateTransaction(ctx, *req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.config.ApprovalTimeout > 0 {
+		return c.awaitApproval(ctx, report)
+	}
+	return report, nil
+}
+
+// CreateTransaction creates a transaction in a Fordefi vault and returns the
+// initial execution report. The report's OrderStatus carries the raw Fordefi
+// state (e.g., "pending_approval"); poll GetOrder to follow the approval workflow.
+func (c *Client) CreateTransaction(ctx context.Context, req TransactionRequest) (*venuesv1.ExecutionReport, error) {
+	if req.Type == "" {
+		return nil, fmt.Errorf("transaction type is required")
+	}
+	if len(req.Details) == 0 {
+		return nil, fmt.Errorf("transaction details are required")
+	}
+	if req.VaultID == "" {
+		req.VaultID = c.config.VaultID
+	}
+	if req.IdempotenceID == "" {
+		id, err := idgen.NewUUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client order ID: %w", err)
+		}
+		req.IdempotenceID = id
+	}
+
+	raw, err := c.do(ctx, http.MethodPost, "/api/v1/transactions", nil, createTransactionRequest{
+		VaultID:    req.VaultID,
+		SignerType: "api_signer",
+		Type:       req.Type,
+		Note:       req.Note,
+		Details:    req.Details,
+	}, map[string]string{"x-idempotence-id": req.IdempotenceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fordefi transaction: %w", err)
+	}
+
+	report, err := fdnorm.NormalizeExecutionReport(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	venueId := VenueID
+	report.VenueId = &venueId
+	if report.GetClientOrderId() == "" {
+		report.ClientOrderId = &req.IdempotenceID
+	}
+	if report.GetAccountId() == "" {
+		report.AccountId = &req.VaultID
+	}
+
+	c.logger.InfoContext(ctx, "fordefi transaction created",
+		"transaction_id", report.GetVenueOrderId(), "vault_id", req.VaultID, "state", report.GetOrderStatus())
+
+	return report, nil
+}
+
+// awaitApproval polls a transaction until it leaves the PENDING stage of the
+// approval workflow or ApprovalTimeout elapses. A timeout is not an error:
+// the latest report is returned and the transaction remains pending.
+func (c *Client) awaitApproval(ctx context.Context, report *venuesv1.ExecutionReport) (*venuesv1.ExecutionReport, error) {
+	transactionID := report.GetVenueOrderId()
+	if fdnorm.MapTransactionState(report.GetOrderStatus()) != venuesv1.OrderStatus_ORDER_STATUS_PENDING {
+		return report, nil
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, c.config.ApprovalTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(c.config.ApprovalPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pollCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.InfoContext(ctx, "fordefi transaction still awaiting approval",
+				"transaction_id", transactionID, "state", report.GetOrderStatus())
+			return report, nil
+		case <-ticker.C:
+		}
+
+		raw, err := c.do(pollCtx, http.MethodGet, "/api/v1/transactions/"+url.PathEscape(transactionID), nil, nil, nil)
+		if err != nil {
+			if pollCtx.Err() != nil {
+				continue
+			}
+			if venueerr.IsRetryable(err) {
+				c.logger.DebugContext(ctx, "fordefi approval poll failed", "transaction_id", transactionID, "error", err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to poll fordefi transaction %s: %w", transactionID, err)
+		}
+
+		latest, err := fdnorm.NormalizeExecutionReport(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+		venueId := VenueID
+		latest.VenueId = &venueId
+		if latest.GetClientOrderId() == "" {
+			latest.ClientOrderId = report.ClientOrderId
+		}
+		report = latest
+
+		if fdnorm.MapTransactionState(report.GetOrderStatus()) != venuesv1.OrderStatus_ORDER_STATUS_PENDING {
+			return report, nil
+		}
+	}
+}
+
+// CancelOrder aborts a transaction that has not yet been signed.
+// Fordefi rejects the abort once the transaction is signed or broadcast.
+func (c *Client) CancelOrder(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	path := "/api/v1/transactions/" + url.PathEscape(orderID) + "/abort"
+	if _, err := c.do(ctx, http.MethodPost, path, nil, nil, nil); err != nil {
+		return nil, fmt.Errorf("failed to abort fordefi transaction %s: %w", orderID, err)
+	}
+
+	status := venuesv1.OrderStatus_ORDER_STATUS_CANCELLED
+	return &status, nil
+}
+
+// PlaceOrders creates several transfers. Fordefi has no batch transaction
+// endpoint, so transfers are created with concurrent PlaceOrder calls (at most
+// client.DefaultBatchConcurrency at a time) and are not atomic. Each transfer
+// runs through the approval workflow independently.
+func (c *Client) PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error) {
+	return client.PlaceOrdersConcurrently(ctx, orders, client.DefaultBatchConcurrency, c.PlaceOrder), nil
+}
+
+// CancelOrders aborts several transactions. Fordefi has no batch abort
+// endpoint, so transactions are aborted with concurrent CancelOrder calls (at
+// most client.DefaultBatchConcurrency at a time).
+func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	return client.CancelOrdersConcurrently(ctx, orderIDs, client.DefaultBatchConcurrency, c.CancelOrder), nil
+}
+
+// CancelAllOrders aborts every transaction in the vault matching the filter.
+// When the filter sets no statuses, PENDING transactions (created, queued or
+// awaiting approval) are targeted, since those are the ones Fordefi can still
+// abort. Matching transactions are listed with GetOrders and aborted with
+// CancelOrders.
+func (c *Client) CancelAllOrders(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error) {
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_PENDING}
+	}
+
+	orders, err := c.GetOrders(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions to abort: %w", err)
+	}
+	return c.CancelOrders(ctx, client.OrderIDs(orders))
+}
+
+// AmendOrder is not supported: Fordefi transactions cannot be modified once
+// created. Abort the transaction with CancelOrder and place a new one instead.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "AmendOrder"}
+}
+
+// GetOrder retrieves a transaction by its Fordefi transaction ID, reported as an Order.
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, "/api/v1/transactions/"+url.PathEscape(orderID), nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := fdnorm.NormalizeOrder(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	venueId := VenueID
+	order.VenueId = &venueId
+	return order, nil
+}
+
+// GetOrders lists transactions of the configured vault, reported as Orders.
+//
+// Statuses are translated to Fordefi states (see fdnorm.StatesForStatus) and
+// the time range is applied to the creation time, both server-side. Symbols
+// are not supported since transactions are not tied to a trading pair.
+// Offset and limit are applied client-side.
+func (c *Client) GetOrders(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order filter: %w", err)
+	}
+	if len(filter.Symbols) > 0 {
+		return nil, fmt.Errorf("fordefi transactions cannot be filtered by symbol")
+	}
+
+	query := url.Values{}
+	query.Set("vault_ids", c.config.VaultID)
+	for _, status := range filter.Statuses {
+		for _, state := range fdnorm.StatesForStatus(status) {
+			query.Add("states", state)
+		}
+	}
+	if len(filter.Statuses) > 0 && len(query["states"]) == 0 {
+		return []*venuesv1.Order{}, nil
+	}
+	if !filter.StartTime.IsZero() {
+		query.Set("created_after", filter.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !filter.EndTime.IsZero() {
+		query.Set("created_before", filter.EndTime.UTC().Format(time.RFC3339))
+	}
+	query.Set("size", strconv.Itoa(DefaultPageSize))
+
+	want := 0
+	if filter.Limit > 0 {
+		want = filter.Offset + filter.Limit
+	}
+
+	var orders []*venuesv1.Order
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		raw, err := c.do(ctx, http.MethodGet, "/api/v1/transactions", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp transactionsResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse fordefi transactions response: %w", err)
+		}
+
+		for _, rawTx := range resp.Transactions {
+			order, err := fdnorm.NormalizeOrder(ctx, rawTx)
+			if err != nil {
+				return nil, err
+			}
+			venueId := VenueID
+			order.VenueId = &venueId
+			orders = append(orders, order)
+		}
+
+		if len(resp.Transactions) == 0 || page*DefaultPageSize >= resp.Total {
+			break
+		}
+		if want > 0 && len(orders) >= want {
+			break
+		}
+	}
+
+	if filter.Offset >= len(orders) {
+		return []*venuesv1.Order{}, nil
+	}
+	orders = orders[filter.Offset:]
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
+	return orders, nil
+}
+
+// GetFills is not supported: Fordefi is a custody venue and its transfers
+// are not trades, so there are no fills with fees or liquidity to report.
+func (c *Client) GetFills(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetFills"}
+}
+
+// buildTransferRequest validates a CQC order and converts it into an EVM
+// transfer from the configured vault.
+func (c *Client) buildTransferRequest(order *venuesv1.Order) (*TransactionRequest, error) {
+	if order == nil {
+		return nil, fmt.Errorf("order is required")
+	}
+
+	symbol := strings.ToUpper(order.GetVenueSymbol())
+	if symbol == "" {
+		return nil, fmt.Errorf("order venue symbol is required")
+	}
+	asset, ok := c.config.Assets[symbol]
+	if !ok {
+		return nil, fmt.Errorf("unknown fordefi asset %q: add it to Config.Assets", symbol)
+	}
+	if order.GetAccountId() == "" {
+		return nil, fmt.Errorf("order account ID (destination address) is required")
+	}
+	if order.GetQuantity() <= 0 {
+		return nil, fmt.Errorf("order quantity must be positive")
+	}
+	if order.GetSide() != venuesv1.OrderSide_ORDER_SIDE_SELL {
+		return nil, fmt.Errorf("fordefi transfers require SELL side (outgoing)")
+	}
+	switch order.GetOrderType() {
+	case venuesv1.OrderType_ORDER_TYPE_UNSPECIFIED, venuesv1.OrderType_ORDER_TYPE_MARKET:
+	default:
+		return nil, fmt.Errorf("unsupported fordefi order type: %s (transfers support MARKET)", order.GetOrderType())
+	}
+
+	units, err := fdnorm.ToBaseUnits(normalizer.FormatDecimal(order.GetQuantity()), asset.Decimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s quantity: %w", symbol, err)
+	}
+
+	details := evmTransferDetails{
+		Type:  "evm_transfer",
+		To:    order.GetAccountId(),
+		Value: transferValue{Type: "value", Value: units},
+		AssetIdentifier: assetIdentifier{
+			Type:    "evm",
+			Details: assetDetails{Type: asset.Type, Chain: asset.Chain},
+		},
+	}
+	if asset.Type == AssetTypeERC20 {
+		details.AssetIdentifier.Details = assetDetails{
+			Type:  AssetTypeERC20,
+			Token: &tokenInfo{Chain: asset.Chain, HexRepr: asset.Contract},
+		}
+	}
+
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transfer details: %w", err)
+	}
+
+	idempotenceID := order.GetClientOrderId()
+	if idempotenceID == "" {
+		idempotenceID, err = idgen.NewUUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client order ID: %w", err)
+		}
+	}
+
+	return &TransactionRequest{
+		VaultID:       c.config.VaultID,
+		Type:          TransactionTypeEVM,
+		Details:       raw,
+		Note:          order.GetNotes(),
+		IdempotenceID: idempotenceID,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package prime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	primenorm "github.com/Combine-Capital/cqvx/internal/normalizer/prime"
+)
+
+// balancesResponse is the response from GET /v1/portfolios/{portfolio_id}/balances.
+type balancesResponse struct {
+	Balances []json.RawMessage `json:"balances"`
+	Type     string            `json:"type"`
+}
+
+// walletBalanceResponse is the response from GET /v1/portfolios/{portfolio_id}/wallets/{wallet_id}/balance.
+type walletBalanceResponse struct {
+	Balance json.RawMessage `json:"balance"`
+}
+
+// GetBalance returns the portfolio balance of the configured BalanceCurrency (default "USD").
+//
+// Prime reports one balance per asset, so a single Balance cannot describe the
+// whole portfolio; use GetBalances for the full set. If the portfolio holds
+// none of BalanceCurrency, a zero balance for that currency is returned.
+func (c *Client) GetBalance(ctx context.Context) (*venuesv1.Balance, error) {
+	balances, err := c.GetBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		if strings.EqualFold(balance.GetAssetId(), c.config.BalanceCurrency) {
+			return balance, nil
+		}
+	}
+
+	venueId := VenueID
+	accountId := c.config.PortfolioID
+	asset := c.config.BalanceCurrency
+	var zero float64
+	return &venuesv1.Balance{
+		VenueId:   &venueId,
+		AccountId: &accountId,
+		AssetId:   &asset,
+		Total:     &zero,
+		Available: &zero,
+		Locked:    &zero,
+	}, nil
+}
+
+// GetBalances returns the trading balance of every asset in the portfolio.
+// Each balance's AccountId is the portfolio ID.
+func (c *Client) GetBalances(ctx context.Context) ([]*venuesv1.Balance, error) {
+	query := url.Values{}
+	query.Set("balance_type", "TRADING_BALANCES")
+
+	raw, err := c.do(ctx, http.MethodGet, c.portfolioPath("/balances"), query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp balancesResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime balances response: %w", err)
+	}
+
+	balances := make([]*venuesv1.Balance, 0, len(resp.Balances))
+	for _, rawBalance := range resp.Balances {
+		balance, err := primenorm.NormalizeBalance(ctx, rawBalance)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, c.stampBalance(balance, c.config.PortfolioID))
+	}
+
+	return balances, nil
+}
+
+// GetWalletBalance returns the balance of a single wallet in the portfolio.
+// The balance's AccountId is the wallet ID.
+func (c *Client) GetWalletBalance(ctx context.Context, walletID string) (*venuesv1.Balance, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, c.portfolioPath("/wallets/"+url.PathEscape(walletID)+"/balance"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp walletBalanceResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime wallet balance response: %w", err)
+	}
+
+	balance, err := primenorm.NormalizeWalletBalance(ctx, resp.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return c.stampBalance(balance, walletID), nil
+}
+
+// stampBalance sets the venue ID, and the account ID if the venue omitted it.
+func (c *Client) stampBalance(balance *venuesv1.Balance, accountID string) *venuesv1.Balance {
+	venueId := VenueID
+	balance.VenueId = &venueId
+	if balance.GetAccountId() == "" {
+		balance.AccountId = &accountID
+	}
+	return balance
+}
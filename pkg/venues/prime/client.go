@@ -0,0 +1,225 @@
+// Package prime implements the VenueClient interface for Coinbase Prime.
+//
+// Requests are authenticated with ES256 JWTs via auth.Middleware and scoped to
+// the configured portfolio. All responses are normalized to CQC types by
+// internal/normalizer/prime.
+package prime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	primenorm "github.com/Combine-Capital/cqvx/internal/normalizer/prime"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+)
+
+// Ensure Client implements the VenueClient interface at compile time
+var _ client.VenueClient = (*Client)(nil)
+
+// Client is a Coinbase Prime venue client scoped to a single portfolio.
+//
+// Thread-safe: All methods can be called concurrently.
+type Client struct {
+	config      Config
+	signer      *auth.JWTSigner
+	httpClient  *http.Client
+	wsDialer    stream.Dialer
+	logger      *slog.Logger
+	instruments *client.InstrumentCache
+	limiter     *ratelimit.Limiter
+	clock       *client.ServerClock
+}
+
+// NewClient creates a new Coinbase Prime client.
+//
+// The httpClient is used for all REST calls; its transport is wrapped with JWT
+// authentication. The caller's client is not modified. If httpClient is nil,
+// http.DefaultClient is used.
+//
+// The wsDialer is used for streaming subscriptions. If wsDialer is nil,
+// stream.NewWebSocketDialer() is used. If logger is nil, logging is disabled.
+func NewClient(config Config, httpClient *http.Client, wsDialer stream.Dialer, logger *slog.Logger) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid prime config: %w", err)
+	}
+	config = config.withDefaults()
+
+	var clock *client.ServerClock
+	var now func() time.Time
+	if !config.DisableClockSync {
+		clock = &client.ServerClock{Interval: config.ClockSyncInterval}
+		now = clock.Now
+	}
+
+	var c *Client
+	signer, err := auth.NewJWTSigner(auth.JWTConfig{
+		KeyName:       config.KeyName,
+		PrivateKey:    config.PrivateKey,
+		ExpiresIn:     config.TokenExpiresIn,
+		CacheTokens:   config.CacheTokens,
+		RefreshMargin: config.TokenRefreshMargin,
+		Now:           now,
+		Credentials:   config.Credentials,
+		OnCredentialError: func(err error) {
+			c.logger.Warn("prime credential rotation failed", "error", err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prime signer: %w", err)
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	signed := *httpClient
+	var limiter *ratelimit.Limiter
+	if !config.DisableRateLimit {
+		limiter, err = ratelimit.New(config.RateLimits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prime rate limiter: %w", err)
+		}
+	}
+	signed.Transport = ratelimit.Middleware(limiter, auth.Middleware(signer, httpClient.Transport))
+
+	if wsDialer == nil {
+		wsDialer = stream.NewWebSocketDialer()
+	}
+
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	c = &Client{
+		config:     config,
+		signer:     signer,
+		httpClient: &signed,
+		limiter:    limiter,
+		clock:      clock,
+		wsDialer:   wsDialer,
+		logger:     logger.With("venue", VenueID, "portfolio_id", config.PortfolioID),
+	}
+	c.instruments = &client.InstrumentCache{
+		Fetch:    c.fetchInstruments,
+		Interval: config.InstrumentRefreshInterval,
+		OnError: func(err error) {
+			c.logger.Warn("prime instrument refresh failed", "error", err)
+		},
+	}
+	if clock != nil {
+		clock.Fetch = client.DateHeaderTime(httpClient, config.BaseURL)
+		clock.OnError = func(err error) {
+			c.logger.Warn("prime clock sync failed", "error", err)
+		}
+	}
+	return c, nil
+}
+
+// RateLimiter returns the client-side rate limiter applied to REST requests,
+// for reading its utilization, or nil if Config.DisableRateLimit is set.
+func (c *Client) RateLimiter() *ratelimit.Limiter {
+	return c.limiter
+}
+
+// ActiveKeyID returns the API key name requests are currently signed with.
+// It changes when Config.Credentials rotates the credentials.
+func (c *Client) ActiveKeyID() string {
+	return c.signer.ActiveKeyID()
+}
+
+// Clock returns the clock whose offset from Prime's time corrects request
+// timestamps, or nil if Config.DisableClockSync is set. It is resynced when
+// Prime rejects a request for clock skew. The client never runs it:
+// callers that want it kept synced in the background start Run themselves,
+// e.g. go c.Clock().Run(ctx), and cancel ctx when done with the client.
+func (c *Client) Clock() *client.ServerClock {
+	return c.clock
+}
+
+// portfolioPath returns the path of a portfolio-scoped endpoint.
+func (c *Client) portfolioPath(path string) string {
+	return "/v1/portfolios/" + url.PathEscape(c.config.PortfolioID) + path
+}
+
+// do performs an authenticated REST request against the Prime API and returns
+// the raw response body.
+//
+// Non-2xx responses are converted to classified errors by primenorm.NormalizeError.
+// A request rejected with venueerr.ErrClockSkew is retried once after
+// resyncing the client's clock, unless Config.DisableClockSync is set.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}) ([]byte, error) {
+	signedAt := time.Now()
+	respBody, err := c.send(ctx, method, path, query, body)
+	if err == nil || c.clock == nil || !errors.Is(err, venueerr.ErrClockSkew) {
+		return respBody, err
+	}
+
+	// Prime rejected the request timestamp: correct the clock and retry once
+	if syncErr := c.clock.Resync(ctx, signedAt); syncErr != nil {
+		c.logger.WarnContext(ctx, "prime clock resync failed", "error", syncErr)
+		return nil, err
+	}
+	c.logger.InfoContext(ctx, "prime clock resynced after skew rejection",
+		"offset", c.clock.Offset())
+	return c.send(ctx, method, path, query, body)
+}
+
+// send performs a single signed request for do, without clock skew recovery.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}) ([]byte, error) {
+	endpoint := strings.TrimRight(c.config.BaseURL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// The JWT uri claim is bound to the request host, which the signer reads
+	// from the Host header. The transport always sends req.Host on the wire.
+	req.Header.Set("Host", req.URL.Host)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("prime request %s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prime response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.DebugContext(ctx, "prime request failed",
+			"method", method, "path", path, "status", resp.StatusCode)
+		return nil, primenorm.NormalizeError(resp, respBody)
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,1080 @@
+package prime_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"github.com/Combine-Capital/cqvx/pkg/venues/prime"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testPortfolioID = "portfolio-123"
+	testKeyName     = "organizations/org-1/apiKeys/key-1"
+	portfolioPrefix = "/v1/portfolios/" + testPortfolioID
+)
+
+// recordedRequest captures a request received by the test server.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// testServer serves recorded Prime payloads and verifies request JWTs.
+type testServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	key      *ecdsa.PrivateKey
+	keyPEM   string
+	routes   map[string]http.HandlerFunc
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	ts := &testServer{t: t, key: key, keyPEM: string(keyPEM), routes: make(map[string]http.HandlerFunc)}
+	ts.server = httptest.NewServer(http.HandlerFunc(ts.serveHTTP))
+	t.Cleanup(ts.server.Close)
+	return ts
+}
+
+func (ts *testServer) handle(method, path string, handler http.HandlerFunc) {
+	ts.routes[method+" "+portfolioPrefix+path] = handler
+}
+
+func (ts *testServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(ts.t, err)
+
+	ts.mu.Lock()
+	ts.requests = append(ts.requests, recordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	ts.mu.Unlock()
+
+	// Verify the JWT is signed by the configured key and bound to this request
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	assert.True(ts.t, ok, "missing bearer token")
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return &ts.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	if assert.NoError(ts.t, err) {
+		claims := token.Claims.(jwt.MapClaims)
+		assert.Equal(ts.t, testKeyName, claims["sub"])
+		assert.Equal(ts.t, r.Method+" "+r.Host+r.URL.Path, claims["uri"])
+	}
+
+	handler, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+func (ts *testServer) config() prime.Config {
+	return prime.Config{
+		PortfolioID: testPortfolioID,
+		KeyName:     testKeyName,
+		PrivateKey:  ts.keyPEM,
+		BaseURL:     ts.server.URL,
+	}
+}
+
+func (ts *testServer) client(t *testing.T) *prime.Client {
+	t.Helper()
+	c, err := prime.NewClient(ts.config(), ts.server.Client(), nil, nil)
+	require.NoError(t, err)
+	return c
+}
+
+// tradingClient returns a client whose instrument cache holds the products
+// fixtures, as PlaceOrder validates orders against it, and forgets the
+// products requests.
+func (ts *testServer) tradingClient(t *testing.T) *prime.Client {
+	t.Helper()
+	ts.handle(http.MethodGet, "/products", serveFiles(t, "products_page1.json", "products_page2.json"))
+	c := ts.client(t)
+	_, err := c.GetInstruments(context.Background())
+	require.NoError(t, err)
+
+	ts.mu.Lock()
+	ts.requests = nil
+	ts.mu.Unlock()
+	return c
+}
+
+// serveFile returns a handler that writes a testdata fixture with the given status.
+func serveFile(t *testing.T, status int, name string) http.HandlerFunc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	}
+}
+
+// serveFiles returns a handler that serves fixtures in order, one per request.
+func serveFiles(t *testing.T, names ...string) http.HandlerFunc {
+	t.Helper()
+	handlers := make([]http.HandlerFunc, len(names))
+	for i, name := range names {
+		handlers[i] = serveFile(t, http.StatusOK, name)
+	}
+	next := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.Less(t, next, len(handlers), "unexpected extra request")
+		handlers[next](w, r)
+		next++
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		mutate  func(*prime.Config)
+		wantErr bool
+	}{
+		{name: "valid config", mutate: func(*prime.Config) {}},
+		{name: "missing portfolio ID", mutate: func(c *prime.Config) { c.PortfolioID = "" }, wantErr: true},
+		{name: "missing key name", mutate: func(c *prime.Config) { c.KeyName = "" }, wantErr: true},
+		{name: "missing private key", mutate: func(c *prime.Config) { c.PrivateKey = "" }, wantErr: true},
+		{name: "invalid private key", mutate: func(c *prime.Config) { c.PrivateKey = "not a pem key" }, wantErr: true},
+		{name: "negative token expiry", mutate: func(c *prime.Config) { c.TokenExpiresIn = -1 }, wantErr: true},
+		{name: "credential provider", mutate: func(c *prime.Config) {
+			c.Credentials = client.StaticCredentials{KeyID: c.KeyName, Secret: c.PrivateKey}
+			c.KeyName, c.PrivateKey = "", ""
+		}},
+		{name: "credential provider with static credentials", mutate: func(c *prime.Config) {
+			c.Credentials = client.StaticCredentials{KeyID: c.KeyName, Secret: c.PrivateKey}
+		}, wantErr: true},
+		{name: "token cache", mutate: func(c *prime.Config) { c.CacheTokens = true }},
+		{name: "token refresh margin not below expiry", mutate: func(c *prime.Config) {
+			c.CacheTokens = true
+			c.TokenExpiresIn = 60
+			c.TokenRefreshMargin = 60
+		}, wantErr: true},
+		{name: "negative backoff", mutate: func(c *prime.Config) { c.ReconnectMinBackoff = -time.Second }, wantErr: true},
+		{name: "min backoff above max", mutate: func(c *prime.Config) {
+			c.ReconnectMinBackoff = time.Minute
+			c.ReconnectMaxBackoff = time.Second
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ts.config()
+			tt.mutate(&config)
+			c, err := prime.NewClient(config, nil, nil, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, c)
+		})
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC)
+	startTime := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		order    *venuesv1.Order
+		opts     prime.OrderOptions
+		wantBody map[string]interface{}
+	}{
+		{
+			name: "market order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("BTC-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+				OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+				Quantity:      floatPtr(0.25),
+			},
+			wantBody: map[string]interface{}{
+				"type":          "MARKET",
+				"side":          "SELL",
+				"base_quantity": "0.25",
+			},
+		},
+		{
+			name: "limit IOC order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("BTC-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+				OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+				TimeInForce:   tifPtr(venuesv1.TimeInForce_TIME_IN_FORCE_IOC),
+				Quantity:      floatPtr(1.5),
+				Price:         floatPtr(50000),
+			},
+			wantBody: map[string]interface{}{
+				"type":          "LIMIT",
+				"side":          "BUY",
+				"base_quantity": "1.5",
+				"limit_price":   "50000",
+				"time_in_force": "IMMEDIATE_OR_CANCEL",
+			},
+		},
+		{
+			name: "post-only iceberg order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("BTC-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+				OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_POST_ONLY),
+				Quantity:      floatPtr(5),
+				Price:         floatPtr(49000),
+			},
+			opts: prime.OrderOptions{DisplaySize: 0.5},
+			wantBody: map[string]interface{}{
+				"type":              "LIMIT",
+				"side":              "BUY",
+				"base_quantity":     "5",
+				"limit_price":       "49000",
+				"time_in_force":     "GOOD_UNTIL_CANCELLED",
+				"display_base_size": "0.5",
+				"post_only":         true,
+			},
+		},
+		{
+			name: "stop limit order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("BTC-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+				OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT),
+				Quantity:      floatPtr(1),
+				Price:         floatPtr(44000),
+				StopPrice:     floatPtr(45000),
+			},
+			wantBody: map[string]interface{}{
+				"type":          "STOP_LIMIT",
+				"side":          "SELL",
+				"base_quantity": "1",
+				"limit_price":   "44000",
+				"stop_price":    "45000",
+				"time_in_force": "GOOD_UNTIL_CANCELLED",
+			},
+		},
+		{
+			name: "TWAP order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("ETH-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+				Quantity:      floatPtr(10),
+				Price:         floatPtr(3100),
+				ExpiresAt:     timestamppb.New(expiresAt),
+			},
+			opts: prime.OrderOptions{Type: prime.OrderTypeTWAP, StartTime: startTime},
+			wantBody: map[string]interface{}{
+				"type":          "TWAP",
+				"side":          "SELL",
+				"base_quantity": "10",
+				"limit_price":   "3100",
+				"start_time":    "2024-01-15T11:00:00Z",
+				"expiry_time":   "2024-01-15T15:00:00Z",
+				"time_in_force": "GOOD_UNTIL_DATE_TIME",
+			},
+		},
+		{
+			name: "VWAP order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("ETH-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+				Quantity:      floatPtr(10),
+				ExpiresAt:     timestamppb.New(expiresAt),
+			},
+			opts: prime.OrderOptions{Type: prime.OrderTypeVWAP, HistoricalPOV: 12.5},
+			wantBody: map[string]interface{}{
+				"type":           "VWAP",
+				"side":           "BUY",
+				"base_quantity":  "10",
+				"expiry_time":    "2024-01-15T15:00:00Z",
+				"time_in_force":  "GOOD_UNTIL_DATE_TIME",
+				"historical_pov": "12.5",
+			},
+		},
+		{
+			name: "block order",
+			order: &venuesv1.Order{
+				ClientOrderId: strPtr("cqvx-test-0001"),
+				VenueSymbol:   strPtr("BTC-USD"),
+				Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+				Quantity:      floatPtr(100),
+				Price:         floatPtr(49800),
+			},
+			opts: prime.OrderOptions{Type: prime.OrderTypeBlock},
+			wantBody: map[string]interface{}{
+				"type":          "BLOCK",
+				"side":          "BUY",
+				"base_quantity": "100",
+				"limit_price":   "49800",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.handle(http.MethodPost, "/order", serveFile(t, http.StatusOK, "create_order.json"))
+			c := ts.tradingClient(t)
+
+			report, err := c.PlaceOrderWithOptions(ctx, tt.order, tt.opts)
+			require.NoError(t, err)
+
+			assert.Equal(t, "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b", report.GetVenueOrderId())
+			assert.Equal(t, "cqvx-test-0001", report.GetClientOrderId())
+			assert.Equal(t, "prime", report.GetVenueId())
+			assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+			assert.Equal(t, "PENDING", report.GetOrderStatus())
+			assert.Equal(t, tt.wantBody["type"], report.GetOrderType())
+
+			require.Len(t, ts.requests, 1)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+
+			want := map[string]interface{}{
+				"portfolio_id":    testPortfolioID,
+				"product_id":      tt.order.GetVenueSymbol(),
+				"client_order_id": "cqvx-test-0001",
+			}
+			for k, v := range tt.wantBody {
+				want[k] = v
+			}
+			assert.Equal(t, want, body)
+		})
+	}
+
+	t.Run("generates client order ID", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/order", serveFile(t, http.StatusOK, "create_order.json"))
+		c := ts.tradingClient(t)
+
+		report, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			Quantity:    floatPtr(0.5),
+		})
+		require.NoError(t, err)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(ts.requests[0].Body, &body))
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, body["client_order_id"])
+		assert.Equal(t, body["client_order_id"], report.GetClientOrderId())
+	})
+
+	t.Run("recovers order after timeout", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/order", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGatewayTimeout)
+		})
+		ts.handle(http.MethodGet, "/open_orders", serveFiles(t, "open_orders_page1.json", "open_orders_page2.json"))
+		ts.handle(http.MethodGet, "/orders", serveFile(t, http.StatusOK, "orders.json"))
+		c := ts.tradingClient(t)
+
+		report, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			ClientOrderId: strPtr("client-filled-1"),
+			VenueSymbol:   strPtr("BTC-USD"),
+			Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_SELL),
+			OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			Quantity:      floatPtr(0.25),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "filled-order-1", report.GetOrderId())
+		assert.Equal(t, "FILLED", report.GetOrderStatus())
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, report.GetExecutionType())
+
+		require.Len(t, ts.requests, 4, "place, two open order pages, historical orders")
+		assert.Equal(t, []string{"BTC-USD"}, ts.requests[3].Query["product_ids"])
+		assert.NotEmpty(t, ts.requests[3].Query["start_date"])
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		invalid := []struct {
+			name  string
+			order *venuesv1.Order
+			opts  prime.OrderOptions
+		}{
+			{name: "nil order"},
+			{
+				name:  "missing symbol",
+				order: &venuesv1.Order{Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET), Quantity: floatPtr(1)},
+			},
+			{
+				name:  "market with price",
+				order: &venuesv1.Order{VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET), Quantity: floatPtr(1), Price: floatPtr(50000)},
+			},
+			{
+				name:  "limit without price",
+				order: &venuesv1.Order{VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT), Quantity: floatPtr(1)},
+			},
+			{
+				name:  "GTD without expiry",
+				order: &venuesv1.Order{VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), OrderType: typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT), TimeInForce: tifPtr(venuesv1.TimeInForce_TIME_IN_FORCE_GTD), Quantity: floatPtr(1), Price: floatPtr(50000)},
+			},
+			{
+				name:  "TWAP without expiry",
+				order: &venuesv1.Order{VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), Quantity: floatPtr(1)},
+				opts:  prime.OrderOptions{Type: prime.OrderTypeTWAP},
+			},
+			{
+				name:  "block without price",
+				order: &venuesv1.Order{VenueSymbol: strPtr("BTC-USD"), Side: sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY), Quantity: floatPtr(1)},
+				opts:  prime.OrderOptions{Type: prime.OrderTypeBlock},
+			},
+		}
+
+		ts := newTestServer(t)
+		c := ts.tradingClient(t)
+		for _, tt := range invalid {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := c.PlaceOrderWithOptions(ctx, tt.order, tt.opts)
+				assert.Error(t, err)
+			})
+		}
+		assert.Empty(t, ts.requests)
+	})
+
+	t.Run("checked against cached product", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/products", serveFiles(t, "products_page1.json", "products_page2.json"))
+		c := ts.client(t)
+
+		// The cold cache is filled before the first order is validated
+		_, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:    floatPtr(0.01),
+			Price:       floatPtr(50000.005),
+		})
+		var invalid *client.OrderValidationError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, client.ValidationRuleTickSize, invalid.Rule)
+
+		_, err = c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("DOGE-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			Quantity:    floatPtr(1),
+		})
+		assert.ErrorIs(t, err, client.ErrInstrumentNotFound)
+		assert.Len(t, ts.requests, 2, "only the products pages are requested")
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodPost, "/order", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"INSUFFICIENT_FUNDS","message":"insufficient balance"}`))
+		})
+		c := ts.tradingClient(t)
+
+		_, err := c.PlaceOrder(ctx, &venuesv1.Order{
+			VenueSymbol: strPtr("BTC-USD"),
+			Side:        sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:   typePtr(venuesv1.OrderType_ORDER_TYPE_MARKET),
+			Quantity:    floatPtr(400),
+		})
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrInsufficientFunds)
+		assert.Equal(t, "INSUFFICIENT_FUNDS", venueErr.Code)
+	})
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodPost, "/orders/8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b/cancel", serveFile(t, http.StatusOK, "cancel_order.json"))
+	c := ts.client(t)
+
+	status, err := c.CancelOrder(ctx, "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b")
+	require.NoError(t, err)
+	assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *status)
+
+	_, err = c.CancelOrder(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestPlaceOrders(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodPost, "/order", serveFile(t, http.StatusOK, "create_order.json"))
+	c := ts.tradingClient(t)
+
+	orders := []*venuesv1.Order{
+		{
+			ClientOrderId: strPtr("cqvx-test-0001"),
+			VenueSymbol:   strPtr("BTC-USD"),
+			Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:      floatPtr(1),
+			Price:         floatPtr(49000),
+		},
+		{
+			ClientOrderId: strPtr("cqvx-test-0002"),
+			VenueSymbol:   strPtr("BTC-USD"),
+			Side:          sidePtr(venuesv1.OrderSide_ORDER_SIDE_BUY),
+			OrderType:     typePtr(venuesv1.OrderType_ORDER_TYPE_LIMIT),
+			Quantity:      floatPtr(1),
+			Price:         floatPtr(48000),
+		},
+		{
+			VenueSymbol: strPtr("BTC-USD"),
+		},
+	}
+
+	results, err := c.PlaceOrders(ctx, orders)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	for i, result := range results[:2] {
+		assert.Same(t, orders[i], result.Order)
+		require.NoError(t, result.Err)
+		assert.Equal(t, orders[i].GetClientOrderId(), result.Report.GetClientOrderId())
+	}
+	assert.Error(t, results[2].Err)
+	assert.Nil(t, results[2].Report)
+	assert.Len(t, ts.requests, 2)
+}
+
+func TestCancelOrders(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodPost, "/orders/open-order-1/cancel", serveFile(t, http.StatusOK, "cancel_order.json"))
+	ts.handle(http.MethodPost, "/orders/missing/cancel", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"ORDER_NOT_FOUND","message":"order not found"}`))
+	})
+	c := ts.client(t)
+
+	results, err := c.CancelOrders(ctx, []string{"open-order-1", "missing"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "open-order-1", results[0].OrderID)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_CANCELLED, *results[0].Status)
+
+	assert.Equal(t, "missing", results[1].OrderID)
+	var venueErr *venueerr.Error
+	require.ErrorAs(t, results[1].Err, &venueErr)
+	assert.ErrorIs(t, results[1].Err, venueerr.ErrNotFound)
+	assert.Equal(t, "ORDER_NOT_FOUND", venueErr.Code)
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/open_orders", serveFiles(t, "open_orders_page1.json", "open_orders_page2.json"))
+	ts.handle(http.MethodPost, "/orders/open-order-1/cancel", serveFile(t, http.StatusOK, "cancel_order.json"))
+	ts.handle(http.MethodPost, "/orders/open-order-2/cancel", serveFile(t, http.StatusOK, "cancel_order.json"))
+	c := ts.client(t)
+
+	results, err := c.CancelAllOrders(ctx, client.OrderFilter{Symbols: []string{"BTC-USD"}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "open-order-1", results[0].OrderID)
+	assert.Equal(t, "open-order-2", results[1].OrderID)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+
+	// Only open orders are listed; the historical endpoint is not queried
+	require.Len(t, ts.requests, 4)
+	assert.Equal(t, portfolioPrefix+"/open_orders", ts.requests[0].Path)
+	assert.Equal(t, []string{"BTC-USD"}, ts.requests[0].Query["product_ids"])
+}
+
+func TestAmendOrder(t *testing.T) {
+	ctx := context.Background()
+	orderID := "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b"
+
+	t.Run("success", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders/"+orderID, serveFiles(t, "order.json", "order_edited.json"))
+		ts.handle(http.MethodPut, "/orders/"+orderID+"/edit", serveFile(t, http.StatusOK, "edit_order.json"))
+		c := ts.client(t)
+
+		report, err := c.AmendOrder(ctx, orderID, client.OrderChanges{
+			Price:       floatPtr(50500),
+			Quantity:    floatPtr(2),
+			DisplaySize: floatPtr(0.25),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, ts.requests, 3)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(ts.requests[1].Body, &body))
+		assert.Equal(t, "cqvx-test-0001", body["orig_client_order_id"])
+		assert.NotEmpty(t, body["client_order_id"])
+		assert.NotEqual(t, "cqvx-test-0001", body["client_order_id"])
+		assert.Equal(t, "50500", body["limit_price"])
+		assert.Equal(t, "2", body["base_quantity"])
+		assert.Equal(t, "0.25", body["display_base_size"])
+		assert.NotContains(t, body, "stop_price")
+
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_REPLACED, report.GetExecutionType())
+		assert.Equal(t, orderID, report.GetOrderId())
+		assert.Equal(t, "cqvx-test-0002", report.GetClientOrderId())
+		assert.Equal(t, "prime", report.GetVenueId())
+		assert.Equal(t, 50500.0, report.GetPrice())
+		assert.Equal(t, 2.0, report.GetQuantity())
+	})
+
+	t.Run("unchanged fields keep current values", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders/"+orderID, serveFiles(t, "order.json", "order_edited.json"))
+		ts.handle(http.MethodPut, "/orders/"+orderID+"/edit", serveFile(t, http.StatusOK, "edit_order.json"))
+		c := ts.client(t)
+
+		_, err := c.AmendOrder(ctx, orderID, client.OrderChanges{Price: floatPtr(50500)})
+		require.NoError(t, err)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(ts.requests[1].Body, &body))
+		assert.Equal(t, "1.5", body["base_quantity"])
+		assert.Equal(t, "50500", body["limit_price"])
+	})
+
+	t.Run("edit rejected", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders/"+orderID, serveFile(t, http.StatusOK, "order.json"))
+		ts.handle(http.MethodPut, "/orders/"+orderID+"/edit", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"INVALID_ARGUMENT","message":"base_quantity must exceed filled quantity"}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.AmendOrder(ctx, orderID, client.OrderChanges{Quantity: floatPtr(0.1)})
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrInvalidRequest)
+	})
+
+	t.Run("invalid changes", func(t *testing.T) {
+		ts := newTestServer(t)
+		c := ts.client(t)
+
+		_, err := c.AmendOrder(ctx, orderID, client.OrderChanges{})
+		assert.ErrorIs(t, err, client.ErrNoOrderChanges)
+		_, err = c.AmendOrder(ctx, "", client.OrderChanges{Price: floatPtr(50500)})
+		assert.Error(t, err)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+func TestGetOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders/8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b", serveFile(t, http.StatusOK, "order.json"))
+		c := ts.client(t)
+
+		order, err := c.GetOrder(ctx, "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b")
+		require.NoError(t, err)
+
+		assert.Equal(t, "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b", order.GetVenueOrderId())
+		assert.Equal(t, "prime", order.GetVenueId())
+		assert.Equal(t, testPortfolioID, order.GetPortfolioId())
+		assert.Equal(t, venuesv1.OrderType_ORDER_TYPE_LIMIT, order.GetOrderType())
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_OPEN, order.GetStatus())
+		assert.Equal(t, 1.5, order.GetQuantity())
+		assert.Equal(t, 0.5, order.GetFilledQuantity())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders/missing", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"ORDER_NOT_FOUND","message":"order not found"}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.GetOrder(ctx, "missing")
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrNotFound)
+		assert.Equal(t, "ORDER_NOT_FOUND", venueErr.Code)
+	})
+}
+
+func TestGetOrders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("open and historical", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/open_orders", serveFiles(t, "open_orders_page1.json", "open_orders_page2.json"))
+		ts.handle(http.MethodGet, "/orders", serveFile(t, http.StatusOK, "orders.json"))
+		c := ts.client(t)
+
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		orders, err := c.GetOrders(ctx, client.OrderFilter{
+			Symbols:   []string{"BTC-USD", "ETH-USD"},
+			StartTime: start,
+		})
+		require.NoError(t, err)
+		require.Len(t, orders, 3)
+
+		assert.Equal(t, "open-order-1", orders[0].GetVenueOrderId())
+		assert.Equal(t, "open-order-2", orders[1].GetVenueOrderId())
+		assert.Equal(t, "filled-order-1", orders[2].GetVenueOrderId())
+		assert.Equal(t, venuesv1.OrderStatus_ORDER_STATUS_FILLED, orders[2].GetStatus())
+		for _, order := range orders {
+			assert.Equal(t, "prime", order.GetVenueId())
+		}
+
+		require.Len(t, ts.requests, 3)
+		assert.Equal(t, []string{"BTC-USD", "ETH-USD"}, ts.requests[0].Query["product_ids"])
+		assert.Equal(t, []string{"2024-01-01T00:00:00Z"}, ts.requests[0].Query["start_date"])
+		assert.Empty(t, ts.requests[0].Query.Get("cursor"))
+		assert.Equal(t, "open-cursor-2", ts.requests[1].Query.Get("cursor"))
+		assert.Equal(t, portfolioPrefix+"/orders", ts.requests[2].Path)
+		assert.Empty(t, ts.requests[2].Query["order_statuses"])
+	})
+
+	t.Run("closed statuses only", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders", serveFile(t, http.StatusOK, "orders.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{
+			Statuses: []venuesv1.OrderStatus{
+				venuesv1.OrderStatus_ORDER_STATUS_FILLED,
+				venuesv1.OrderStatus_ORDER_STATUS_REJECTED,
+				venuesv1.OrderStatus_ORDER_STATUS_FAILED,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+
+		require.Len(t, ts.requests, 1)
+		assert.Equal(t, []string{"FILLED", "FAILED"}, ts.requests[0].Query["order_statuses"])
+	})
+
+	t.Run("open statuses only", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/open_orders", serveFiles(t, "open_orders_page1.json", "open_orders_page2.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{
+			Statuses: []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_OPEN},
+		})
+		require.NoError(t, err)
+		assert.Len(t, orders, 2)
+		assert.Len(t, ts.requests, 2)
+	})
+
+	t.Run("offset and limit", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/open_orders", serveFiles(t, "open_orders_page1.json", "open_orders_page2.json"))
+		c := ts.client(t)
+
+		orders, err := c.GetOrders(ctx, client.OrderFilter{Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "open-order-2", orders[0].GetVenueOrderId())
+
+		// Enough orders were collected from the open endpoint; history is not queried
+		require.Len(t, ts.requests, 2)
+		assert.Equal(t, "2", ts.requests[0].Query.Get("limit"))
+	})
+}
+
+func TestGetOrderFills(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/orders/8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b/fills", serveFile(t, http.StatusOK, "fills.json"))
+	c := ts.client(t)
+
+	fills, err := c.GetOrderFills(ctx, "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b")
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+
+	fill := fills[0]
+	assert.Equal(t, "prime", fill.GetVenueId())
+	assert.Equal(t, "fill-789", fill.GetExecutionId())
+	assert.Equal(t, "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b", fill.GetVenueOrderId())
+	assert.Equal(t, 49950.0, fill.GetPrice())
+	assert.Equal(t, 0.5, fill.GetQuantity())
+	assert.Equal(t, 25.0, fill.GetFee())
+}
+
+func TestGetFills(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("portfolio fills", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/fills", serveFile(t, http.StatusOK, "portfolio_fills.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{
+			EndTime: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			Limit:   50,
+			Cursor:  "fills-page-1",
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 2)
+		assert.Equal(t, "fills-page-2", page.NextCursor)
+
+		fill := page.Fills[0]
+		assert.Equal(t, "prime", fill.GetVenueId())
+		assert.Equal(t, "fill-901", fill.GetExecutionId())
+		assert.Equal(t, "match-def", fill.GetTradeId())
+		assert.Equal(t, 12.5, fill.GetFee())
+		assert.Equal(t, "USD", fill.GetFeeAssetId())
+		assert.Equal(t, "987654323", page.Fills[1].GetTradeId(), "exec ID is the trade ID without a match ID")
+		assert.Equal(t, "ETH", page.Fills[1].GetFeeAssetId())
+
+		query := ts.requests[0].Query
+		assert.Equal(t, []string{"1970-01-01T00:00:00Z"}, query["start_date"])
+		assert.Equal(t, []string{"2024-01-31T00:00:00Z"}, query["end_date"])
+		assert.Equal(t, []string{"50"}, query["limit"])
+		assert.Equal(t, []string{"fills-page-1"}, query["cursor"])
+	})
+
+	t.Run("filters symbols client-side", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/fills", serveFile(t, http.StatusOK, "portfolio_fills.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{Symbols: []string{"ETH-USD"}})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 1)
+		assert.Equal(t, "fill-902", page.Fills[0].GetExecutionId())
+		assert.Equal(t, "fills-page-2", page.NextCursor)
+	})
+
+	t.Run("order fills", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/orders/8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b/fills", serveFile(t, http.StatusOK, "fills.json"))
+		c := ts.client(t)
+
+		page, err := c.GetFills(ctx, client.FillFilter{OrderID: "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b"})
+		require.NoError(t, err)
+		require.Len(t, page.Fills, 1)
+		assert.Empty(t, page.NextCursor)
+		assert.Empty(t, ts.requests[0].Query["start_date"])
+
+		page, err = c.GetFills(ctx, client.FillFilter{
+			OrderID:   "8a5e2c1f-3b4d-4e6f-9a0b-1c2d3e4f5a6b",
+			StartTime: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, page.Fills, "time range is applied client-side")
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		ts := newTestServer(t)
+		_, err := ts.client(t).GetFills(ctx, client.FillFilter{Limit: -1})
+		assert.ErrorIs(t, err, client.ErrInvalidLimit)
+		assert.Empty(t, ts.requests)
+	})
+}
+
+func TestGetBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all balances", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/balances", serveFile(t, http.StatusOK, "balances.json"))
+		c := ts.client(t)
+
+		balances, err := c.GetBalances(ctx)
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+
+		btc := balances[0]
+		assert.Equal(t, "prime", btc.GetVenueId())
+		assert.Equal(t, testPortfolioID, btc.GetAccountId())
+		assert.Equal(t, "BTC", btc.GetAssetId())
+		assert.Equal(t, 10.5, btc.GetTotal())
+		assert.Equal(t, "TRADING_BALANCES", ts.requests[0].Query.Get("balance_type"))
+	})
+
+	t.Run("configured currency", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/balances", serveFile(t, http.StatusOK, "balances.json"))
+		c := ts.client(t)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "USD", balance.GetAssetId())
+		assert.Equal(t, 250000.0, balance.GetTotal())
+		assert.Equal(t, 1500.0, balance.GetLocked())
+	})
+
+	t.Run("currency not held", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/balances", serveFile(t, http.StatusOK, "balances.json"))
+		config := ts.config()
+		config.BalanceCurrency = "EUR"
+		c, err := prime.NewClient(config, ts.server.Client(), nil, nil)
+		require.NoError(t, err)
+
+		balance, err := c.GetBalance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "EUR", balance.GetAssetId())
+		assert.Equal(t, testPortfolioID, balance.GetAccountId())
+		assert.Zero(t, balance.GetTotal())
+	})
+
+	t.Run("wallet balance", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/wallets/wallet-eth-1/balance", serveFile(t, http.StatusOK, "wallet_balance.json"))
+		c := ts.client(t)
+
+		balance, err := c.GetWalletBalance(ctx, "wallet-eth-1")
+		require.NoError(t, err)
+		assert.Equal(t, "prime", balance.GetVenueId())
+		assert.Equal(t, "wallet-eth-1", balance.GetAccountId())
+		assert.Equal(t, "ETH", balance.GetAssetId())
+		assert.Equal(t, 40.0, balance.GetAvailable())
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "/balances", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"UNAUTHENTICATED","message":"invalid token"}`))
+		})
+		c := ts.client(t)
+
+		_, err := c.GetBalance(ctx)
+		var venueErr *venueerr.Error
+		require.ErrorAs(t, err, &venueErr)
+		assert.ErrorIs(t, err, venueerr.ErrAuth)
+		assert.Equal(t, "UNAUTHENTICATED", venueErr.Code)
+	})
+}
+
+func TestGetInstruments(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.handle(http.MethodGet, "/products", serveFiles(t, "products_page1.json", "products_page2.json"))
+	c := ts.client(t)
+
+	instruments, err := c.GetInstruments(ctx)
+	require.NoError(t, err)
+	require.Len(t, instruments, 2)
+
+	btc := instruments[0]
+	assert.Equal(t, "prime", btc.VenueID)
+	assert.Equal(t, "BTC-USD", btc.Symbol)
+	assert.Equal(t, "BTC", btc.BaseAsset)
+	assert.Equal(t, "USD", btc.QuoteAsset)
+	assert.Equal(t, client.InstrumentStatusOnline, btc.Status)
+	assert.Equal(t, 0.01, btc.TickSize)
+	assert.Equal(t, 0.0001, btc.MinQuantity)
+	assert.Equal(t, 500.0, btc.MaxQuantity)
+	assert.Equal(t, 1.0, btc.MinNotional)
+
+	sol := instruments[1]
+	assert.Equal(t, "SOL-USD", sol.Symbol)
+	assert.Equal(t, client.InstrumentStatusOffline, sol.Status, "no trade permission")
+	assert.Equal(t, 0.001, sol.TickSize, "falls back to quote increment")
+
+	_, err = c.GetInstrument(ctx, "SOL-USD")
+	require.NoError(t, err)
+	_, err = c.GetInstrument(ctx, "DOGE-USD")
+	assert.ErrorIs(t, err, client.ErrInstrumentNotFound)
+
+	require.Len(t, ts.requests, 2, "products are fetched once")
+	assert.Equal(t, "100", ts.requests[0].Query.Get("limit"))
+	assert.Empty(t, ts.requests[0].Query.Get("cursor"))
+	assert.Equal(t, "cursor-2", ts.requests[1].Query.Get("cursor"))
+}
+
+func TestUnsupported(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := ts.client(t)
+
+	_, err := c.GetOrderBook(ctx, "BTC-USD")
+	assert.ErrorIs(t, err, client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeOrderBook(ctx, "BTC-USD", nil), client.ErrUnsupported)
+	assert.ErrorIs(t, c.SubscribeTrades(ctx, "BTC-USD", nil), client.ErrUnsupported)
+	assert.Empty(t, ts.requests)
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("healthy", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "", serveFile(t, http.StatusOK, "portfolio.json"))
+		assert.NoError(t, ts.client(t).Health(ctx))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.handle(http.MethodGet, "", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code":"UNAVAILABLE","message":"service unavailable"}`))
+		})
+		err := ts.client(t).Health(ctx)
+		assert.ErrorIs(t, err, venueerr.ErrUnavailable)
+	})
+}
+
+func strPtr(s string) *string     { return &s }
+func floatPtr(f float64) *float64 { return &f }
+func sidePtr(s venuesv1.OrderSide) *venuesv1.OrderSide {
+	return &s
+}
+func typePtr(t venuesv1.OrderType) *venuesv1.OrderType {
+	return &t
+}
+func tifPtr(t venuesv1.TimeInForce) *venuesv1.TimeInForce {
+	return &t
+}
@@ -0,0 +1,225 @@
+package prime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/ratelimit"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+)
+
+const (
+	// VenueID is the CQC venue identifier for Coinbase Prime.
+	VenueID = "prime"
+
+	// DefaultBaseURL is the Coinbase Prime REST API base URL.
+	DefaultBaseURL = "https://api.prime.coinbase.com"
+
+	// DefaultWebSocketURL is the Coinbase Prime WebSocket feed endpoint.
+	DefaultWebSocketURL = "wss://ws-feed.prime.coinbase.com"
+
+	// DefaultBalanceCurrency is the currency reported by GetBalance when none is configured.
+	DefaultBalanceCurrency = "USD"
+
+	// DefaultReconnectMinBackoff is the initial delay before reconnecting a dropped stream.
+	DefaultReconnectMinBackoff = stream.DefaultReconnectMinBackoff
+
+	// DefaultReconnectMaxBackoff caps the exponential reconnect delay.
+	DefaultReconnectMaxBackoff = stream.DefaultReconnectMaxBackoff
+
+	// DefaultBalancePollInterval is the delay between balance polls made by
+	// SubscribeBalances when no order activity triggers an earlier one.
+	DefaultBalancePollInterval = 30 * time.Second
+
+	// DefaultInstrumentRefreshInterval is the maximum age of the cached
+	// product list served by GetInstruments and GetInstrument.
+	DefaultInstrumentRefreshInterval = 5 * time.Minute
+
+	// DefaultClockSyncInterval is the delay between server time syncs made by
+	// the client's clock.
+	DefaultClockSyncInterval = 5 * time.Minute
+)
+
+// Config contains configuration for the Coinbase Prime client.
+type Config struct {
+	// PortfolioID is the Prime portfolio that all orders, balances and fills are scoped to
+	PortfolioID string
+
+	// KeyName is the API key name in the format "organizations/{org_id}/apiKeys/{key_id}"
+	KeyName string
+
+	// PrivateKey is the PEM-encoded EC private key used to sign JWTs
+	PrivateKey string
+
+	// Credentials, if set, supplies the key name (KeyID) and PEM-encoded
+	// private key (Secret) in place of KeyName and PrivateKey, which must then
+	// be empty. Rotated keys are picked up without restarting; see
+	// client.FileCredentials and client.CallbackCredentials.
+	Credentials client.CredentialProvider
+
+	// TokenExpiresIn is the JWT lifetime in seconds (default: 120)
+	TokenExpiresIn int64
+
+	// CacheTokens reuses each JWT for requests to the same method and path,
+	// including WebSocket handshakes, until TokenRefreshMargin before it
+	// expires. It saves an ECDSA signature per request when endpoints are
+	// polled at high frequency. See auth.JWTConfig.CacheTokens.
+	CacheTokens bool
+
+	// TokenRefreshMargin is how many seconds before expiry a cached JWT is
+	// replaced (default: 30, or half of TokenExpiresIn if that is shorter).
+	// Only used with CacheTokens.
+	TokenRefreshMargin int64
+
+	// BaseURL is the REST API base URL (default: DefaultBaseURL)
+	BaseURL string
+
+	// WebSocketURL is the WebSocket feed URL (default: DefaultWebSocketURL)
+	WebSocketURL string
+
+	// BalanceCurrency is the currency whose balance GetBalance returns (default: "USD").
+	// Use GetBalances to retrieve balances for every currency in the portfolio.
+	BalanceCurrency string
+
+	// ReconnectMinBackoff is the initial delay before reconnecting a dropped
+	// stream (default: DefaultReconnectMinBackoff). The delay doubles after each
+	// failed attempt up to ReconnectMaxBackoff.
+	ReconnectMinBackoff time.Duration
+
+	// ReconnectMaxBackoff caps the reconnect delay (default: DefaultReconnectMaxBackoff)
+	ReconnectMaxBackoff time.Duration
+
+	// BalancePollInterval is the delay between balance polls made by
+	// SubscribeBalances (default: DefaultBalancePollInterval). Order activity
+	// on the private stream triggers an immediate poll; the interval catches
+	// transfers and other changes the stream does not report.
+	BalancePollInterval time.Duration
+
+	// InstrumentRefreshInterval is the maximum age of the cached product list
+	// served by GetInstruments and GetInstrument
+	// (default: DefaultInstrumentRefreshInterval).
+	InstrumentRefreshInterval time.Duration
+
+	// RoundOrders rounds order prices to the tick size and quantities down to
+	// the base increment (see client.RoundOrder) before PlaceOrder validates
+	// them. If false, misaligned orders fail validation with a
+	// *client.OrderValidationError.
+	RoundOrders bool
+
+	// RateLimits are the client-side rate limits applied to REST requests
+	// (default: DefaultRateLimits()). Requests that would exceed them are
+	// queued, or rejected if their context deadline would pass first.
+	RateLimits ratelimit.Config
+
+	// DisableRateLimit sends REST requests without client-side rate limiting.
+	DisableRateLimit bool
+
+	// ClockSyncInterval is the delay between server time syncs made by
+	// Clock().Run (default: DefaultClockSyncInterval). Prime has no server
+	// time endpoint, so the time is read from a response's Date header.
+	//
+	// The client does not sync on its own: run the clock for the lifetime of
+	// the client with go c.Clock().Run(ctx). Otherwise requests are signed
+	// with the local clock until Prime first rejects one for clock skew, which
+	// triggers a one-off resync.
+	ClockSyncInterval time.Duration
+
+	// DisableClockSync signs requests with the local clock, uncorrected for
+	// skew, and returns requests rejected with venueerr.ErrClockSkew without
+	// resyncing and retrying them.
+	DisableClockSync bool
+}
+
+// Validate checks that the configuration has the fields required to connect.
+func (c Config) Validate() error {
+	if c.PortfolioID == "" {
+		return fmt.Errorf("portfolio ID is required")
+	}
+	switch {
+	case c.Credentials != nil:
+		if c.KeyName != "" || c.PrivateKey != "" {
+			return fmt.Errorf("static credentials and credential provider are mutually exclusive")
+		}
+	case c.KeyName == "":
+		return fmt.Errorf("key name is required")
+	case c.PrivateKey == "":
+		return fmt.Errorf("private key is required")
+	}
+	if c.TokenExpiresIn < 0 {
+		return fmt.Errorf("token expiry must be non-negative")
+	}
+	if c.TokenRefreshMargin < 0 {
+		return fmt.Errorf("token refresh margin must be non-negative")
+	}
+	if c.ReconnectMinBackoff < 0 || c.ReconnectMaxBackoff < 0 {
+		return fmt.Errorf("reconnect backoff must be non-negative")
+	}
+	if c.ReconnectMaxBackoff > 0 && c.ReconnectMinBackoff > c.ReconnectMaxBackoff {
+		return fmt.Errorf("reconnect min backoff must not exceed max backoff")
+	}
+	if c.BalancePollInterval < 0 {
+		return fmt.Errorf("balance poll interval must be non-negative")
+	}
+	if c.InstrumentRefreshInterval < 0 {
+		return fmt.Errorf("instrument refresh interval must be non-negative")
+	}
+	if c.ClockSyncInterval < 0 {
+		return fmt.Errorf("clock sync interval must be non-negative")
+	}
+	if !c.RateLimits.IsZero() {
+		if err := c.RateLimits.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limits: %w", err)
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the config with default values applied.
+func (c Config) withDefaults() Config {
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultBaseURL
+	}
+	if c.WebSocketURL == "" {
+		c.WebSocketURL = DefaultWebSocketURL
+	}
+	if c.BalanceCurrency == "" {
+		c.BalanceCurrency = DefaultBalanceCurrency
+	}
+	if c.ReconnectMinBackoff == 0 {
+		c.ReconnectMinBackoff = DefaultReconnectMinBackoff
+	}
+	if c.ReconnectMaxBackoff == 0 {
+		c.ReconnectMaxBackoff = DefaultReconnectMaxBackoff
+	}
+	if c.ReconnectMaxBackoff < c.ReconnectMinBackoff {
+		c.ReconnectMaxBackoff = c.ReconnectMinBackoff
+	}
+	if c.BalancePollInterval == 0 {
+		c.BalancePollInterval = DefaultBalancePollInterval
+	}
+	if c.InstrumentRefreshInterval == 0 {
+		c.InstrumentRefreshInterval = DefaultInstrumentRefreshInterval
+	}
+	if c.ClockSyncInterval == 0 {
+		c.ClockSyncInterval = DefaultClockSyncInterval
+	}
+	if c.RateLimits.IsZero() {
+		c.RateLimits = DefaultRateLimits()
+	}
+	if c.RateLimits.Venue == "" {
+		c.RateLimits.Venue = VenueID
+	}
+	return c
+}
+
+// DefaultRateLimits returns the Prime REST limits: 25 requests per second per
+// portfolio with bursts of up to 50. A Client is scoped to one portfolio, so
+// every request counts against its "portfolio" bucket.
+func DefaultRateLimits() ratelimit.Config {
+	return ratelimit.Config{
+		Venue:   VenueID,
+		Buckets: []ratelimit.Bucket{{Name: "portfolio", Rate: 25, Burst: 50}},
+		Rules:   []ratelimit.Rule{{Buckets: []string{"portfolio"}}},
+	}
+}
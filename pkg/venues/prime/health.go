@@ -0,0 +1,16 @@
+package prime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Health checks that the Prime API is reachable and that the credentials can
+// access the configured portfolio.
+func (c *Client) Health(ctx context.Context) error {
+	if _, err := c.do(ctx, http.MethodGet, c.portfolioPath(""), nil, nil); err != nil {
+		return fmt.Errorf("prime health check failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package prime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	primenorm "github.com/Combine-Capital/cqvx/internal/normalizer/prime"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+)
+
+// listProductsResponse is the response from the portfolio products endpoint.
+type listProductsResponse struct {
+	Products   []primenorm.PrimeProduct `json:"products"`
+	Pagination pagination               `json:"pagination"`
+}
+
+// GetInstruments retrieves the trading rules of every product available to
+// the portfolio.
+//
+// The product list is cached for Config.InstrumentRefreshInterval; if a
+// refresh fails while products are cached, the cached products are returned
+// and the failure is logged.
+func (c *Client) GetInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	return c.instruments.All(ctx)
+}
+
+// GetInstrument retrieves the trading rules of one product (e.g., "BTC-USD")
+// from the same cache as GetInstruments.
+func (c *Client) GetInstrument(ctx context.Context, symbol string) (*client.Instrument, error) {
+	return c.instruments.Get(ctx, symbol)
+}
+
+// Instruments returns the client's instrument cache. Pre-trade checks can
+// read it with Lookup without a network call, and Run keeps it refreshed in
+// the background so that PlaceOrder never waits for a fetch.
+func (c *Client) Instruments() *client.InstrumentCache {
+	return c.instruments
+}
+
+// fetchInstruments pages through the portfolio's products.
+func (c *Client) fetchInstruments(ctx context.Context) ([]*client.Instrument, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", defaultPageSize))
+
+	var instruments []*client.Instrument
+	for {
+		raw, err := c.do(ctx, http.MethodGet, c.portfolioPath("/products"), query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp listProductsResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse prime products response: %w", err)
+		}
+
+		for _, product := range resp.Products {
+			instrument, err := normalizeInstrument(product)
+			if err != nil {
+				return nil, fmt.Errorf("invalid product %s: %w", product.ID, err)
+			}
+			instruments = append(instruments, instrument)
+		}
+
+		if !resp.Pagination.HasNext || resp.Pagination.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", resp.Pagination.NextCursor)
+	}
+
+	return instruments, nil
+}
+
+// normalizeInstrument converts a Prime product to an Instrument.
+//
+// The price increment is the tick size, falling back to the quote increment
+// for products that do not report one. Quote min and max sizes bound the
+// order value, so they become the notional limits. Prime has no trading
+// status: products the portfolio may trade are online, others offline.
+func normalizeInstrument(product primenorm.PrimeProduct) (*client.Instrument, error) {
+	base, quote, _ := strings.Cut(product.ID, "-")
+
+	status := client.InstrumentStatusOffline
+	if slices.Contains(product.Permissions, primenorm.ProductPermissionTrade) {
+		status = client.InstrumentStatusOnline
+	}
+
+	instrument := &client.Instrument{
+		VenueID:    VenueID,
+		Symbol:     product.ID,
+		BaseAsset:  base,
+		QuoteAsset: quote,
+		Status:     status,
+	}
+
+	tickSize := product.PriceIncrement
+	if tickSize == "" {
+		tickSize = product.QuoteIncrement
+	}
+	fields := []struct {
+		name  string
+		value string
+		dest  *float64
+	}{
+		{"price_increment", tickSize, &instrument.TickSize},
+		{"base_increment", product.BaseIncrement, &instrument.BaseIncrement},
+		{"quote_increment", product.QuoteIncrement, &instrument.QuoteIncrement},
+		{"base_min_size", product.BaseMinSize, &instrument.MinQuantity},
+		{"base_max_size", product.BaseMaxSize, &instrument.MaxQuantity},
+		{"quote_min_size", product.QuoteMinSize, &instrument.MinNotional},
+		{"quote_max_size", product.QuoteMaxSize, &instrument.MaxNotional},
+	}
+	for _, field := range fields {
+		value, err := normalizer.ParseDecimal(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field.name, err)
+		}
+		*field.dest = value
+	}
+	return instrument, nil
+}
@@ -0,0 +1,14 @@
+package prime
+
+import (
+	"context"
+
+	marketsv1 "github.com/Combine-Capital/cqc/gen/go/cqc/markets/v1"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+)
+
+// GetOrderBook is not supported: Prime publishes L2 data only over its
+// WebSocket feed and has no REST order book endpoint.
+func (c *Client) GetOrderBook(ctx context.Context, symbol string) (*marketsv1.OrderBook, error) {
+	return nil, &client.UnsupportedError{Venue: VenueID, Operation: "GetOrderBook"}
+}
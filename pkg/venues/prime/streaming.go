@@ -0,0 +1,200 @@
+package prime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/auth"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	primenorm "github.com/Combine-Capital/cqvx/internal/normalizer/prime"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/stream"
+)
+
+// subscribeRequest is a WebSocket subscribe message. Subscriptions are scoped
+// to the configured portfolio; no product IDs means all products.
+type subscribeRequest struct {
+	Type        string   `json:"type"`
+	Channel     string   `json:"channel"`
+	PortfolioID string   `json:"portfolio_id,omitempty"`
+	ProductIDs  []string `json:"product_ids,omitempty"`
+}
+
+// SubscribeOrderBook is not yet supported by the Prime client.
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbol string, handler client.OrderBookHandler) error {
+	return &client.UnsupportedError{Venue: VenueID, Operation: "SubscribeOrderBook"}
+}
+
+// SubscribeTrades is not yet supported by the Prime client.
+func (c *Client) SubscribeTrades(ctx context.Context, symbol string, handler client.TradeHandler) error {
+	return &client.UnsupportedError{Venue: VenueID, Operation: "SubscribeTrades"}
+}
+
+// SubscribeExecutions streams execution reports for the portfolio's orders
+// from the orders channel, across all products.
+//
+// The WebSocket handshake is authenticated with a JWT bound to the feed URL.
+// The orders channel pushes cumulative order state, which is diffed against
+// the last state seen for each order to report acknowledgements, fills (with
+// the quantity, price and fee of each fill), cancels, rejections and expiries.
+// The snapshot sent on first connect is recorded without being reported. After
+// a reconnect the new snapshot is diffed instead, so fills and new orders
+// missed while disconnected are reported; orders that completed while
+// disconnected are not in the snapshot and should be reconciled with GetOrder.
+//
+// If the connection drops or a sequence gap is detected, the client reconnects
+// with exponential backoff. The call blocks until ctx is cancelled (returning
+// ctx.Err()) or handler returns an error (returning that error).
+func (c *Client) SubscribeExecutions(ctx context.Context, handler client.ExecutionHandler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	tracker := normalizer.NewOrderTracker()
+
+	run := &stream.Subscription[*primenorm.PrimeWSMessage]{
+		Connect: c.connectOrders,
+		Decode: func(data []byte) (*primenorm.PrimeWSMessage, int64, error) {
+			msg, err := primenorm.ParseWSMessage(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			return msg, msg.SequenceNum, nil
+		},
+		Handle: func(ctx context.Context, msg *primenorm.PrimeWSMessage) (bool, error) {
+			if msg.Channel != primenorm.WSChannelOrders {
+				return false, nil
+			}
+			events, err := primenorm.ParseOrdersEvents(msg)
+			if err != nil {
+				return true, err
+			}
+
+			timestamp := normalizer.ParseTimestampOrNow(msg.Timestamp)
+			for _, event := range events {
+				updates := make([]normalizer.OrderUpdate, 0, len(event.Orders))
+				for _, primeOrder := range event.Orders {
+					update, err := primenorm.NormalizeStreamOrder(primeOrder, timestamp)
+					if err != nil {
+						return true, err
+					}
+					updates = append(updates, update)
+				}
+
+				var reports []*venuesv1.ExecutionReport
+				if event.Type == primenorm.WSEventSnapshot {
+					reports = tracker.Snapshot(updates)
+				} else {
+					for _, update := range updates {
+						reports = append(reports, tracker.Reports(update)...)
+					}
+				}
+				for _, report := range reports {
+					venueId := VenueID
+					report.VenueId = &venueId
+					if err := handler(report); err != nil {
+						return true, &stream.HandlerError{Err: err}
+					}
+				}
+			}
+			return true, nil
+		},
+		MinBackoff: c.config.ReconnectMinBackoff,
+		MaxBackoff: c.config.ReconnectMaxBackoff,
+		OnDisconnect: func(err error, backoff time.Duration) {
+			c.logger.WarnContext(ctx, "prime websocket disconnected, reconnecting",
+				"channel", primenorm.WSChannelOrders, "error", err, "backoff", backoff)
+		},
+	}
+	return run.Run(ctx)
+}
+
+// SubscribeBalances reports balance changes across all assets in the portfolio.
+//
+// Prime has no balance stream, so balances are polled with GetBalances.
+// Order activity on the orders channel (see SubscribeExecutions) triggers an
+// immediate poll, so fills and order holds show up within one round trip;
+// BalancePollInterval bounds how long transfers and other changes not tied to
+// orders take to appear. The current balances are reported first, then only
+// balances whose amounts change.
+//
+// Failed polls are logged and retried. The call blocks until ctx is cancelled
+// (returning ctx.Err()), handler returns an error (returning that error) or
+// the orders channel subscription fails (returning its error).
+func (c *Client) SubscribeBalances(ctx context.Context, handler client.BalanceHandler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	poller := &client.BalancePoller{
+		Fetch:      c.GetBalances,
+		Interval:   c.config.BalancePollInterval,
+		Executions: c.SubscribeExecutions,
+		OnError: func(err error) {
+			c.logger.WarnContext(ctx, "prime balance poll failed", "error", err)
+		},
+	}
+	return poller.Run(ctx, handler)
+}
+
+// connectOrders dials the feed with an authenticated handshake and subscribes
+// to the portfolio's orders channel and to heartbeats.
+func (c *Client) connectOrders(ctx context.Context) (stream.Conn, error) {
+	header, err := c.wsAuthHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.wsDialer.Dial(ctx, c.config.WebSocketURL, header)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []subscribeRequest{
+		{Type: "subscribe", Channel: primenorm.WSChannelOrders, PortfolioID: c.config.PortfolioID},
+		{Type: "subscribe", Channel: primenorm.WSChannelHeartbeats},
+	}
+	for _, req := range requests {
+		payload, err := json.Marshal(req)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to encode subscribe request: %w", err)
+		}
+		if err := conn.WriteMessage(ctx, payload); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to subscribe to %s: %w", req.Channel, err)
+		}
+	}
+
+	c.logger.DebugContext(ctx, "prime websocket subscribed", "channel", primenorm.WSChannelOrders)
+	return conn, nil
+}
+
+// wsAuthHeader returns the handshake headers carrying a JWT bound to the
+// WebSocket feed URL.
+func (c *Client) wsAuthHeader(ctx context.Context) (http.Header, error) {
+	u, err := url.Parse(c.config.WebSocketURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prime websocket URL: %w", err)
+	}
+
+	result, err := c.signer.Sign(ctx, auth.SignRequest{
+		Method:  http.MethodGet,
+		Path:    u.Path,
+		Headers: http.Header{"Host": []string{u.Host}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign websocket handshake: %w", err)
+	}
+
+	header := http.Header{}
+	for key, value := range result.Headers {
+		header.Set(key, value)
+	}
+	return header, nil
+}
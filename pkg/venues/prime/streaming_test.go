@@ -0,0 +1,239 @@
+package prime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/pkg/venues/prime"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wsStandIn is a local stand-in for the Prime WebSocket feed. Each accepted
+// connection verifies the handshake JWT, reads the client's subscribe
+// messages and then runs the script for that connection (indexed from zero).
+type wsStandIn struct {
+	ts     *testServer
+	server *httptest.Server
+	script func(conn *wsScriptConn, index int)
+
+	mu         sync.Mutex
+	subscribes []map[string]interface{}
+	conns      int
+}
+
+// wsScriptConn is the server side of a stand-in connection.
+type wsScriptConn struct {
+	conn *websocket.Conn
+	seq  int64
+}
+
+// send writes a message on channel with the next sequence number.
+func (c *wsScriptConn) send(channel string, events string) {
+	msg := fmt.Sprintf(`{"channel":%q,"timestamp":"2024-01-15T10:30:00.123456Z","sequence_num":%d,"events":%s}`,
+		channel, c.seq, events)
+	c.seq++
+	_ = c.conn.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+// skip advances the sequence number without sending, simulating a lost message.
+func (c *wsScriptConn) skip() {
+	c.seq++
+}
+
+// waitClosed blocks until the client closes the connection.
+func (c *wsScriptConn) waitClosed() {
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func newWSStandIn(t *testing.T, script func(conn *wsScriptConn, index int)) *wsStandIn {
+	t.Helper()
+	s := &wsStandIn{ts: newTestServer(t), script: script}
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		assert.True(t, ok, "missing bearer token")
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return &s.ts.key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"ES256"}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "GET "+r.Host+"/ws", token.Claims.(jwt.MapClaims)["uri"])
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Expect the orders subscription followed by heartbeats
+		for i := 0; i < 2; i++ {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var sub map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &sub))
+			s.mu.Lock()
+			s.subscribes = append(s.subscribes, sub)
+			s.mu.Unlock()
+		}
+
+		s.mu.Lock()
+		index := s.conns
+		s.conns++
+		s.mu.Unlock()
+
+		s.script(&wsScriptConn{conn: conn}, index)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *wsStandIn) subscriptions() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}(nil), s.subscribes...)
+}
+
+func (s *wsStandIn) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func (s *wsStandIn) client(t *testing.T) *prime.Client {
+	t.Helper()
+	config := s.ts.config()
+	config.WebSocketURL = "ws" + strings.TrimPrefix(s.server.URL, "http") + "/ws"
+	config.ReconnectMinBackoff = time.Millisecond
+	config.ReconnectMaxBackoff = 10 * time.Millisecond
+	c, err := prime.NewClient(config, nil, nil, nil)
+	require.NoError(t, err)
+	return c
+}
+
+// collectExecutions subscribes until n reports are received.
+func collectExecutions(t *testing.T, c *prime.Client, n int) []*venuesv1.ExecutionReport {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var reports []*venuesv1.ExecutionReport
+	errDone := errors.New("done")
+	err := c.SubscribeExecutions(ctx, func(report *venuesv1.ExecutionReport) error {
+		reports = append(reports, report)
+		if len(reports) == n {
+			return errDone
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errDone)
+	return reports
+}
+
+func TestSubscribeExecutions(t *testing.T) {
+	const (
+		ordersSnapshot = `[{"type":"snapshot","orders":[
+			{"order_id":"order-resting","client_order_id":"client-resting","cum_qty":"0","leaves_qty":"5","avg_px":"0","fees":"0","status":"OPEN","product_id":"ETH-USD","side":"SELL","type":"LIMIT"}
+		]}]`
+		ordersAck = `[{"type":"update","orders":[
+			{"order_id":"order-1","client_order_id":"client-1","cum_qty":"0","leaves_qty":"2","avg_px":"0","fees":"0","status":"OPEN","product_id":"BTC-USD","side":"BUY","type":"LIMIT"}
+		]}]`
+		ordersFilled = `[{"type":"update","orders":[
+			{"order_id":"order-1","client_order_id":"client-1","cum_qty":"2","leaves_qty":"0","avg_px":"50000","fees":"50","status":"FILLED","product_id":"BTC-USD","side":"BUY","type":"LIMIT"}
+		]}]`
+		ordersExpired = `[{"type":"update","orders":[
+			{"order_id":"order-resting","client_order_id":"client-resting","cum_qty":"0","leaves_qty":"5","avg_px":"0","fees":"0","status":"EXPIRED","product_id":"ETH-USD","side":"SELL","type":"LIMIT"}
+		]}]`
+		heartbeat = `[{"current_time":"2024-01-15T10:30:00Z","heartbeat_counter":1}]`
+	)
+
+	t.Run("acks fills and expiries", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.send("orders", ordersSnapshot)
+			conn.send("heartbeats", heartbeat)
+			conn.send("orders", ordersAck)
+			conn.send("orders", ordersFilled)
+			conn.send("orders", ordersExpired)
+			conn.waitClosed()
+		})
+
+		reports := collectExecutions(t, s.client(t), 3)
+
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_NEW, reports[0].GetExecutionType())
+		assert.Equal(t, "prime", reports[0].GetVenueId())
+		assert.Equal(t, "order-1", reports[0].GetVenueOrderId())
+		assert.Equal(t, "client-1", reports[0].GetClientOrderId())
+
+		fill := reports[1]
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, fill.GetExecutionType())
+		assert.Equal(t, "FILLED", fill.GetOrderStatus())
+		assert.Equal(t, "BTC-USD", fill.GetVenueSymbol())
+		assert.Equal(t, 2.0, fill.GetQuantity())
+		assert.Equal(t, 50000.0, fill.GetPrice())
+		assert.Equal(t, 50.0, fill.GetFee())
+
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_EXPIRED, reports[2].GetExecutionType())
+		assert.Equal(t, "order-resting", reports[2].GetVenueOrderId())
+
+		subscribes := s.subscriptions()
+		require.Len(t, subscribes, 2)
+		assert.Equal(t, map[string]interface{}{
+			"type": "subscribe", "channel": "orders", "portfolio_id": testPortfolioID,
+		}, subscribes[0])
+		assert.Equal(t, map[string]interface{}{"type": "subscribe", "channel": "heartbeats"}, subscribes[1])
+	})
+
+	t.Run("sequence gap reconnects and diffs the new snapshot", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			if index == 0 {
+				conn.send("orders", ordersSnapshot)
+				conn.skip()
+				conn.send("orders", ordersAck)
+				conn.waitClosed()
+				return
+			}
+			conn.send("orders", ordersSnapshot)
+			conn.send("orders", ordersFilled)
+			conn.waitClosed()
+		})
+
+		reports := collectExecutions(t, s.client(t), 1)
+		assert.Equal(t, venuesv1.ExecutionType_EXECUTION_TYPE_FILL, reports[0].GetExecutionType(), "update after gap must not be applied")
+		assert.Equal(t, 2, s.connections())
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {
+			conn.waitClosed()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := s.client(t).SubscribeExecutions(ctx, func(*venuesv1.ExecutionReport) error { return nil })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		s := newWSStandIn(t, func(conn *wsScriptConn, index int) {})
+		assert.Error(t, s.client(t).SubscribeExecutions(context.Background(), nil))
+		assert.Zero(t, s.connections())
+	})
+}
@@ -0,0 +1,823 @@
+package prime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	venuesv1 "github.com/Combine-Capital/cqc/gen/go/cqc/venues/v1"
+	"github.com/Combine-Capital/cqvx/internal/idgen"
+	"github.com/Combine-Capital/cqvx/internal/normalizer"
+	primenorm "github.com/Combine-Capital/cqvx/internal/normalizer/prime"
+	"github.com/Combine-Capital/cqvx/pkg/client"
+	"github.com/Combine-Capital/cqvx/pkg/venueerr"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultPageSize is the page size used when listing orders and fills.
+const defaultPageSize = 100
+
+// OrderType is a Coinbase Prime order type.
+type OrderType string
+
+// Prime order types. TWAP, VWAP and BLOCK have no CQC OrderType equivalent and
+// are placed with PlaceOrderWithOptions.
+const (
+	OrderTypeMarket    OrderType = "MARKET"
+	OrderTypeLimit     OrderType = "LIMIT"
+	OrderTypeStopLimit OrderType = "STOP_LIMIT"
+	OrderTypeTWAP      OrderType = "TWAP"
+	OrderTypeVWAP      OrderType = "VWAP"
+	OrderTypeBlock     OrderType = "BLOCK"
+)
+
+// OrderOptions carries Prime-specific order parameters.
+type OrderOptions struct {
+	// Type overrides the Prime order type derived from the order's OrderType.
+	// Set it to OrderTypeTWAP, OrderTypeVWAP or OrderTypeBlock for those orders.
+	Type OrderType
+
+	// StartTime is when a TWAP or VWAP order begins executing.
+	// If zero, execution starts immediately.
+	StartTime time.Time
+
+	// HistoricalPOV is the maximum participation rate, as a percentage of
+	// historical volume, for VWAP orders. Zero uses the venue default.
+	HistoricalPOV float64
+
+	// DisplaySize is the visible base quantity of an iceberg LIMIT order.
+	// Zero displays the full quantity.
+	DisplaySize float64
+}
+
+// createOrderRequest is the request body for POST /v1/portfolios/{portfolio_id}/order.
+type createOrderRequest struct {
+	PortfolioID     string `json:"portfolio_id"`
+	ProductID       string `json:"product_id"`
+	Side            string `json:"side"`
+	ClientOrderID   string `json:"client_order_id"`
+	Type            string `json:"type"`
+	BaseQuantity    string `json:"base_quantity"`
+	LimitPrice      string `json:"limit_price,omitempty"`
+	StopPrice       string `json:"stop_price,omitempty"`
+	StartTime       string `json:"start_time,omitempty"`
+	ExpiryTime      string `json:"expiry_time,omitempty"`
+	TimeInForce     string `json:"time_in_force,omitempty"`
+	HistoricalPOV   string `json:"historical_pov,omitempty"`
+	DisplayBaseSize string `json:"display_base_size,omitempty"`
+	PostOnly        bool   `json:"post_only,omitempty"`
+}
+
+// createOrderResponse is the response from the create order endpoint.
+type createOrderResponse struct {
+	OrderID string `json:"order_id"`
+}
+
+// cancelOrderResponse is the response from the cancel order endpoint.
+type cancelOrderResponse struct {
+	ID string `json:"id"`
+}
+
+// editOrderRequest is the request body for PUT /v1/portfolios/{portfolio_id}/orders/{order_id}/edit.
+type editOrderRequest struct {
+	OrigClientOrderID string `json:"orig_client_order_id"`
+	ClientOrderID     string `json:"client_order_id"`
+	BaseQuantity      string `json:"base_quantity,omitempty"`
+	LimitPrice        string `json:"limit_price,omitempty"`
+	StopPrice         string `json:"stop_price,omitempty"`
+	DisplayBaseSize   string `json:"display_base_size,omitempty"`
+}
+
+// editOrderResponse is the response from the edit order endpoint.
+type editOrderResponse struct {
+	OrderID string `json:"order_id"`
+}
+
+// getOrderResponse is the response from GET /v1/portfolios/{portfolio_id}/orders/{order_id}.
+type getOrderResponse struct {
+	Order json.RawMessage `json:"order"`
+}
+
+// pagination is the cursor pagination block returned by list endpoints.
+type pagination struct {
+	NextCursor    string `json:"next_cursor"`
+	SortDirection string `json:"sort_direction"`
+	HasNext       bool   `json:"has_next"`
+}
+
+// listOrdersResponse is the response from the open and historical order list endpoints.
+type listOrdersResponse struct {
+	Orders     []json.RawMessage `json:"orders"`
+	Pagination pagination        `json:"pagination"`
+}
+
+// listFillsResponse is the response from the portfolio and order fills endpoints.
+type listFillsResponse struct {
+	Fills      []json.RawMessage `json:"fills"`
+	Pagination pagination        `json:"pagination"`
+}
+
+// PlaceOrder submits a MARKET, LIMIT, POST_ONLY or STOP_LIMIT order to the
+// configured portfolio. Use PlaceOrderWithOptions for TWAP, VWAP and BLOCK orders.
+func (c *Client) PlaceOrder(ctx context.Context, order *venuesv1.Order) (*venuesv1.ExecutionReport, error) {
+	return c.PlaceOrderWithOptions(ctx, order, OrderOptions{})
+}
+
+// PlaceOrderWithOptions submits an order with Prime-specific options.
+//
+// The order must set VenueSymbol, Side and Quantity. Requirements by type:
+//   - MARKET: no price
+//   - LIMIT: Price; TimeInForce GTC (default), GTD (with ExpiresAt), IOC or FOK
+//   - STOP_LIMIT: Price and StopPrice
+//   - TWAP, VWAP: ExpiresAt (end of the execution window); Price is an optional limit
+//   - BLOCK: Price
+//
+// Orders without opts.Type are first checked with client.ValidateOrder against
+// the cached product (see GetInstruments); if the product is not cached and
+// the cache is empty or stale, the products are fetched first, within
+// client.DefaultInstrumentLookupTimeout. Invalid orders return a
+// *client.OrderValidationError, and orders for products Prime does not list an
+// error matching client.ErrInstrumentNotFound. TWAP, VWAP and BLOCK orders
+// have no CQC order type and are checked when the request is built.
+//
+// If ClientOrderId is empty a random one is generated for this call and
+// reused if the order is resubmitted. The returned report has ExecutionType
+// NEW and OrderStatus "PENDING"; use GetOrder to follow progress.
+//
+// If the request times out or Prime returns a 5xx error, the order is looked
+// up by client order ID before it is resubmitted (see
+// client.PlaceOrderIdempotently); a recovered order is reported with its
+// current status. If its outcome still cannot be determined, a
+// *venueerr.Error matching venueerr.ErrUnknownOutcome is returned; resolve it
+// with FindOrderByClientOrderID before placing the order again.
+func (c *Client) PlaceOrderWithOptions(ctx context.Context, order *venuesv1.Order, opts OrderOptions) (*venuesv1.ExecutionReport, error) {
+	if opts.Type == "" {
+		prepared, err := c.instruments.PrepareOrder(ctx, order, c.config.RoundOrders)
+		if err != nil {
+			return nil, err
+		}
+		order = prepared
+	}
+
+	req, err := c.buildCreateOrderRequest(order, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.PlaceOrderIdempotently(ctx, VenueID, req.ClientOrderID,
+		func(ctx context.Context) (*venuesv1.ExecutionReport, error) {
+			return c.createOrder(ctx, order, req)
+		},
+		func(ctx context.Context, since time.Time) (*venuesv1.Order, error) {
+			return c.FindOrderByClientOrderID(ctx, req.ProductID, req.ClientOrderID, since)
+		})
+}
+
+// createOrder submits a create order request once.
+func (c *Client) createOrder(ctx context.Context, order *venuesv1.Order, req *createOrderRequest) (*venuesv1.ExecutionReport, error) {
+	raw, err := c.do(ctx, http.MethodPost, c.portfolioPath("/order"), nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp createOrderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime create order response: %w", err)
+	}
+	if resp.OrderID == "" {
+		return nil, fmt.Errorf("prime create order response missing order_id")
+	}
+
+	executionType := venuesv1.ExecutionType_EXECUTION_TYPE_NEW
+	orderStatus := "PENDING"
+	venueId := VenueID
+	price := order.GetPrice()
+	quantity := order.GetQuantity()
+
+	return &venuesv1.ExecutionReport{
+		ExecutionId:   &resp.OrderID,
+		OrderId:       &resp.OrderID,
+		VenueOrderId:  &resp.OrderID,
+		ClientOrderId: &req.ClientOrderID,
+		VenueId:       &venueId,
+		VenueSymbol:   &req.ProductID,
+		ExecutionType: &executionType,
+		OrderStatus:   &orderStatus,
+		Side:          &req.Side,
+		OrderType:     &req.Type,
+		Timestamp:     timestamppb.Now(),
+		Price:         &price,
+		Quantity:      &quantity,
+	}, nil
+}
+
+// FindOrderByClientOrderID returns the order on the product with the given
+// client order ID, searching open and historical orders in the configured
+// portfolio created since the given time.
+//
+// Prime cannot filter orders by client order ID, so the product's orders in
+// the time range are listed and matched client-side; keep the range short.
+// Returns a *venueerr.Error matching venueerr.ErrNotFound if there is no such
+// order.
+func (c *Client) FindOrderByClientOrderID(ctx context.Context, symbol, clientOrderID string, since time.Time) (*venuesv1.Order, error) {
+	if clientOrderID == "" {
+		return nil, fmt.Errorf("client order ID is required")
+	}
+
+	orders, err := c.GetOrders(ctx, client.OrderFilter{Symbols: []string{symbol}, StartTime: since})
+	if err != nil {
+		return nil, err
+	}
+	if order := client.OrderByClientOrderID(orders, clientOrderID); order != nil {
+		return order, nil
+	}
+	return nil, &venueerr.Error{
+		Venue:    VenueID,
+		Category: venueerr.ErrNotFound,
+		Message:  fmt.Sprintf("no %s order with client order ID %s since %s", symbol, clientOrderID, since.UTC().Format(time.RFC3339)),
+	}
+}
+
+// CancelOrder cancels an open order in the configured portfolio.
+// Returns ORDER_STATUS_CANCELLED once the venue accepts the cancel request.
+func (c *Client) CancelOrder(ctx context.Context, orderID string) (*venuesv1.OrderStatus, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodPost, c.portfolioPath("/orders/"+url.PathEscape(orderID)+"/cancel"), nil, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp cancelOrderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime cancel response: %w", err)
+	}
+
+	status := venuesv1.OrderStatus_ORDER_STATUS_CANCELLED
+	return &status, nil
+}
+
+// PlaceOrders submits several orders. Prime has no batch order endpoint, so
+// orders are placed with concurrent PlaceOrder calls (at most
+// client.DefaultBatchConcurrency at a time) and are not atomic.
+func (c *Client) PlaceOrders(ctx context.Context, orders []*venuesv1.Order) ([]client.PlaceOrderResult, error) {
+	return client.PlaceOrdersConcurrently(ctx, orders, client.DefaultBatchConcurrency, c.PlaceOrder), nil
+}
+
+// CancelOrders cancels several orders in the configured portfolio. Prime has
+// no batch cancel endpoint, so orders are cancelled with concurrent
+// CancelOrder calls (at most client.DefaultBatchConcurrency at a time).
+func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) ([]client.CancelOrderResult, error) {
+	return client.CancelOrdersConcurrently(ctx, orderIDs, client.DefaultBatchConcurrency, c.CancelOrder), nil
+}
+
+// CancelAllOrders cancels every order in the configured portfolio matching the
+// filter. Open orders are targeted when the filter sets no statuses. Matching
+// orders are listed with GetOrders and cancelled with CancelOrders.
+func (c *Client) CancelAllOrders(ctx context.Context, filter client.OrderFilter) ([]client.CancelOrderResult, error) {
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []venuesv1.OrderStatus{venuesv1.OrderStatus_ORDER_STATUS_OPEN}
+	}
+
+	orders, err := c.GetOrders(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders to cancel: %w", err)
+	}
+	return c.CancelOrders(ctx, client.OrderIDs(orders))
+}
+
+// AmendOrder edits an open order in place via PUT /orders/{order_id}/edit.
+//
+// Prime identifies the order being edited by its client order ID and assigns
+// the edit a new one, so the current order is read first. Fields not set in
+// changes keep their current values; DisplaySize sets display_base_size on
+// iceberg LIMIT orders. The new client order ID is generated with
+// idgen.NewUUID.
+//
+// The returned REPLACED execution report reflects the order as re-read after
+// the edit, including its new client order ID.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, changes client.OrderChanges) (*venuesv1.ExecutionReport, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+	if err := changes.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order changes: %w", err)
+	}
+
+	raw, err := c.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order %s: %w", orderID, err)
+	}
+	var current primenorm.PrimeOrder
+	if err := json.Unmarshal(raw, &current); err != nil {
+		return nil, fmt.Errorf("failed to parse prime order: %w", err)
+	}
+
+	clientOrderID, err := idgen.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client order ID: %w", err)
+	}
+
+	req := editOrderRequest{
+		OrigClientOrderID: current.ClientOrderID,
+		ClientOrderID:     clientOrderID,
+		BaseQuantity:      current.BaseQuantity,
+		LimitPrice:        current.LimitPrice,
+		StopPrice:         current.StopPrice,
+		DisplayBaseSize:   current.DisplayBaseSize,
+	}
+	if changes.Price != nil {
+		req.LimitPrice = normalizer.FormatDecimal(*changes.Price)
+	}
+	if changes.Quantity != nil {
+		req.BaseQuantity = normalizer.FormatDecimal(*changes.Quantity)
+	}
+	if changes.StopPrice != nil {
+		req.StopPrice = normalizer.FormatDecimal(*changes.StopPrice)
+	}
+	if changes.DisplaySize != nil {
+		req.DisplayBaseSize = normalizer.FormatDecimal(*changes.DisplaySize)
+	}
+
+	respRaw, err := c.do(ctx, http.MethodPut, c.portfolioPath("/orders/"+url.PathEscape(orderID)+"/edit"), nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp editOrderResponse
+	if err := json.Unmarshal(respRaw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime edit response: %w", err)
+	}
+
+	amended, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get amended order %s: %w", orderID, err)
+	}
+
+	return normalizer.ReplacedExecutionReport(amended), nil
+}
+
+// GetOrder retrieves a single order from the configured portfolio.
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*venuesv1.Order, error) {
+	raw, err := c.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return c.normalizeOrder(ctx, raw)
+}
+
+// getOrder fetches the raw Prime order JSON for an order in the configured portfolio.
+func (c *Client) getOrder(ctx context.Context, orderID string) (json.RawMessage, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	raw, err := c.do(ctx, http.MethodGet, c.portfolioPath("/orders/"+url.PathEscape(orderID)), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp getOrderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime order response: %w", err)
+	}
+
+	return resp.Order, nil
+}
+
+// GetOrders lists open and historical orders in the configured portfolio.
+//
+// Prime serves open orders and historical (closed) orders from separate
+// endpoints. The status filter selects which are queried:
+//   - OPEN, PARTIALLY_FILLED, PENDING, SUBMITTED -> open orders
+//   - FILLED, CANCELLED, EXPIRED, REJECTED, FAILED -> historical orders
+//   - no statuses -> both, open orders first
+//
+// Offset and Limit are applied client-side across the combined result.
+func (c *Client) GetOrders(ctx context.Context, filter client.OrderFilter) ([]*venuesv1.Order, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order filter: %w", err)
+	}
+
+	wantOpen, closedStatuses := splitStatuses(filter.Statuses)
+	wantClosed := len(filter.Statuses) == 0 || len(closedStatuses) > 0
+
+	max := 0
+	if filter.Limit > 0 {
+		max = filter.Offset + filter.Limit
+	}
+
+	var orders []*venuesv1.Order
+	if wantOpen {
+		open, err := c.listOrders(ctx, "/open_orders", ordersQuery(filter, nil), max)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, open...)
+	}
+	if wantClosed && (max == 0 || len(orders) < max) {
+		remaining := 0
+		if max > 0 {
+			remaining = max - len(orders)
+		}
+		closed, err := c.listOrders(ctx, "/orders", ordersQuery(filter, closedStatuses), remaining)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, closed...)
+	}
+
+	if filter.Offset >= len(orders) {
+		return []*venuesv1.Order{}, nil
+	}
+	orders = orders[filter.Offset:]
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
+	return orders, nil
+}
+
+// GetOrderFills returns the fills of an order as CQC ExecutionReports.
+func (c *Client) GetOrderFills(ctx context.Context, orderID string) ([]*venuesv1.ExecutionReport, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", defaultPageSize))
+
+	var reports []*venuesv1.ExecutionReport
+	for {
+		page, err := c.listFills(ctx, "/orders/"+url.PathEscape(orderID)+"/fills", query)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, page.Fills...)
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", page.NextCursor)
+	}
+
+	return reports, nil
+}
+
+// GetFills lists fills matching the filter, one page at a time.
+//
+// Without an order ID the portfolio fills endpoint is queried with the time
+// range (Prime requires a start date, so an unset StartTime means the Unix
+// epoch). With an order ID the order fills endpoint is used, which has no time
+// parameters. Symbols, and the time range for order fills, are applied
+// client-side, so a page may hold fewer than Limit fills while NextCursor is
+// still set.
+func (c *Client) GetFills(ctx context.Context, filter client.FillFilter) (*client.FillPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fill filter: %w", err)
+	}
+
+	query := url.Values{}
+	path := "/fills"
+	if filter.OrderID != "" {
+		path = "/orders/" + url.PathEscape(filter.OrderID) + "/fills"
+	} else {
+		start := filter.StartTime
+		if start.IsZero() {
+			start = time.Unix(0, 0)
+		}
+		query.Set("start_date", start.UTC().Format(time.RFC3339))
+		if !filter.EndTime.IsZero() {
+			query.Set("end_date", filter.EndTime.UTC().Format(time.RFC3339))
+		}
+	}
+
+	limit := defaultPageSize
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	query.Set("limit", fmt.Sprintf("%d", limit))
+	if filter.Cursor != "" {
+		query.Set("cursor", filter.Cursor)
+	}
+
+	page, err := c.listFills(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	fills := page.Fills[:0]
+	for _, fill := range page.Fills {
+		if fillMatches(fill, filter) {
+			fills = append(fills, fill)
+		}
+	}
+	page.Fills = fills
+
+	return page, nil
+}
+
+// listFills fetches and normalizes one page from a fills endpoint. NextCursor
+// is empty on the last page.
+func (c *Client) listFills(ctx context.Context, path string, query url.Values) (*client.FillPage, error) {
+	raw, err := c.do(ctx, http.MethodGet, c.portfolioPath(path), query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listFillsResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse prime fills response: %w", err)
+	}
+
+	page := &client.FillPage{Fills: make([]*venuesv1.ExecutionReport, 0, len(resp.Fills))}
+	for _, rawFill := range resp.Fills {
+		report, err := primenorm.NormalizeExecutionReport(ctx, rawFill)
+		if err != nil {
+			return nil, err
+		}
+		venueId := VenueID
+		report.VenueId = &venueId
+		page.Fills = append(page.Fills, report)
+	}
+	if resp.Pagination.HasNext {
+		page.NextCursor = resp.Pagination.NextCursor
+	}
+
+	return page, nil
+}
+
+// fillMatches reports whether a fill passes the filter's symbol and time range.
+func fillMatches(fill *venuesv1.ExecutionReport, filter client.FillFilter) bool {
+	if len(filter.Symbols) > 0 && !slices.Contains(filter.Symbols, fill.GetVenueSymbol()) {
+		return false
+	}
+	if fill.Timestamp == nil {
+		return true
+	}
+	at := fill.GetTimestamp().AsTime()
+	if !filter.StartTime.IsZero() && at.Before(filter.StartTime) {
+		return false
+	}
+	if !filter.EndTime.IsZero() && at.After(filter.EndTime) {
+		return false
+	}
+	return true
+}
+
+// listOrders pages through an order list endpoint, stopping once max orders
+// have been collected (zero means all pages).
+func (c *Client) listOrders(ctx context.Context, path string, query url.Values, max int) ([]*venuesv1.Order, error) {
+	pageSize := defaultPageSize
+	if max > 0 && max < pageSize {
+		pageSize = max
+	}
+	query.Set("limit", fmt.Sprintf("%d", pageSize))
+
+	var orders []*venuesv1.Order
+	for {
+		raw, err := c.do(ctx, http.MethodGet, c.portfolioPath(path), query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp listOrdersResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse prime orders response: %w", err)
+		}
+
+		for _, rawOrder := range resp.Orders {
+			order, err := c.normalizeOrder(ctx, rawOrder)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+
+		if max > 0 && len(orders) >= max {
+			break
+		}
+		if !resp.Pagination.HasNext || resp.Pagination.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", resp.Pagination.NextCursor)
+	}
+
+	return orders, nil
+}
+
+// normalizeOrder normalizes a raw Prime order and stamps the venue and portfolio IDs.
+func (c *Client) normalizeOrder(ctx context.Context, raw []byte) (*venuesv1.Order, error) {
+	order, err := primenorm.NormalizeOrder(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	venueId := VenueID
+	order.VenueId = &venueId
+	if order.GetPortfolioId() == "" {
+		portfolioId := c.config.PortfolioID
+		order.PortfolioId = &portfolioId
+	}
+	return order, nil
+}
+
+// buildCreateOrderRequest converts a CQC order and options into a Prime create order request.
+//
+// An empty ClientOrderId gets a random UUID. An ID derived from the order's
+// contents would make Prime deduplicate a deliberately repeated order against
+// the earlier one; retries of one placement share the request, and with it the
+// generated ID.
+func (c *Client) buildCreateOrderRequest(order *venuesv1.Order, opts OrderOptions) (*createOrderRequest, error) {
+	if order == nil {
+		return nil, fmt.Errorf("order is required")
+	}
+	if order.GetVenueSymbol() == "" {
+		return nil, fmt.Errorf("order venue symbol is required")
+	}
+	if order.GetQuantity() <= 0 {
+		return nil, fmt.Errorf("order quantity must be positive")
+	}
+
+	req := &createOrderRequest{
+		PortfolioID:   c.config.PortfolioID,
+		ProductID:     order.GetVenueSymbol(),
+		ClientOrderID: order.GetClientOrderId(),
+		BaseQuantity:  normalizer.FormatDecimal(order.GetQuantity()),
+	}
+
+	switch order.GetSide() {
+	case venuesv1.OrderSide_ORDER_SIDE_BUY:
+		req.Side = "BUY"
+	case venuesv1.OrderSide_ORDER_SIDE_SELL:
+		req.Side = "SELL"
+	default:
+		return nil, fmt.Errorf("order side is required")
+	}
+
+	orderType := opts.Type
+	if orderType == "" {
+		switch order.GetOrderType() {
+		case venuesv1.OrderType_ORDER_TYPE_MARKET:
+			orderType = OrderTypeMarket
+		case venuesv1.OrderType_ORDER_TYPE_LIMIT:
+			orderType = OrderTypeLimit
+		case venuesv1.OrderType_ORDER_TYPE_POST_ONLY:
+			orderType = OrderTypeLimit
+			req.PostOnly = true
+		case venuesv1.OrderType_ORDER_TYPE_STOP_LIMIT:
+			orderType = OrderTypeStopLimit
+		default:
+			return nil, fmt.Errorf("unsupported prime order type: %s", order.GetOrderType())
+		}
+	}
+	req.Type = string(orderType)
+	req.PostOnly = req.PostOnly || order.GetPostOnly()
+
+	if order.GetPrice() > 0 {
+		req.LimitPrice = normalizer.FormatDecimal(order.GetPrice())
+	}
+	if order.GetExpiresAt() != nil {
+		req.ExpiryTime = order.GetExpiresAt().AsTime().UTC().Format(time.RFC3339)
+	}
+
+	switch orderType {
+	case OrderTypeMarket:
+		if req.LimitPrice != "" {
+			return nil, fmt.Errorf("MARKET order must not set a price")
+		}
+
+	case OrderTypeLimit:
+		if req.LimitPrice == "" {
+			return nil, fmt.Errorf("LIMIT order requires a positive price")
+		}
+		tif, err := timeInForce(order.GetTimeInForce())
+		if err != nil {
+			return nil, err
+		}
+		if tif == "GOOD_UNTIL_DATE_TIME" && req.ExpiryTime == "" {
+			return nil, fmt.Errorf("GTD order requires expires_at")
+		}
+		req.TimeInForce = tif
+		if opts.DisplaySize > 0 {
+			req.DisplayBaseSize = normalizer.FormatDecimal(opts.DisplaySize)
+		}
+
+	case OrderTypeStopLimit:
+		if req.LimitPrice == "" {
+			return nil, fmt.Errorf("STOP_LIMIT order requires a positive price")
+		}
+		if order.GetStopPrice() <= 0 {
+			return nil, fmt.Errorf("STOP_LIMIT order requires a positive stop price")
+		}
+		req.StopPrice = normalizer.FormatDecimal(order.GetStopPrice())
+		tif, err := timeInForce(order.GetTimeInForce())
+		if err != nil {
+			return nil, err
+		}
+		req.TimeInForce = tif
+
+	case OrderTypeTWAP, OrderTypeVWAP:
+		if req.ExpiryTime == "" {
+			return nil, fmt.Errorf("%s order requires expires_at", orderType)
+		}
+		if !opts.StartTime.IsZero() {
+			req.StartTime = opts.StartTime.UTC().Format(time.RFC3339)
+		}
+		req.TimeInForce = "GOOD_UNTIL_DATE_TIME"
+		if orderType == OrderTypeVWAP && opts.HistoricalPOV > 0 {
+			req.HistoricalPOV = normalizer.FormatDecimal(opts.HistoricalPOV)
+		}
+
+	case OrderTypeBlock:
+		if req.LimitPrice == "" {
+			return nil, fmt.Errorf("BLOCK order requires a positive price")
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported prime order type: %s", orderType)
+	}
+
+	if req.ClientOrderID == "" {
+		id, err := idgen.NewUUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client order ID: %w", err)
+		}
+		req.ClientOrderID = id
+	}
+
+	return req, nil
+}
+
+// timeInForce maps a CQC time in force to the Prime value. Unspecified means GTC.
+func timeInForce(tif venuesv1.TimeInForce) (string, error) {
+	switch tif {
+	case venuesv1.TimeInForce_TIME_IN_FORCE_UNSPECIFIED, venuesv1.TimeInForce_TIME_IN_FORCE_GTC:
+		return "GOOD_UNTIL_CANCELLED", nil
+	case venuesv1.TimeInForce_TIME_IN_FORCE_GTD:
+		return "GOOD_UNTIL_DATE_TIME", nil
+	case venuesv1.TimeInForce_TIME_IN_FORCE_IOC:
+		return "IMMEDIATE_OR_CANCEL", nil
+	case venuesv1.TimeInForce_TIME_IN_FORCE_FOK:
+		return "FILL_OR_KILL", nil
+	default:
+		return "", fmt.Errorf("unsupported prime time in force: %s", tif)
+	}
+}
+
+// splitStatuses reports whether any open statuses are requested and maps the
+// requested closed statuses to Prime order_statuses values.
+func splitStatuses(statuses []venuesv1.OrderStatus) (wantOpen bool, closed []string) {
+	if len(statuses) == 0 {
+		return true, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, status := range statuses {
+		var primeStatus string
+		switch status {
+		case venuesv1.OrderStatus_ORDER_STATUS_OPEN, venuesv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED,
+			venuesv1.OrderStatus_ORDER_STATUS_PENDING, venuesv1.OrderStatus_ORDER_STATUS_SUBMITTED:
+			wantOpen = true
+			continue
+		case venuesv1.OrderStatus_ORDER_STATUS_FILLED:
+			primeStatus = "FILLED"
+		case venuesv1.OrderStatus_ORDER_STATUS_CANCELLED:
+			primeStatus = "CANCELLED"
+		case venuesv1.OrderStatus_ORDER_STATUS_EXPIRED:
+			primeStatus = "EXPIRED"
+		case venuesv1.OrderStatus_ORDER_STATUS_REJECTED, venuesv1.OrderStatus_ORDER_STATUS_FAILED:
+			primeStatus = "FAILED"
+		default:
+			continue
+		}
+		if !seen[primeStatus] {
+			seen[primeStatus] = true
+			closed = append(closed, primeStatus)
+		}
+	}
+	return wantOpen, closed
+}
+
+// ordersQuery builds the list orders query parameters for a filter.
+func ordersQuery(filter client.OrderFilter, statuses []string) url.Values {
+	query := url.Values{}
+	for _, symbol := range filter.Symbols {
+		query.Add("product_ids", symbol)
+	}
+	for _, status := range statuses {
+		query.Add("order_statuses", status)
+	}
+	if !filter.StartTime.IsZero() {
+		query.Set("start_date", filter.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !filter.EndTime.IsZero() {
+		query.Set("end_date", filter.EndTime.UTC().Format(time.RFC3339))
+	}
+	return query
+}